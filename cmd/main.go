@@ -58,6 +58,7 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/systemdiscovery"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/validate"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/version"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/wlmevaluate"
 	"github.com/GoogleCloudPlatform/sapagent/internal/startdaemon"
 	"github.com/GoogleCloudPlatform/sapagent/internal/utils/filesystem"
 	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
@@ -107,6 +108,7 @@ func registerSubCommands(ctx context.Context, lp log.Parameters, cloudProps *iip
 		&systemdiscovery.SystemDiscovery{},
 		&validate.Validate{},
 		&version.Version{},
+		&wlmevaluate.WLMEvaluate{},
 		d,
 		subcommands.HelpCommand(), // Implement "help"
 	}
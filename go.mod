@@ -33,6 +33,8 @@ require (
         github.com/googleapis/gax-go/v2 v2.17.0
         github.com/jonboulle/clockwork v0.5.0
         github.com/kardianos/service v1.2.2
+        github.com/klauspost/compress v1.17.11
+        github.com/pierrec/lz4/v4 v4.1.31
         github.com/pkg/errors v0.9.1
         github.com/shirou/gopsutil/v3 v3.24.5
         github.com/zieckey/goini v0.0.0-20240615065340-08ee21c836fb // indirect
@@ -40,9 +42,11 @@ require (
         golang.org/x/exp v0.0.0-20250128182459-e0ece0dbea4c
         golang.org/x/oauth2 v0.35.0
         golang.org/x/sys v0.41.0
+        golang.org/x/time v0.14.0
         google.golang.org/api v0.269.0
         google.golang.org/genproto v0.0.0-20260128011058-8636f8732409
         google.golang.org/genproto/googleapis/api v0.0.0-20260128011058-8636f8732409
+        google.golang.org/grpc v1.79.1
         google.golang.org/protobuf v1.36.11
 )
 
@@ -108,8 +112,6 @@ require (
         golang.org/x/net v0.50.0 // indirect
         golang.org/x/sync v0.19.0 // indirect
         golang.org/x/text v0.34.0 // indirect
-        golang.org/x/time v0.14.0 // indirect
         google.golang.org/genproto/googleapis/rpc v0.0.0-20260217215200-42d3e9bedb6d // indirect
-        google.golang.org/grpc v1.79.1 // indirect
         mvdan.cc/sh/v3 v3.7.0 // indirect
 )
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backend provides the storage backends Backint functions read and write objects with.
+// Cloud Storage is the default, with a local or NFS filesystem and S3-compatible
+// object stores available for on-premises targets.
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	store "cloud.google.com/go/storage"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+// ErrObjectNotExist is returned by backends when the requested object does not exist.
+// It is the same error returned by the Cloud Storage client so callers can check either.
+var ErrObjectNotExist = store.ErrObjectNotExist
+
+// ObjectAttrs holds the attributes of a stored object.
+type ObjectAttrs struct {
+	Name     string
+	Size     int64
+	Created  time.Time
+	Metadata map[string]string
+
+	// CRC32C is the Castagnoli checksum of the object's data. It is only valid if
+	// HasCRC32C is set, which Cloud Storage does for every object.
+	CRC32C    uint32
+	HasCRC32C bool
+
+	// CustomTime and RetainUntil are only reported by Cloud Storage and are
+	// zero for other backends.
+	CustomTime  time.Time
+	RetainUntil time.Time
+}
+
+// Backend abstracts the object operations needed by the Backint functions.
+type Backend interface {
+	// Upload stores the reader's data as the object and returns the bytes written.
+	Upload(ctx context.Context, object string, reader io.Reader, metadata map[string]string) (int64, error)
+
+	// Download writes length bytes of the object starting at offset into the writer.
+	// A negative length reads until the end of the object.
+	Download(ctx context.Context, object string, writer io.Writer, offset, length int64) (int64, error)
+
+	// List returns objects whose name starts with prefix and ends with filter,
+	// sorted by creation time with the latest first. An empty filter matches all objects.
+	List(ctx context.Context, prefix, filter string) ([]*ObjectAttrs, error)
+
+	// Attrs returns the attributes of a single object.
+	Attrs(ctx context.Context, object string) (*ObjectAttrs, error)
+
+	// Compose concatenates the sources, in order, into the object.
+	Compose(ctx context.Context, object string, sources []string, metadata map[string]string) error
+
+	// Delete removes the object. ErrObjectNotExist is returned if it does not exist.
+	Delete(ctx context.Context, object string) error
+
+	// UpdateMetadata replaces the object's metadata without rewriting its data.
+	// The object's creation time is preserved.
+	UpdateMetadata(ctx context.Context, object string, metadata map[string]string) error
+}
+
+// New creates the backend selected by the configuration. Cloud Storage is used
+// if no backend is configured, connecting to the bucket with connectParams.
+func New(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters) (Backend, error) {
+	switch config.GetStorageBackend() {
+	case bpb.StorageBackend_FILESYSTEM:
+		return NewFilesystem(config.GetFilesystemPath())
+	case bpb.StorageBackend_S3:
+		return NewS3(config)
+	default:
+		bucketHandle, ok := storage.ConnectToBucket(ctx, connectParams)
+		if !ok {
+			return nil, fmt.Errorf("failed to connect to bucket: %s", config.GetBucket())
+		}
+		return NewGCS(bucketHandle, config), nil
+	}
+}
+
+// sizedReader is a reader whose total size is known.
+type sizedReader struct {
+	io.Reader
+	size int64
+}
+
+// WithSize records the expected size of the reader's data for Upload, which S3
+// uses to choose the multipart upload part size. A size of 0 or less is unknown.
+func WithSize(reader io.Reader, size int64) io.Reader {
+	if size <= 0 {
+		return reader
+	}
+	return &sizedReader{Reader: reader, size: size}
+}
+
+// IsGCS returns true if the configuration uses the Cloud Storage backend.
+func IsGCS(config *bpb.BackintConfiguration) bool {
+	b := config.GetStorageBackend()
+	return b == bpb.StorageBackend_STORAGE_BACKEND_UNSPECIFIED || b == bpb.StorageBackend_GCS
+}
+
+// matches returns true if the object name has the prefix and filter suffix.
+func matches(name, prefix, filter string) bool {
+	return strings.HasPrefix(name, prefix) && strings.HasSuffix(name, filter)
+}
+
+// sortLatestFirst orders objects by creation time, latest first.
+func sortLatestFirst(objects []*ObjectAttrs) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		return objects[i].Created.After(objects[j].Created)
+	})
+}
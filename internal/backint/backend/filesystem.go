@@ -0,0 +1,276 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// metadataDir holds a JSON sidecar for each object with its metadata and
+// creation time. It is skipped when listing objects.
+const metadataDir = ".metadata"
+
+// Filesystem stores objects as files under a root directory, such as an NFS
+// mount. Object names map directly to relative paths.
+type Filesystem struct {
+	root string
+}
+
+// sidecar is the metadata stored alongside each object.
+type sidecar struct {
+	Created  time.Time         `json:"created"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// NewFilesystem returns a backend rooted at the directory, creating it if needed.
+func NewFilesystem(root string) (*Filesystem, error) {
+	if root == "" {
+		return nil, fmt.Errorf("filesystem_path must be set for the FILESYSTEM storage backend")
+	}
+	if err := os.MkdirAll(root, 0750); err != nil {
+		return nil, fmt.Errorf("creating filesystem backend root %s: %v", root, err)
+	}
+	return &Filesystem{root: filepath.Clean(root)}, nil
+}
+
+// path returns the file path for the object, rejecting names which would escape the root.
+func (f *Filesystem) path(dir, object string) (string, error) {
+	p := filepath.Join(f.root, dir, filepath.FromSlash(object))
+	rel, err := filepath.Rel(f.root, p)
+	if object == "" || err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object name %q", object)
+	}
+	return p, nil
+}
+
+// Upload writes the object to a temporary file which is renamed into place
+// once complete, so partially written objects are never visible.
+func (f *Filesystem) Upload(ctx context.Context, object string, reader io.Reader, metadata map[string]string) (int64, error) {
+	p, err := f.path("", object)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+		return 0, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), "."+filepath.Base(p)+".tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	n, err := io.Copy(tmp, contextReader{ctx: ctx, r: reader})
+	if err != nil {
+		tmp.Close()
+		return n, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return n, err
+	}
+	if err := tmp.Close(); err != nil {
+		return n, err
+	}
+	if err := f.writeSidecar(object, sidecar{Created: time.Now(), Metadata: metadata}); err != nil {
+		return n, err
+	}
+	return n, os.Rename(tmp.Name(), p)
+}
+
+// Download copies the requested range of the object into the writer.
+func (f *Filesystem) Download(ctx context.Context, object string, writer io.Writer, offset, length int64) (int64, error) {
+	p, err := f.path("", object)
+	if err != nil {
+		return 0, err
+	}
+	file, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, ErrObjectNotExist
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	var r io.Reader = file
+	if length >= 0 {
+		r = io.LimitReader(file, length)
+	}
+	return io.Copy(writer, contextReader{ctx: ctx, r: r})
+}
+
+// List walks the root for objects matching the prefix and filter, latest first.
+func (f *Filesystem) List(ctx context.Context, prefix, filter string) ([]*ObjectAttrs, error) {
+	var objects []*ObjectAttrs
+	err := filepath.WalkDir(f.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(f.root, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if d.IsDir() {
+			if name == metadataDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") && strings.Contains(d.Name(), ".tmp") {
+			return nil
+		}
+		if !matches(name, prefix, filter) {
+			return nil
+		}
+		attrs, err := f.Attrs(ctx, name)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, attrs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortLatestFirst(objects)
+	return objects, nil
+}
+
+// Attrs returns the attributes of the object, falling back to the file's
+// modification time if the metadata sidecar is missing.
+func (f *Filesystem) Attrs(ctx context.Context, object string) (*ObjectAttrs, error) {
+	p, err := f.path("", object)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	attrs := &ObjectAttrs{Name: object, Size: info.Size(), Created: info.ModTime()}
+	if s, err := f.readSidecar(object); err == nil {
+		attrs.Created = s.Created
+		attrs.Metadata = s.Metadata
+	}
+	return attrs, nil
+}
+
+// Compose concatenates the sources into the object.
+func (f *Filesystem) Compose(ctx context.Context, object string, sources []string, metadata map[string]string) error {
+	var readers []io.Reader
+	for _, s := range sources {
+		p, err := f.path("", s)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("compose source %s: %w", s, ErrObjectNotExist)
+		}
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+	_, err := f.Upload(ctx, object, io.MultiReader(readers...), metadata)
+	return err
+}
+
+// Delete removes the object and its metadata.
+func (f *Filesystem) Delete(ctx context.Context, object string) error {
+	p, err := f.path("", object)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); errors.Is(err, fs.ErrNotExist) {
+		return ErrObjectNotExist
+	} else if err != nil {
+		return err
+	}
+	if s, err := f.path(metadataDir, object+".json"); err == nil {
+		os.Remove(s)
+	}
+	return nil
+}
+
+// UpdateMetadata rewrites the object's metadata sidecar.
+func (f *Filesystem) UpdateMetadata(ctx context.Context, object string, metadata map[string]string) error {
+	attrs, err := f.Attrs(ctx, object)
+	if err != nil {
+		return err
+	}
+	return f.writeSidecar(object, sidecar{Created: attrs.Created, Metadata: metadata})
+}
+
+func (f *Filesystem) writeSidecar(object string, s sidecar) error {
+	p, err := f.path(metadataDir, object+".json")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0640)
+}
+
+func (f *Filesystem) readSidecar(object string) (sidecar, error) {
+	var s sidecar
+	p, err := f.path(metadataDir, object+".json")
+	if err != nil {
+		return s, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return s, err
+	}
+	return s, json.Unmarshal(data, &s)
+}
+
+// contextReader stops reading once the context is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
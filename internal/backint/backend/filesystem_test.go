@@ -0,0 +1,216 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestFilesystem(t *testing.T) *Filesystem {
+	t.Helper()
+	f, err := NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	return f
+}
+
+func TestNewFilesystemNoPath(t *testing.T) {
+	if _, err := NewFilesystem(""); err == nil {
+		t.Error("NewFilesystem(\"\") succeeded, want error")
+	}
+}
+
+func TestFilesystemInvalidObjectName(t *testing.T) {
+	f := newTestFilesystem(t)
+	for _, name := range []string{"", "../escape", "a/../../escape"} {
+		if _, err := f.Upload(context.Background(), name, strings.NewReader("data"), nil); err == nil {
+			t.Errorf("Upload(%q) succeeded, want error", name)
+		}
+	}
+}
+
+func TestFilesystemUploadDownload(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFilesystem(t)
+	metadata := map[string]string{"X-Backup-Type": "FILE"}
+	n, err := f.Upload(ctx, "test@TST/object.txt/1.bak", strings.NewReader("0123456789"), metadata)
+	if err != nil || n != 10 {
+		t.Fatalf("Upload() = (%d, %v), want (10, nil)", n, err)
+	}
+
+	tests := []struct {
+		name   string
+		offset int64
+		length int64
+		want   string
+	}{
+		{name: "All", length: -1, want: "0123456789"},
+		{name: "Range", offset: 2, length: 3, want: "234"},
+		{name: "Remainder", offset: 7, length: -1, want: "789"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if _, err := f.Download(ctx, "test@TST/object.txt/1.bak", buf, test.offset, test.length); err != nil {
+				t.Fatalf("Download() failed: %v", err)
+			}
+			if buf.String() != test.want {
+				t.Errorf("Download(%d, %d) = %q, want %q", test.offset, test.length, buf.String(), test.want)
+			}
+		})
+	}
+
+	attrs, err := f.Attrs(ctx, "test@TST/object.txt/1.bak")
+	if err != nil {
+		t.Fatalf("Attrs() failed: %v", err)
+	}
+	if diff := cmp.Diff(metadata, attrs.Metadata); diff != "" || attrs.Size != 10 {
+		t.Errorf("Attrs() returned size %d, metadata diff (-want +got):\n%s", attrs.Size, diff)
+	}
+}
+
+func TestFilesystemNotExist(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFilesystem(t)
+	if _, err := f.Download(ctx, "missing", &bytes.Buffer{}, 0, -1); !errors.Is(err, ErrObjectNotExist) {
+		t.Errorf("Download(missing) = %v, want ErrObjectNotExist", err)
+	}
+	if _, err := f.Attrs(ctx, "missing"); !errors.Is(err, ErrObjectNotExist) {
+		t.Errorf("Attrs(missing) = %v, want ErrObjectNotExist", err)
+	}
+	if err := f.Delete(ctx, "missing"); !errors.Is(err, ErrObjectNotExist) {
+		t.Errorf("Delete(missing) = %v, want ErrObjectNotExist", err)
+	}
+	if err := f.UpdateMetadata(ctx, "missing", nil); !errors.Is(err, ErrObjectNotExist) {
+		t.Errorf("UpdateMetadata(missing) = %v, want ErrObjectNotExist", err)
+	}
+}
+
+func TestFilesystemUpdateMetadata(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFilesystem(t)
+	if _, err := f.Upload(ctx, "object", strings.NewReader("data"), map[string]string{"X-Backup-Type": "FILE"}); err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	before, err := f.Attrs(ctx, "object")
+	if err != nil {
+		t.Fatalf("Attrs() failed: %v", err)
+	}
+	want := map[string]string{"X-Backup-Type": "FILE", "X-Backint-Kek": "keyring/k2"}
+	if err := f.UpdateMetadata(ctx, "object", want); err != nil {
+		t.Fatalf("UpdateMetadata() failed: %v", err)
+	}
+	after, err := f.Attrs(ctx, "object")
+	if err != nil {
+		t.Fatalf("Attrs() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, after.Metadata); diff != "" {
+		t.Errorf("UpdateMetadata() had unexpected metadata diff (-want +got):\n%s", diff)
+	}
+	if !after.Created.Equal(before.Created) {
+		t.Errorf("UpdateMetadata() changed creation time from %v to %v", before.Created, after.Created)
+	}
+}
+
+func TestFilesystemList(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFilesystem(t)
+	for _, name := range []string{"test@TST/a.txt/1.bak", "test@TST/a.txt/2.bak", "test@TST/b.txt/1.bak", "other/c.txt/1.bak"} {
+		if _, err := f.Upload(ctx, name, strings.NewReader(name), nil); err != nil {
+			t.Fatalf("Upload(%s) failed: %v", name, err)
+		}
+		// Ensure distinct creation times for ordering.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		filter string
+		want   []string
+	}{
+		{
+			name:   "PrefixLatestFirst",
+			prefix: "test@TST/a.txt/",
+			want:   []string{"test@TST/a.txt/2.bak", "test@TST/a.txt/1.bak"},
+		},
+		{
+			name:   "Filter",
+			prefix: "test@TST/",
+			filter: "1.bak",
+			want:   []string{"test@TST/b.txt/1.bak", "test@TST/a.txt/1.bak"},
+		},
+		{
+			name:   "NoneFound",
+			prefix: "missing/",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objects, err := f.List(ctx, test.prefix, test.filter)
+			if err != nil {
+				t.Fatalf("List() failed: %v", err)
+			}
+			var got []string
+			for _, o := range objects {
+				got = append(got, o.Name)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("List(%q, %q) had unexpected diff (-want +got):\n%s", test.prefix, test.filter, diff)
+			}
+		})
+	}
+}
+
+func TestFilesystemComposeAndDelete(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFilesystem(t)
+	sources := []string{"obj.bak0", "obj.bak1", "obj.bak2"}
+	for i, s := range sources {
+		if _, err := f.Upload(ctx, s, strings.NewReader(strings.Repeat(string(rune('a'+i)), 3)), nil); err != nil {
+			t.Fatalf("Upload(%s) failed: %v", s, err)
+		}
+	}
+	if err := f.Compose(ctx, "obj.bak", sources, map[string]string{"X-Backup-Type": "FILE"}); err != nil {
+		t.Fatalf("Compose() failed: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := f.Download(ctx, "obj.bak", buf, 0, -1); err != nil || buf.String() != "aaabbbccc" {
+		t.Errorf("Download(obj.bak) = (%q, %v), want (%q, nil)", buf.String(), err, "aaabbbccc")
+	}
+	if err := f.Compose(ctx, "bad.bak", []string{"missing"}, nil); !errors.Is(err, ErrObjectNotExist) {
+		t.Errorf("Compose(missing) = %v, want ErrObjectNotExist", err)
+	}
+
+	for _, s := range sources {
+		if err := f.Delete(ctx, s); err != nil {
+			t.Errorf("Delete(%s) failed: %v", s, err)
+		}
+	}
+	objects, err := f.List(ctx, "", "")
+	if err != nil || len(objects) != 1 || objects[0].Name != "obj.bak" {
+		t.Errorf("List() after Delete() = (%v, %v), want only obj.bak", objects, err)
+	}
+}
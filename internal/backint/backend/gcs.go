@@ -0,0 +1,176 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"time"
+
+	store "cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/ratelimit"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+// GCS stores objects in a Cloud Storage bucket.
+type GCS struct {
+	bucketHandle *store.BucketHandle
+	config       *bpb.BackintConfiguration
+}
+
+// NewGCS returns a backend for the bucket. A nil bucket handle is
+// allowed, in which case every operation returns an error.
+func NewGCS(bucketHandle *store.BucketHandle, config *bpb.BackintConfiguration) *GCS {
+	return &GCS{bucketHandle: bucketHandle, config: config}
+}
+
+// BucketHandle returns the underlying bucket handle so callers can use
+// Cloud Storage specific features such as parallel downloads.
+func (g *GCS) BucketHandle() *store.BucketHandle {
+	return g.bucketHandle
+}
+
+// Upload writes the reader to the object using the configured storage options.
+func (g *GCS) Upload(ctx context.Context, object string, reader io.Reader, metadata map[string]string) (int64, error) {
+	if g.bucketHandle == nil {
+		return 0, fmt.Errorf("no bucket handle defined, cannot upload %s", object)
+	}
+	rw := storage.ReadWriter{
+		Reader:                 reader,
+		Copier:                 io.Copy,
+		BucketHandle:           g.bucketHandle,
+		BucketName:             g.config.GetBucket(),
+		ChunkSizeMb:            g.config.GetBufferSizeMb(),
+		ObjectName:             object,
+		RateLimitBytes:         ratelimit.StaticBytes(g.config),
+		EncryptionKey:          g.config.GetEncryptionKey(),
+		KMSKey:                 g.config.GetKmsKey(),
+		MaxRetries:             g.config.GetRetries(),
+		VerifyUpload:           true,
+		Metadata:               metadata,
+		RetryBackoffInitial:    time.Duration(g.config.GetRetryBackoffInitial()) * time.Second,
+		RetryBackoffMax:        time.Duration(g.config.GetRetryBackoffMax()) * time.Second,
+		RetryBackoffMultiplier: float64(g.config.GetRetryBackoffMultiplier()),
+	}
+	return rw.Upload(ctx)
+}
+
+// Download copies the requested range of the object into the writer.
+func (g *GCS) Download(ctx context.Context, object string, writer io.Writer, offset, length int64) (int64, error) {
+	if g.bucketHandle == nil {
+		return 0, fmt.Errorf("no bucket handle defined, cannot download %s", object)
+	}
+	r, err := g.object(object).NewRangeReader(ctx, offset, length)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(writer, r)
+}
+
+// List returns the objects matching the prefix and filter, latest first.
+func (g *GCS) List(ctx context.Context, prefix, filter string) ([]*ObjectAttrs, error) {
+	objects, err := storage.ListObjects(ctx, g.bucketHandle, prefix, filter, g.config.GetRetries())
+	if err != nil {
+		return nil, err
+	}
+	var result []*ObjectAttrs
+	for _, o := range objects {
+		result = append(result, fromGCS(o))
+	}
+	return result, nil
+}
+
+// Attrs returns the attributes of the object.
+func (g *GCS) Attrs(ctx context.Context, object string) (*ObjectAttrs, error) {
+	if g.bucketHandle == nil {
+		return nil, fmt.Errorf("no bucket handle defined, cannot get attributes of %s", object)
+	}
+	attrs, err := g.bucketHandle.Object(object).Attrs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return fromGCS(attrs), nil
+}
+
+// Compose combines up to 32 sources into the object.
+func (g *GCS) Compose(ctx context.Context, object string, sources []string, metadata map[string]string) error {
+	if g.bucketHandle == nil {
+		return fmt.Errorf("no bucket handle defined, cannot compose %s", object)
+	}
+	var srcs []*store.ObjectHandle
+	for _, s := range sources {
+		srcs = append(srcs, g.bucketHandle.Object(s))
+	}
+	// Default to octet-stream, but set to the first source's content type if available.
+	contentType := "application/octet-stream"
+	if len(sources) > 0 {
+		if attrs, err := g.bucketHandle.Object(sources[0]).Attrs(ctx); err == nil {
+			contentType = attrs.ContentType
+		}
+	}
+	composer := g.bucketHandle.Object(object).ComposerFrom(srcs...)
+	composer.ObjectAttrs.ContentType = contentType
+	composer.ObjectAttrs.Metadata = metadata
+	_, err := composer.Run(ctx)
+	return err
+}
+
+// Delete removes the object.
+func (g *GCS) Delete(ctx context.Context, object string) error {
+	return storage.DeleteObject(ctx, g.bucketHandle, object, g.config.GetRetries())
+}
+
+// UpdateMetadata patches the object's metadata.
+func (g *GCS) UpdateMetadata(ctx context.Context, object string, metadata map[string]string) error {
+	if g.bucketHandle == nil {
+		return fmt.Errorf("no bucket handle defined, cannot update metadata of %s", object)
+	}
+	_, err := g.bucketHandle.Object(object).Update(ctx, store.ObjectAttrsToUpdate{Metadata: metadata})
+	return err
+}
+
+// object returns a handle for the object, applying the customer-supplied
+// encryption key if one is configured.
+func (g *GCS) object(name string) *store.ObjectHandle {
+	o := g.bucketHandle.Object(name)
+	if g.config.GetEncryptionKey() != "" {
+		if key, err := base64.StdEncoding.DecodeString(g.config.GetEncryptionKey()); err == nil {
+			o = o.Key(key)
+		}
+	}
+	return o
+}
+
+func fromGCS(attrs *store.ObjectAttrs) *ObjectAttrs {
+	o := &ObjectAttrs{
+		Name:       attrs.Name,
+		Size:       attrs.Size,
+		Created:    attrs.Created,
+		Metadata:   attrs.Metadata,
+		CustomTime: attrs.CustomTime,
+		CRC32C:     attrs.CRC32C,
+		HasCRC32C:  true,
+	}
+	if attrs.Retention != nil {
+		o.RetainUntil = attrs.Retention.RetainUntil
+	}
+	return o
+}
@@ -0,0 +1,680 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+const (
+	s3MetadataPrefix = "x-amz-meta-"
+	s3UnsignedBody   = "UNSIGNED-PAYLOAD"
+	s3DefaultRegion  = "us-east-1"
+
+	// Multipart upload limits: every part except the last must be at least
+	// s3MinPartSize, and parts, including the byte ranges copied by
+	// UploadPartCopy, can be at most s3MaxPartSize. An upload has at most
+	// s3MaxParts parts. s3MaxPartSize is also the largest single CopyObject.
+	s3MinPartSize = 5 * 1024 * 1024
+	s3MaxPartSize = 5 * 1024 * 1024 * 1024
+	s3MaxParts    = 10000
+
+	// s3ListConcurrency is the number of HEAD requests List sends at a time.
+	s3ListConcurrency = 16
+)
+
+// s3CreatedMetadataKey records the original creation time of objects whose
+// metadata was replaced, since replacing it resets Last-Modified.
+const s3CreatedMetadataKey = "X-Backint-Created"
+
+// S3 stores objects in an S3-compatible object store. Requests are signed
+// with AWS Signature Version 4.
+type S3 struct {
+	client    *http.Client
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	partSize  int64
+	now       func() time.Time
+
+	// The multipart limits are fields so tests can lower them.
+	minPartSize int64
+	maxPartSize int64
+	maxParts    int
+}
+
+// s3Credentials is the format of the s3_credentials_file.
+type s3Credentials struct {
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+}
+
+// NewS3 returns a backend for the configured S3 endpoint and bucket.
+func NewS3(config *bpb.BackintConfiguration) (*S3, error) {
+	endpoint, err := url.Parse(config.GetS3Endpoint())
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3_endpoint %q: %v", config.GetS3Endpoint(), err)
+	}
+	if endpoint.Scheme != "https" && endpoint.Scheme != "http" {
+		return nil, fmt.Errorf("invalid s3_endpoint %q: the scheme must be https or http", config.GetS3Endpoint())
+	}
+	creds := s3Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+	}
+	if config.GetS3CredentialsFile() != "" {
+		data, err := os.ReadFile(config.GetS3CredentialsFile())
+		if err != nil {
+			return nil, fmt.Errorf("reading s3_credentials_file: %v", err)
+		}
+		if err := json.Unmarshal(data, &creds); err != nil {
+			return nil, fmt.Errorf("parsing s3_credentials_file: %v", err)
+		}
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 credentials not found, set s3_credentials_file or the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables")
+	}
+	region := config.GetS3Region()
+	if region == "" {
+		region = s3DefaultRegion
+	}
+	partSize := config.GetBufferSizeMb() * 1024 * 1024
+	if partSize < s3MinPartSize {
+		partSize = s3MinPartSize
+	}
+	return &S3{
+		client:      &http.Client{},
+		endpoint:    endpoint,
+		bucket:      config.GetBucket(),
+		region:      region,
+		accessKey:   creds.AccessKeyID,
+		secretKey:   creds.SecretAccessKey,
+		pathStyle:   config.GetS3PathStyle(),
+		partSize:    partSize,
+		now:         time.Now,
+		minPartSize: s3MinPartSize,
+		maxPartSize: s3MaxPartSize,
+		maxParts:    s3MaxParts,
+	}, nil
+}
+
+// uploadPartSize returns the size of the given part of a multipart upload.
+// Parts start at buffer_size_mb, or larger if the object's size is known and
+// would not fit in the part limit. The size doubles every tenth of the part
+// limit, so objects of unknown size, such as pipes, can reach the maximum
+// object size while small objects keep small parts.
+func (s *S3) uploadPartSize(part int, size int64) int64 {
+	partSize := s.partSize
+	if fit := (size + int64(s.maxParts) - 1) / int64(s.maxParts); fit > partSize {
+		partSize = (fit + 1024*1024 - 1) / (1024 * 1024) * 1024 * 1024
+	}
+	for i := s.maxParts / 10; i > 0 && part > i && partSize < s.maxPartSize; i += s.maxParts / 10 {
+		partSize *= 2
+	}
+	if partSize > s.maxPartSize {
+		partSize = s.maxPartSize
+	}
+	return partSize
+}
+
+// Upload stores the reader as the object. Data larger than a single part is
+// sent as a multipart upload so memory use is bounded by the part size, which
+// grows with the object's size as described by uploadPartSize.
+func (s *S3) Upload(ctx context.Context, object string, reader io.Reader, metadata map[string]string) (int64, error) {
+	size := int64(-1)
+	if r, ok := reader.(*sizedReader); ok {
+		size = r.size
+	}
+	buf := make([]byte, s.uploadPartSize(1, size))
+	n, err := io.ReadFull(reader, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		header := metadataHeader(metadata)
+		_, err := s.do(ctx, http.MethodPut, object, nil, header, buf[:n], http.StatusOK)
+		return int64(n), err
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	uploadID, err := s.createMultipartUpload(ctx, object, metadata)
+	if err != nil {
+		return 0, err
+	}
+	var parts []s3Part
+	var total int64
+	for part := 1; n > 0; part++ {
+		if part > s.maxParts {
+			s.abortMultipartUpload(ctx, object, uploadID)
+			return total, fmt.Errorf("object %s exceeds the limit of %d parts", object, s.maxParts)
+		}
+		resp, err := s.do(ctx, http.MethodPut, object, url.Values{"partNumber": {strconv.Itoa(part)}, "uploadId": {uploadID}}, nil, buf[:n], http.StatusOK)
+		if err != nil {
+			s.abortMultipartUpload(ctx, object, uploadID)
+			return total, err
+		}
+		parts = append(parts, s3Part{PartNumber: part, ETag: resp.Header.Get("ETag")})
+		total += int64(n)
+		if partSize := s.uploadPartSize(part+1, size); partSize != int64(len(buf)) {
+			buf = make([]byte, partSize)
+		}
+		n, err = io.ReadFull(reader, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			s.abortMultipartUpload(ctx, object, uploadID)
+			return total, err
+		}
+	}
+	if err := s.completeMultipartUpload(ctx, object, uploadID, parts); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// Download copies the requested range of the object into the writer.
+func (s *S3) Download(ctx context.Context, object string, writer io.Writer, offset, length int64) (int64, error) {
+	header := http.Header{}
+	if offset > 0 || length >= 0 {
+		r := fmt.Sprintf("bytes=%d-", offset)
+		if length >= 0 {
+			if length == 0 {
+				return 0, nil
+			}
+			r += strconv.FormatInt(offset+length-1, 10)
+		}
+		header.Set("Range", r)
+	}
+	req, err := s.request(ctx, http.MethodGet, object, nil, header, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, http.StatusOK, http.StatusPartialContent); err != nil {
+		return 0, err
+	}
+	return io.Copy(writer, resp.Body)
+}
+
+// List pages through ListObjectsV2 for the prefix, latest first. S3 does not
+// record a creation time, so the last modified time is used. ListObjectsV2
+// does not return metadata, so the attributes of the listed objects are read
+// with concurrent HEAD requests.
+func (s *S3) List(ctx context.Context, prefix, filter string) ([]*ObjectAttrs, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil, nil, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parsing list response: %v", err)
+		}
+		for _, c := range result.Contents {
+			if matches(c.Key, prefix, filter) {
+				keys = append(keys, c.Key)
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	objects := make([]*ObjectAttrs, len(keys))
+	errs := make([]error, len(keys))
+	sem := make(chan struct{}, s3ListConcurrency)
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			objects[i], errs[i] = s.Attrs(ctx, key)
+		}()
+	}
+	wg.Wait()
+	var listed []*ObjectAttrs
+	for i, err := range errs {
+		// Objects deleted since they were listed are skipped.
+		if errors.Is(err, ErrObjectNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		listed = append(listed, objects[i])
+	}
+	sortLatestFirst(listed)
+	return listed, nil
+}
+
+// Attrs returns the attributes of the object from a HEAD request.
+func (s *S3) Attrs(ctx context.Context, object string) (*ObjectAttrs, error) {
+	resp, err := s.do(ctx, http.MethodHead, object, nil, nil, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	attrs := &ObjectAttrs{Name: object, Size: resp.ContentLength}
+	if t, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		attrs.Created = t
+	}
+	for k, v := range resp.Header {
+		if lk := strings.ToLower(k); strings.HasPrefix(lk, s3MetadataPrefix) && len(v) > 0 {
+			key := canonicalMetadataKey(strings.TrimPrefix(lk, s3MetadataPrefix))
+			if key == s3CreatedMetadataKey {
+				if t, err := time.Parse(time.RFC3339Nano, v[0]); err == nil {
+					attrs.Created = t
+				}
+				continue
+			}
+			if attrs.Metadata == nil {
+				attrs.Metadata = make(map[string]string)
+			}
+			attrs.Metadata[key] = v[0]
+		}
+	}
+	return attrs, nil
+}
+
+// Compose copies the sources into a new multipart upload for the object.
+// Sources larger than the part limit are copied in byte ranges. Parallel
+// upload chunks are sized fileSize/parallel_streams, so if a source other
+// than the last is smaller than the minimum part size, which UploadPartCopy
+// rejects, the sources are downloaded and uploaded as the object instead.
+func (s *S3) Compose(ctx context.Context, object string, sources []string, metadata map[string]string) error {
+	var total int64
+	small := false
+	sizes := make([]int64, len(sources))
+	for i, src := range sources {
+		attrs, err := s.Attrs(ctx, src)
+		if err != nil {
+			return fmt.Errorf("reading part %s: %w", src, err)
+		}
+		sizes[i] = attrs.Size
+		total += attrs.Size
+		small = small || (i < len(sources)-1 && attrs.Size < s.minPartSize)
+	}
+	if small {
+		return s.composeByUpload(ctx, object, sources, total, metadata)
+	}
+
+	uploadID, err := s.createMultipartUpload(ctx, object, metadata)
+	if err != nil {
+		return err
+	}
+	var parts []s3Part
+	for i, src := range sources {
+		srcParts, err := s.copyParts(ctx, object, uploadID, src, sizes[i], len(parts)+1)
+		if err != nil {
+			s.abortMultipartUpload(ctx, object, uploadID)
+			return fmt.Errorf("copying part %s: %v", src, err)
+		}
+		parts = append(parts, srcParts...)
+	}
+	return s.completeMultipartUpload(ctx, object, uploadID, parts)
+}
+
+// composeByUpload streams the sources, in order, into a new upload of the object.
+func (s *S3) composeByUpload(ctx context.Context, object string, sources []string, total int64, metadata map[string]string) error {
+	reader, writer := io.Pipe()
+	go func() {
+		for _, src := range sources {
+			if _, err := s.Download(ctx, src, writer, 0, -1); err != nil {
+				writer.CloseWithError(fmt.Errorf("reading part %s: %v", src, err))
+				return
+			}
+		}
+		writer.Close()
+	}()
+	_, err := s.Upload(ctx, object, WithSize(reader, total), metadata)
+	// Unblocks the download if the upload failed.
+	reader.CloseWithError(io.ErrClosedPipe)
+	return err
+}
+
+// copyParts copies the source into the multipart upload starting at the part
+// number, splitting it into byte ranges no larger than the part limit.
+func (s *S3) copyParts(ctx context.Context, object, uploadID, src string, size int64, firstPart int) ([]s3Part, error) {
+	ranges := []string{""}
+	if size > s.maxPartSize {
+		n := (size + s.maxPartSize - 1) / s.maxPartSize
+		step := (size + n - 1) / n
+		ranges = nil
+		for start := int64(0); start < size; start += step {
+			end := min(start+step, size) - 1
+			ranges = append(ranges, fmt.Sprintf("bytes=%d-%d", start, end))
+		}
+	}
+	var parts []s3Part
+	for i, r := range ranges {
+		part := firstPart + i
+		header := http.Header{}
+		header.Set("x-amz-copy-source", "/"+s.bucket+"/"+escapePath(src))
+		if r != "" {
+			header.Set("x-amz-copy-source-range", r)
+		}
+		resp, err := s.do(ctx, http.MethodPut, object, url.Values{"partNumber": {strconv.Itoa(part)}, "uploadId": {uploadID}}, header, nil, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			ETag string `xml:"ETag"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parsing copy part response: %v", err)
+		}
+		parts = append(parts, s3Part{PartNumber: part, ETag: result.ETag})
+	}
+	return parts, nil
+}
+
+// Delete removes the object. S3 deletes are idempotent, so the object is
+// checked first in order to report ErrObjectNotExist.
+func (s *S3) Delete(ctx context.Context, object string) error {
+	if _, err := s.Attrs(ctx, object); err != nil {
+		return err
+	}
+	_, err := s.do(ctx, http.MethodDelete, object, nil, nil, nil, http.StatusNoContent, http.StatusOK)
+	return err
+}
+
+// UpdateMetadata copies the object onto itself, replacing its metadata.
+// Objects larger than a single CopyObject allows are copied with a
+// multipart copy.
+func (s *S3) UpdateMetadata(ctx context.Context, object string, metadata map[string]string) error {
+	attrs, err := s.Attrs(ctx, object)
+	if err != nil {
+		return err
+	}
+	replaced := make(map[string]string)
+	for k, v := range metadata {
+		replaced[k] = v
+	}
+	replaced[s3CreatedMetadataKey] = attrs.Created.UTC().Format(time.RFC3339Nano)
+	if attrs.Size > s.maxPartSize {
+		uploadID, err := s.createMultipartUpload(ctx, object, replaced)
+		if err != nil {
+			return err
+		}
+		parts, err := s.copyParts(ctx, object, uploadID, object, attrs.Size, 1)
+		if err != nil {
+			s.abortMultipartUpload(ctx, object, uploadID)
+			return fmt.Errorf("copying %s: %v", object, err)
+		}
+		return s.completeMultipartUpload(ctx, object, uploadID, parts)
+	}
+	header := metadataHeader(replaced)
+	header.Set("x-amz-copy-source", "/"+s.bucket+"/"+escapePath(object))
+	header.Set("x-amz-metadata-directive", "REPLACE")
+	resp, err := s.do(ctx, http.MethodPut, object, nil, header, nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type s3Part struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3) createMultipartUpload(ctx context.Context, object string, metadata map[string]string) (string, error) {
+	resp, err := s.do(ctx, http.MethodPost, object, url.Values{"uploads": {""}}, metadataHeader(metadata), nil, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("parsing create multipart upload response: %v", err)
+	}
+	return result.UploadID, nil
+}
+
+func (s *S3) completeMultipartUpload(ctx context.Context, object, uploadID string, parts []s3Part) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []s3Part `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPost, object, url.Values{"uploadId": {uploadID}}, nil, body, http.StatusOK)
+	if err != nil {
+		s.abortMultipartUpload(ctx, object, uploadID)
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3) abortMultipartUpload(ctx context.Context, object, uploadID string) {
+	if resp, err := s.do(ctx, http.MethodDelete, object, url.Values{"uploadId": {uploadID}}, nil, nil, http.StatusNoContent); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// do sends a signed request and checks the response status. The caller
+// must close the response body on success.
+func (s *S3) do(ctx context.Context, method, object string, query url.Values, header http.Header, body []byte, wantStatus ...int) (*http.Response, error) {
+	req, err := s.request(ctx, method, object, query, header, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, wantStatus...); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// request builds a request for the object and signs it.
+func (s *S3) request(ctx context.Context, method, object string, query url.Values, header http.Header, body []byte) (*http.Request, error) {
+	u := *s.endpoint
+	path := "/"
+	if s.pathStyle {
+		path += s.bucket + "/"
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	path += object
+	u.Path = path
+	u.RawPath = escapePath(path)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	s.sign(req, body)
+	return req, nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request.
+// The payload is only left out of the signature over TLS, since over plain
+// http it could otherwise be altered in transit.
+func (s *S3) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := s3UnsignedBody
+	if req.URL.Scheme != "https" {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	req.Header.Set("Host", req.URL.Host)
+
+	var names []string
+	for k := range req.Header {
+		names = append(names, strings.ToLower(k))
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(hash[:])}, "\n")
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// checkResponse returns an error if the response status is not wanted.
+// A 404 maps to ErrObjectNotExist.
+func checkResponse(resp *http.Response, wantStatus ...int) error {
+	for _, s := range wantStatus {
+		if resp.StatusCode == s {
+			return nil
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrObjectNotExist
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("S3 request %s %s failed with status %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, body)
+}
+
+// metadataHeader converts object metadata to x-amz-meta-* headers.
+func metadataHeader(metadata map[string]string) http.Header {
+	header := http.Header{}
+	for k, v := range metadata {
+		header.Set(s3MetadataPrefix+strings.ToLower(k), v)
+	}
+	return header
+}
+
+// canonicalMetadataKey restores the casing of metadata keys such as
+// X-Backup-Type, which S3 returns in lower case.
+func canonicalMetadataKey(key string) string {
+	return http.CanonicalHeaderKey(key)
+}
+
+// escapePath URI-encodes each segment of the path as required by SigV4.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = uriEncode(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery encodes the query sorted by key with SigV4 escaping.
+func canonicalQuery(query url.Values) string {
+	var keys []string
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			pairs = append(pairs, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode escapes everything except the unreserved characters A-Z, a-z, 0-9, '-', '.', '_' and '~'.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,534 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+// fakeS3 is an in-memory, path-style S3 server supporting the requests made by the backend.
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string]*fakeS3Object
+	uploads  map[string]map[int][]byte
+	uploadMD map[string]http.Header
+	nextID   int
+	now      time.Time
+	// copies counts the UploadPartCopy requests.
+	copies int
+	// payloadHashes records the x-amz-content-sha256 header of each request.
+	payloadHashes []string
+}
+
+type fakeS3Object struct {
+	data     []byte
+	header   http.Header
+	modified time.Time
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{
+		objects:  make(map[string]*fakeS3Object),
+		uploads:  make(map[string]map[int][]byte),
+		uploadMD: make(map[string]http.Header),
+		now:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	// Path-style requests are /<bucket>/<key>.
+	key := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[1:]
+	name := strings.Join(key, "")
+	query := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+	f.payloadHashes = append(f.payloadHashes, r.Header.Get("x-amz-content-sha256"))
+
+	switch {
+	case r.Method == http.MethodGet && query.Get("list-type") == "2":
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, query.Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		// Return one key per page to exercise pagination.
+		start := 0
+		if token := query.Get("continuation-token"); token != "" {
+			start, _ = strconv.Atoi(token)
+		}
+		type content struct {
+			Key string `xml:"Key"`
+		}
+		result := struct {
+			XMLName               xml.Name  `xml:"ListBucketResult"`
+			Contents              []content `xml:"Contents"`
+			IsTruncated           bool      `xml:"IsTruncated"`
+			NextContinuationToken string    `xml:"NextContinuationToken,omitempty"`
+		}{}
+		if start < len(keys) {
+			result.Contents = []content{{Key: keys[start]}}
+		}
+		if start+1 < len(keys) {
+			result.IsTruncated = true
+			result.NextContinuationToken = strconv.Itoa(start + 1)
+		}
+		xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.nextID++
+		id := fmt.Sprintf("upload-%d", f.nextID)
+		f.uploads[id] = make(map[int][]byte)
+		f.uploadMD[id] = r.Header.Clone()
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		parts, ok := f.uploads[query.Get("uploadId")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		part, _ := strconv.Atoi(query.Get("partNumber"))
+		if src := r.Header.Get("x-amz-copy-source"); src != "" {
+			srcName, _ := url.PathUnescape(strings.SplitN(strings.TrimPrefix(src, "/"), "/", 2)[1])
+			o, ok := f.objects[srcName]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			data := o.data
+			if rng := r.Header.Get("x-amz-copy-source-range"); rng != "" {
+				var start, end int
+				fmt.Sscanf(rng, "bytes=%d-%d", &start, &end)
+				data = data[start : end+1]
+			}
+			f.copies++
+			parts[part] = data
+			fmt.Fprintf(w, "<CopyPartResult><ETag>\"etag-%d\"</ETag></CopyPartResult>", part)
+			return
+		}
+		parts[part] = body
+		w.Header().Set("ETag", fmt.Sprintf("\"etag-%d\"", part))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		id := query.Get("uploadId")
+		parts := f.uploads[id]
+		var data []byte
+		for i := 1; i <= len(parts); i++ {
+			data = append(data, parts[i]...)
+		}
+		f.put(name, data, f.uploadMD[id])
+		delete(f.uploads, id)
+		fmt.Fprint(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(f.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && r.Header.Get("x-amz-copy-source") != "":
+		srcName, _ := url.PathUnescape(strings.SplitN(strings.TrimPrefix(r.Header.Get("x-amz-copy-source"), "/"), "/", 2)[1])
+		o, ok := f.objects[srcName]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		header := o.header
+		if r.Header.Get("x-amz-metadata-directive") == "REPLACE" {
+			header = r.Header
+		}
+		f.put(name, o.data, header)
+		fmt.Fprint(w, "<CopyObjectResult></CopyObjectResult>")
+	case r.Method == http.MethodPut:
+		f.put(name, body, r.Header)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		o, ok := f.objects[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for k, v := range o.header {
+			if strings.HasPrefix(strings.ToLower(k), s3MetadataPrefix) {
+				w.Header()[k] = v
+			}
+		}
+		w.Header().Set("Last-Modified", o.modified.Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(o.data)))
+		if r.Method == http.MethodHead {
+			return
+		}
+		data := o.data
+		if rng := r.Header.Get("Range"); rng != "" {
+			var start, end int
+			if _, err := fmt.Sscanf(rng, "bytes=%d-%d", &start, &end); err != nil {
+				end = len(data) - 1
+			}
+			data = data[start : end+1]
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeS3) put(name string, data []byte, header http.Header) {
+	f.now = f.now.Add(time.Second)
+	f.objects[name] = &fakeS3Object{data: data, header: header, modified: f.now}
+}
+
+func newTestS3(t *testing.T, server *httptest.Server) *S3 {
+	t.Helper()
+	credentials := filepath.Join(t.TempDir(), "credentials.json")
+	os.WriteFile(credentials, []byte(`{"access_key_id": "access", "secret_access_key": "secret"}`), 0600)
+	s, err := NewS3(&bpb.BackintConfiguration{
+		Bucket:            "test-bucket",
+		S3Endpoint:        server.URL,
+		S3CredentialsFile: credentials,
+		S3PathStyle:       true,
+		BufferSizeMb:      1,
+	})
+	if err != nil {
+		t.Fatalf("NewS3() failed: %v", err)
+	}
+	return s
+}
+
+func TestNewS3(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *bpb.BackintConfiguration
+	}{
+		{
+			name:   "InvalidEndpoint",
+			config: &bpb.BackintConfiguration{S3Endpoint: "not a url"},
+		},
+		{
+			name:   "UnsupportedScheme",
+			config: &bpb.BackintConfiguration{S3Endpoint: "ftp://s3.example.com"},
+		},
+		{
+			name:   "MissingCredentialsFile",
+			config: &bpb.BackintConfiguration{S3Endpoint: "https://s3.example.com", S3CredentialsFile: "/does/not/exist"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewS3(test.config); err == nil {
+				t.Errorf("NewS3(%v) succeeded, want error", test.config)
+			}
+		})
+	}
+}
+
+func TestS3UploadDownload(t *testing.T) {
+	ctx := context.Background()
+	_, server := newFakeS3(t)
+	s := newTestS3(t, server)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "Empty", data: nil},
+		{name: "SinglePart", data: []byte("0123456789")},
+		// Larger than the 5MiB minimum part size, forcing a multipart upload.
+		{name: "Multipart", data: bytes.Repeat([]byte("0123456789"), 600*1024)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			object := "test@TST/" + test.name + "/1.bak"
+			n, err := s.Upload(ctx, object, bytes.NewReader(test.data), map[string]string{"X-Backup-Type": "FILE"})
+			if err != nil || n != int64(len(test.data)) {
+				t.Fatalf("Upload() = (%d, %v), want (%d, nil)", n, err, len(test.data))
+			}
+			buf := &bytes.Buffer{}
+			if _, err := s.Download(ctx, object, buf, 0, -1); err != nil {
+				t.Fatalf("Download() failed: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), test.data) {
+				t.Errorf("Download() returned %d bytes which do not match the %d uploaded", buf.Len(), len(test.data))
+			}
+			attrs, err := s.Attrs(ctx, object)
+			if err != nil {
+				t.Fatalf("Attrs() failed: %v", err)
+			}
+			if attrs.Size != int64(len(test.data)) || attrs.Metadata["X-Backup-Type"] != "FILE" {
+				t.Errorf("Attrs() = %+v, want size %d and X-Backup-Type FILE", attrs, len(test.data))
+			}
+		})
+	}
+}
+
+func TestS3RangedDownload(t *testing.T) {
+	ctx := context.Background()
+	_, server := newFakeS3(t)
+	s := newTestS3(t, server)
+	if _, err := s.Upload(ctx, "object", strings.NewReader("0123456789"), nil); err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := s.Download(ctx, "object", buf, 2, 3); err != nil || buf.String() != "234" {
+		t.Errorf("Download(2, 3) = (%q, %v), want (%q, nil)", buf.String(), err, "234")
+	}
+}
+
+func TestS3ListComposeDelete(t *testing.T) {
+	ctx := context.Background()
+	_, server := newFakeS3(t)
+	s := newTestS3(t, server)
+	for _, name := range []string{"test@TST/a.txt/1.bak0", "test@TST/a.txt/1.bak1", "other/b.txt/1.bak"} {
+		if _, err := s.Upload(ctx, name, strings.NewReader(name), nil); err != nil {
+			t.Fatalf("Upload(%s) failed: %v", name, err)
+		}
+	}
+	objects, err := s.List(ctx, "test@TST/", "")
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	var got []string
+	for _, o := range objects {
+		got = append(got, o.Name)
+	}
+	if diff := cmp.Diff([]string{"test@TST/a.txt/1.bak1", "test@TST/a.txt/1.bak0"}, got); diff != "" {
+		t.Errorf("List() had unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if err := s.Compose(ctx, "test@TST/a.txt/1.bak", []string{"test@TST/a.txt/1.bak0", "test@TST/a.txt/1.bak1"}, map[string]string{"X-Backup-Type": "FILE"}); err != nil {
+		t.Fatalf("Compose() failed: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	want := "test@TST/a.txt/1.bak0test@TST/a.txt/1.bak1"
+	if _, err := s.Download(ctx, "test@TST/a.txt/1.bak", buf, 0, -1); err != nil || buf.String() != want {
+		t.Errorf("Download(composed) = (%q, %v), want (%q, nil)", buf.String(), err, want)
+	}
+
+	if err := s.Delete(ctx, "test@TST/a.txt/1.bak0"); err != nil {
+		t.Errorf("Delete() failed: %v", err)
+	}
+	if err := s.Delete(ctx, "test@TST/a.txt/1.bak0"); !errors.Is(err, ErrObjectNotExist) {
+		t.Errorf("Delete(deleted) = %v, want ErrObjectNotExist", err)
+	}
+}
+
+func TestS3UploadPartSize(t *testing.T) {
+	const mib = 1024 * 1024
+	s := &S3{partSize: 16 * mib, maxPartSize: s3MaxPartSize, maxParts: s3MaxParts}
+	tests := []struct {
+		name string
+		part int
+		size int64
+		want int64
+	}{
+		{name: "UnknownSize", part: 1, size: -1, want: 16 * mib},
+		{name: "SmallObject", part: 1, size: 100 * mib, want: 16 * mib},
+		{name: "LargeObject", part: 1, size: 1024 * 1024 * mib, want: 105 * mib},
+		{name: "GrowsAfterATenthOfTheParts", part: 1001, size: -1, want: 32 * mib},
+		{name: "GrowsAgain", part: 2001, size: -1, want: 64 * mib},
+		{name: "CappedAtMaxPartSize", part: 9001, size: -1, want: s3MaxPartSize},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := s.uploadPartSize(test.part, test.size); got != test.want {
+				t.Errorf("uploadPartSize(%d, %d) = %d, want %d", test.part, test.size, got, test.want)
+			}
+		})
+	}
+	// The growing parts reach the maximum S3 object size of 5TiB.
+	var total int64
+	for part := 1; part <= s3MaxParts; part++ {
+		total += s.uploadPartSize(part, -1)
+	}
+	if total < 5*1024*1024*mib {
+		t.Errorf("uploadPartSize() allows %d bytes in %d parts, want at least 5TiB", total, s3MaxParts)
+	}
+}
+
+func TestS3ComposeLargeSources(t *testing.T) {
+	ctx := context.Background()
+	fake, server := newFakeS3(t)
+	s := newTestS3(t, server)
+	data := []string{"00000000001111111111", "22222222223333333333"}
+	for i, d := range data {
+		if _, err := s.Upload(ctx, fmt.Sprintf("object%d", i), strings.NewReader(d), nil); err != nil {
+			t.Fatalf("Upload() failed: %v", err)
+		}
+	}
+	s.minPartSize, s.maxPartSize = 1, 8
+	if err := s.Compose(ctx, "object", []string{"object0", "object1"}, nil); err != nil {
+		t.Fatalf("Compose() failed: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := s.Download(ctx, "object", buf, 0, -1); err != nil || buf.String() != data[0]+data[1] {
+		t.Errorf("Download(composed) = (%q, %v), want (%q, nil)", buf.String(), err, data[0]+data[1])
+	}
+	// Each 20 byte source is copied in 3 ranges of at most 8 bytes.
+	if fake.copies != 6 {
+		t.Errorf("Compose() sent %d UploadPartCopy requests, want 6", fake.copies)
+	}
+}
+
+func TestS3ComposeSmallSourcesUploads(t *testing.T) {
+	ctx := context.Background()
+	fake, server := newFakeS3(t)
+	s := newTestS3(t, server)
+	for _, name := range []string{"object0", "object1"} {
+		if _, err := s.Upload(ctx, name, strings.NewReader(name), nil); err != nil {
+			t.Fatalf("Upload() failed: %v", err)
+		}
+	}
+	if err := s.Compose(ctx, "object", []string{"object0", "object1"}, map[string]string{"X-Backup-Type": "FILE"}); err != nil {
+		t.Fatalf("Compose() failed: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := s.Download(ctx, "object", buf, 0, -1); err != nil || buf.String() != "object0object1" {
+		t.Errorf("Download(composed) = (%q, %v), want (%q, nil)", buf.String(), err, "object0object1")
+	}
+	if fake.copies != 0 {
+		t.Errorf("Compose() of sources below the minimum part size sent %d UploadPartCopy requests, want 0", fake.copies)
+	}
+	if err := s.Compose(ctx, "missing", []string{"object0", "nothere"}, nil); !errors.Is(err, ErrObjectNotExist) {
+		t.Errorf("Compose() with a missing source = %v, want ErrObjectNotExist", err)
+	}
+}
+
+func TestS3UpdateMetadataMultipartCopy(t *testing.T) {
+	ctx := context.Background()
+	fake, server := newFakeS3(t)
+	s := newTestS3(t, server)
+	if _, err := s.Upload(ctx, "object", strings.NewReader("0123456789"), map[string]string{"X-Backup-Type": "FILE"}); err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	s.minPartSize, s.maxPartSize = 1, 3
+	before, err := s.Attrs(ctx, "object")
+	if err != nil {
+		t.Fatalf("Attrs() failed: %v", err)
+	}
+	want := map[string]string{"X-Backup-Type": "FILE", "X-Backint-Kek": "keyring/k2"}
+	if err := s.UpdateMetadata(ctx, "object", want); err != nil {
+		t.Fatalf("UpdateMetadata() failed: %v", err)
+	}
+	after, err := s.Attrs(ctx, "object")
+	if err != nil {
+		t.Fatalf("Attrs() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, after.Metadata); diff != "" {
+		t.Errorf("UpdateMetadata() had unexpected metadata diff (-want +got):\n%s", diff)
+	}
+	if !after.Created.Equal(before.Created) {
+		t.Errorf("UpdateMetadata() changed creation time from %v to %v", before.Created, after.Created)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := s.Download(ctx, "object", buf, 0, -1); err != nil || buf.String() != "0123456789" {
+		t.Errorf("Download() = (%q, %v), want (%q, nil)", buf.String(), err, "0123456789")
+	}
+	if fake.copies != 4 {
+		t.Errorf("UpdateMetadata() sent %d UploadPartCopy requests, want 4", fake.copies)
+	}
+}
+
+func TestS3SignsPayloadOverHTTP(t *testing.T) {
+	ctx := context.Background()
+	fake, server := newFakeS3(t)
+	s := newTestS3(t, server)
+	if _, err := s.Upload(ctx, "object", strings.NewReader("data"), nil); err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	// The SHA-256 of "data".
+	want := "3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7"
+	if got := fake.payloadHashes[len(fake.payloadHashes)-1]; got != want {
+		t.Errorf("x-amz-content-sha256 over http = %s, want %s", got, want)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://s3.example.com/bucket/object", nil)
+	s.sign(req, nil)
+	if got := req.Header.Get("x-amz-content-sha256"); got != s3UnsignedBody {
+		t.Errorf("x-amz-content-sha256 over https = %s, want %s", got, s3UnsignedBody)
+	}
+}
+
+func TestS3UpdateMetadata(t *testing.T) {
+	ctx := context.Background()
+	_, server := newFakeS3(t)
+	s := newTestS3(t, server)
+	if _, err := s.Upload(ctx, "object", strings.NewReader("data"), map[string]string{"X-Backup-Type": "FILE"}); err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	before, err := s.Attrs(ctx, "object")
+	if err != nil {
+		t.Fatalf("Attrs() failed: %v", err)
+	}
+
+	want := map[string]string{"X-Backup-Type": "FILE", "X-Backint-Kek": "keyring/k2"}
+	for i := 0; i < 2; i++ {
+		if err := s.UpdateMetadata(ctx, "object", want); err != nil {
+			t.Fatalf("UpdateMetadata() failed: %v", err)
+		}
+	}
+	after, err := s.Attrs(ctx, "object")
+	if err != nil {
+		t.Fatalf("Attrs() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, after.Metadata); diff != "" {
+		t.Errorf("UpdateMetadata() had unexpected metadata diff (-want +got):\n%s", diff)
+	}
+	if !after.Created.Equal(before.Created) {
+		t.Errorf("UpdateMetadata() changed creation time from %v to %v", before.Created, after.Created)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := s.Download(ctx, "object", buf, 0, -1); err != nil || buf.String() != "data" {
+		t.Errorf("Download() = (%q, %v), want (%q, nil)", buf.String(), err, "data")
+	}
+	if err := s.UpdateMetadata(ctx, "missing", want); !errors.Is(err, ErrObjectNotExist) {
+		t.Errorf("UpdateMetadata(missing) = %v, want ErrObjectNotExist", err)
+	}
+}
+
+func TestURIEncode(t *testing.T) {
+	if got, want := escapePath("/bucket/test@TST/a b.txt"), "/bucket/test%40TST/a%20b.txt"; got != want {
+		t.Errorf("escapePath() = %s, want %s", got, want)
+	}
+	if got, want := canonicalQuery(url.Values{"prefix": {"a/b"}, "list-type": {"2"}}), "list-type=2&prefix=a%2Fb"; got != want {
+		t.Errorf("canonicalQuery() = %s, want %s", got, want)
+	}
+}
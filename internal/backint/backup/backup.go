@@ -31,8 +31,15 @@ import (
 
 	store "cloud.google.com/go/storage"
 	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/compression"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/dedup"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/envelope"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/integrity"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/metrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/parse"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/ratelimit"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/replication"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
@@ -61,6 +68,22 @@ type parameters struct {
 	// was already verified so connection via a new client should always succeed.
 	connectParams *storage.ConnectParameters
 
+	// storageBackend is set when a backend other than Cloud Storage is
+	// configured, in which case bucketHandle is not used.
+	storageBackend backend.Backend
+
+	// envelopeKeys is set when envelope_encryption is enabled.
+	envelopeKeys *envelope.Keys
+
+	// manifest records file checksums when integrity_manifest is enabled.
+	// Parallel upload chunks store their checksum in sectionSum instead,
+	// since the file's entry is added once all chunks are uploaded.
+	manifest   *integrity.Manifest
+	sectionSum *string
+
+	// replicator is set for SYNC replication to the recovery bucket.
+	replicator *replication.Replicator
+
 	config       *bpb.BackintConfiguration
 	bucketHandle *store.BucketHandle
 	cloudProps   *ipb.CloudProperties
@@ -74,6 +97,15 @@ type parameters struct {
 	stat         statFunc
 }
 
+// objectStore returns the storage backend, wrapping the bucket handle
+// when Cloud Storage is used.
+func (p parameters) objectStore() backend.Backend {
+	if p.storageBackend != nil {
+		return p.storageBackend
+	}
+	return backend.NewGCS(p.bucketHandle, p.config)
+}
+
 // Execute logs information and performs the requested backup. Returns false on failures.
 func Execute(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, input io.Reader, output io.Writer, cloudProps *ipb.CloudProperties) bool {
 	log.CtxLogger(ctx).Infow("BACKUP starting", "inFile", config.GetInputFile(), "outFile", config.GetOutputFile())
@@ -92,6 +124,31 @@ func Execute(ctx context.Context, config *bpb.BackintConfiguration, connectParam
 // written to the output. Issues with file operations will return errors.
 func backup(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, input io.Reader, output io.Writer, cloudProps *ipb.CloudProperties) error {
 	startTime := time.Now()
+	var storageBackend backend.Backend
+	if !backend.IsGCS(config) {
+		var err error
+		if storageBackend, err = backend.New(ctx, config, connectParams); err != nil {
+			return err
+		}
+	}
+	var envelopeKeys *envelope.Keys
+	if config.GetEnvelopeEncryption() {
+		var err error
+		if envelopeKeys, err = envelope.NewKeys(config, os.ReadFile); err != nil {
+			return err
+		}
+	}
+	var replicator *replication.Replicator
+	if config.GetReplicationMode() == bpb.ReplicationMode_SYNC && !config.GetDumpData() {
+		var err error
+		if replicator, err = replication.New(ctx, config, connectParams); err != nil {
+			log.CtxLogger(ctx).Errorw("Unable to connect for replication, objects will be queued for REPLICATE", "recoveryBucket", config.GetRecoveryBucket(), "err", err)
+		}
+	}
+	var manifest *integrity.Manifest
+	if config.GetIntegrityManifest() && !config.GetDumpData() {
+		manifest = integrity.New(strconv.FormatInt(startTime.UnixMilli(), 10), startTime)
+	}
 	wp := workerpool.New(int(config.GetThreads()))
 	mu := &sync.Mutex{}
 	scanner := bufio.NewScanner(input)
@@ -111,6 +168,10 @@ func backup(ctx context.Context, config *bpb.BackintConfiguration, connectParams
 			p := parameters{
 				config:           config,
 				connectParams:    connectParams,
+				storageBackend:   storageBackend,
+				envelopeKeys:     envelopeKeys,
+				manifest:         manifest,
+				replicator:       replicator,
 				cloudProps:       cloudProps,
 				fileType:         s[0],
 				fileName:         s[1],
@@ -143,7 +204,9 @@ func backup(ctx context.Context, config *bpb.BackintConfiguration, connectParams
 				continue
 			}
 			wp.Submit(func() {
-				p.bucketHandle, _ = storage.ConnectToBucket(ctx, p.connectParams)
+				if p.storageBackend == nil {
+					p.bucketHandle, _ = storage.ConnectToBucket(ctx, p.connectParams)
+				}
 				out := backupFile(ctx, p)
 				mu.Lock()
 				output.Write([]byte(out))
@@ -157,6 +220,12 @@ func backup(ctx context.Context, config *bpb.BackintConfiguration, connectParams
 	if err := scanner.Err(); err != nil {
 		return err
 	}
+	if manifest != nil && manifest.Len() > 0 {
+		writeIntegrityManifest(ctx, config, connectParams, storageBackend, manifest)
+	}
+	if replication.Enabled(config) && !config.GetDumpData() {
+		startReplication(ctx, config, cloudProps, replication.StartDrain)
+	}
 	metrics.WriteFileTransferLog(ctx, "backup", parse.TrimAndClean(lastFileName), time.Since(startTime), config, cloudProps)
 	return nil
 }
@@ -166,11 +235,19 @@ func backup(ctx context.Context, config *bpb.BackintConfiguration, connectParams
 func backupFile(ctx context.Context, p parameters) string {
 	fileNameTrim := parse.TrimAndClean(p.fileName)
 	object := parse.CreateObjectPath(p.config, fileNameTrim, p.externalBackupID, p.extension)
-	metadata := p.config.GetMetadata()
-	if metadata == nil {
-		metadata = make(map[string]string)
+	// Copy the configured metadata since it is shared across uploads.
+	metadata := make(map[string]string)
+	for k, v := range p.config.GetMetadata() {
+		metadata[k] = v
 	}
 	metadata["X-Backup-Type"] = strings.ReplaceAll(p.fileType, "#", "")
+	codec := compression.Codec(p.config)
+	if name := compression.Name(codec); name != "" {
+		metadata[compression.MetadataKey] = name
+	}
+	if replication.Enabled(p.config) {
+		metadata[replication.StatusMetadataKey] = replication.StatusPending
+	}
 	log.CtxLogger(ctx).Infow("Backing up file", "fileType", p.fileType, "fileName", p.fileName, "obj", object, "fileSize", p.fileSize, "fileType", p.fileType, "storageClass", p.config.GetStorageClass().String(), "metadata", metadata)
 	if p.reader == nil {
 		f, err := parse.OpenFileWithRetries(fileNameTrim, os.O_RDONLY, 0, p.config.GetFileReadTimeoutMs())
@@ -189,6 +266,43 @@ func backupFile(ctx context.Context, p parameters) string {
 		}
 		p.reader = f
 	}
+	// Checksums cover the original data so VERIFY can check a full restore.
+	var hasher *integrity.Hasher
+	if p.manifest != nil {
+		hasher = integrity.NewHasher(0, 1)
+		p.reader = io.TeeReader(p.reader, hasher)
+	}
+	// Data is compressed before it is encrypted, since ciphertext does not compress.
+	var compressed io.ReadCloser
+	if compression.IsStreaming(codec) {
+		var err error
+		if compressed, err = compression.NewCompressReader(p.reader, codec, p.config.GetCompressionLevel()); err != nil {
+			log.CtxLogger(ctx).Errorw("Error preparing compression", "fileName", p.fileName, "obj", object, "codec", codec, "err", err)
+			return fmt.Sprintf("#ERROR %s\n", p.fileName)
+		}
+		// Closing stops the compression goroutine if the upload fails.
+		defer compressed.Close()
+		p.reader = compressed
+		// The compressed size is not known in advance.
+		p.fileSize = 0
+	}
+	var encrypted io.Reader
+	if p.config.GetEnvelopeEncryption() {
+		var err error
+		if encrypted, err = encryptReader(ctx, p, metadata); err != nil {
+			log.CtxLogger(ctx).Errorw("Error preparing envelope encryption", "fileName", p.fileName, "obj", object, "fileType", p.fileType, "err", err)
+			return fmt.Sprintf("#ERROR %s\n", p.fileName)
+		}
+		p.reader = encrypted
+		if p.fileSize > 0 {
+			p.fileSize = envelope.EncryptedSize(p.fileSize)
+		}
+	}
+	// Rate limit windows are applied to the reader, so the limit follows the
+	// schedule for the duration of the upload.
+	if schedule := ratelimit.New(p.config); schedule != nil {
+		p.reader = schedule.Reader(ctx, p.reader)
+	}
 	storageClass := p.config.GetStorageClass().String()
 	if storageClass == "STORAGE_CLASS_UNSPECIFIED" {
 		// An empty string allows the bucket default to be used.
@@ -204,10 +318,10 @@ func backupFile(ctx context.Context, p parameters) string {
 		ObjectName:                 object,
 		TotalBytes:                 p.fileSize,
 		LogDelay:                   time.Duration(p.config.GetLogDelaySec()) * time.Second,
-		Compress:                   p.config.GetCompress(),
+		Compress:                   codec == bpb.CompressionCodec_GZIP,
 		StorageClass:               storageClass,
 		DumpData:                   p.config.GetDumpData(),
-		RateLimitBytes:             p.config.GetRateLimitMb() * 1024 * 1024,
+		RateLimitBytes:             ratelimit.StaticBytes(p.config),
 		EncryptionKey:              p.config.GetEncryptionKey(),
 		KMSKey:                     p.config.GetKmsKey(),
 		MaxRetries:                 p.config.GetRetries(),
@@ -225,7 +339,29 @@ func backupFile(ctx context.Context, p parameters) string {
 		ObjectRetentionTime:        parse.CustomTime(ctx, "object_retention_time", p.config.GetObjectRetentionTime(), time.Now().UTC()),
 	}
 	startTime := time.Now()
-	bytesWritten, err := rw.Upload(ctx)
+	var bytesWritten int64
+	var err error
+	if p.config.GetDeduplicate() {
+		bytesWritten, err = dedup.Upload(ctx, dedup.Parameters{
+			Config:       p.config,
+			BucketHandle: p.bucketHandle,
+			Copier:       p.copier,
+			ObjectName:   object,
+			FileName:     fileNameTrim,
+			Metadata:     metadata,
+			StorageClass: storageClass,
+		}, p.reader)
+	} else if p.storageBackend != nil {
+		bytesWritten, err = p.storageBackend.Upload(ctx, object, backend.WithSize(p.reader, p.fileSize), metadata)
+	} else {
+		bytesWritten, err = rw.Upload(ctx)
+	}
+	// Report the original size, which is what a restore will write.
+	if compressed != nil && err == nil {
+		bytesWritten = compression.UncompressedBytes(compressed)
+	} else if encrypted != nil && err == nil {
+		bytesWritten = envelope.PlaintextBytes(encrypted)
+	}
 	uploadTime := time.Since(startTime)
 	defer metrics.SendToCloudMonitoring(ctx, "backup", p.fileName, bytesWritten, uploadTime, p.config, err == nil, p.cloudProps, cloudmonitoring.NoBackOff(), metrics.DefaultMetricClient)
 	if err != nil {
@@ -233,12 +369,38 @@ func backupFile(ctx context.Context, p parameters) string {
 		return fmt.Sprintf("#ERROR %s\n", p.fileName)
 	}
 	log.CtxLogger(ctx).Infow("File uploaded", "bucket", p.config.GetBucket(), "fileName", p.fileName, "obj", object, "bytesWritten", bytesWritten, "fileSize", p.fileSize, "fileType", p.fileType, "uploadTimeSec", uploadTime.Round(time.Millisecond))
+	if hasher != nil && p.sectionSum != nil {
+		*p.sectionSum = hasher.Sums()[0]
+	} else if hasher != nil {
+		p.manifest.Add(integrity.Entry{Object: object, FileName: p.fileName, Size: hasher.Size(), SHA256: hasher.Sums()})
+	}
+	if p.extension == ".bak" {
+		replicate(ctx, p, object)
+	}
 	return fmt.Sprintf("#SAVED %q %s %s\n", p.externalBackupID, p.fileName, strconv.FormatInt(bytesWritten, 10))
 }
 
+// encryptReader generates a data key for the object and returns a reader
+// encrypting with it. The wrapped data key is added to the metadata.
+func encryptReader(ctx context.Context, p parameters, metadata map[string]string) (io.Reader, error) {
+	if p.envelopeKeys == nil {
+		return nil, fmt.Errorf("envelope encryption keys are not loaded")
+	}
+	dataKey, keyMetadata, err := p.envelopeKeys.NewDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range keyMetadata {
+		metadata[k] = v
+	}
+	return envelope.NewEncryptReader(p.reader, dataKey)
+}
+
 // backupFileParallel chunks a file and uploads the sections in parallel
 // to the bucket. The last chunk uploaded will trigger a compose operation
 // which combines all chunks into 1 object and deletes the temporary chunks.
+// If resumable uploads are enabled, progress is persisted in a manifest
+// so chunks uploaded by a failed attempt are not uploaded again.
 // A returned error indicates the parallel jobs were not started.
 func backupFileParallel(ctx context.Context, p parameters) (string, error) {
 	fileNameTrim := parse.TrimAndClean(p.fileName)
@@ -255,15 +417,29 @@ func backupFileParallel(ctx context.Context, p parameters) (string, error) {
 		}
 		p.fileSize = fi.Size()
 	}
-	if fileInfo, err := f.Stat(); err != nil || fileInfo.Mode()&0444 == 0 {
+	fileInfo, err := f.Stat()
+	if err != nil || fileInfo.Mode()&0444 == 0 {
 		log.CtxLogger(ctx).Errorw("Backup file does not have readable permissions", "fileName", p.fileName, "err", err)
 		return fmt.Sprintf("#ERROR %s\n", p.fileName), fmt.Errorf("backup file does not have readable permissions")
 	}
 
+	// A resumed upload reuses the externalBackupID and completed chunks of the previous attempt.
+	var manifest *uploadManifest
+	manifestFile := ""
+	if p.config.GetResumableUploads() {
+		manifestFile = manifestPath(p.config, fileNameTrim)
+		manifest = loadOrCreateManifest(ctx, p, manifestFile, fileInfo.ModTime())
+		p.externalBackupID = manifest.ExternalBackupID
+	}
+
 	startTime := time.Now()
 	sectionLength := p.fileSize / p.config.GetParallelStreams()
 	chunksCompleted := int64(0)
 	chunkError := false
+	var sectionSums []string
+	if p.manifest != nil {
+		sectionSums = make([]string, p.config.GetParallelStreams())
+	}
 	log.CtxLogger(ctx).Infow("Sectioning file into equal sized chunks for parallel upload", "fileName", p.fileName, "fileSize", p.fileSize, "parallelStreams", p.config.GetParallelStreams(), "sectionLength", sectionLength, "externalBackupID", p.externalBackupID)
 	for i := int64(0); i < p.config.GetParallelStreams(); i++ {
 		// Since wp.Submit() is non-blocking, the for loop might progress before
@@ -280,11 +456,35 @@ func backupFileParallel(ctx context.Context, p parameters) (string, error) {
 			}
 
 			chunkParameters := p
-			chunkParameters.bucketHandle, _ = storage.ConnectToBucket(ctx, p.connectParams)
+			if p.storageBackend == nil {
+				chunkParameters.bucketHandle, _ = storage.ConnectToBucket(ctx, p.connectParams)
+			}
 			chunkParameters.extension += strconv.FormatInt(i, 10)
 			chunkParameters.reader = io.NewSectionReader(f, offset, length)
 			chunkParameters.fileSize = length
-			result := backupFile(ctx, chunkParameters)
+			if sectionSums != nil {
+				chunkParameters.sectionSum = &sectionSums[i]
+			}
+			var result string
+			chunkObject := parse.CreateObjectPath(p.config, fileNameTrim, p.externalBackupID, chunkParameters.extension)
+			reused := false
+			if manifest != nil && manifest.Chunks[i].Completed {
+				// A chunk is only reused if its checksum matches the local section of the file.
+				crc, sha256Sum, err := sectionChecksums(io.NewSectionReader(f, offset, length), sectionSums != nil)
+				if err != nil {
+					log.CtxLogger(ctx).Warnw("Error reading chunk checksum, uploading again", "fileName", p.fileName, "chunk", i, "err", err)
+				} else if chunkUploaded(ctx, chunkParameters.objectStore(), chunkObject, length, crc) {
+					log.CtxLogger(ctx).Infow("Chunk already uploaded by a previous attempt, skipping", "bucket", p.config.GetBucket(), "fileName", p.fileName, "offset", offset, "sectionLength", length, "chunk", i)
+					result = "#SAVED"
+					reused = true
+					if sectionSums != nil {
+						sectionSums[i] = sha256Sum
+					}
+				}
+			}
+			if !reused {
+				result = backupFile(ctx, chunkParameters)
+			}
 
 			p.mu.Lock()
 			defer p.mu.Unlock()
@@ -293,11 +493,25 @@ func backupFileParallel(ctx context.Context, p parameters) (string, error) {
 				chunkError = true
 			} else {
 				log.CtxLogger(ctx).Infow("Chunk uploaded", "bucket", p.config.GetBucket(), "fileName", p.fileName, "offset", offset, "sectionLength", length, "chunk", i)
+				if manifest != nil && !manifest.Chunks[i].Completed {
+					manifest.Chunks[i].Completed = true
+					if err := writeManifest(manifestFile, manifest); err != nil {
+						log.CtxLogger(ctx).Warnw("Unable to update upload manifest", "manifest", manifestFile, "chunk", i, "err", err)
+					}
+				}
 			}
 			chunksCompleted++
 			if chunksCompleted == p.config.GetParallelStreams() {
 				p.bucketHandle = chunkParameters.bucketHandle
-				p.output.Write([]byte(composeChunks(ctx, p, chunkError, startTime)))
+				out := composeChunks(ctx, p, chunkError, startTime)
+				if strings.HasPrefix(out, "#SAVED") {
+					object := parse.CreateObjectPath(p.config, fileNameTrim, p.externalBackupID, ".bak")
+					if sectionSums != nil {
+						p.manifest.Add(integrity.Entry{Object: object, FileName: p.fileName, Size: p.fileSize, SectionSize: sectionLength, SHA256: sectionSums})
+					}
+					replicate(ctx, p, object)
+				}
+				p.output.Write([]byte(out))
 				f.Close()
 			}
 		})
@@ -321,42 +535,48 @@ func composeChunks(ctx context.Context, p parameters, chunkError bool, startTime
 		log.CtxLogger(ctx).Warnw("dump_data set to true, not composing objects.", "chunks", p.config.GetParallelStreams(), "fileName", p.fileName, "object", object)
 		return ret()
 	}
-	if p.bucketHandle == nil {
+	if p.storageBackend == nil && p.bucketHandle == nil {
 		log.CtxLogger(ctx).Error("No bucket handle defined, cannot compose objects")
 		chunkError = true
 		return ret()
 	}
+	// Keep the uploaded chunks so a retried backup can resume the upload.
+	if chunkError && p.config.GetResumableUploads() {
+		log.CtxLogger(ctx).Warnw("Chunk upload failed, keeping uploaded chunks for a resumed backup", "chunks", p.config.GetParallelStreams(), "fileName", p.fileName, "object", object)
+		return ret()
+	}
 
 	avgTransferSpeedMBps := float64(p.fileSize) / time.Since(startTime).Seconds() / 1024 / 1024
 	log.CtxLogger(ctx).Infow("All chunks uploaded, composing into 1 object", "chunks", p.config.GetParallelStreams(), "fileName", p.fileName, "object", object, "avgTransferSpeedMBps", fmt.Sprintf("%g", math.Round(avgTransferSpeedMBps)))
-	var srcs []*store.ObjectHandle
+	b := p.objectStore()
+	var srcs []string
 	// The composer can take between 1 to 32 objects to compose into 1 object
 	// parallel_streams are limited to 32 in the configuration so only 1 compose call is needed.
 	for i := int64(0); i < p.config.GetParallelStreams(); i++ {
-		srcs = append(srcs, p.bucketHandle.Object(object+strconv.FormatInt(i, 10)))
+		srcs = append(srcs, object+strconv.FormatInt(i, 10))
 	}
 
-	// Default to octet-stream, but set to the first chunk's content type if available.
-	contentType := "application/octet-stream"
 	// Default to the fileType, but set to the first chunk's metadata if available.
 	metadata := map[string]string{"X-Backup-Type": strings.ReplaceAll(p.fileType, "#", "")}
-	if attrs, err := p.bucketHandle.Object(object + "0").Attrs(ctx); err == nil {
-		contentType = attrs.ContentType
+	if attrs, err := b.Attrs(ctx, object+"0"); err == nil {
 		metadata = attrs.Metadata
 	}
-	composer := p.bucketHandle.Object(object).ComposerFrom(srcs...)
-	composer.ObjectAttrs.ContentType = contentType
-	composer.ObjectAttrs.Metadata = metadata
-	if _, err := composer.Run(ctx); err != nil {
+	if err := b.Compose(ctx, object, srcs, metadata); err != nil {
 		log.CtxLogger(ctx).Errorw("Error composing object", "object", object, "chunks", p.config.GetParallelStreams(), "err", err)
 		chunkError = true
+		if p.config.GetResumableUploads() {
+			return ret()
+		}
 	} else {
 		log.CtxLogger(ctx).Infow("Object composed", "object", object, "chunks", p.config.GetParallelStreams())
+		if p.config.GetResumableUploads() {
+			removeManifest(ctx, manifestPath(p.config, fileNameTrim))
+		}
 	}
 
 	log.CtxLogger(ctx).Infow("Deleting temporary chunked objects", "object", object, "chunks", p.config.GetParallelStreams())
 	for i := int64(0); i < p.config.GetParallelStreams(); i++ {
-		if err := storage.DeleteObject(ctx, p.bucketHandle, object+strconv.FormatInt(i, 10), p.config.GetRetries()); err != nil {
+		if err := b.Delete(ctx, object+strconv.FormatInt(i, 10)); err != nil {
 			log.CtxLogger(ctx).Errorw("Error deleting temporary chunked object", "object", object+strconv.FormatInt(i, 10), "err", err)
 			// Do not set chunkError as the full upload and compose were successful.
 		}
@@ -364,3 +584,58 @@ func composeChunks(ctx context.Context, p parameters, chunkError bool, startTime
 
 	return ret()
 }
+
+// writeIntegrityManifest stores the manifest of the files backed up. Failures
+// are logged without failing the backup, since the backup data is complete.
+func writeIntegrityManifest(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, storageBackend backend.Backend, manifest *integrity.Manifest) {
+	b := storageBackend
+	if b == nil {
+		var err error
+		if b, err = backend.New(ctx, config, connectParams); err != nil {
+			log.CtxLogger(ctx).Errorw("Error writing integrity manifest", "backupID", manifest.BackupID, "err", err)
+			return
+		}
+	}
+	object, err := manifest.Write(ctx, b, config)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Error writing integrity manifest", "backupID", manifest.BackupID, "err", err)
+		return
+	}
+	log.CtxLogger(ctx).Infow("Integrity manifest written", "obj", object, "files", manifest.Len())
+}
+
+// startReplication reports the replication queue and, if objects are queued,
+// starts REPLICATE in the background so the queue is drained without an
+// external scheduler.
+func startReplication(ctx context.Context, config *bpb.BackintConfiguration, cloudProps *ipb.CloudProperties, start func(context.Context, *bpb.BackintConfiguration) error) {
+	replication.ReportQueue(ctx, config, cloudProps, time.Now())
+	depth, _, err := replication.QueueStats(config)
+	if err != nil || depth == 0 {
+		return
+	}
+	if err := start(ctx, config); err != nil {
+		log.CtxLogger(ctx).Errorw("Unable to start REPLICATE in the background, run the REPLICATE function to replicate queued objects", "queue", config.GetReplicationQueueDirectory(), "err", err)
+	}
+}
+
+// replicate copies the object to the recovery bucket for SYNC replication.
+// The object is queued for the REPLICATE function instead for ASYNC
+// replication, or if the copy fails, so no object is left unreplicated.
+// Queued objects are replicated in the background once BACKUP finishes.
+func replicate(ctx context.Context, p parameters, object string) {
+	if !replication.Enabled(p.config) || p.config.GetDumpData() {
+		return
+	}
+	if p.replicator != nil {
+		err := p.replicator.Replicate(ctx, object)
+		if err == nil {
+			return
+		}
+		log.CtxLogger(ctx).Errorw("Error replicating object, queueing for REPLICATE", "obj", object, "recoveryBucket", p.config.GetRecoveryBucket(), "err", err)
+	}
+	if err := replication.Enqueue(p.config, object, time.Now()); err != nil {
+		log.CtxLogger(ctx).Errorw("Error queueing object for replication", "obj", object, "queue", p.config.GetReplicationQueueDirectory(), "err", err)
+		return
+	}
+	log.CtxLogger(ctx).Infow("Object queued for replication", "obj", object, "queue", p.config.GetReplicationQueueDirectory())
+}
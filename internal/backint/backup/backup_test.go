@@ -33,6 +33,7 @@ import (
 	"github.com/fsouza/fake-gcs-server/fakestorage"
 	"google.golang.org/api/option"
 	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/replication"
 	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
@@ -427,3 +428,49 @@ func TestComposeChunks(t *testing.T) {
 		})
 	}
 }
+
+func TestStartReplication(t *testing.T) {
+	tests := []struct {
+		name      string
+		queued    []string
+		startErr  error
+		wantStart bool
+	}{
+		{
+			name: "EmptyQueue",
+		},
+		{
+			name:      "QueuedObjects",
+			queued:    []string{"test@TST/object.txt/12345.bak"},
+			wantStart: true,
+		},
+		{
+			name:      "StartFailure",
+			queued:    []string{"test@TST/object.txt/12345.bak"},
+			startErr:  errors.New("start failed"),
+			wantStart: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &bpb.BackintConfiguration{
+				ReplicationMode:           bpb.ReplicationMode_ASYNC,
+				RecoveryBucket:            "recovery-bucket",
+				ReplicationQueueDirectory: t.TempDir(),
+			}
+			for _, object := range test.queued {
+				if err := replication.Enqueue(config, object, time.Now()); err != nil {
+					t.Fatalf("replication.Enqueue(%s) failed: %v", object, err)
+				}
+			}
+			gotStart := false
+			startReplication(context.Background(), config, defaultCloudProperties, func(context.Context, *bpb.BackintConfiguration) error {
+				gotStart = true
+				return test.startErr
+			})
+			if gotStart != test.wantStart {
+				t.Errorf("startReplication() started REPLICATE: %t, want: %t", gotStart, test.wantStart)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/integrity"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/parse"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+// crc32cTable computes the CRC32C checksums Cloud Storage reports for objects.
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// uploadManifest records the progress of a parallel upload. It is persisted
+// after every chunk so a retried BACKUP of the same file can reuse the
+// chunks which were already uploaded and verified.
+type uploadManifest struct {
+	FileName         string        `json:"fileName"`
+	FileSize         int64         `json:"fileSize"`
+	ModTime          time.Time     `json:"modTime"`
+	ExternalBackupID string        `json:"externalBackupID"`
+	ParallelStreams  int64         `json:"parallelStreams"`
+	Chunks           []chunkStatus `json:"chunks"`
+}
+
+// chunkStatus holds the section of the file covered by a chunk
+// and whether the chunk upload completed.
+type chunkStatus struct {
+	Offset    int64 `json:"offset"`
+	Length    int64 `json:"length"`
+	Completed bool  `json:"completed"`
+}
+
+// newUploadManifest creates a manifest with all chunks pending.
+func newUploadManifest(fileName string, fileSize int64, modTime time.Time, externalBackupID string, parallelStreams int64) *uploadManifest {
+	m := &uploadManifest{
+		FileName:         fileName,
+		FileSize:         fileSize,
+		ModTime:          modTime,
+		ExternalBackupID: externalBackupID,
+		ParallelStreams:  parallelStreams,
+	}
+	sectionLength := fileSize / parallelStreams
+	for i := int64(0); i < parallelStreams; i++ {
+		offset := sectionLength * i
+		length := sectionLength
+		if i == parallelStreams-1 {
+			length = fileSize - offset
+		}
+		m.Chunks = append(m.Chunks, chunkStatus{Offset: offset, Length: length})
+	}
+	return m
+}
+
+// matches returns true if the manifest was created for the same version of
+// the file and with the same chunking, meaning its chunks can be reused.
+func (m *uploadManifest) matches(fileName string, fileSize int64, modTime time.Time, parallelStreams int64) bool {
+	return m.FileName == fileName && m.FileSize == fileSize && m.ModTime.Equal(modTime) && m.ParallelStreams == parallelStreams && int64(len(m.Chunks)) == parallelStreams
+}
+
+// manifestPath returns the location of the manifest for a file. The name is
+// derived from the object path so different users and folder prefixes
+// backing up the same file name do not share a manifest.
+func manifestPath(config *bpb.BackintConfiguration, fileNameTrim string) string {
+	sum := sha256.Sum256([]byte(parse.CreateObjectPath(config, fileNameTrim, "", "")))
+	return filepath.Join(config.GetResumableStateDirectory(), hex.EncodeToString(sum[:])+".json")
+}
+
+// readManifest reads and unmarshals a manifest file.
+func readManifest(path string) (*uploadManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &uploadManifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("malformed upload manifest %s: %v", path, err)
+	}
+	return m, nil
+}
+
+// writeManifest persists the manifest. The data is written to a temporary
+// file and renamed so a crash never leaves a partially written manifest.
+func writeManifest(path string, m *uploadManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// removeManifest deletes the manifest once the upload is complete.
+func removeManifest(ctx context.Context, path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.CtxLogger(ctx).Warnw("Unable to remove upload manifest", "manifest", path, "err", err)
+	}
+}
+
+// loadOrCreateManifest returns the persisted manifest if it matches the file
+// being backed up. Otherwise chunks belonging to a stale manifest are deleted
+// from the bucket and a new manifest is saved for this upload.
+func loadOrCreateManifest(ctx context.Context, p parameters, path string, modTime time.Time) *uploadManifest {
+	fileNameTrim := parse.TrimAndClean(p.fileName)
+	m, err := readManifest(path)
+	if err == nil && m.matches(fileNameTrim, p.fileSize, modTime, p.config.GetParallelStreams()) {
+		log.CtxLogger(ctx).Infow("Resuming parallel upload from manifest", "fileName", p.fileName, "manifest", path, "externalBackupID", m.ExternalBackupID)
+		return m
+	}
+	if err == nil {
+		log.CtxLogger(ctx).Infow("Upload manifest does not match the file, starting a new upload", "fileName", p.fileName, "manifest", path, "externalBackupID", m.ExternalBackupID)
+		discardChunks(ctx, p, m)
+	} else if !os.IsNotExist(err) {
+		log.CtxLogger(ctx).Warnw("Unable to read upload manifest, starting a new upload", "fileName", p.fileName, "manifest", path, "err", err)
+	}
+
+	m = newUploadManifest(fileNameTrim, p.fileSize, modTime, p.externalBackupID, p.config.GetParallelStreams())
+	if err := writeManifest(path, m); err != nil {
+		log.CtxLogger(ctx).Warnw("Unable to save upload manifest, upload will not be resumable", "fileName", p.fileName, "manifest", path, "err", err)
+	}
+	return m
+}
+
+// discardChunks deletes the completed chunks of a stale manifest from the storage backend.
+func discardChunks(ctx context.Context, p parameters, m *uploadManifest) {
+	b := p.storageBackend
+	if b == nil {
+		bucketHandle, ok := storage.ConnectToBucket(ctx, p.connectParams)
+		if !ok {
+			return
+		}
+		b = backend.NewGCS(bucketHandle, p.config)
+	}
+	object := parse.CreateObjectPath(p.config, m.FileName, m.ExternalBackupID, ".bak")
+	for i, chunk := range m.Chunks {
+		if !chunk.Completed {
+			continue
+		}
+		if err := b.Delete(ctx, object+strconv.Itoa(i)); err != nil {
+			log.CtxLogger(ctx).Warnw("Error deleting stale chunked object", "object", object+strconv.Itoa(i), "err", err)
+		}
+	}
+}
+
+// chunkUploaded checks the storage backend for a chunk object of the expected length
+// whose CRC32C matches the local section of the file. Backends which do not report
+// a CRC32C have the object downloaded to compute it.
+func chunkUploaded(ctx context.Context, b backend.Backend, object string, length int64, crc uint32) bool {
+	attrs, err := b.Attrs(ctx, object)
+	if err != nil {
+		log.CtxLogger(ctx).Infow("Previously uploaded chunk not found in bucket, uploading again", "object", object, "err", err)
+		return false
+	}
+	if attrs.Size != length {
+		log.CtxLogger(ctx).Infow("Previously uploaded chunk has an unexpected size, uploading again", "object", object, "size", attrs.Size, "want", length)
+		return false
+	}
+	objectCRC := attrs.CRC32C
+	if !attrs.HasCRC32C {
+		h := crc32.New(crc32cTable)
+		if _, err := b.Download(ctx, object, h, 0, -1); err != nil {
+			log.CtxLogger(ctx).Infow("Unable to read previously uploaded chunk, uploading again", "object", object, "err", err)
+			return false
+		}
+		objectCRC = h.Sum32()
+	}
+	if objectCRC != crc {
+		log.CtxLogger(ctx).Warnw("Previously uploaded chunk does not match the file, uploading again", "object", object, "crc32c", objectCRC, "want", crc)
+		return false
+	}
+	return true
+}
+
+// sectionChecksums reads a section of the file and returns its CRC32C and, if
+// withSHA256 is set, its SHA256 checksum for the integrity manifest.
+func sectionChecksums(section io.Reader, withSHA256 bool) (crc uint32, sha256Sum string, err error) {
+	c := crc32.New(crc32cTable)
+	var w io.Writer = c
+	var h *integrity.Hasher
+	if withSHA256 {
+		h = integrity.NewHasher(0, 1)
+		w = io.MultiWriter(c, h)
+	}
+	if _, err := io.Copy(w, section); err != nil {
+		return 0, "", err
+	}
+	if h != nil {
+		sha256Sum = h.Sums()[0]
+	}
+	return c.Sum32(), sha256Sum, nil
+}
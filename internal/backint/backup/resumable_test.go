@@ -0,0 +1,344 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+var defaultModTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestNewUploadManifest(t *testing.T) {
+	want := &uploadManifest{
+		FileName:         "/object.txt",
+		FileSize:         11,
+		ModTime:          defaultModTime,
+		ExternalBackupID: "12345",
+		ParallelStreams:  3,
+		Chunks: []chunkStatus{
+			{Offset: 0, Length: 3},
+			{Offset: 3, Length: 3},
+			{Offset: 6, Length: 5},
+		},
+	}
+	got := newUploadManifest("/object.txt", 11, defaultModTime, "12345", 3)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("newUploadManifest() had unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestManifestMatches(t *testing.T) {
+	m := newUploadManifest("/object.txt", 12, defaultModTime, "12345", 2)
+	tests := []struct {
+		name            string
+		fileName        string
+		fileSize        int64
+		modTime         time.Time
+		parallelStreams int64
+		want            bool
+	}{
+		{
+			name:            "Match",
+			fileName:        "/object.txt",
+			fileSize:        12,
+			modTime:         defaultModTime,
+			parallelStreams: 2,
+			want:            true,
+		},
+		{
+			name:            "DifferentFile",
+			fileName:        "/other.txt",
+			fileSize:        12,
+			modTime:         defaultModTime,
+			parallelStreams: 2,
+		},
+		{
+			name:            "DifferentSize",
+			fileName:        "/object.txt",
+			fileSize:        13,
+			modTime:         defaultModTime,
+			parallelStreams: 2,
+		},
+		{
+			name:            "FileModified",
+			fileName:        "/object.txt",
+			fileSize:        12,
+			modTime:         defaultModTime.Add(time.Second),
+			parallelStreams: 2,
+		},
+		{
+			name:            "DifferentParallelStreams",
+			fileName:        "/object.txt",
+			fileSize:        12,
+			modTime:         defaultModTime,
+			parallelStreams: 4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := m.matches(test.fileName, test.fileSize, test.modTime, test.parallelStreams)
+			if got != test.want {
+				t.Errorf("matches(%s, %d, %v, %d) = %v, want %v", test.fileName, test.fileSize, test.modTime, test.parallelStreams, got, test.want)
+			}
+		})
+	}
+}
+
+func TestManifestPath(t *testing.T) {
+	config := &bpb.BackintConfiguration{UserId: "test@TST", ResumableStateDirectory: "/tmp/resumable"}
+	got := manifestPath(config, "/object.txt")
+	if filepath.Dir(got) != "/tmp/resumable" || !strings.HasSuffix(got, ".json") {
+		t.Errorf("manifestPath() = %s, want a .json file in /tmp/resumable", got)
+	}
+	config.FolderPrefix = "prefix/"
+	if other := manifestPath(config, "/object.txt"); other == got {
+		t.Errorf("manifestPath() = %s for different folder prefixes, want distinct paths", got)
+	}
+}
+
+func TestWriteAndReadManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "manifest.json")
+	want := newUploadManifest("/object.txt", 12, defaultModTime, "12345", 2)
+	want.Chunks[1].Completed = true
+	if err := writeManifest(path, want); err != nil {
+		t.Fatalf("writeManifest(%s) failed: %v", path, err)
+	}
+	got, err := readManifest(path)
+	if err != nil {
+		t.Fatalf("readManifest(%s) failed: %v", path, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("readManifest(%s) had unexpected diff (-want +got):\n%s", path, diff)
+	}
+
+	removeManifest(context.Background(), path)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("removeManifest(%s) did not remove the manifest, stat err: %v", path, err)
+	}
+}
+
+func TestReadManifestMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(path, []byte("not json"), 0640); err != nil {
+		t.Fatalf("os.WriteFile(%s) failed: %v", path, err)
+	}
+	if _, err := readManifest(path); err == nil {
+		t.Errorf("readManifest(%s) succeeded, want error", path)
+	}
+}
+
+func TestLoadOrCreateManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *uploadManifest
+		want     string
+	}{
+		{
+			name: "NoManifest",
+			want: "67890",
+		},
+		{
+			name:     "MatchingManifest",
+			existing: newUploadManifest("/object.txt", 12, defaultModTime, "12345", 2),
+			want:     "12345",
+		},
+		{
+			name:     "StaleManifest",
+			existing: newUploadManifest("/object.txt", 24, defaultModTime, "12345", 2),
+			want:     "67890",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "manifest.json")
+			if test.existing != nil {
+				if err := writeManifest(path, test.existing); err != nil {
+					t.Fatalf("writeManifest(%s) failed: %v", path, err)
+				}
+			}
+			p := defaultParameters
+			p.fileSize = 12
+			p.externalBackupID = "67890"
+
+			got := loadOrCreateManifest(context.Background(), p, path, defaultModTime)
+			if got.ExternalBackupID != test.want {
+				t.Errorf("loadOrCreateManifest() externalBackupID = %s, want %s", got.ExternalBackupID, test.want)
+			}
+			saved, err := readManifest(path)
+			if err != nil {
+				t.Fatalf("readManifest(%s) failed: %v", path, err)
+			}
+			if diff := cmp.Diff(got, saved); diff != "" {
+				t.Errorf("loadOrCreateManifest() did not persist the manifest (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChunkUploaded(t *testing.T) {
+	contentCRC := crc32.Checksum([]byte("test content"), crc32cTable)
+	tests := []struct {
+		name     string
+		object   string
+		length   int64
+		crc      uint32
+		noHandle bool
+		want     bool
+	}{
+		{
+			name:     "NoBucketHandle",
+			object:   "test@TST/object.txt/12345.bak0",
+			length:   12,
+			crc:      contentCRC,
+			noHandle: true,
+		},
+		{
+			name:   "ChunkNotFound",
+			object: "test@TST/object.txt/12345.bak5",
+			length: 12,
+			crc:    contentCRC,
+		},
+		{
+			name:   "SizeMismatch",
+			object: "test@TST/object.txt/12345.bak0",
+			length: 5,
+			crc:    contentCRC,
+		},
+		{
+			name:   "ChecksumMismatch",
+			object: "test@TST/object.txt/12345.bak0",
+			length: 12,
+			crc:    crc32.Checksum([]byte("test cont3nt"), crc32cTable),
+		},
+		{
+			name:   "ChunkUploaded",
+			object: "test@TST/object.txt/12345.bak0",
+			length: 12,
+			crc:    contentCRC,
+			want:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bucketHandle := defaultBucketHandle
+			if test.noHandle {
+				bucketHandle = nil
+			}
+			got := chunkUploaded(context.Background(), backend.NewGCS(bucketHandle, defaultParameters.config), test.object, test.length, test.crc)
+			if got != test.want {
+				t.Errorf("chunkUploaded(%s, %d, %d) = %v, want %v", test.object, test.length, test.crc, got, test.want)
+			}
+		})
+	}
+}
+
+func TestChunkUploadedWithoutObjectChecksum(t *testing.T) {
+	b, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("backend.NewFilesystem() = %v, want nil", err)
+	}
+	object := "test@TST/object.txt/12345.bak0"
+	if _, err := b.Upload(context.Background(), object, strings.NewReader("test cont3nt"), nil); err != nil {
+		t.Fatalf("Upload(%s) = %v, want nil", object, err)
+	}
+
+	tests := []struct {
+		name string
+		crc  uint32
+		want bool
+	}{
+		{
+			name: "CorruptedChunk",
+			crc:  crc32.Checksum([]byte("test content"), crc32cTable),
+		},
+		{
+			name: "ChunkUploaded",
+			crc:  crc32.Checksum([]byte("test cont3nt"), crc32cTable),
+			want: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := chunkUploaded(context.Background(), b, object, 12, test.crc); got != test.want {
+				t.Errorf("chunkUploaded(%s, 12, %d) = %v, want %v", object, test.crc, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSectionChecksums(t *testing.T) {
+	tests := []struct {
+		name       string
+		withSHA256 bool
+		wantSHA256 string
+	}{
+		{
+			name: "CRC32COnly",
+		},
+		{
+			name:       "WithSHA256",
+			withSHA256: true,
+			wantSHA256: "6ae8a75555209fd6c44157c0aed8016e763ff435a19cf186f76863140143ff72",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			crc, sha256Sum, err := sectionChecksums(strings.NewReader("test content"), test.withSHA256)
+			if err != nil {
+				t.Fatalf("sectionChecksums() = %v, want nil", err)
+			}
+			if want := crc32.Checksum([]byte("test content"), crc32cTable); crc != want {
+				t.Errorf("sectionChecksums() crc = %d, want %d", crc, want)
+			}
+			if sha256Sum != test.wantSHA256 {
+				t.Errorf("sectionChecksums() sha256 = %q, want %q", sha256Sum, test.wantSHA256)
+			}
+		})
+	}
+}
+
+func TestComposeChunksResumableKeepsChunks(t *testing.T) {
+	bucketHandle := fakeServer("resumable-error").Client().Bucket("resumable-error")
+	p := defaultParameters
+	p.bucketHandle = bucketHandle
+	p.config = &bpb.BackintConfiguration{
+		UserId:                  "test@TST",
+		ParallelStreams:         2,
+		ResumableUploads:        true,
+		ResumableStateDirectory: t.TempDir(),
+	}
+
+	got := composeChunks(context.Background(), p, true, time.Now())
+	if !strings.HasPrefix(got, "#ERROR") {
+		t.Errorf("composeChunks() = %s, want prefix #ERROR", got)
+	}
+	for _, chunk := range []string{"test@TST/object.txt/12345.bak0", "test@TST/object.txt/12345.bak1"} {
+		if _, err := bucketHandle.Object(chunk).Attrs(context.Background()); err != nil {
+			t.Errorf("composeChunks() deleted chunk %s, want it kept for a resumed backup: %v", chunk, err)
+		}
+	}
+}
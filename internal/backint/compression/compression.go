@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compression applies the zstd and lz4 compression codecs to Backint
+// objects as they are streamed. The codec is recorded in the object metadata
+// so restores decompress independently of the current configuration.
+package compression
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+// MetadataKey holds the lower case codec name of compressed objects.
+const MetadataKey = "X-Backup-Compression"
+
+// Codec returns the configured codec, treating compress as GZIP.
+func Codec(config *bpb.BackintConfiguration) bpb.CompressionCodec {
+	if config.GetCompressionCodec() == bpb.CompressionCodec_COMPRESSION_CODEC_UNSPECIFIED && config.GetCompress() {
+		return bpb.CompressionCodec_GZIP
+	}
+	return config.GetCompressionCodec()
+}
+
+// IsStreaming returns true if the codec is applied by Backint rather than by
+// the storage package.
+func IsStreaming(codec bpb.CompressionCodec) bool {
+	return codec == bpb.CompressionCodec_ZSTD || codec == bpb.CompressionCodec_LZ4
+}
+
+// Name returns the metadata value for the codec, or an empty string if
+// objects are not compressed.
+func Name(codec bpb.CompressionCodec) string {
+	if codec == bpb.CompressionCodec_COMPRESSION_CODEC_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(codec.String())
+}
+
+// ValidateLevel checks the compression level is supported by the codec.
+func ValidateLevel(codec bpb.CompressionCodec, level int32) error {
+	switch {
+	case level == 0:
+		return nil
+	case codec == bpb.CompressionCodec_ZSTD && (level < 1 || level > 22):
+		return fmt.Errorf("compression_level (%d) must be between 1 and 22 for ZSTD", level)
+	case codec == bpb.CompressionCodec_LZ4 && (level < 1 || level > 9):
+		return fmt.Errorf("compression_level (%d) must be between 1 and 9 for LZ4", level)
+	case !IsStreaming(codec):
+		return fmt.Errorf("compression_level is only supported by the ZSTD and LZ4 compression_codec")
+	}
+	return nil
+}
+
+// compressReader compresses the source in a separate goroutine, counting
+// the uncompressed bytes read.
+type compressReader struct {
+	*io.PipeReader
+	n atomic.Int64
+}
+
+// NewCompressReader returns a reader of the source compressed with the codec.
+func NewCompressReader(r io.Reader, codec bpb.CompressionCodec, level int32) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	enc, err := newEncoder(pw, codec, level)
+	if err != nil {
+		return nil, err
+	}
+	c := &compressReader{PipeReader: pr}
+	go func() {
+		n, err := io.Copy(enc, r)
+		c.n.Store(n)
+		if closeErr := enc.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+	return c, nil
+}
+
+// UncompressedBytes returns the bytes read from the source of a reader
+// returned by NewCompressReader, once it has been read to the end.
+func UncompressedBytes(r io.Reader) int64 {
+	if c, ok := r.(*compressReader); ok {
+		return c.n.Load()
+	}
+	return 0
+}
+
+func newEncoder(w io.Writer, codec bpb.CompressionCodec, level int32) (io.WriteCloser, error) {
+	if err := ValidateLevel(codec, level); err != nil {
+		return nil, err
+	}
+	switch codec {
+	case bpb.CompressionCodec_ZSTD:
+		opts := []zstd.EOption{}
+		if level != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(int(level))))
+		}
+		return zstd.NewWriter(w, opts...)
+	case bpb.CompressionCodec_LZ4:
+		lw := lz4.NewWriter(w)
+		opts := []lz4.Option{lz4.ConcurrencyOption(-1)}
+		if level != 0 {
+			opts = append(opts, lz4.CompressionLevelOption(lz4.CompressionLevel(1<<(8+level))))
+		}
+		if err := lw.Apply(opts...); err != nil {
+			return nil, err
+		}
+		return lw, nil
+	default:
+		return nil, fmt.Errorf("compression codec %s is not applied by Backint", codec)
+	}
+}
+
+// decompressWriter decompresses the data written to it into the destination
+// in a separate goroutine.
+type decompressWriter struct {
+	*io.PipeWriter
+	done chan struct{}
+	n    int64
+	err  error
+}
+
+// NewDecompressWriter returns a writer which decompresses into w using the
+// codec recorded in object metadata. Close must be called to flush the
+// remaining data and report decompression errors.
+func NewDecompressWriter(w io.Writer, name string) (io.WriteCloser, error) {
+	pr, pw := io.Pipe()
+	var dec io.Reader
+	release := func() {}
+	switch name {
+	case Name(bpb.CompressionCodec_ZSTD):
+		zr, err := zstd.NewReader(pr)
+		if err != nil {
+			return nil, err
+		}
+		dec, release = zr, zr.Close
+	case Name(bpb.CompressionCodec_LZ4):
+		dec = lz4.NewReader(pr)
+	default:
+		return nil, fmt.Errorf("unsupported compression codec %q", name)
+	}
+	d := &decompressWriter{PipeWriter: pw, done: make(chan struct{})}
+	go func() {
+		defer close(d.done)
+		defer release()
+		d.n, d.err = io.Copy(w, dec)
+		// Fail further writes once decompression has stopped.
+		pr.CloseWithError(d.err)
+	}()
+	return d, nil
+}
+
+// Close flushes the remaining data and waits for decompression to finish.
+func (d *decompressWriter) Close() error {
+	d.PipeWriter.Close()
+	<-d.done
+	return d.err
+}
+
+// DecompressedBytes returns the bytes written to the destination of a
+// writer returned by NewDecompressWriter, once it has been closed.
+func DecompressedBytes(w io.Writer) int64 {
+	if d, ok := w.(*decompressWriter); ok {
+		return d.n
+	}
+	return 0
+}
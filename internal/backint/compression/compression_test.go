@@ -0,0 +1,161 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compression
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+func TestCodec(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *bpb.BackintConfiguration
+		want   bpb.CompressionCodec
+	}{
+		{
+			name:   "Uncompressed",
+			config: &bpb.BackintConfiguration{},
+			want:   bpb.CompressionCodec_COMPRESSION_CODEC_UNSPECIFIED,
+		},
+		{
+			name:   "Compress",
+			config: &bpb.BackintConfiguration{Compress: true},
+			want:   bpb.CompressionCodec_GZIP,
+		},
+		{
+			name:   "CompressionCodec",
+			config: &bpb.BackintConfiguration{CompressionCodec: bpb.CompressionCodec_ZSTD},
+			want:   bpb.CompressionCodec_ZSTD,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Codec(test.config); got != test.want {
+				t.Errorf("Codec() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		codec   bpb.CompressionCodec
+		level   int32
+		wantErr bool
+	}{
+		{name: "Default", codec: bpb.CompressionCodec_ZSTD},
+		{name: "ZstdMax", codec: bpb.CompressionCodec_ZSTD, level: 22},
+		{name: "ZstdTooHigh", codec: bpb.CompressionCodec_ZSTD, level: 23, wantErr: true},
+		{name: "LZ4", codec: bpb.CompressionCodec_LZ4, level: 9},
+		{name: "LZ4TooHigh", codec: bpb.CompressionCodec_LZ4, level: 10, wantErr: true},
+		{name: "Gzip", codec: bpb.CompressionCodec_GZIP, level: 1, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateLevel(test.codec, test.level)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("ValidateLevel(%v, %d) = %v, wantErr %v", test.codec, test.level, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// Half random and half repeated data, so the output is compressible.
+	data := make([]byte, 4<<20)
+	rand.New(rand.NewSource(1)).Read(data[:2<<20])
+	tests := []struct {
+		name  string
+		codec bpb.CompressionCodec
+		level int32
+		data  []byte
+	}{
+		{name: "ZstdDefault", codec: bpb.CompressionCodec_ZSTD, data: data},
+		{name: "ZstdLevel", codec: bpb.CompressionCodec_ZSTD, level: 19, data: data[1<<20 : 3<<20]},
+		{name: "LZ4Default", codec: bpb.CompressionCodec_LZ4, data: data},
+		{name: "LZ4Level", codec: bpb.CompressionCodec_LZ4, level: 9, data: data[1<<20 : 3<<20]},
+		{name: "ZstdEmpty", codec: bpb.CompressionCodec_ZSTD},
+		{name: "LZ4Empty", codec: bpb.CompressionCodec_LZ4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewCompressReader(bytes.NewReader(test.data), test.codec, test.level)
+			if err != nil {
+				t.Fatalf("NewCompressReader() failed: %v", err)
+			}
+			compressed, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll() failed: %v", err)
+			}
+			if got := UncompressedBytes(r); got != int64(len(test.data)) {
+				t.Errorf("UncompressedBytes() = %d, want %d", got, len(test.data))
+			}
+			if len(test.data) > 0 && len(compressed) >= len(test.data) {
+				t.Errorf("compressed size %d is not smaller than %d", len(compressed), len(test.data))
+			}
+
+			out := &bytes.Buffer{}
+			w, err := NewDecompressWriter(out, Name(test.codec))
+			if err != nil {
+				t.Fatalf("NewDecompressWriter() failed: %v", err)
+			}
+			if _, err := io.CopyBuffer(w, bytes.NewReader(compressed), make([]byte, 1000)); err != nil {
+				t.Fatalf("Write() failed: %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() failed: %v", err)
+			}
+			if !bytes.Equal(out.Bytes(), test.data) {
+				t.Errorf("decompressed %d bytes which do not match the %d compressed", out.Len(), len(test.data))
+			}
+			if got := DecompressedBytes(w); got != int64(len(test.data)) {
+				t.Errorf("DecompressedBytes() = %d, want %d", got, len(test.data))
+			}
+		})
+	}
+}
+
+func TestDecompressErrors(t *testing.T) {
+	if _, err := NewDecompressWriter(io.Discard, "gzip"); err == nil {
+		t.Error("NewDecompressWriter(gzip) succeeded, want error")
+	}
+	for _, name := range []string{"zstd", "lz4"} {
+		w, err := NewDecompressWriter(io.Discard, name)
+		if err != nil {
+			t.Fatalf("NewDecompressWriter(%s) failed: %v", name, err)
+		}
+		w.Write([]byte("not compressed data"))
+		if err := w.Close(); err == nil {
+			t.Errorf("Close() of corrupt %s data succeeded, want error", name)
+		}
+	}
+}
+
+func TestNewCompressReaderUnsupported(t *testing.T) {
+	if _, err := NewCompressReader(bytes.NewReader(nil), bpb.CompressionCodec_GZIP, 0); err == nil {
+		t.Error("NewCompressReader(GZIP) succeeded, want error")
+	}
+	if _, err := NewCompressReader(bytes.NewReader(nil), bpb.CompressionCodec_ZSTD, 30); err == nil {
+		t.Error("NewCompressReader() with an invalid level succeeded, want error")
+	}
+}
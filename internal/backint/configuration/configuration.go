@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -29,6 +30,8 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"go.uber.org/zap/zapcore"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/compression"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/ratelimit"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
@@ -113,7 +116,7 @@ func (p *Parameters) parseCommandLineArgs() error {
 	}
 	function := BackintFunction(p.Function)
 	if function == bpb.Function_FUNCTION_UNSPECIFIED {
-		return errors.New("function must be one of: [backup, restore, inquire, delete, diagnose]")
+		return errors.New("function must be one of: [backup, restore, inquire, delete, diagnose, rewrap, housekeeping, verify, replicate]")
 	}
 
 	p.Config = &bpb.BackintConfiguration{
@@ -146,7 +149,8 @@ func (p *Parameters) readParametersFile(read ReadConfigFile) (int, error) {
 	proto.Merge(p.Config, config)
 
 	// For RESTORE operations, several parameters can be overridden.
-	if p.Config.GetFunction() == bpb.Function_RESTORE {
+	// With replication the recovery bucket holds replicas which RESTORE falls back to.
+	if p.Config.GetFunction() == bpb.Function_RESTORE && p.Config.GetReplicationMode() == bpb.ReplicationMode_REPLICATION_MODE_UNSPECIFIED {
 		if p.Config.GetRecoveryBucket() != "" {
 			p.Config.Bucket = p.Config.GetRecoveryBucket()
 			p.Config.FolderPrefix = p.Config.GetRecoveryFolderPrefix()
@@ -168,7 +172,29 @@ func (p *Parameters) readParametersFile(read ReadConfigFile) (int, error) {
 
 // validateParameters ensures parameters from the params file provide a valid configuration.
 func (p *Parameters) validateParameters() error {
-	if p.Config.GetBucket() == "" {
+	switch p.Config.GetStorageBackend() {
+	case bpb.StorageBackend_FILESYSTEM:
+		if p.Config.GetFilesystemPath() == "" {
+			return errors.New("filesystem_path must be provided for the FILESYSTEM storage_backend")
+		}
+	case bpb.StorageBackend_S3:
+		if p.Config.GetS3Endpoint() == "" {
+			return errors.New("s3_endpoint must be provided for the S3 storage_backend")
+		}
+	}
+	codec := compression.Codec(p.Config)
+	if p.Config.GetCompress() && p.Config.GetCompressionCodec() != bpb.CompressionCodec_COMPRESSION_CODEC_UNSPECIFIED && p.Config.GetCompressionCodec() != bpb.CompressionCodec_GZIP {
+		return fmt.Errorf("compress cannot be combined with compression_codec: %s", p.Config.GetCompressionCodec())
+	}
+	if err := compression.ValidateLevel(codec, p.Config.GetCompressionLevel()); err != nil {
+		return err
+	}
+	if p.Config.GetStorageBackend() != bpb.StorageBackend_STORAGE_BACKEND_UNSPECIFIED && p.Config.GetStorageBackend() != bpb.StorageBackend_GCS {
+		if codec == bpb.CompressionCodec_GZIP || p.Config.GetEncryptionKey() != "" || p.Config.GetKmsKey() != "" || p.Config.GetDeduplicate() || p.Config.GetXmlMultipartUpload() {
+			return fmt.Errorf("compress, gzip compression_codec, encryption_key, kms_key, deduplicate and xml_multipart_upload are only supported by the GCS storage_backend, got: %s", p.Config.GetStorageBackend())
+		}
+	}
+	if p.Config.GetBucket() == "" && p.Config.GetStorageBackend() != bpb.StorageBackend_FILESYSTEM {
 		return errors.New("bucket must be provided")
 	}
 	if strings.Contains(p.Config.GetBucket(), "/") || strings.HasPrefix(p.Config.GetBucket(), "gs:") {
@@ -180,19 +206,62 @@ func (p *Parameters) validateParameters() error {
 	if p.Config.GetEncryptionKey() != "" && p.Config.GetKmsKey() != "" {
 		return errors.New("only one of encryption_key or kms_key can be provided")
 	}
+	if (p.Config.GetEnvelopeEncryption() || p.Config.GetFunction() == bpb.Function_REWRAP) && p.Config.GetEnvelopeKeyringFile() == "" && p.Config.GetEnvelopeKmsKey() == "" {
+		return errors.New("envelope_keyring_file or envelope_kms_key must be provided for envelope encryption")
+	}
+	if p.Config.GetFunction() == bpb.Function_HOUSEKEEPING && p.Config.GetHousekeepingHdbuserstoreKey() == "" {
+		return errors.New("housekeeping_hdbuserstore_key must be provided for the housekeeping function")
+	}
+	if p.Config.GetEnvelopeEncryption() {
+		if p.Config.GetEncryptionKey() != "" || p.Config.GetKmsKey() != "" {
+			return errors.New("envelope_encryption cannot be combined with encryption_key or kms_key")
+		}
+		if codec == bpb.CompressionCodec_GZIP || p.Config.GetDeduplicate() {
+			return errors.New("envelope_encryption cannot be combined with gzip compression or deduplicate")
+		}
+	}
+	if compression.IsStreaming(codec) && p.Config.GetDeduplicate() {
+		return fmt.Errorf("compression_codec %s cannot be combined with deduplicate", codec)
+	}
 	if p.Config.GetFunction() == bpb.Function_BACKUP && (p.Config.GetParallelStreams() > 1 || p.Config.GetXmlMultipartUpload()) {
-		if p.Config.GetCompress() {
+		if p.Config.GetEnvelopeEncryption() {
+			return errors.New("envelope encrypted parallel backups are not supported - 'parallel_streams' must be set to 1 in order to use envelope_encryption")
+		}
+		if codec != bpb.CompressionCodec_COMPRESSION_CODEC_UNSPECIFIED {
 			return errors.New("compressed parallel backups are not supported - 'parallel_streams' must be set to 1 in order to compress data")
 		}
 		if p.Config.GetEncryptionKey() != "" || p.Config.GetKmsKey() != "" {
 			return errors.New("encrypted parallel backups are not supported - 'parallel_streams' must be set to 1 in order to encrypt data")
 		}
+		if p.Config.GetDeduplicate() {
+			return errors.New("deduplicated parallel backups are not supported - 'parallel_streams' must be set to 1 in order to deduplicate data")
+		}
 	}
 	if p.Config.GetFunction() == bpb.Function_RESTORE && p.Config.GetParallelRecoveryStreams() > 1 {
-		if p.Config.GetCompress() {
+		if codec == bpb.CompressionCodec_GZIP {
 			return errors.New("compressed parallel restores are not supported - 'parallel_recovery_streams' must be set to 0 or 1 in order to compress data")
 		}
 	}
+	if p.Config.GetReplicationMode() != bpb.ReplicationMode_REPLICATION_MODE_UNSPECIFIED || p.Config.GetFunction() == bpb.Function_REPLICATE {
+		if p.Config.GetRecoveryBucket() == "" {
+			return errors.New("recovery_bucket must be provided for replication")
+		}
+		if p.Config.GetRecoveryBucket() == p.Config.GetBucket() && p.Config.GetRecoveryFolderPrefix() == p.Config.GetFolderPrefix() {
+			return errors.New("recovery_bucket or recovery_folder_prefix must differ from bucket and folder_prefix for replication")
+		}
+		if (p.Config.GetStorageBackend() != bpb.StorageBackend_STORAGE_BACKEND_UNSPECIFIED && p.Config.GetStorageBackend() != bpb.StorageBackend_GCS) || p.Config.GetDeduplicate() {
+			return errors.New("replication is only supported by the GCS storage_backend without deduplicate")
+		}
+	}
+	if p.Config.GetVerifySamplePercent() < 0 || p.Config.GetVerifySamplePercent() > 100 {
+		return fmt.Errorf("verify_sample_percent (%d) must be between 0 and 100", p.Config.GetVerifySamplePercent())
+	}
+	if _, err := ratelimit.Parse(p.Config.GetRateLimitWindows()); err != nil {
+		return err
+	}
+	if size := p.Config.GetDedupChunkSizeKb(); size != 0 && (size < 64 || size > 8192 || size&(size-1) != 0) {
+		return fmt.Errorf("dedup_chunk_size_kb (%d) must be a power of 2 between 64 and 8192", size)
+	}
 	if p.Config.GetObjectRetentionMode() != "" && p.Config.GetObjectRetentionMode() != "Unlocked" && p.Config.GetObjectRetentionMode() != "Locked" {
 		return errors.New("object_retention_mode must be either 'Unlocked' or 'Locked'")
 	}
@@ -273,6 +342,18 @@ func (p *Parameters) ApplyDefaults(numCPU int64) {
 		}
 		log.Logger.Infof("folder_prefix is set. All objects in the GCS bucket will be prefixed with '%s'", p.Config.GetFolderPrefix())
 	}
+	if p.Config.GetDeduplicate() && p.Config.GetDedupChunkSizeKb() <= 0 {
+		log.Logger.Info("dedup_chunk_size_kb defaulted to 1024")
+		p.Config.DedupChunkSizeKb = 1024
+	}
+	if p.Config.GetResumableUploads() && p.Config.GetResumableStateDirectory() == "" {
+		p.Config.ResumableStateDirectory = filepath.Join(filepath.Dir(p.Config.GetParamFile()), "resumable")
+		log.Logger.Infof("resumable_state_directory defaulted to %s", p.Config.GetResumableStateDirectory())
+	}
+	if (p.Config.GetReplicationMode() != bpb.ReplicationMode_REPLICATION_MODE_UNSPECIFIED || p.Config.GetFunction() == bpb.Function_REPLICATE) && p.Config.GetReplicationQueueDirectory() == "" {
+		p.Config.ReplicationQueueDirectory = filepath.Join(filepath.Dir(p.Config.GetParamFile()), "replication")
+		log.Logger.Infof("replication_queue_directory defaulted to %s", p.Config.GetReplicationQueueDirectory())
+	}
 	if p.Config.GetLogLevel() == bpb.LogLevel_LOG_LEVEL_UNSPECIFIED {
 		p.Config.LogLevel = bpb.LogLevel_INFO
 	}
@@ -267,6 +267,425 @@ func TestParseArgsAndValidateConfig(t *testing.T) {
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			name:   "DeduplicatedParallelBackup",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:          "testUser",
+				Function:        bpb.Function_BACKUP,
+				ParamFile:       "testParamsFile.json",
+				Bucket:          "testBucket",
+				ParallelStreams: 2,
+				Deduplicate:     true,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "parallel_streams": 2, "deduplicate": true}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "InvalidDedupChunkSize",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:           "testUser",
+				Function:         bpb.Function_BACKUP,
+				ParamFile:        "testParamsFile.json",
+				Bucket:           "testBucket",
+				Deduplicate:      true,
+				DedupChunkSizeKb: 1000,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "deduplicate": true, "dedup_chunk_size_kb": 1000}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "FilesystemBackendNoPath",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:         "testUser",
+				Function:       bpb.Function_BACKUP,
+				ParamFile:      "testParamsFile.json",
+				StorageBackend: bpb.StorageBackend_FILESYSTEM,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"storage_backend": "FILESYSTEM"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "S3BackendNoEndpoint",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:         "testUser",
+				Function:       bpb.Function_BACKUP,
+				ParamFile:      "testParamsFile.json",
+				Bucket:         "testBucket",
+				StorageBackend: bpb.StorageBackend_S3,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "storage_backend": "S3"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "CompressedS3Backup",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:         "testUser",
+				Function:       bpb.Function_BACKUP,
+				ParamFile:      "testParamsFile.json",
+				Bucket:         "testBucket",
+				StorageBackend: bpb.StorageBackend_S3,
+				S3Endpoint:     "https://s3.example.com",
+				Compress:       true,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "storage_backend": "S3", "s3_endpoint": "https://s3.example.com", "compress": true}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "FilesystemBackendNoBucket",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:                  "testUser",
+				Function:                bpb.Function_BACKUP,
+				ParamFile:               "testParamsFile.json",
+				StorageBackend:          bpb.StorageBackend_FILESYSTEM,
+				FilesystemPath:          "/mnt/backups",
+				ParallelStreams:         1,
+				BufferSizeMb:            100,
+				FileReadTimeoutMs:       60000,
+				Retries:                 5,
+				Threads:                 defaultThreads(),
+				InputFile:               "/dev/stdin",
+				OutputFile:              "/dev/stdout",
+				LogToCloud:              wpb.Bool(true),
+				LogLevel:                bpb.LogLevel_INFO,
+				SendMetricsToMonitoring: wpb.Bool(true),
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"storage_backend": "FILESYSTEM", "filesystem_path": "/mnt/backups"}`), nil
+			},
+		},
+		{
+			name:   "EnvelopeEncryptionNoKEK",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:             "testUser",
+				Function:           bpb.Function_BACKUP,
+				ParamFile:          "testParamsFile.json",
+				Bucket:             "testBucket",
+				EnvelopeEncryption: true,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "envelope_encryption": true}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "EnvelopeEncryptionWithKmsKey",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:              "testUser",
+				Function:            bpb.Function_BACKUP,
+				ParamFile:           "testParamsFile.json",
+				Bucket:              "testBucket",
+				EnvelopeEncryption:  true,
+				EnvelopeKeyringFile: "/etc/keyring.json",
+				KmsKey:              "testKey",
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "envelope_encryption": true, "envelope_keyring_file": "/etc/keyring.json", "kms_key": "testKey"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "EnvelopeEncryptedParallelBackup",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:              "testUser",
+				Function:            bpb.Function_BACKUP,
+				ParamFile:           "testParamsFile.json",
+				Bucket:              "testBucket",
+				ParallelStreams:     2,
+				EnvelopeEncryption:  true,
+				EnvelopeKeyringFile: "/etc/keyring.json",
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "parallel_streams": 2, "envelope_encryption": true, "envelope_keyring_file": "/etc/keyring.json"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "RewrapNoKEK",
+			params: &Parameters{
+				User:      "testUser",
+				Function:  "rewrap",
+				ParamFile: "testParamsFile.json",
+			},
+			want: &bpb.BackintConfiguration{
+				UserId:    "testUser",
+				Function:  bpb.Function_REWRAP,
+				ParamFile: "testParamsFile.json",
+				Bucket:    "testBucket",
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "HousekeepingNoUserstoreKey",
+			params: &Parameters{
+				User:      "testUser",
+				Function:  "housekeeping",
+				ParamFile: "testParamsFile.json",
+			},
+			want: &bpb.BackintConfiguration{
+				UserId:    "testUser",
+				Function:  bpb.Function_HOUSEKEEPING,
+				ParamFile: "testParamsFile.json",
+				Bucket:    "testBucket",
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "EnvelopeEncryptionKeyring",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:                  "testUser",
+				Function:                bpb.Function_BACKUP,
+				ParamFile:               "testParamsFile.json",
+				Bucket:                  "testBucket",
+				EnvelopeEncryption:      true,
+				EnvelopeKeyringFile:     "/etc/keyring.json",
+				ParallelStreams:         1,
+				BufferSizeMb:            100,
+				FileReadTimeoutMs:       60000,
+				Retries:                 5,
+				Threads:                 defaultThreads(),
+				InputFile:               "/dev/stdin",
+				OutputFile:              "/dev/stdout",
+				LogToCloud:              wpb.Bool(true),
+				LogLevel:                bpb.LogLevel_INFO,
+				SendMetricsToMonitoring: wpb.Bool(true),
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "envelope_encryption": true, "envelope_keyring_file": "/etc/keyring.json"}`), nil
+			},
+		},
+		{
+			name:   "CompressionCodecWithCompress",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:           "testUser",
+				Function:         bpb.Function_BACKUP,
+				ParamFile:        "testParamsFile.json",
+				Bucket:           "testBucket",
+				Compress:         true,
+				CompressionCodec: bpb.CompressionCodec_ZSTD,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "compress": true, "compression_codec": "ZSTD"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "InvalidCompressionLevel",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:           "testUser",
+				Function:         bpb.Function_BACKUP,
+				ParamFile:        "testParamsFile.json",
+				Bucket:           "testBucket",
+				CompressionCodec: bpb.CompressionCodec_LZ4,
+				CompressionLevel: 12,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "compression_codec": "LZ4", "compression_level": 12}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "ZstdParallelBackup",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:           "testUser",
+				Function:         bpb.Function_BACKUP,
+				ParamFile:        "testParamsFile.json",
+				Bucket:           "testBucket",
+				ParallelStreams:  2,
+				CompressionCodec: bpb.CompressionCodec_ZSTD,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "parallel_streams": 2, "compression_codec": "ZSTD"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "ZstdDeduplicate",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:           "testUser",
+				Function:         bpb.Function_BACKUP,
+				ParamFile:        "testParamsFile.json",
+				Bucket:           "testBucket",
+				Deduplicate:      true,
+				CompressionCodec: bpb.CompressionCodec_ZSTD,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "deduplicate": true, "compression_codec": "ZSTD"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "InvalidVerifySamplePercent",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:              "testUser",
+				Function:            bpb.Function_BACKUP,
+				ParamFile:           "testParamsFile.json",
+				Bucket:              "testBucket",
+				VerifySamplePercent: 101,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "verify_sample_percent": 101}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "InvalidRateLimitWindow",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:           "testUser",
+				Function:         bpb.Function_BACKUP,
+				ParamFile:        "testParamsFile.json",
+				Bucket:           "testBucket",
+				RateLimitWindows: []*bpb.RateLimitWindow{{Days: "Mon-Fri", StartTime: "8:00am", EndTime: "18:00", RateLimitMb: 200}},
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "rate_limit_windows": [{"days": "Mon-Fri", "start_time": "8:00am", "end_time": "18:00", "rate_limit_mb": 200}]}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "RateLimitWindows",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:                  "testUser",
+				Function:                bpb.Function_BACKUP,
+				ParamFile:               "testParamsFile.json",
+				Bucket:                  "testBucket",
+				RateLimitWindows:        []*bpb.RateLimitWindow{{Days: "Mon-Fri", StartTime: "08:00", EndTime: "18:00", RateLimitMb: 200}},
+				RestoreBypassRateLimit:  true,
+				ParallelStreams:         1,
+				BufferSizeMb:            100,
+				FileReadTimeoutMs:       60000,
+				Retries:                 5,
+				Threads:                 defaultThreads(),
+				InputFile:               "/dev/stdin",
+				OutputFile:              "/dev/stdout",
+				LogToCloud:              wpb.Bool(true),
+				LogLevel:                bpb.LogLevel_INFO,
+				SendMetricsToMonitoring: wpb.Bool(true),
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "rate_limit_windows": [{"days": "Mon-Fri", "start_time": "08:00", "end_time": "18:00", "rate_limit_mb": 200}], "restore_bypass_rate_limit": true}`), nil
+			},
+		},
+		{
+			name:   "ReplicationNoRecoveryBucket",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:          "testUser",
+				Function:        bpb.Function_BACKUP,
+				ParamFile:       "testParamsFile.json",
+				Bucket:          "testBucket",
+				ReplicationMode: bpb.ReplicationMode_ASYNC,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "replication_mode": "ASYNC"}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "ReplicationWithDeduplicate",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:          "testUser",
+				Function:        bpb.Function_BACKUP,
+				ParamFile:       "testParamsFile.json",
+				Bucket:          "testBucket",
+				RecoveryBucket:  "recoveryBucket",
+				ReplicationMode: bpb.ReplicationMode_SYNC,
+				Deduplicate:     true,
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "recovery_bucket": "recoveryBucket", "replication_mode": "SYNC", "deduplicate": true}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "ReplicationRestoreKeepsBucket",
+			params: &Parameters{
+				User:      "testUser",
+				ParamFile: "/etc/backint/testParamsFile.json",
+				Function:  "restore",
+			},
+			want: &bpb.BackintConfiguration{
+				UserId:                    "testUser",
+				Function:                  bpb.Function_RESTORE,
+				ParamFile:                 "/etc/backint/testParamsFile.json",
+				Bucket:                    "testBucket",
+				RecoveryBucket:            "recoveryBucket",
+				ReplicationMode:           bpb.ReplicationMode_ASYNC,
+				ReplicationQueueDirectory: "/etc/backint/replication",
+				ParallelStreams:           1,
+				BufferSizeMb:              100,
+				FileReadTimeoutMs:         60000,
+				Retries:                   5,
+				Threads:                   defaultThreads(),
+				InputFile:                 "/dev/stdin",
+				OutputFile:                "/dev/stdout",
+				LogToCloud:                wpb.Bool(true),
+				LogLevel:                  bpb.LogLevel_INFO,
+				SendMetricsToMonitoring:   wpb.Bool(true),
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"bucket": "testBucket", "recovery_bucket": "recoveryBucket", "replication_mode": "ASYNC"}`), nil
+			},
+		},
+		{
+			name:   "ZstdFilesystemBackend",
+			params: defaultParameters,
+			want: &bpb.BackintConfiguration{
+				UserId:                  "testUser",
+				Function:                bpb.Function_BACKUP,
+				ParamFile:               "testParamsFile.json",
+				StorageBackend:          bpb.StorageBackend_FILESYSTEM,
+				FilesystemPath:          "/mnt/backups",
+				CompressionCodec:        bpb.CompressionCodec_ZSTD,
+				CompressionLevel:        9,
+				ParallelStreams:         1,
+				BufferSizeMb:            100,
+				FileReadTimeoutMs:       60000,
+				Retries:                 5,
+				Threads:                 defaultThreads(),
+				InputFile:               "/dev/stdin",
+				OutputFile:              "/dev/stdout",
+				LogToCloud:              wpb.Bool(true),
+				LogLevel:                bpb.LogLevel_INFO,
+				SendMetricsToMonitoring: wpb.Bool(true),
+			},
+			read: func(p string) ([]byte, error) {
+				return []byte(`{"storage_backend": "FILESYSTEM", "filesystem_path": "/mnt/backups", "compression_codec": "ZSTD", "compression_level": 9}`), nil
+			},
+		},
 		{
 			name:   "CompressedXMLMultipartBackup",
 			params: defaultParameters,
@@ -819,6 +1238,18 @@ func TestApplyDefaultMaxThreads(t *testing.T) {
 	}
 }
 
+func TestApplyDefaultResumableStateDirectory(t *testing.T) {
+	params := Parameters{Config: &bpb.BackintConfiguration{
+		ParamFile:        "/usr/sap/TST/SYS/global/hdb/opt/backint/backint-gcs/parameters.json",
+		ResumableUploads: true,
+	}}
+	want := "/usr/sap/TST/SYS/global/hdb/opt/backint/backint-gcs/resumable"
+	params.ApplyDefaults(1)
+	if got := params.Config.GetResumableStateDirectory(); got != want {
+		t.Errorf("%#v.applyDefaults(1) resumable_state_directory = %s, want %s", params, got, want)
+	}
+}
+
 func TestLogLevelToZapcore(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedup
+
+import (
+	"bufio"
+	"io"
+	"math/bits"
+)
+
+// gear holds the random values used by the rolling hash. The values are
+// derived from a fixed seed and must never change, otherwise chunk
+// boundaries shift and previously stored chunks can no longer be reused.
+var gear = func() [256]uint64 {
+	var g [256]uint64
+	seed := uint64(0x5ab4c7a9e2d3f1b7)
+	for i := range g {
+		// splitmix64
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		g[i] = z ^ (z >> 31)
+	}
+	return g
+}()
+
+// chunker splits a stream into content-defined chunks using a gear rolling
+// hash. A boundary is declared when the top bits of the hash are all zero,
+// so inserting or removing data only changes the chunks around the edit.
+type chunker struct {
+	r        *bufio.Reader
+	min, max int
+	mask     uint64
+}
+
+// newChunker returns a chunker producing chunks of avgSize bytes on average.
+// Chunks are never smaller than avgSize/4 (except the last) or larger than avgSize*4.
+func newChunker(r io.Reader, avgSize int) *chunker {
+	b := bits.Len(uint(avgSize)) - 1
+	return &chunker{
+		r:    bufio.NewReaderSize(r, 1024*1024),
+		min:  avgSize / 4,
+		max:  avgSize * 4,
+		mask: ((uint64(1) << b) - 1) << (64 - b),
+	}
+}
+
+// next returns the next chunk. io.EOF is returned once the stream is exhausted.
+func (c *chunker) next() ([]byte, error) {
+	buf := make([]byte, 0, c.min*2)
+	var hash uint64
+	for {
+		b, err := c.r.ReadByte()
+		if err == io.EOF {
+			if len(buf) == 0 {
+				return nil, io.EOF
+			}
+			return buf, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, b)
+		if len(buf) < c.min {
+			continue
+		}
+		hash = (hash << 1) + gear[b]
+		if hash&c.mask == 0 || len(buf) >= c.max {
+			return buf, nil
+		}
+	}
+}
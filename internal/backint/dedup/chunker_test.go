@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func randomData(seed int64, size int) []byte {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(data)
+	return data
+}
+
+func chunkAll(t *testing.T, data []byte, avgSize int) [][]byte {
+	t.Helper()
+	var chunks [][]byte
+	c := newChunker(bytes.NewReader(data), avgSize)
+	for {
+		chunk, err := c.next()
+		if err == io.EOF {
+			return chunks
+		}
+		if err != nil {
+			t.Fatalf("next() failed: %v", err)
+		}
+		chunks = append(chunks, chunk)
+	}
+}
+
+func TestChunkerBounds(t *testing.T) {
+	avgSize := 4096
+	data := randomData(1, 1024*1024)
+	chunks := chunkAll(t, data, avgSize)
+	if got := bytes.Join(chunks, nil); !bytes.Equal(got, data) {
+		t.Fatalf("chunks do not reassemble the original data")
+	}
+	for i, chunk := range chunks {
+		if len(chunk) > avgSize*4 {
+			t.Errorf("chunk %d has size %d, want <= %d", i, len(chunk), avgSize*4)
+		}
+		if i < len(chunks)-1 && len(chunk) < avgSize/4 {
+			t.Errorf("chunk %d has size %d, want >= %d", i, len(chunk), avgSize/4)
+		}
+	}
+	if len(chunks) < 64 || len(chunks) > 1024 {
+		t.Errorf("chunkAll() returned %d chunks for 1MiB with an average of 4KiB, want roughly 256", len(chunks))
+	}
+}
+
+func TestChunkerEmpty(t *testing.T) {
+	if chunks := chunkAll(t, nil, 4096); len(chunks) != 0 {
+		t.Errorf("chunkAll(nil) returned %d chunks, want 0", len(chunks))
+	}
+}
+
+func TestChunkerBoundariesSurviveInsert(t *testing.T) {
+	data := randomData(2, 512*1024)
+	shifted := append([]byte("inserted bytes at the start of the stream"), data...)
+
+	hashes := func(chunks [][]byte) map[[32]byte]bool {
+		m := make(map[[32]byte]bool)
+		for _, c := range chunks {
+			m[sha256.Sum256(c)] = true
+		}
+		return m
+	}
+	original := hashes(chunkAll(t, data, 4096))
+	shiftedChunks := chunkAll(t, shifted, 4096)
+	shared := 0
+	for h := range hashes(shiftedChunks) {
+		if original[h] {
+			shared++
+		}
+	}
+	// Only the chunks around the insert should differ.
+	if shared < len(original)-3 {
+		t.Errorf("chunks shared after insert = %d, want at least %d of %d", shared, len(original)-3, len(original))
+	}
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dedup stores Backint backups as content-addressed chunks.
+//
+// Each backup is split into content-defined chunks which are stored once under
+// <folder_prefix>.chunks/<user_id>/<sha256>. The backup object itself holds a
+// small manifest listing the chunks, so INQUIRE output is unchanged. RESTORE
+// reassembles the chunks and DELETE garbage-collects chunks which are no
+// longer referenced by any manifest.
+//
+// A backup in progress writes a marker under <folder_prefix>.chunks-inprogress/<user_id>/
+// and refreshes the chunks it reuses, so that garbage collection keeps every
+// chunk used since the oldest backup in progress started.
+package dedup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	store "cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/ratelimit"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+const (
+	// ManifestMetadataKey marks backup objects which contain a manifest rather than data.
+	ManifestMetadataKey = "X-Backint-Manifest"
+	manifestVersion     = 1
+
+	// chunkUsedMetadataKey is rewritten when a backup reuses a chunk, which
+	// refreshes the chunk's update time.
+	chunkUsedMetadataKey = "X-Backint-Chunk-Used"
+
+	// gcGracePeriod protects chunks uploaded by a backup which has not written
+	// its manifest yet from being garbage-collected by a concurrent DELETE.
+	gcGracePeriod = 24 * time.Hour
+	// inProgressTimeout is when the marker of a backup which never finished,
+	// for example because the agent was killed, no longer protects chunks.
+	inProgressTimeout = 7 * 24 * time.Hour
+)
+
+// Manifest lists the chunks which make up a deduplicated backup, in order.
+type Manifest struct {
+	Version  int        `json:"version"`
+	FileName string     `json:"fileName"`
+	Size     int64      `json:"size"`
+	Chunks   []ChunkRef `json:"chunks"`
+}
+
+// ChunkRef identifies a stored chunk by its SHA-256 hash.
+type ChunkRef struct {
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+}
+
+// Parameters holds the options for deduplicated uploads and downloads.
+type Parameters struct {
+	Config       *bpb.BackintConfiguration
+	BucketHandle *store.BucketHandle
+	Copier       storage.IOFileCopier
+	// ObjectName is the name of the manifest object.
+	ObjectName string
+	FileName   string
+	// Metadata is applied to the manifest object.
+	Metadata     map[string]string
+	StorageClass string
+}
+
+// ChunkPrefix returns the content-addressed prefix where chunks for the configured user are stored.
+func ChunkPrefix(config *bpb.BackintConfiguration) string {
+	return config.GetFolderPrefix() + ".chunks/" + config.GetUserId() + "/"
+}
+
+// inProgressPrefix returns the prefix of the markers written by backups in progress.
+func inProgressPrefix(config *bpb.BackintConfiguration) string {
+	return config.GetFolderPrefix() + ".chunks-inprogress/" + config.GetUserId() + "/"
+}
+
+// IsManifest returns true if the object metadata marks a deduplication manifest.
+func IsManifest(metadata map[string]string) bool {
+	return metadata[ManifestMetadataKey] == "true"
+}
+
+// Upload splits the reader into chunks, uploads the chunks not already
+// present in the bucket and writes the manifest. Returns the number of bytes
+// read from the reader.
+func Upload(ctx context.Context, p Parameters, reader io.Reader) (int64, error) {
+	if p.BucketHandle == nil {
+		return 0, fmt.Errorf("no bucket handle defined, cannot upload %s", p.ObjectName)
+	}
+	// The marker protects the chunks of this backup from garbage collection until
+	// its manifest references them.
+	sum := sha256.Sum256([]byte(p.ObjectName))
+	marker := inProgressPrefix(p.Config) + hex.EncodeToString(sum[:])
+	if err := uploadBytes(ctx, p, marker, []byte(p.ObjectName), nil); err != nil {
+		return 0, fmt.Errorf("uploading in-progress marker %s: %v", marker, err)
+	}
+	defer func() {
+		if err := storage.DeleteObject(ctx, p.BucketHandle, marker, p.Config.GetRetries()); err != nil {
+			log.CtxLogger(ctx).Warnw("Error deleting in-progress marker", "object", marker, "err", err)
+		}
+	}()
+
+	m := &Manifest{Version: manifestVersion, FileName: p.FileName}
+	c := newChunker(reader, int(p.Config.GetDedupChunkSizeKb())*1024)
+	uploaded := make(map[string]bool)
+	var newBytes int64
+	for {
+		chunk, err := c.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return m.Size, err
+		}
+		sum := sha256.Sum256(chunk)
+		ref := ChunkRef{Hash: hex.EncodeToString(sum[:]), Size: int64(len(chunk))}
+		m.Chunks = append(m.Chunks, ref)
+		m.Size += ref.Size
+		if uploaded[ref.Hash] {
+			continue
+		}
+		uploaded[ref.Hash] = true
+		object := ChunkPrefix(p.Config) + ref.Hash
+		if refreshChunk(ctx, p.BucketHandle, object) {
+			continue
+		}
+		if err := uploadBytes(ctx, p, object, chunk, nil); err != nil {
+			return m.Size, fmt.Errorf("uploading chunk %s: %v", object, err)
+		}
+		newBytes += ref.Size
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return m.Size, err
+	}
+	metadata := make(map[string]string)
+	for k, v := range p.Metadata {
+		metadata[k] = v
+	}
+	metadata[ManifestMetadataKey] = "true"
+	if err := uploadBytes(ctx, p, p.ObjectName, data, metadata); err != nil {
+		return m.Size, fmt.Errorf("uploading manifest %s: %v", p.ObjectName, err)
+	}
+	log.CtxLogger(ctx).Infow("Deduplicated backup uploaded", "obj", p.ObjectName, "fileSize", m.Size, "chunks", len(m.Chunks), "uniqueChunks", len(uploaded), "newBytes", newBytes)
+	return m.Size, nil
+}
+
+// refreshChunk marks an existing chunk as used by rewriting its metadata, which
+// sets its update time. Returns false if the chunk does not exist or could not
+// be refreshed, in which case it must be uploaded again.
+func refreshChunk(ctx context.Context, bucketHandle *store.BucketHandle, object string) bool {
+	_, err := bucketHandle.Object(object).Update(ctx, store.ObjectAttrsToUpdate{
+		Metadata: map[string]string{chunkUsedMetadataKey: time.Now().UTC().Format(time.RFC3339)},
+	})
+	if err != nil && !errors.Is(err, store.ErrObjectNotExist) {
+		log.CtxLogger(ctx).Infow("Unable to refresh existing chunk, uploading again", "object", object, "err", err)
+	}
+	return err == nil
+}
+
+// uploadBytes uploads data to the object using the configured storage options.
+func uploadBytes(ctx context.Context, p Parameters, object string, data []byte, metadata map[string]string) error {
+	rw := storage.ReadWriter{
+		Reader:                 bytes.NewReader(data),
+		Copier:                 p.Copier,
+		BucketHandle:           p.BucketHandle,
+		BucketName:             p.Config.GetBucket(),
+		ChunkSizeMb:            p.Config.GetBufferSizeMb(),
+		ObjectName:             object,
+		TotalBytes:             int64(len(data)),
+		StorageClass:           p.StorageClass,
+		RateLimitBytes:         ratelimit.StaticBytes(p.Config),
+		EncryptionKey:          p.Config.GetEncryptionKey(),
+		KMSKey:                 p.Config.GetKmsKey(),
+		MaxRetries:             p.Config.GetRetries(),
+		VerifyUpload:           true,
+		Metadata:               metadata,
+		RetryBackoffInitial:    time.Duration(p.Config.GetRetryBackoffInitial()) * time.Second,
+		RetryBackoffMax:        time.Duration(p.Config.GetRetryBackoffMax()) * time.Second,
+		RetryBackoffMultiplier: float64(p.Config.GetRetryBackoffMultiplier()),
+	}
+	_, err := rw.Upload(ctx)
+	return err
+}
+
+// ReadManifest downloads and parses the manifest stored in the object.
+func ReadManifest(ctx context.Context, config *bpb.BackintConfiguration, bucketHandle *store.BucketHandle, object string) (*Manifest, error) {
+	buf := &bytes.Buffer{}
+	if err := readObject(ctx, config, bucketHandle, object, buf); err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(buf.Bytes(), m); err != nil {
+		return nil, fmt.Errorf("malformed manifest %s: %v", object, err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d in %s", m.Version, object)
+	}
+	return m, nil
+}
+
+// Download reassembles the backup described by the manifest object into the
+// writer. Each chunk is verified against its hash. Returns the bytes written.
+func Download(ctx context.Context, p Parameters, writer io.Writer) (int64, error) {
+	if p.BucketHandle == nil {
+		return 0, fmt.Errorf("no bucket handle defined, cannot download %s", p.ObjectName)
+	}
+	m, err := ReadManifest(ctx, p.Config, p.BucketHandle, p.ObjectName)
+	if err != nil {
+		return 0, err
+	}
+	var written int64
+	for i, ref := range m.Chunks {
+		object := ChunkPrefix(p.Config) + ref.Hash
+		h := sha256.New()
+		n, err := copyObject(ctx, p, object, io.MultiWriter(writer, h))
+		written += n
+		if err != nil {
+			return written, fmt.Errorf("downloading chunk %d (%s): %v", i, object, err)
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != ref.Hash || n != ref.Size {
+			return written, fmt.Errorf("chunk %d (%s) is corrupt: got hash %s and size %d, want hash %s and size %d", i, object, got, n, ref.Hash, ref.Size)
+		}
+	}
+	log.CtxLogger(ctx).Infow("Deduplicated backup downloaded", "obj", p.ObjectName, "bytesWritten", written, "chunks", len(m.Chunks))
+	return written, nil
+}
+
+// copyObject streams the object's data into the writer.
+func copyObject(ctx context.Context, p Parameters, object string, writer io.Writer) (int64, error) {
+	copier := p.Copier
+	if copier == nil {
+		copier = io.Copy
+	}
+	r, err := objectHandle(p.Config, p.BucketHandle, object).NewReader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return copier(writer, r)
+}
+
+// readObject reads an entire object into the writer.
+func readObject(ctx context.Context, config *bpb.BackintConfiguration, bucketHandle *store.BucketHandle, object string, writer io.Writer) error {
+	_, err := copyObject(ctx, Parameters{Config: config, BucketHandle: bucketHandle}, object, writer)
+	return err
+}
+
+// objectHandle returns a handle for the object, applying the customer-supplied
+// encryption key if one is configured.
+func objectHandle(config *bpb.BackintConfiguration, bucketHandle *store.BucketHandle, object string) *store.ObjectHandle {
+	o := bucketHandle.Object(object)
+	if config.GetEncryptionKey() != "" {
+		if key, err := base64.StdEncoding.DecodeString(config.GetEncryptionKey()); err == nil {
+			o = o.Key(key)
+		}
+	}
+	return o
+}
+
+// CollectGarbage deletes chunks which are not referenced by any manifest for
+// the configured user. Chunks used within the grace period, or since the oldest
+// backup in progress started, are kept. It runs whether or not deduplication is
+// enabled, so that chunks of earlier deduplicated backups are removed once their
+// manifests are deleted. Returns the number of chunks deleted.
+func CollectGarbage(ctx context.Context, config *bpb.BackintConfiguration, bucketHandle *store.BucketHandle, now time.Time) (int, error) {
+	if bucketHandle == nil {
+		return 0, fmt.Errorf("no bucket handle defined, cannot collect garbage")
+	}
+	chunks, err := storage.ListObjects(ctx, bucketHandle, ChunkPrefix(config), "", config.GetRetries())
+	if err != nil {
+		return 0, fmt.Errorf("listing chunks: %v", err)
+	}
+	if len(chunks) == 0 {
+		return 0, nil
+	}
+	markers, err := storage.ListObjects(ctx, bucketHandle, inProgressPrefix(config), "", config.GetRetries())
+	if err != nil {
+		return 0, fmt.Errorf("listing in-progress backups: %v", err)
+	}
+	cutoff := gcCutoff(ctx, markers, now)
+
+	objects, err := storage.ListObjects(ctx, bucketHandle, config.GetFolderPrefix()+config.GetUserId()+"/", "", config.GetRetries())
+	if err != nil {
+		return 0, fmt.Errorf("listing manifests: %v", err)
+	}
+	referenced := make(map[string]bool)
+	for _, object := range objects {
+		if !IsManifest(object.Metadata) {
+			continue
+		}
+		// Any unreadable manifest aborts the collection so referenced chunks are never deleted.
+		m, err := ReadManifest(ctx, config, bucketHandle, object.Name)
+		if err != nil {
+			return 0, err
+		}
+		for _, ref := range m.Chunks {
+			referenced[ref.Hash] = true
+		}
+	}
+
+	deleted := 0
+	for _, name := range unreferencedChunks(ChunkPrefix(config), chunks, referenced, cutoff) {
+		if err := storage.DeleteObject(ctx, bucketHandle, name, config.GetRetries()); err != nil {
+			log.CtxLogger(ctx).Errorw("Error deleting unreferenced chunk", "object", name, "err", err)
+			continue
+		}
+		deleted++
+	}
+	log.CtxLogger(ctx).Infow("Deduplication garbage collection finished", "chunks", len(chunks), "referenced", len(referenced), "deleted", deleted)
+	return deleted, nil
+}
+
+// gcCutoff returns the time before which unreferenced chunks may be deleted: the
+// end of the grace period, or the start of the oldest backup in progress.
+func gcCutoff(ctx context.Context, markers []*store.ObjectAttrs, now time.Time) time.Time {
+	cutoff := now.Add(-gcGracePeriod)
+	for _, marker := range markers {
+		if now.Sub(marker.Created) > inProgressTimeout {
+			log.CtxLogger(ctx).Warnw("Ignoring the marker of a backup which did not finish", "object", marker.Name, "created", marker.Created)
+			continue
+		}
+		if marker.Created.Before(cutoff) {
+			cutoff = marker.Created
+		}
+	}
+	return cutoff
+}
+
+// unreferencedChunks returns the names of chunk objects which are not
+// referenced and were last uploaded or reused before the cutoff.
+func unreferencedChunks(prefix string, chunks []*store.ObjectAttrs, referenced map[string]bool, cutoff time.Time) []string {
+	var names []string
+	for _, chunk := range chunks {
+		lastUsed := chunk.Created
+		if chunk.Updated.After(lastUsed) {
+			lastUsed = chunk.Updated
+		}
+		if referenced[strings.TrimPrefix(chunk.Name, prefix)] || !lastUsed.Before(cutoff) {
+			continue
+		}
+		names = append(names, chunk.Name)
+	}
+	return names
+}
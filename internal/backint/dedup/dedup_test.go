@@ -0,0 +1,276 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedup
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	store "cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+func TestMain(t *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(t.Run())
+}
+
+var defaultConfig = &bpb.BackintConfiguration{
+	Bucket:           "test-bucket",
+	UserId:           "test@TST",
+	Deduplicate:      true,
+	DedupChunkSizeKb: 64,
+	BufferSizeMb:     1,
+}
+
+// fakeBucket creates a new empty bucket. Use separate bucket names
+// for tests that delete objects so they do not interfere.
+func fakeBucket(bucketName string) *store.BucketHandle {
+	server := fakestorage.NewServer([]fakestorage.Object{})
+	server.CreateBucketWithOpts(fakestorage.CreateBucketOpts{Name: bucketName})
+	return server.Client().Bucket(bucketName)
+}
+
+func TestChunkPrefix(t *testing.T) {
+	config := &bpb.BackintConfiguration{UserId: "test@TST", FolderPrefix: "prefix/"}
+	if got, want := ChunkPrefix(config), "prefix/.chunks/test@TST/"; got != want {
+		t.Errorf("ChunkPrefix() = %s, want %s", got, want)
+	}
+}
+
+func TestIsManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		want     bool
+	}{
+		{
+			name: "NilMetadata",
+		},
+		{
+			name:     "DataObject",
+			metadata: map[string]string{"X-Backup-Type": "PIPE"},
+		},
+		{
+			name:     "Manifest",
+			metadata: map[string]string{"X-Backup-Type": "PIPE", ManifestMetadataKey: "true"},
+			want:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsManifest(test.metadata); got != test.want {
+				t.Errorf("IsManifest(%v) = %v, want %v", test.metadata, got, test.want)
+			}
+		})
+	}
+}
+
+func TestUploadNoBucketHandle(t *testing.T) {
+	if _, err := Upload(context.Background(), Parameters{Config: defaultConfig}, bytes.NewReader([]byte("data"))); err == nil {
+		t.Error("Upload() with no bucket handle succeeded, want error")
+	}
+}
+
+func TestDownloadNoBucketHandle(t *testing.T) {
+	if _, err := Download(context.Background(), Parameters{Config: defaultConfig}, io.Discard); err == nil {
+		t.Error("Download() with no bucket handle succeeded, want error")
+	}
+}
+
+func TestUploadDownloadAndCollectGarbage(t *testing.T) {
+	ctx := context.Background()
+	bucketHandle := fakeBucket("test-bucket")
+	block := randomData(3, 256*1024)
+	// The same block repeated should be stored once.
+	data := bytes.Join([][]byte{block, block, block}, nil)
+
+	p := Parameters{
+		Config:       defaultConfig,
+		BucketHandle: bucketHandle,
+		Copier:       io.Copy,
+		ObjectName:   "test@TST/object.txt/1.bak",
+		FileName:     "/object.txt",
+		Metadata:     map[string]string{"X-Backup-Type": "PIPE"},
+	}
+	n, err := Upload(ctx, p, bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Errorf("Upload() = %d, want %d", n, len(data))
+	}
+	chunks, err := storage.ListObjects(ctx, bucketHandle, ChunkPrefix(defaultConfig), "", 1)
+	if err != nil {
+		t.Fatalf("ListObjects() failed: %v", err)
+	}
+	m, err := ReadManifest(ctx, defaultConfig, bucketHandle, p.ObjectName)
+	if err != nil {
+		t.Fatalf("ReadManifest() failed: %v", err)
+	}
+	if len(chunks) >= len(m.Chunks) {
+		t.Errorf("Upload() stored %d chunks for a manifest of %d chunks, want fewer due to repeated data", len(chunks), len(m.Chunks))
+	}
+	markers, err := storage.ListObjects(ctx, bucketHandle, inProgressPrefix(defaultConfig), "", 1)
+	if err != nil || len(markers) != 0 {
+		t.Errorf("Upload() left in-progress markers %v (err: %v), want none", markers, err)
+	}
+
+	// A second backup of the same data adds no chunks.
+	p2 := p
+	p2.ObjectName = "test@TST/object.txt/2.bak"
+	if _, err := Upload(ctx, p2, bytes.NewReader(data)); err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	chunks2, _ := storage.ListObjects(ctx, bucketHandle, ChunkPrefix(defaultConfig), "", 1)
+	if len(chunks2) != len(chunks) {
+		t.Errorf("second Upload() stored %d chunks, want %d", len(chunks2), len(chunks))
+	}
+
+	got := &bytes.Buffer{}
+	if _, err := Download(ctx, p, got); err != nil {
+		t.Fatalf("Download() failed: %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), data) {
+		t.Errorf("Download() returned %d bytes which do not match the %d uploaded", got.Len(), len(data))
+	}
+
+	// Chunks are still referenced by the second manifest.
+	if err := storage.DeleteObject(ctx, bucketHandle, p.ObjectName, 1); err != nil {
+		t.Fatalf("DeleteObject() failed: %v", err)
+	}
+	future := time.Now().Add(2 * gcGracePeriod)
+	if deleted, err := CollectGarbage(ctx, defaultConfig, bucketHandle, future); err != nil || deleted != 0 {
+		t.Errorf("CollectGarbage() = (%d, %v), want (0, nil)", deleted, err)
+	}
+	if err := storage.DeleteObject(ctx, bucketHandle, p2.ObjectName, 1); err != nil {
+		t.Fatalf("DeleteObject() failed: %v", err)
+	}
+	if deleted, err := CollectGarbage(ctx, defaultConfig, bucketHandle, future); err != nil || deleted != len(chunks) {
+		t.Errorf("CollectGarbage() = (%d, %v), want (%d, nil)", deleted, err, len(chunks))
+	}
+}
+
+func TestUnreferencedChunks(t *testing.T) {
+	now := time.Now()
+	chunks := []*store.ObjectAttrs{
+		{Name: ".chunks/test@TST/referenced", Created: now.Add(-48 * time.Hour)},
+		{Name: ".chunks/test@TST/orphan", Created: now.Add(-48 * time.Hour)},
+		{Name: ".chunks/test@TST/recent", Created: now.Add(-time.Hour)},
+		{Name: ".chunks/test@TST/reused", Created: now.Add(-48 * time.Hour), Updated: now.Add(-time.Hour)},
+	}
+	referenced := map[string]bool{"referenced": true}
+	want := []string{".chunks/test@TST/orphan"}
+	got := unreferencedChunks(".chunks/test@TST/", chunks, referenced, now.Add(-gcGracePeriod))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unreferencedChunks() had unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestGCCutoff(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		markers []*store.ObjectAttrs
+		want    time.Time
+	}{
+		{
+			name: "NoBackupInProgress",
+			want: now.Add(-gcGracePeriod),
+		},
+		{
+			name: "RecentBackupInProgress",
+			markers: []*store.ObjectAttrs{
+				{Name: "marker", Created: now.Add(-time.Hour)},
+			},
+			want: now.Add(-gcGracePeriod),
+		},
+		{
+			name: "LongBackupInProgress",
+			markers: []*store.ObjectAttrs{
+				{Name: "long", Created: now.Add(-72 * time.Hour)},
+				{Name: "longer", Created: now.Add(-96 * time.Hour)},
+			},
+			want: now.Add(-96 * time.Hour),
+		},
+		{
+			name: "StaleMarkerIgnored",
+			markers: []*store.ObjectAttrs{
+				{Name: "stale", Created: now.Add(-2 * inProgressTimeout)},
+			},
+			want: now.Add(-gcGracePeriod),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := gcCutoff(context.Background(), tc.markers, now)
+			if !got.Equal(tc.want) {
+				t.Errorf("gcCutoff() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCollectGarbageKeepsChunksOfBackupInProgress(t *testing.T) {
+	ctx := context.Background()
+	bucketHandle := fakeBucket("test-bucket")
+	p := Parameters{
+		Config:       defaultConfig,
+		BucketHandle: bucketHandle,
+		Copier:       io.Copy,
+		ObjectName:   "test@TST/object.txt/1.bak",
+	}
+	// A chunk written by a backup which has not written its manifest yet.
+	if err := uploadBytes(ctx, p, inProgressPrefix(defaultConfig)+"marker", []byte(p.ObjectName), nil); err != nil {
+		t.Fatalf("uploadBytes() failed: %v", err)
+	}
+	if err := uploadBytes(ctx, p, ChunkPrefix(defaultConfig)+"chunk", []byte("chunk"), nil); err != nil {
+		t.Fatalf("uploadBytes() failed: %v", err)
+	}
+
+	later := time.Now().Add(2 * gcGracePeriod)
+	if deleted, err := CollectGarbage(ctx, defaultConfig, bucketHandle, later); err != nil || deleted != 0 {
+		t.Errorf("CollectGarbage() = (%d, %v), want (0, nil)", deleted, err)
+	}
+	// Once the marker is stale, the chunk is collected.
+	muchLater := time.Now().Add(2 * inProgressTimeout)
+	if deleted, err := CollectGarbage(ctx, defaultConfig, bucketHandle, muchLater); err != nil || deleted != 1 {
+		t.Errorf("CollectGarbage() = (%d, %v), want (1, nil)", deleted, err)
+	}
+}
+
+func TestCollectGarbageWithoutDeduplication(t *testing.T) {
+	ctx := context.Background()
+	bucketHandle := fakeBucket("test-bucket")
+	config := &bpb.BackintConfiguration{Bucket: "test-bucket", UserId: "test@TST", BufferSizeMb: 1}
+	p := Parameters{Config: config, BucketHandle: bucketHandle, Copier: io.Copy}
+	if err := uploadBytes(ctx, p, ChunkPrefix(config)+"chunk", []byte("chunk"), nil); err != nil {
+		t.Fatalf("uploadBytes() failed: %v", err)
+	}
+	later := time.Now().Add(2 * gcGracePeriod)
+	if deleted, err := CollectGarbage(ctx, config, bucketHandle, later); err != nil || deleted != 1 {
+		t.Errorf("CollectGarbage() = (%d, %v), want (1, nil)", deleted, err)
+	}
+}
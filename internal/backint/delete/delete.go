@@ -14,7 +14,8 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package delete removes Backint files from a GCS bucket.
+// Package delete removes Backint files from a GCS bucket, along with their
+// replicas in the recovery bucket.
 package delete
 
 import (
@@ -25,10 +26,13 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"time"
 
-	store "cloud.google.com/go/storage"
 	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/dedup"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/parse"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/replication"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
@@ -55,6 +59,7 @@ func Execute(ctx context.Context, config *bpb.BackintConfiguration, connectParam
 func delete(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, input io.Reader, output io.Writer) error {
 	wp := workerpool.New(int(config.GetThreads()))
 	mu := &sync.Mutex{}
+	deleted := 0
 	scanner := bufio.NewScanner(input)
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -73,11 +78,22 @@ func delete(ctx context.Context, config *bpb.BackintConfiguration, connectParams
 			object := parse.CreateObjectPath(config, parse.TrimAndClean(fileName), externalBackupID, ".bak")
 			wp.Submit(func() {
 				log.CtxLogger(ctx).Infow("Deleting object", "object", object)
-				bucketHandle, _ := storage.ConnectToBucket(ctx, connectParams)
-				err := storage.DeleteObject(ctx, bucketHandle, object, config.GetRetries())
+				b, err := backend.New(ctx, config, connectParams)
+				if err == nil {
+					err = b.Delete(ctx, object)
+				}
+				// The replica is removed so restores do not fall back to a deleted backup.
+				if replication.Enabled(config) && (err == nil || errors.Is(err, backend.ErrObjectNotExist)) {
+					removed, replicaErr := replication.RemoveReplica(ctx, config, connectParams, object)
+					if replicaErr != nil {
+						err = replicaErr
+					} else if removed {
+						err = nil
+					}
+				}
 				mu.Lock()
 				defer mu.Unlock()
-				if errors.Is(err, store.ErrObjectNotExist) {
+				if errors.Is(err, backend.ErrObjectNotExist) {
 					log.CtxLogger(ctx).Errorw("Object not found", "object", object, "err", err)
 					output.Write([]byte(fmt.Sprintf("#NOTFOUND %q %s\n", externalBackupID, fileName)))
 				} else if err != nil {
@@ -85,6 +101,7 @@ func delete(ctx context.Context, config *bpb.BackintConfiguration, connectParams
 					output.Write([]byte(fmt.Sprintf("#ERROR %q %s\n", externalBackupID, fileName)))
 				} else {
 					log.CtxLogger(ctx).Infow("Object deleted", "object", object)
+					deleted++
 					output.Write([]byte(fmt.Sprintf("#DELETED %q %s\n", externalBackupID, fileName)))
 				}
 			})
@@ -96,5 +113,12 @@ func delete(ctx context.Context, config *bpb.BackintConfiguration, connectParams
 	if err := scanner.Err(); err != nil {
 		return err
 	}
+	// Deduplicated backups share chunks, which can only be removed once no manifest references them.
+	if backend.IsGCS(config) && deleted > 0 {
+		bucketHandle, _ := storage.ConnectToBucket(ctx, connectParams)
+		if _, err := dedup.CollectGarbage(ctx, config, bucketHandle, time.Now()); err != nil {
+			log.CtxLogger(ctx).Errorw("Error collecting unreferenced deduplication chunks", "err", err)
+		}
+	}
 	return nil
 }
@@ -29,8 +29,8 @@ import (
 	"strings"
 	"time"
 
-	store "cloud.google.com/go/storage"
 	"golang.org/x/sys/unix"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backup"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/delete"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/inquire"
@@ -200,10 +200,13 @@ func removeFiles(ctx context.Context, opts diagnoseOptions, remove removeFunc) b
 			allFilesDeleted = false
 		}
 		if opts.connectParams != nil {
-			bucketHandle, _ := storage.ConnectToBucket(ctx, opts.connectParams)
 			object := parse.CreateObjectPath(opts.config, file.fileName, file.externalBackupID, ".bak")
 			log.CtxLogger(ctx).Infow("Removing Cloud Storage Bucket file", "object", object)
-			if err := bucketHandle.Object(object).Delete(ctx); err != nil && !errors.Is(err, store.ErrObjectNotExist) {
+			b, err := backend.New(ctx, opts.config, opts.connectParams)
+			if err == nil {
+				err = b.Delete(ctx, object)
+			}
+			if err != nil && !errors.Is(err, backend.ErrObjectNotExist) {
 				log.CtxLogger(ctx).Errorw("Failed to remove Cloud Storage Bucket file", "link", fmt.Sprintf("https://console.cloud.google.com/storage/browser/_details/%s/%s", opts.config.GetBucket(), object), "object", object, "err", err)
 				allFilesDeleted = false
 			}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envelope encrypts Backint objects with per-object data keys.
+//
+// Each object is encrypted with a random AES-256 data key. The data key is
+// wrapped by a key-encryption key (KEK), either a key from a local keyring
+// file or a Cloud KMS key, and stored in the object metadata together with
+// the ID of the KEK. Restores select the KEK from the metadata, so rotating
+// the KEK only requires re-wrapping the data keys, not re-encrypting data.
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+const (
+	// WrappedKeyMetadataKey holds the base64 encoded wrapped data key.
+	WrappedKeyMetadataKey = "X-Backint-Wrapped-Key"
+	// KEKMetadataKey holds the ID of the KEK which wrapped the data key.
+	KEKMetadataKey = "X-Backint-Kek"
+
+	keyringPrefix = "keyring/"
+	kmsPrefix     = "kms/"
+)
+
+// KEK wraps and unwraps data keys.
+type KEK interface {
+	// ID identifies the KEK in object metadata.
+	ID() string
+	Wrap(ctx context.Context, dataKey []byte) ([]byte, error)
+	Unwrap(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// ReadFile abstracts reading the keyring file.
+type ReadFile func(name string) ([]byte, error)
+
+// Keys resolves the KEKs available to the configuration.
+type Keys struct {
+	keyring *Keyring
+	kmsKey  string
+
+	// newKMS creates the Cloud KMS client on first use.
+	newKMS  func(ctx context.Context) (kmsClient, error)
+	kmsOnce sync.Once
+	kms     kmsClient
+	kmsErr  error
+}
+
+// NewKeys loads the keyring file and prepares the Cloud KMS client from the
+// configuration. The keyring is optional when envelope_kms_key is set,
+// but is still used to unwrap data keys of backups made before a switch to KMS.
+func NewKeys(config *bpb.BackintConfiguration, read ReadFile) (*Keys, error) {
+	k := &Keys{
+		kmsKey: config.GetEnvelopeKmsKey(),
+		newKMS: func(ctx context.Context) (kmsClient, error) {
+			return newKMSService(ctx, config.GetServiceAccountKey())
+		},
+	}
+	if config.GetEnvelopeKeyringFile() != "" {
+		data, err := read(config.GetEnvelopeKeyringFile())
+		if err != nil {
+			return nil, fmt.Errorf("reading envelope_keyring_file: %v", err)
+		}
+		if k.keyring, err = ParseKeyring(data); err != nil {
+			return nil, err
+		}
+	}
+	if k.keyring == nil && k.kmsKey == "" {
+		return nil, fmt.Errorf("envelope encryption requires envelope_keyring_file or envelope_kms_key")
+	}
+	return k, nil
+}
+
+func (k *Keys) kmsClient(ctx context.Context) (kmsClient, error) {
+	k.kmsOnce.Do(func() {
+		k.kms, k.kmsErr = k.newKMS(ctx)
+	})
+	return k.kms, k.kmsErr
+}
+
+// Primary returns the KEK used to wrap new data keys.
+func (k *Keys) Primary(ctx context.Context) (KEK, error) {
+	if k.kmsKey != "" {
+		client, err := k.kmsClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &kmsKEK{name: k.kmsKey, client: client}, nil
+	}
+	return k.keyring.primary()
+}
+
+// Lookup returns the KEK with the ID recorded in object metadata.
+func (k *Keys) Lookup(ctx context.Context, id string) (KEK, error) {
+	switch {
+	case strings.HasPrefix(id, keyringPrefix):
+		if k.keyring == nil {
+			return nil, fmt.Errorf("KEK %s requires envelope_keyring_file", id)
+		}
+		return k.keyring.lookup(strings.TrimPrefix(id, keyringPrefix))
+	case strings.HasPrefix(id, kmsPrefix):
+		client, err := k.kmsClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &kmsKEK{name: strings.TrimPrefix(id, kmsPrefix), client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown KEK %q", id)
+	}
+}
+
+// IsEncrypted returns true if the object metadata holds a wrapped data key.
+func IsEncrypted(metadata map[string]string) bool {
+	return metadata[WrappedKeyMetadataKey] != ""
+}
+
+// NewDataKey returns a random data key along with the metadata recording
+// the data key wrapped by the primary KEK.
+func (k *Keys) NewDataKey(ctx context.Context) ([]byte, map[string]string, error) {
+	kek, err := k.Primary(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataKey := make([]byte, dataKeyBytes)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := kek.Wrap(ctx, dataKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("wrapping data key with %s: %v", kek.ID(), err)
+	}
+	return dataKey, map[string]string{
+		WrappedKeyMetadataKey: base64.StdEncoding.EncodeToString(wrapped),
+		KEKMetadataKey:        kek.ID(),
+	}, nil
+}
+
+// DataKey unwraps the data key recorded in the object metadata.
+func (k *Keys) DataKey(ctx context.Context, metadata map[string]string) ([]byte, error) {
+	wrapped, err := base64.StdEncoding.DecodeString(metadata[WrappedKeyMetadataKey])
+	if err != nil {
+		return nil, fmt.Errorf("malformed wrapped data key: %v", err)
+	}
+	kek, err := k.Lookup(ctx, metadata[KEKMetadataKey])
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := kek.Unwrap(ctx, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key with %s: %v", kek.ID(), err)
+	}
+	return dataKey, nil
+}
+
+// Rewrap returns a copy of the object metadata with the data key wrapped by
+// the primary KEK. Returns false if the data key is already wrapped by a
+// primary keyring key and was left unchanged. Data keys wrapped by Cloud KMS
+// are always re-wrapped so they move to the primary key version.
+func (k *Keys) Rewrap(ctx context.Context, metadata map[string]string) (map[string]string, bool, error) {
+	primary, err := k.Primary(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if metadata[KEKMetadataKey] == primary.ID() && strings.HasPrefix(primary.ID(), keyringPrefix) {
+		return metadata, false, nil
+	}
+	dataKey, err := k.DataKey(ctx, metadata)
+	if err != nil {
+		return nil, false, err
+	}
+	wrapped, err := primary.Wrap(ctx, dataKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("wrapping data key with %s: %v", primary.ID(), err)
+	}
+	updated := make(map[string]string)
+	for key, value := range metadata {
+		updated[key] = value
+	}
+	updated[WrappedKeyMetadataKey] = base64.StdEncoding.EncodeToString(wrapped)
+	updated[KEKMetadataKey] = primary.ID()
+	return updated, true, nil
+}
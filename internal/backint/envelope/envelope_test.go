@@ -0,0 +1,216 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+var (
+	key1 = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	key2 = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+)
+
+func keyringFile(primary string, keys map[string]string) ReadFile {
+	return func(string) ([]byte, error) {
+		data := fmt.Sprintf(`{"primary": %q, "keys": {`, primary)
+		sep := ""
+		for id, key := range keys {
+			data += fmt.Sprintf(`%s%q: %q`, sep, id, key)
+			sep = ","
+		}
+		return []byte(data + "}}"), nil
+	}
+}
+
+// fakeKMS "encrypts" by prefixing the key name, which is enough to verify
+// the key used for unwrapping.
+type fakeKMS struct {
+	err error
+}
+
+func (f *fakeKMS) Encrypt(ctx context.Context, name string, plaintext []byte) ([]byte, error) {
+	return append([]byte(name+":"), plaintext...), f.err
+}
+
+func (f *fakeKMS) Decrypt(ctx context.Context, name string, ciphertext []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, []byte(name+":")) {
+		return nil, errors.New("wrong key")
+	}
+	return ciphertext[len(name)+1:], f.err
+}
+
+func TestNewKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *bpb.BackintConfiguration
+		read    ReadFile
+		wantErr bool
+	}{
+		{
+			name:    "NoKEK",
+			config:  &bpb.BackintConfiguration{},
+			wantErr: true,
+		},
+		{
+			name:    "ReadError",
+			config:  &bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"},
+			read:    func(string) ([]byte, error) { return nil, errors.New("read error") },
+			wantErr: true,
+		},
+		{
+			name:    "MalformedKeyring",
+			config:  &bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"},
+			read:    func(string) ([]byte, error) { return []byte("{"), nil },
+			wantErr: true,
+		},
+		{
+			name:    "MissingPrimary",
+			config:  &bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"},
+			read:    keyringFile("k2", map[string]string{"k1": key1}),
+			wantErr: true,
+		},
+		{
+			name:    "ShortKey",
+			config:  &bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"},
+			read:    keyringFile("k1", map[string]string{"k1": "c2hvcnQ="}),
+			wantErr: true,
+		},
+		{
+			name:   "Keyring",
+			config: &bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"},
+			read:   keyringFile("k1", map[string]string{"k1": key1}),
+		},
+		{
+			name:   "KMS",
+			config: &bpb.BackintConfiguration{EnvelopeKmsKey: "projects/p/locations/l/keyRings/r/cryptoKeys/k"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewKeys(test.config, test.read)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("NewKeys() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDataKeyRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	keys, err := NewKeys(&bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"}, keyringFile("k1", map[string]string{"k1": key1}))
+	if err != nil {
+		t.Fatalf("NewKeys() failed: %v", err)
+	}
+	dataKey, metadata, err := keys.NewDataKey(ctx)
+	if err != nil {
+		t.Fatalf("NewDataKey() failed: %v", err)
+	}
+	if !IsEncrypted(metadata) || metadata[KEKMetadataKey] != "keyring/k1" {
+		t.Errorf("NewDataKey() metadata = %v, want a data key wrapped by keyring/k1", metadata)
+	}
+	got, err := keys.DataKey(ctx, metadata)
+	if err != nil {
+		t.Fatalf("DataKey() failed: %v", err)
+	}
+	if !bytes.Equal(got, dataKey) {
+		t.Error("DataKey() did not return the wrapped data key")
+	}
+
+	// A wrapped key cannot be moved to a different key ID.
+	tampered := map[string]string{WrappedKeyMetadataKey: metadata[WrappedKeyMetadataKey], KEKMetadataKey: "keyring/k2"}
+	if _, err := keys.DataKey(ctx, tampered); err == nil {
+		t.Error("DataKey() with an unknown key ID succeeded, want error")
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	ctx := context.Background()
+	oldKeys, _ := NewKeys(&bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"}, keyringFile("k1", map[string]string{"k1": key1}))
+	dataKey, metadata, err := oldKeys.NewDataKey(ctx)
+	if err != nil {
+		t.Fatalf("NewDataKey() failed: %v", err)
+	}
+	metadata["X-Backup-Type"] = "FILE"
+
+	// Rotate to k2, keeping k1 to unwrap.
+	newKeys, _ := NewKeys(&bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"}, keyringFile("k2", map[string]string{"k1": key1, "k2": key2}))
+	updated, changed, err := newKeys.Rewrap(ctx, metadata)
+	if err != nil || !changed {
+		t.Fatalf("Rewrap() = (%v, %v), want (true, nil)", changed, err)
+	}
+	if updated[KEKMetadataKey] != "keyring/k2" || updated["X-Backup-Type"] != "FILE" {
+		t.Errorf("Rewrap() metadata = %v, want keyring/k2 with other metadata kept", updated)
+	}
+
+	// Once k1 is removed, the re-wrapped data key is still readable.
+	rotatedKeys, _ := NewKeys(&bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"}, keyringFile("k2", map[string]string{"k2": key2}))
+	got, err := rotatedKeys.DataKey(ctx, updated)
+	if err != nil || !bytes.Equal(got, dataKey) {
+		t.Errorf("DataKey() after rewrap = (%v, %v), want the original data key", got, err)
+	}
+	if _, err := rotatedKeys.DataKey(ctx, metadata); err == nil {
+		t.Error("DataKey() with a removed key succeeded, want error")
+	}
+
+	// Data keys already wrapped by the primary are unchanged.
+	if _, changed, err := newKeys.Rewrap(ctx, updated); err != nil || changed {
+		t.Errorf("Rewrap() of current data key = (%v, %v), want (false, nil)", changed, err)
+	}
+}
+
+func TestRewrapToKMS(t *testing.T) {
+	ctx := context.Background()
+	keyringKeys, _ := NewKeys(&bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"}, keyringFile("k1", map[string]string{"k1": key1}))
+	dataKey, metadata, err := keyringKeys.NewDataKey(ctx)
+	if err != nil {
+		t.Fatalf("NewDataKey() failed: %v", err)
+	}
+
+	kmsKey := "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+	keys, _ := NewKeys(&bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json", EnvelopeKmsKey: kmsKey}, keyringFile("k1", map[string]string{"k1": key1}))
+	keys.newKMS = func(context.Context) (kmsClient, error) { return &fakeKMS{}, nil }
+	updated, changed, err := keys.Rewrap(ctx, metadata)
+	if err != nil || !changed {
+		t.Fatalf("Rewrap() = (%v, %v), want (true, nil)", changed, err)
+	}
+	if updated[KEKMetadataKey] != "kms/"+kmsKey {
+		t.Errorf("Rewrap() KEK = %s, want kms/%s", updated[KEKMetadataKey], kmsKey)
+	}
+	got, err := keys.DataKey(ctx, updated)
+	if err != nil || !bytes.Equal(got, dataKey) {
+		t.Errorf("DataKey() after rewrap = (%v, %v), want the original data key", got, err)
+	}
+}
+
+func TestLookupErrors(t *testing.T) {
+	ctx := context.Background()
+	keys, _ := NewKeys(&bpb.BackintConfiguration{EnvelopeKmsKey: "projects/p/locations/l/keyRings/r/cryptoKeys/k"}, nil)
+	keys.newKMS = func(context.Context) (kmsClient, error) { return nil, errors.New("no credentials") }
+	for _, id := range []string{"unknown/k1", "keyring/k1", "kms/projects/p/locations/l/keyRings/r/cryptoKeys/k"} {
+		if _, err := keys.Lookup(ctx, id); err == nil {
+			t.Errorf("Lookup(%s) succeeded, want error", id)
+		}
+	}
+}
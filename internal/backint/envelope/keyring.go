@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Keyring holds local KEKs by ID. The primary key wraps new data keys and
+// the remaining keys are kept to unwrap data keys of older backups.
+type Keyring struct {
+	Primary string            `json:"primary"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// ParseKeyring parses and validates a JSON keyring.
+func ParseKeyring(data []byte) (*Keyring, error) {
+	k := &Keyring{}
+	if err := json.Unmarshal(data, k); err != nil {
+		return nil, fmt.Errorf("malformed envelope keyring: %v", err)
+	}
+	if _, ok := k.Keys[k.Primary]; !ok {
+		return nil, fmt.Errorf("envelope keyring primary key %q not found in keys", k.Primary)
+	}
+	for id := range k.Keys {
+		if _, err := k.lookup(id); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+func (k *Keyring) primary() (KEK, error) {
+	return k.lookup(k.Primary)
+}
+
+func (k *Keyring) lookup(id string) (KEK, error) {
+	encoded, ok := k.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in envelope keyring", id)
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != dataKeyBytes {
+		return nil, fmt.Errorf("envelope keyring key %q must be a base64 encoded %d byte key", id, dataKeyBytes)
+	}
+	return &keyringKEK{id: id, key: key}, nil
+}
+
+// keyringKEK wraps data keys with AES-256-GCM, binding the key ID as additional data.
+type keyringKEK struct {
+	id  string
+	key []byte
+}
+
+func (k *keyringKEK) ID() string {
+	return keyringPrefix + k.id
+}
+
+func (k *keyringKEK) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	aead, err := newAEAD(k.key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, dataKey, []byte(k.ID())), nil
+}
+
+func (k *keyringKEK) Unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	aead, err := newAEAD(k.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, fmt.Errorf("wrapped data key is too short")
+	}
+	nonce, ciphertext := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, []byte(k.ID()))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"encoding/base64"
+
+	"google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+)
+
+// kmsClient abstracts the Cloud KMS encrypt and decrypt calls.
+type kmsClient interface {
+	Encrypt(ctx context.Context, name string, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, name string, ciphertext []byte) ([]byte, error)
+}
+
+// kmsKEK wraps data keys with a Cloud KMS key. Decrypt selects the key
+// version automatically, so rotated KMS keys keep working.
+type kmsKEK struct {
+	name   string
+	client kmsClient
+}
+
+func (k *kmsKEK) ID() string {
+	return kmsPrefix + k.name
+}
+
+func (k *kmsKEK) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	return k.client.Encrypt(ctx, k.name, dataKey)
+}
+
+func (k *kmsKEK) Unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	return k.client.Decrypt(ctx, k.name, wrapped)
+}
+
+// kmsService calls Cloud KMS through the REST API.
+type kmsService struct {
+	service *cloudkms.Service
+}
+
+func newKMSService(ctx context.Context, serviceAccount string) (kmsClient, error) {
+	var opts []option.ClientOption
+	if serviceAccount != "" {
+		opts = append(opts, option.WithCredentialsFile(serviceAccount))
+	}
+	service, err := cloudkms.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &kmsService{service: service}, nil
+}
+
+func (k *kmsService) Encrypt(ctx context.Context, name string, plaintext []byte) ([]byte, error) {
+	resp, err := k.service.Projects.Locations.KeyRings.CryptoKeys.Encrypt(name, &cloudkms.EncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(plaintext),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Ciphertext)
+}
+
+func (k *kmsService) Decrypt(ctx context.Context, name string, ciphertext []byte) ([]byte, error) {
+	resp, err := k.service.Projects.Locations.KeyRings.CryptoKeys.Decrypt(name, &cloudkms.DecryptRequest{
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
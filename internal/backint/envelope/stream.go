@@ -0,0 +1,214 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The data is encrypted in segments with AES-256-GCM so objects of any size
+// can be streamed. Each segment nonce is the random per-object prefix, the
+// segment counter and a flag marking the final segment, which prevents
+// segments from being reordered, dropped or the stream being truncated.
+const (
+	segmentSize  = 64 * 1024
+	prefixSize   = 7
+	headerMagic  = "BKE1"
+	headerSize   = len(headerMagic) + prefixSize
+	lastSegment  = 1
+	dataKeyBytes = 32
+)
+
+// EncryptedSize returns the size of the ciphertext for a plaintext of the given size.
+func EncryptedSize(size int64) int64 {
+	segments := (size + segmentSize - 1) / segmentSize
+	if segments == 0 {
+		// Empty data is still sealed as one segment.
+		segments = 1
+	}
+	return int64(headerSize) + size + segments*16
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != dataKeyBytes {
+		return nil, fmt.Errorf("data key must be %d bytes, got %d", dataKeyBytes, len(dataKey))
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func segmentNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[prefixSize:], counter)
+	if last {
+		nonce[11] = lastSegment
+	}
+	return nonce
+}
+
+// encryptReader encrypts the underlying reader as it is read.
+type encryptReader struct {
+	r       io.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	// next holds plaintext read ahead to detect the final segment.
+	next []byte
+	out  bytes.Buffer
+	done bool
+	read int64
+}
+
+// NewEncryptReader returns a reader producing the encrypted form of r.
+func NewEncryptReader(r io.Reader, dataKey []byte) (io.Reader, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+	e := &encryptReader{r: r, aead: aead, prefix: prefix}
+	e.out.WriteString(headerMagic)
+	e.out.Write(prefix)
+	return e, nil
+}
+
+// PlaintextBytes returns the number of plaintext bytes read from an encrypting
+// reader created by NewEncryptReader, or -1 for other readers.
+func PlaintextBytes(r io.Reader) int64 {
+	if e, ok := r.(*encryptReader); ok {
+		return e.read
+	}
+	return -1
+}
+
+func (e *encryptReader) Read(p []byte) (int, error) {
+	for e.out.Len() == 0 {
+		if e.done {
+			return 0, io.EOF
+		}
+		if err := e.fill(); err != nil {
+			return 0, err
+		}
+	}
+	return e.out.Read(p)
+}
+
+// fill encrypts the next segment into the output buffer.
+func (e *encryptReader) fill() error {
+	buf := make([]byte, segmentSize+1)
+	copy(buf, e.next)
+	n := len(e.next)
+	m, err := io.ReadFull(e.r, buf[n:])
+	n += m
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	last := n <= segmentSize
+	segment := buf[:min(n, segmentSize)]
+	e.next = append(e.next[:0], buf[len(segment):n]...)
+	e.read += int64(len(segment))
+	e.out.Write(e.aead.Seal(nil, segmentNonce(e.prefix, e.counter, last), segment, nil))
+	e.counter++
+	e.done = last
+	return nil
+}
+
+// decryptWriter decrypts data written to it into the underlying writer.
+type decryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	written int64
+}
+
+// NewDecryptWriter returns a writer which decrypts data produced by
+// NewEncryptReader into w. Close must be called to decrypt and verify the
+// final segment.
+func NewDecryptWriter(w io.Writer, dataKey []byte) (io.WriteCloser, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptWriter{w: w, aead: aead}, nil
+}
+
+// DecryptedBytes returns the number of plaintext bytes written by a decrypting
+// writer created by NewDecryptWriter, or -1 for other writers.
+func DecryptedBytes(w io.Writer) int64 {
+	if d, ok := w.(*decryptWriter); ok {
+		return d.written
+	}
+	return -1
+}
+
+func (d *decryptWriter) Write(p []byte) (int, error) {
+	d.buf = append(d.buf, p...)
+	if d.prefix == nil {
+		if len(d.buf) < headerSize {
+			return len(p), nil
+		}
+		if string(d.buf[:len(headerMagic)]) != headerMagic {
+			return 0, errors.New("data is not envelope encrypted")
+		}
+		d.prefix = append([]byte(nil), d.buf[len(headerMagic):headerSize]...)
+		d.buf = d.buf[headerSize:]
+	}
+	// Keep at least one full segment buffered, since only Close knows which segment is last.
+	sealed := segmentSize + d.aead.Overhead()
+	for len(d.buf) > sealed {
+		if err := d.open(d.buf[:sealed], false); err != nil {
+			return 0, err
+		}
+		d.buf = d.buf[sealed:]
+	}
+	return len(p), nil
+}
+
+// Close decrypts the final segment, failing if the data was truncated.
+func (d *decryptWriter) Close() error {
+	if d.prefix == nil {
+		return errors.New("encrypted data is missing its header")
+	}
+	return d.open(d.buf, true)
+}
+
+func (d *decryptWriter) open(segment []byte, last bool) error {
+	plaintext, err := d.aead.Open(nil, segmentNonce(d.prefix, d.counter, last), segment, nil)
+	if err != nil {
+		return fmt.Errorf("decrypting segment %d: %v", d.counter, err)
+	}
+	d.counter++
+	n, err := d.w.Write(plaintext)
+	d.written += int64(n)
+	return err
+}
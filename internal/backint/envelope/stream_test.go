@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+var testDataKey = bytes.Repeat([]byte{7}, dataKeyBytes)
+
+func encrypt(t *testing.T, plaintext []byte) []byte {
+	t.Helper()
+	r, err := NewEncryptReader(bytes.NewReader(plaintext), testDataKey)
+	if err != nil {
+		t.Fatalf("NewEncryptReader() failed: %v", err)
+	}
+	ciphertext, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if got := PlaintextBytes(r); got != int64(len(plaintext)) {
+		t.Errorf("PlaintextBytes() = %d, want %d", got, len(plaintext))
+	}
+	return ciphertext
+}
+
+func decrypt(ciphertext []byte, chunk int) ([]byte, error) {
+	out := &bytes.Buffer{}
+	w, err := NewDecryptWriter(out, testDataKey)
+	if err != nil {
+		return nil, err
+	}
+	for len(ciphertext) > 0 {
+		n := min(chunk, len(ciphertext))
+		if _, err := w.Write(ciphertext[:n]); err != nil {
+			return nil, err
+		}
+		ciphertext = ciphertext[n:]
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if got := DecryptedBytes(w); got != int64(out.Len()) {
+		return nil, io.ErrShortWrite
+	}
+	return out.Bytes(), nil
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "Empty", size: 0},
+		{name: "OneByte", size: 1},
+		{name: "ExactSegment", size: segmentSize},
+		{name: "ExactTwoSegments", size: 2 * segmentSize},
+		{name: "PartialSegment", size: 2*segmentSize + 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plaintext := make([]byte, test.size)
+			rand.New(rand.NewSource(int64(test.size))).Read(plaintext)
+			ciphertext := encrypt(t, plaintext)
+			if got, want := int64(len(ciphertext)), EncryptedSize(int64(test.size)); got != want {
+				t.Errorf("ciphertext size = %d, want EncryptedSize() = %d", got, want)
+			}
+			// Write in odd sized pieces to exercise buffering across segments.
+			got, err := decrypt(ciphertext, 1000)
+			if err != nil {
+				t.Fatalf("decrypt() failed: %v", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("decrypt() returned %d bytes which do not match the %d encrypted", len(got), len(plaintext))
+			}
+		})
+	}
+}
+
+func TestDecryptDetectsTampering(t *testing.T) {
+	plaintext := bytes.Repeat([]byte("backint"), segmentSize/2)
+	ciphertext := encrypt(t, plaintext)
+
+	tests := []struct {
+		name       string
+		ciphertext []byte
+	}{
+		{
+			name:       "FlippedBit",
+			ciphertext: func() []byte { c := bytes.Clone(ciphertext); c[headerSize+10] ^= 1; return c }(),
+		},
+		{
+			name:       "TruncatedAtSegment",
+			ciphertext: ciphertext[:headerSize+segmentSize+16],
+		},
+		{
+			name:       "MissingHeader",
+			ciphertext: ciphertext[:3],
+		},
+		{
+			name:       "NotEncrypted",
+			ciphertext: plaintext,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := decrypt(test.ciphertext, 4096); err == nil {
+				t.Error("decrypt() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestInvalidDataKey(t *testing.T) {
+	if _, err := NewEncryptReader(bytes.NewReader(nil), []byte("short")); err == nil {
+		t.Error("NewEncryptReader() with a short key succeeded, want error")
+	}
+	if _, err := NewDecryptWriter(io.Discard, []byte("short")); err == nil {
+		t.Error("NewDecryptWriter() with a short key succeeded, want error")
+	}
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package housekeeping removes Backint objects which are no longer known to
+// the HANA backup catalog, such as objects left by failed or aborted backups.
+package housekeeping
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/dedup"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/replication"
+	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+const (
+	// databaseQuery returns the SID and name of the connected database.
+	databaseQuery = `SELECT SYSTEM_ID, DATABASE_NAME FROM M_DATABASE`
+	// catalogQuery returns the external backup IDs of all Backint backups in the
+	// catalog of the connected database.
+	catalogQuery = `SELECT DISTINCT EXTERNAL_BACKUP_ID FROM M_BACKUP_CATALOG_FILES WHERE DESTINATION_TYPE_NAME = 'backint' AND EXTERNAL_BACKUP_ID IS NOT NULL`
+	// tenantCatalogQuery returns the external backup IDs of all Backint backups
+	// in the catalog of a tenant, queried from SYSTEMDB.
+	tenantCatalogQuery = `SELECT DISTINCT EXTERNAL_BACKUP_ID FROM SYS_DATABASES.M_BACKUP_CATALOG_FILES WHERE DATABASE_NAME = '%s' AND DESTINATION_TYPE_NAME = 'backint' AND EXTERNAL_BACKUP_ID IS NOT NULL`
+
+	defaultMinAgeHours = 24
+)
+
+// objectPattern matches backup objects and temporary parallel upload chunks,
+// capturing the external backup ID: <externalBackupID>.bak[<chunk>].
+var objectPattern = regexp.MustCompile(`^(\d+)\.bak\d*$`)
+
+// databaseNamePattern matches the database names which can be used in tenantCatalogQuery.
+var databaseNamePattern = regexp.MustCompile(`^\w+$`)
+
+// catalogFunc returns the set of external backup IDs known to the backup catalog.
+type catalogFunc func(ctx context.Context) (map[string]bool, error)
+
+// Execute compares the objects for the user ID with the HANA backup catalog
+// and reports, or deletes with housekeeping_delete, objects the catalog does
+// not know. With replication enabled, the replicas in the recovery bucket are
+// compared as well. Results for each object are written to the output.
+// Returns false on failures.
+func Execute(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, output io.Writer, cloudProps *ipb.CloudProperties) bool {
+	log.CtxLogger(ctx).Infow("HOUSEKEEPING starting", "outFile", config.GetOutputFile(), "delete", config.GetHousekeepingDelete())
+	b, err := backend.New(ctx, config, connectParams)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("HOUSEKEEPING failed", "err", err)
+		return false
+	}
+	var replica backend.Backend
+	if replication.Enabled(config) {
+		if replica, err = backend.New(ctx, replication.ReplicaConfig(config), replication.ReplicaConnectParams(config, connectParams)); err != nil {
+			log.CtxLogger(ctx).Errorw("HOUSEKEEPING failed", "err", err)
+			return false
+		}
+	}
+	catalog := func(ctx context.Context) (map[string]bool, error) {
+		return readCatalog(ctx, config, commandlineexecutor.ExecuteCommand)
+	}
+	if err := housekeeping(ctx, config, b, replica, catalog, output, time.Now()); err != nil {
+		log.CtxLogger(ctx).Errorw("HOUSEKEEPING failed", "err", err)
+		return false
+	}
+	log.CtxLogger(ctx).Infow("HOUSEKEEPING finished", "outFile", config.GetOutputFile())
+	return true
+}
+
+// readCatalog queries the backup catalog with the configured userstore key.
+// The key must connect to the database of the user ID, or to the SYSTEMDB of
+// its SID, so that objects are never compared with another database's catalog.
+func readCatalog(ctx context.Context, config *bpb.BackintConfiguration, exec commandlineexecutor.Execute) (map[string]bool, error) {
+	db, err := databaseconnector.CreateDBHandle(ctx, databaseconnector.Params{
+		HDBUserKey: config.GetHousekeepingHdbuserstoreKey(),
+		SID:        sid(config.GetUserId()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connecting to HANA: %v", err)
+	}
+	rows, err := db.Query(ctx, databaseQuery, exec)
+	if err != nil {
+		return nil, fmt.Errorf("querying connected database: %v", err)
+	}
+	var connectedSID, connectedDB string
+	if !rows.Next() {
+		return nil, fmt.Errorf("querying connected database: no rows returned")
+	}
+	if err := rows.ReadRow(&connectedSID, &connectedDB); err != nil {
+		return nil, fmt.Errorf("reading connected database: %v", err)
+	}
+	query, err := catalogQueryFor(config.GetUserId(), connectedSID, connectedDB)
+	if err != nil {
+		return nil, err
+	}
+	rows, err = db.Query(ctx, query, exec)
+	if err != nil {
+		return nil, fmt.Errorf("querying backup catalog: %v", err)
+	}
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.ReadRow(&id); err != nil {
+			return nil, fmt.Errorf("reading backup catalog: %v", err)
+		}
+		ids[id] = true
+	}
+	return ids, nil
+}
+
+// catalogQueryFor returns the query for the backup catalog of the user ID's
+// database, given the database housekeeping_hdbuserstore_key connects to.
+func catalogQueryFor(userID, connectedSID, connectedDB string) (string, error) {
+	if !strings.EqualFold(connectedSID, sid(userID)) {
+		return "", fmt.Errorf("housekeeping_hdbuserstore_key connects to %s@%s, which does not match the user ID %s", connectedDB, connectedSID, userID)
+	}
+	database := databaseName(userID)
+	switch {
+	case database == "" || strings.EqualFold(database, connectedDB):
+		return catalogQuery, nil
+	case strings.EqualFold(connectedDB, "SYSTEMDB") && databaseNamePattern.MatchString(database):
+		return fmt.Sprintf(tenantCatalogQuery, strings.ToUpper(database)), nil
+	default:
+		return "", fmt.Errorf("housekeeping_hdbuserstore_key connects to %s@%s, which does not match the user ID %s", connectedDB, connectedSID, userID)
+	}
+}
+
+// sid returns the SID from a user ID in the format <DBNAME>@<SID>.
+func sid(userID string) string {
+	return userID[strings.LastIndex(userID, "@")+1:]
+}
+
+// databaseName returns the database name from a user ID in the format
+// <DBNAME>@<SID>, or an empty string if the user ID has no database name.
+func databaseName(userID string) string {
+	i := strings.LastIndex(userID, "@")
+	if i < 0 {
+		return ""
+	}
+	return userID[:i]
+}
+
+// housekeeping compares the objects in the bucket, and in the recovery bucket
+// if replica is set, with the backup catalog.
+func housekeeping(ctx context.Context, config *bpb.BackintConfiguration, b, replica backend.Backend, catalog catalogFunc, output io.Writer, now time.Time) error {
+	known, err := catalog(ctx)
+	if err != nil {
+		return err
+	}
+	// An empty catalog more likely indicates the wrong database than no backups,
+	// so nothing is deleted rather than every object.
+	if len(known) == 0 {
+		return fmt.Errorf("backup catalog for %s has no Backint backups, check housekeeping_hdbuserstore_key", config.GetUserId())
+	}
+	deleted, err := removeOrphans(ctx, config, b, config.GetFolderPrefix()+config.GetUserId()+"/", known, output, now)
+	// Deleted manifests may leave deduplication chunks which are no longer referenced.
+	if gcs, ok := b.(*backend.GCS); ok && deleted > 0 {
+		if _, err := dedup.CollectGarbage(ctx, config, gcs.BucketHandle(), now); err != nil {
+			log.CtxLogger(ctx).Errorw("Error collecting unreferenced deduplication chunks", "err", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if replica != nil {
+		replicaPrefix := config.GetRecoveryFolderPrefix() + config.GetUserId() + "/"
+		if _, err := removeOrphans(ctx, config, replica, replicaPrefix, known, output, now); err != nil {
+			return fmt.Errorf("recovery_bucket: %v", err)
+		}
+	}
+	return nil
+}
+
+// removeOrphans deletes objects under the prefix whose external backup ID is
+// not in the backup catalog. Objects younger than the minimum age, or held by
+// an object retention or custom time in the future, are kept. Without
+// housekeeping_delete, or if most of the backups would be deleted, the objects
+// are only reported. Returns the number of objects deleted.
+func removeOrphans(ctx context.Context, config *bpb.BackintConfiguration, b backend.Backend, prefix string, known map[string]bool, output io.Writer, now time.Time) (int, error) {
+	objects, err := b.List(ctx, prefix, "")
+	if err != nil {
+		return 0, fmt.Errorf("listing objects with prefix %s: %v", prefix, err)
+	}
+	minAge := time.Duration(config.GetHousekeepingMinAgeHours()) * time.Hour
+	if minAge <= 0 {
+		minAge = defaultMinAgeHours * time.Hour
+	}
+	log.CtxLogger(ctx).Infow("Comparing objects with the backup catalog", "prefix", prefix, "objects", len(objects), "catalogBackups", len(known), "minAge", minAge)
+
+	backups := 0
+	var orphans []*backend.ObjectAttrs
+	for _, object := range objects {
+		match := objectPattern.FindStringSubmatch(path.Base(object.Name))
+		if match == nil {
+			continue
+		}
+		backups++
+		if known[match[1]] {
+			continue
+		}
+		switch {
+		case now.Sub(object.Created) < minAge:
+			log.CtxLogger(ctx).Infow("Keeping recent object not in the backup catalog", "object", object.Name, "created", object.Created)
+		case object.RetainUntil.After(now) || object.CustomTime.After(now):
+			log.CtxLogger(ctx).Infow("Keeping object not in the backup catalog due to retention", "object", object.Name, "retainUntil", object.RetainUntil, "customTime", object.CustomTime)
+			output.Write([]byte(fmt.Sprintf("#RETAINED %q\n", object.Name)))
+		default:
+			orphans = append(orphans, object)
+		}
+	}
+
+	// Deleting most of the backups more likely indicates a catalog which does
+	// not match the objects, such as a restored or wrong database.
+	tooMany := 2*len(orphans) > backups
+	if !config.GetHousekeepingDelete() || tooMany {
+		for _, object := range orphans {
+			log.CtxLogger(ctx).Infow("Object not in the backup catalog", "object", object.Name, "created", object.Created)
+			output.Write([]byte(fmt.Sprintf("#ORPHANED %q\n", object.Name)))
+		}
+		if config.GetHousekeepingDelete() && tooMany {
+			return 0, fmt.Errorf("refusing to delete %d of %d objects with prefix %s which are not in the backup catalog, check housekeeping_hdbuserstore_key", len(orphans), backups, prefix)
+		}
+		return 0, nil
+	}
+
+	wp := workerpool.New(int(config.GetThreads()))
+	mu := &sync.Mutex{}
+	deleted, failed := 0, 0
+	for _, object := range orphans {
+		wp.Submit(func() {
+			err := b.Delete(ctx, object.Name)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.CtxLogger(ctx).Errorw("Error deleting object", "object", object.Name, "err", err)
+				failed++
+				output.Write([]byte(fmt.Sprintf("#ERROR %q\n", object.Name)))
+				return
+			}
+			log.CtxLogger(ctx).Infow("Deleted object not in the backup catalog", "object", object.Name, "created", object.Created)
+			deleted++
+			output.Write([]byte(fmt.Sprintf("#DELETED %q\n", object.Name)))
+		})
+	}
+	wp.StopWait()
+	if failed > 0 {
+		return deleted, fmt.Errorf("failed to delete %d objects", failed)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package housekeeping
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+func TestMain(t *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(t.Run())
+}
+
+// retainedBackend reports a retention time in the future for the retained objects.
+type retainedBackend struct {
+	backend.Backend
+	retained map[string]bool
+}
+
+func (r *retainedBackend) List(ctx context.Context, prefix, filter string) ([]*backend.ObjectAttrs, error) {
+	objects, err := r.Backend.List(ctx, prefix, filter)
+	for _, o := range objects {
+		if r.retained[o.Name] {
+			o.RetainUntil = time.Now().Add(30 * 24 * time.Hour)
+		}
+	}
+	return objects, err
+}
+
+func catalog(ids ...string) catalogFunc {
+	return func(context.Context) (map[string]bool, error) {
+		known := make(map[string]bool)
+		for _, id := range ids {
+			known[id] = true
+		}
+		return known, nil
+	}
+}
+
+func newTestBackend(t *testing.T, objects ...string) *retainedBackend {
+	t.Helper()
+	f, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	for _, o := range objects {
+		if _, err := f.Upload(context.Background(), o, strings.NewReader("data"), nil); err != nil {
+			t.Fatalf("Upload(%s) failed: %v", o, err)
+		}
+	}
+	return &retainedBackend{Backend: f, retained: map[string]bool{}}
+}
+
+func remaining(t *testing.T, b backend.Backend) []string {
+	t.Helper()
+	objects, err := b.List(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	var names []string
+	for _, o := range objects {
+		names = append(names, o.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestHousekeeping(t *testing.T) {
+	objects := []string{
+		"test@TST/usr/sap/TST/data.txt/100.bak",
+		"test@TST/usr/sap/TST/data.txt/200.bak",
+		"test@TST/usr/sap/TST/data.txt/300.bak0",
+		"test@TST/usr/sap/TST/data.txt/400.bak",
+		"test@TST/usr/sap/TST/data.txt/notes.txt",
+		"other@OTH/usr/sap/OTH/data.txt/500.bak",
+	}
+	tests := []struct {
+		name          string
+		config        *bpb.BackintConfiguration
+		catalog       catalogFunc
+		retained      map[string]bool
+		now           time.Time
+		wantOutput    []string
+		wantRemaining []string
+		wantErr       error
+	}{
+		{
+			name:     "DeletesOrphans",
+			config:   &bpb.BackintConfiguration{UserId: "test@TST", HousekeepingDelete: true},
+			catalog:  catalog("100"),
+			retained: map[string]bool{"test@TST/usr/sap/TST/data.txt/400.bak": true},
+			now:      time.Now().Add(48 * time.Hour),
+			wantOutput: []string{
+				`#DELETED "test@TST/usr/sap/TST/data.txt/200.bak"`,
+				`#DELETED "test@TST/usr/sap/TST/data.txt/300.bak0"`,
+				`#RETAINED "test@TST/usr/sap/TST/data.txt/400.bak"`,
+			},
+			wantRemaining: []string{
+				"other@OTH/usr/sap/OTH/data.txt/500.bak",
+				"test@TST/usr/sap/TST/data.txt/100.bak",
+				"test@TST/usr/sap/TST/data.txt/400.bak",
+				"test@TST/usr/sap/TST/data.txt/notes.txt",
+			},
+		},
+		{
+			name:    "ReportsByDefault",
+			config:  &bpb.BackintConfiguration{UserId: "test@TST"},
+			catalog: catalog("100", "400"),
+			now:     time.Now().Add(48 * time.Hour),
+			wantOutput: []string{
+				`#ORPHANED "test@TST/usr/sap/TST/data.txt/200.bak"`,
+				`#ORPHANED "test@TST/usr/sap/TST/data.txt/300.bak0"`,
+			},
+			wantRemaining: objects,
+		},
+		{
+			name:    "RefusesToDeleteMostBackups",
+			config:  &bpb.BackintConfiguration{UserId: "test@TST", HousekeepingDelete: true},
+			catalog: catalog("400"),
+			now:     time.Now().Add(48 * time.Hour),
+			wantOutput: []string{
+				`#ORPHANED "test@TST/usr/sap/TST/data.txt/100.bak"`,
+				`#ORPHANED "test@TST/usr/sap/TST/data.txt/200.bak"`,
+				`#ORPHANED "test@TST/usr/sap/TST/data.txt/300.bak0"`,
+			},
+			wantRemaining: objects,
+			wantErr:       cmpopts.AnyError,
+		},
+		{
+			name:          "RecentObjectsKept",
+			config:        &bpb.BackintConfiguration{UserId: "test@TST", HousekeepingDelete: true},
+			catalog:       catalog("100"),
+			now:           time.Now(),
+			wantRemaining: objects,
+		},
+		{
+			name:          "MinAgeHours",
+			config:        &bpb.BackintConfiguration{UserId: "test@TST", HousekeepingDelete: true, HousekeepingMinAgeHours: 72},
+			catalog:       catalog("100", "300", "400"),
+			now:           time.Now().Add(48 * time.Hour),
+			wantRemaining: objects,
+		},
+		{
+			name:          "EmptyCatalog",
+			config:        &bpb.BackintConfiguration{UserId: "test@TST", HousekeepingDelete: true},
+			catalog:       catalog(),
+			now:           time.Now().Add(48 * time.Hour),
+			wantRemaining: objects,
+			wantErr:       cmpopts.AnyError,
+		},
+		{
+			name:   "CatalogError",
+			config: &bpb.BackintConfiguration{UserId: "test@TST", HousekeepingDelete: true},
+			catalog: func(context.Context) (map[string]bool, error) {
+				return nil, errors.New("catalog error")
+			},
+			now:           time.Now().Add(48 * time.Hour),
+			wantRemaining: objects,
+			wantErr:       cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := newTestBackend(t, objects...)
+			if test.retained != nil {
+				b.retained = test.retained
+			}
+			output := &bytes.Buffer{}
+			err := housekeeping(context.Background(), test.config, b, nil, test.catalog, output, test.now)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("housekeeping() = %v, want %v", err, test.wantErr)
+			}
+			var gotOutput []string
+			if output.Len() > 0 {
+				gotOutput = strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+			}
+			if diff := cmp.Diff(test.wantOutput, gotOutput, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("housekeeping() had unexpected output diff (-want +got):\n%s", diff)
+			}
+			wantRemaining := append([]string{}, test.wantRemaining...)
+			sort.Strings(wantRemaining)
+			if diff := cmp.Diff(wantRemaining, remaining(t, b)); diff != "" {
+				t.Errorf("housekeeping() had unexpected remaining objects diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHousekeepingReplicas(t *testing.T) {
+	config := &bpb.BackintConfiguration{UserId: "test@TST", RecoveryFolderPrefix: "dr/", HousekeepingDelete: true}
+	b := newTestBackend(t, "test@TST/data.txt/100.bak", "test@TST/data.txt/200.bak", "test@TST/data.txt/300.bak")
+	replica := newTestBackend(t, "dr/test@TST/data.txt/100.bak", "dr/test@TST/data.txt/200.bak", "dr/test@TST/data.txt/300.bak")
+	output := &bytes.Buffer{}
+	if err := housekeeping(context.Background(), config, b, replica, catalog("100", "300"), output, time.Now().Add(48*time.Hour)); err != nil {
+		t.Fatalf("housekeeping() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"dr/test@TST/data.txt/100.bak", "dr/test@TST/data.txt/300.bak"}, remaining(t, replica)); diff != "" {
+		t.Errorf("housekeeping() had unexpected remaining replicas diff (-want +got):\n%s", diff)
+	}
+	if !strings.Contains(output.String(), `#DELETED "dr/test@TST/data.txt/200.bak"`) {
+		t.Errorf("housekeeping() output = %q, want the deleted replica", output.String())
+	}
+}
+
+// hdbsql returns the output of the database query and the catalog query.
+func hdbsql(database, catalog string) commandlineexecutor.Execute {
+	return func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+		query := params.Args[len(params.Args)-1]
+		if query == databaseQuery {
+			return commandlineexecutor.Result{StdOut: database}
+		}
+		if strings.Contains(query, "M_BACKUP_CATALOG_FILES") {
+			return commandlineexecutor.Result{StdOut: catalog}
+		}
+		return commandlineexecutor.Result{ExitCode: 1, StdErr: "unexpected query"}
+	}
+}
+
+func TestReadCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *bpb.BackintConfiguration
+		exec    commandlineexecutor.Execute
+		want    map[string]bool
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			config: &bpb.BackintConfiguration{UserId: "SYSTEMDB@TST", HousekeepingHdbuserstoreKey: "BACKINT"},
+			exec:   hdbsql("\"TST\",\"SYSTEMDB\"\n", "\"1700000000000\"\n\"1700000000001\"\n"),
+			want:   map[string]bool{"1700000000000": true, "1700000000001": true},
+		},
+		{
+			name:   "TenantFromSystemDB",
+			config: &bpb.BackintConfiguration{UserId: "HDB@TST", HousekeepingHdbuserstoreKey: "BACKINT"},
+			exec:   hdbsql("\"TST\",\"SYSTEMDB\"\n", "\"1700000000000\"\n"),
+			want:   map[string]bool{"1700000000000": true},
+		},
+		{
+			name:    "OtherTenant",
+			config:  &bpb.BackintConfiguration{UserId: "HDB@TST", HousekeepingHdbuserstoreKey: "BACKINT"},
+			exec:    hdbsql("\"TST\",\"OTHER\"\n", "\"1700000000000\"\n"),
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "OtherSID",
+			config:  &bpb.BackintConfiguration{UserId: "SYSTEMDB@TST", HousekeepingHdbuserstoreKey: "BACKINT"},
+			exec:    hdbsql("\"OTH\",\"SYSTEMDB\"\n", "\"1700000000000\"\n"),
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "NoUserstoreKey",
+			config:  &bpb.BackintConfiguration{UserId: "SYSTEMDB@TST"},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:   "QueryError",
+			config: &bpb.BackintConfiguration{UserId: "SYSTEMDB@TST", HousekeepingHdbuserstoreKey: "BACKINT"},
+			exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{ExitCode: 1, StdErr: "invalid key"}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := readCatalog(context.Background(), test.config, test.exec)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("readCatalog() = %v, want %v", err, test.wantErr)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("readCatalog() had unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCatalogQueryFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      string
+		connectedDB string
+		want        string
+		wantErr     error
+	}{
+		{name: "SameDatabase", userID: "HDB@TST", connectedDB: "HDB", want: catalogQuery},
+		{name: "NoDatabaseName", userID: "TST", connectedDB: "HDB", want: catalogQuery},
+		{name: "TenantFromSystemDB", userID: "hdb@TST", connectedDB: "SYSTEMDB", want: fmt.Sprintf(tenantCatalogQuery, "HDB")},
+		{name: "InvalidDatabaseName", userID: "HDB'--@TST", connectedDB: "SYSTEMDB", wantErr: cmpopts.AnyError},
+		{name: "OtherTenant", userID: "HDB@TST", connectedDB: "OTHER", wantErr: cmpopts.AnyError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := catalogQueryFor(test.userID, "TST", test.connectedDB)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("catalogQueryFor(%s, TST, %s) = %v, want %v", test.userID, test.connectedDB, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("catalogQueryFor(%s, TST, %s) = %q, want %q", test.userID, test.connectedDB, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSID(t *testing.T) {
+	if got, want := sid("SYSTEMDB@TST"), "TST"; got != want {
+		t.Errorf("sid() = %s, want %s", got, want)
+	}
+}
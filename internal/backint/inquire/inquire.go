@@ -19,6 +19,7 @@ package inquire
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -26,9 +27,10 @@ import (
 	"strings"
 	"sync"
 
-	store "cloud.google.com/go/storage"
 	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/parse"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/replication"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
@@ -72,10 +74,12 @@ func inquire(ctx context.Context, config *bpb.BackintConfiguration, connectParam
 			if len(s) > 1 {
 				fileName = s[1]
 			}
-			prefix := parse.CreateObjectPath(config, parse.TrimAndClean(fileName), "", "")
+			query := func(b backend.Backend, config *bpb.BackintConfiguration) []byte {
+				prefix := parse.CreateObjectPath(config, parse.TrimAndClean(fileName), "", "")
+				return inquireFiles(ctx, b, prefix, fileName, "", backintVersion, "", config)
+			}
 			wp.Submit(func() {
-				bucketHandle, _ := storage.ConnectToBucket(ctx, connectParams)
-				out := inquireFiles(ctx, bucketHandle, prefix, fileName, "", backintVersion, "", config)
+				out := inquireWithFallback(ctx, config, connectParams, query)
 				mu.Lock()
 				defer mu.Unlock()
 				output.Write(out)
@@ -88,17 +92,21 @@ func inquire(ctx context.Context, config *bpb.BackintConfiguration, connectParam
 			externalBackupID := parse.TrimAndClean(s[1])
 			// fileName is an optional parameter not present in Backint 1.00
 			fileName := ""
-			prefix := config.GetFolderPrefix() + config.GetUserId() + "/"
-			filter := ""
 			if len(s) > 2 {
 				fileName = s[2]
-				prefix = parse.CreateObjectPath(config, parse.TrimAndClean(fileName), externalBackupID, ".bak")
-			} else {
-				filter = externalBackupID + ".bak"
+			}
+			query := func(b backend.Backend, config *bpb.BackintConfiguration) []byte {
+				prefix := config.GetFolderPrefix() + config.GetUserId() + "/"
+				filter := ""
+				if fileName != "" {
+					prefix = parse.CreateObjectPath(config, parse.TrimAndClean(fileName), externalBackupID, ".bak")
+				} else {
+					filter = externalBackupID + ".bak"
+				}
+				return inquireFiles(ctx, b, prefix, fileName, externalBackupID, backintVersion, filter, config)
 			}
 			wp.Submit(func() {
-				bucketHandle, _ := storage.ConnectToBucket(ctx, connectParams)
-				out := inquireFiles(ctx, bucketHandle, prefix, fileName, externalBackupID, backintVersion, filter, config)
+				out := inquireWithFallback(ctx, config, connectParams, query)
 				mu.Lock()
 				defer mu.Unlock()
 				output.Write(out)
@@ -114,12 +122,39 @@ func inquire(ctx context.Context, config *bpb.BackintConfiguration, connectParam
 	return nil
 }
 
-// inquireFiles queries the bucket with the specified prefix and returns all
-// objects found according to SAP HANA formatting specifications.
-func inquireFiles(ctx context.Context, bucketHandle *store.BucketHandle, prefix, fileName, externalBackupID, backintVersion, filter string, config *bpb.BackintConfiguration) []byte {
+// inquireWithFallback runs the query against the configured storage backend.
+// With replication enabled, the recovery bucket is queried if the bucket
+// returns an error or no objects, such as when its region is unavailable.
+// Replicas of backups deleted by DELETE are not reported.
+func inquireWithFallback(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, query func(backend.Backend, *bpb.BackintConfiguration) []byte) []byte {
+	b, _ := backend.New(ctx, config, connectParams)
+	out := query(b, config)
+	if !replication.Enabled(config) || !(bytes.HasPrefix(out, []byte("#ERROR")) || bytes.HasPrefix(out, []byte("#NOTFOUND"))) {
+		return out
+	}
+	replicaConfig := replication.ReplicaConfig(config)
+	log.CtxLogger(ctx).Infow("Querying the replica in the recovery bucket", "recoveryBucket", replicaConfig.GetBucket(), "recoveryFolderPrefix", replicaConfig.GetFolderPrefix())
+	replica, err := backend.New(ctx, replicaConfig, replication.ReplicaConnectParams(config, connectParams))
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Error connecting to the recovery bucket", "recoveryBucket", replicaConfig.GetBucket(), "err", err)
+		return out
+	}
+	if replicaOut := query(replication.HideRemoved(config, replica), replicaConfig); bytes.HasPrefix(replicaOut, []byte("#BACKUP")) {
+		return replicaOut
+	}
+	return out
+}
+
+// inquireFiles queries the storage backend with the specified prefix and returns
+// all objects found according to SAP HANA formatting specifications.
+func inquireFiles(ctx context.Context, b backend.Backend, prefix, fileName, externalBackupID, backintVersion, filter string, config *bpb.BackintConfiguration) []byte {
 	var result []byte
 	log.CtxLogger(ctx).Infow("Listing objects", "fileName", fileName, "prefix", prefix, "externalBackupID", externalBackupID, "filter", filter)
-	objects, err := storage.ListObjects(ctx, bucketHandle, prefix, filter, config.GetRetries())
+	var objects []*backend.ObjectAttrs
+	err := fmt.Errorf("no storage backend available")
+	if b != nil {
+		objects, err = b.List(ctx, prefix, filter)
+	}
 	if err != nil {
 		log.CtxLogger(ctx).Errorw("Error listing objects", "fileName", fileName, "prefix", prefix, "err", err, "externalBackupID", externalBackupID, "filter", filter)
 		result = []byte("#ERROR")
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package integrity records per-backup manifests of file sizes and SHA-256
+// checksums, which the VERIFY function checks stored backups against.
+//
+// Checksums cover the data read from HANA, before compression or encryption,
+// so a verified object is known to restore to the original file.
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+const manifestVersion = 1
+
+// Entry records a single backed up file.
+type Entry struct {
+	Object   string `json:"object"`
+	FileName string `json:"file_name"`
+	Size     int64  `json:"size"`
+	// SectionSize is set for parallel uploads, which are checksummed in
+	// sections of SectionSize bytes with the last section holding the remainder.
+	SectionSize int64 `json:"section_size,omitempty"`
+	// SHA256 holds the hex encoded checksum of each section, or a single
+	// checksum of the whole file.
+	SHA256 []string `json:"sha256"`
+}
+
+// Manifest records the files written by a single BACKUP. Add is safe for
+// concurrent use.
+type Manifest struct {
+	Version  int       `json:"version"`
+	BackupID string    `json:"backup_id"`
+	Created  time.Time `json:"created"`
+	Entries  []Entry   `json:"entries"`
+
+	mu sync.Mutex
+}
+
+// New returns an empty manifest for the backup.
+func New(backupID string, created time.Time) *Manifest {
+	return &Manifest{Version: manifestVersion, BackupID: backupID, Created: created}
+}
+
+// Add records an entry in the manifest.
+func (m *Manifest) Add(e Entry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Entries = append(m.Entries, e)
+}
+
+// Len returns the number of entries in the manifest.
+func (m *Manifest) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.Entries)
+}
+
+// Prefix returns the prefix where manifests for the configured user are stored.
+// Manifests are kept outside the user's backup objects so INQUIRE does not list them.
+func Prefix(config *bpb.BackintConfiguration) string {
+	return config.GetFolderPrefix() + ".manifests/" + config.GetUserId() + "/"
+}
+
+// Write stores the manifest as <prefix><backupID>.json and returns the object name.
+func (m *Manifest) Write(ctx context.Context, b backend.Backend, config *bpb.BackintConfiguration) (string, error) {
+	m.mu.Lock()
+	data, err := json.Marshal(m)
+	m.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	object := Prefix(config) + m.BackupID + ".json"
+	if _, err := b.Upload(ctx, object, bytes.NewReader(data), map[string]string{"X-Backup-Type": "MANIFEST"}); err != nil {
+		return "", fmt.Errorf("uploading integrity manifest %s: %v", object, err)
+	}
+	return object, nil
+}
+
+// Read downloads and parses the manifest object.
+func Read(ctx context.Context, b backend.Backend, object string) (*Manifest, error) {
+	var buf bytes.Buffer
+	if _, err := b.Download(ctx, object, &buf, 0, -1); err != nil {
+		return nil, fmt.Errorf("downloading integrity manifest %s: %v", object, err)
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(buf.Bytes(), m); err != nil {
+		return nil, fmt.Errorf("malformed integrity manifest %s: %v", object, err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported integrity manifest version %d in %s", m.Version, object)
+	}
+	return m, nil
+}
+
+// Hasher computes the size and per-section SHA-256 checksums of the data
+// written to it.
+type Hasher struct {
+	sectionSize int64
+	sections    []hash.Hash
+	written     int64
+}
+
+// NewHasher returns a Hasher for the given number of sections, each
+// sectionSize bytes except the last which holds the remainder. A sectionSize
+// of 0 places all data in the last section.
+func NewHasher(sectionSize int64, sections int) *Hasher {
+	h := &Hasher{sectionSize: sectionSize, sections: make([]hash.Hash, max(sections, 1))}
+	for i := range h.sections {
+		h.sections[i] = sha256.New()
+	}
+	return h
+}
+
+// NewHasher returns a Hasher matching the entry's sections.
+func (e Entry) NewHasher() *Hasher {
+	return NewHasher(e.SectionSize, len(e.SHA256))
+}
+
+// Write implements io.Writer.
+func (h *Hasher) Write(p []byte) (int, error) {
+	total := len(p)
+	last := int64(len(h.sections) - 1)
+	for len(p) > 0 {
+		i, n := last, int64(len(p))
+		if h.sectionSize > 0 {
+			i = min(h.written/h.sectionSize, last)
+		}
+		if i < last {
+			n = min(n, (i+1)*h.sectionSize-h.written)
+		}
+		h.sections[i].Write(p[:n])
+		h.written += n
+		p = p[n:]
+	}
+	return total, nil
+}
+
+// Size returns the number of bytes written.
+func (h *Hasher) Size() int64 {
+	return h.written
+}
+
+// Sums returns the hex encoded checksum of each section.
+func (h *Hasher) Sums() []string {
+	sums := make([]string, len(h.sections))
+	for i, s := range h.sections {
+		sums[i] = hex.EncodeToString(s.Sum(nil))
+	}
+	return sums
+}
+
+// Check compares the data written to the hasher with the entry.
+func (e Entry) Check(h *Hasher) error {
+	if h.Size() != e.Size {
+		return fmt.Errorf("size mismatch: got %d bytes, want %d", h.Size(), e.Size)
+	}
+	if got := h.Sums(); strings.Join(got, ",") != strings.Join(e.SHA256, ",") {
+		return fmt.Errorf("checksum mismatch: got %v, want %v", got, e.SHA256)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+func sum(data []byte) string {
+	s := sha256.Sum256(data)
+	return hex.EncodeToString(s[:])
+}
+
+func TestHasher(t *testing.T) {
+	data := []byte("0123456789abcdefghij")
+	tests := []struct {
+		name        string
+		sectionSize int64
+		sections    int
+		want        []string
+	}{
+		{
+			name:     "SingleSection",
+			sections: 1,
+			want:     []string{sum(data)},
+		},
+		{
+			name:        "EqualSections",
+			sectionSize: 5,
+			sections:    4,
+			want:        []string{sum(data[:5]), sum(data[5:10]), sum(data[10:15]), sum(data[15:])},
+		},
+		{
+			name:        "LastSectionHoldsRemainder",
+			sectionSize: 6,
+			sections:    3,
+			want:        []string{sum(data[:6]), sum(data[6:12]), sum(data[12:])},
+		},
+		{
+			// Files smaller than the number of parallel streams have empty sections.
+			name:     "EmptySections",
+			sections: 3,
+			want:     []string{sum(nil), sum(nil), sum(data)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := NewHasher(test.sectionSize, test.sections)
+			// Write in odd sized pieces to cross section boundaries.
+			for p := data; len(p) > 0; {
+				n := min(7, len(p))
+				h.Write(p[:n])
+				p = p[n:]
+			}
+			if h.Size() != int64(len(data)) {
+				t.Errorf("Size() = %d, want %d", h.Size(), len(data))
+			}
+			if diff := cmp.Diff(test.want, h.Sums()); diff != "" {
+				t.Errorf("Sums() had unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	data := []byte("backint")
+	entry := Entry{Object: "obj", Size: int64(len(data)), SHA256: []string{sum(data)}}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "Match", data: data},
+		{name: "Truncated", data: data[:3], wantErr: true},
+		{name: "Modified", data: []byte("backinT"), wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := entry.NewHasher()
+			h.Write(test.data)
+			if err := entry.Check(h); (err != nil) != test.wantErr {
+				t.Errorf("Check() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	ctx := context.Background()
+	b, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	config := &bpb.BackintConfiguration{UserId: "DB@SID", FolderPrefix: "prefix/"}
+	m := New("1700000000000", time.Unix(1700000000, 0).UTC())
+	m.Add(Entry{Object: "prefix/DB@SID/file/1.bak", FileName: "/file", Size: 7, SHA256: []string{sum([]byte("backint"))}})
+
+	object, err := m.Write(ctx, b, config)
+	if err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if want := "prefix/.manifests/DB@SID/1700000000000.json"; object != want {
+		t.Errorf("Write() object = %s, want %s", object, want)
+	}
+	got, err := Read(ctx, b, object)
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if diff := cmp.Diff(m, got, cmpopts.IgnoreUnexported(Manifest{})); diff != "" {
+		t.Errorf("Read() had unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if _, err := b.Upload(ctx, "bad.json", bytes.NewReader([]byte(`{"version": 2}`)), nil); err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	if _, err := Read(ctx, b, "bad.json"); err == nil {
+		t.Error("Read() of an unsupported version succeeded, want error")
+	}
+}
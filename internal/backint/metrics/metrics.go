@@ -146,3 +146,39 @@ func SendToCloudMonitoring(ctx context.Context, operation, fileName string, file
 	log.CtxLogger(ctx).Infow("Successfully sent Backint file transfer metrics to cloud monitoring", "mtype", mtype, "fileName", fileName)
 	return true
 }
+
+// SendReplicationQueue sends the number of objects waiting for replication and the age of
+// the oldest one, so a replication queue which is not drained is visible.
+func SendReplicationQueue(ctx context.Context, depth int64, oldestAge time.Duration, config *bpb.BackintConfiguration, cloudProps *ipb.CloudProperties, bo *cloudmonitoring.BackOffIntervals, metricClient metricClientFunc) bool {
+	if !config.GetSendMetricsToMonitoring().GetValue() {
+		return false
+	}
+	mc, err := metricClient(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to create Cloud Monitoring metric client", "err", err)
+		return false
+	}
+	now := tspb.Now()
+	labels := map[string]string{"recoveryBucket": config.GetRecoveryBucket()}
+	ts := []*mrpb.TimeSeries{
+		timeseries.BuildInt(timeseries.Params{
+			CloudProp:    protostruct.ConvertCloudPropertiesToStruct(cloudProps),
+			MetricType:   metricPrefix + "replication/queue_depth",
+			Timestamp:    now,
+			Int64Value:   depth,
+			MetricLabels: labels,
+		}),
+		timeseries.BuildFloat64(timeseries.Params{
+			CloudProp:    protostruct.ConvertCloudPropertiesToStruct(cloudProps),
+			MetricType:   metricPrefix + "replication/queue_age",
+			Timestamp:    now,
+			Float64Value: oldestAge.Seconds(),
+			MetricLabels: labels,
+		}),
+	}
+	if _, _, err := cloudmonitoring.SendTimeSeries(ctx, ts, mc, bo, cloudProps.GetProjectId()); err != nil {
+		log.CtxLogger(ctx).Debugw("Error sending replication queue metrics to cloud monitoring", "error", err.Error())
+		return false
+	}
+	return true
+}
@@ -103,3 +103,49 @@ func TestSendToCloudMonitoring(t *testing.T) {
 		})
 	}
 }
+
+func TestSendReplicationQueue(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *bpb.BackintConfiguration
+		metricClient metricClientFunc
+		want         bool
+	}{
+		{
+			name:   "DontSendToMonitoring",
+			config: &bpb.BackintConfiguration{SendMetricsToMonitoring: &wpb.BoolValue{Value: false}},
+			want:   false,
+		},
+		{
+			name:   "FailedToCreateMetricClient",
+			config: &bpb.BackintConfiguration{SendMetricsToMonitoring: &wpb.BoolValue{Value: true}},
+			metricClient: func(ctx context.Context) (cloudmonitoring.TimeSeriesCreator, error) {
+				return nil, fmt.Errorf("failed to create metric client")
+			},
+			want: false,
+		},
+		{
+			name:   "FailedToSendMetric",
+			config: &bpb.BackintConfiguration{SendMetricsToMonitoring: &wpb.BoolValue{Value: true}},
+			metricClient: func(ctx context.Context) (cloudmonitoring.TimeSeriesCreator, error) {
+				return &fake.TimeSeriesCreator{Err: fmt.Errorf("failed to send queue metrics")}, nil
+			},
+			want: false,
+		},
+		{
+			name:         "Success",
+			config:       &bpb.BackintConfiguration{SendMetricsToMonitoring: &wpb.BoolValue{Value: true}, RecoveryBucket: "recovery"},
+			metricClient: defaultMetricClient,
+			want:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := SendReplicationQueue(context.Background(), 3, time.Hour, test.config, defaultCloudProperties, defaultBackOffIntervals, test.metricClient)
+			if got != test.want {
+				t.Errorf("SendReplicationQueue(%v) = %v, want: %v", test.config, got, test.want)
+			}
+		})
+	}
+}
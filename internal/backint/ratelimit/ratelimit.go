@@ -0,0 +1,251 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit applies Backint rate limits which change by time of day.
+//
+// Without rate_limit_windows, the static rate_limit_mb is passed to
+// storage.ReadWriter. With windows, transfers are limited by wrapping their
+// reader or writer in a token bucket which follows the schedule, so a long
+// transfer speeds up or slows down as windows start and end.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+var dayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// window is a parsed RateLimitWindow.
+type window struct {
+	days [7]bool
+	// start and end are minutes after midnight.
+	start, end int
+	bytes      int64
+}
+
+// contains returns true if the time falls within the window.
+func (w window) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if w.start < w.end {
+		return w.days[day] && minute >= w.start && minute < w.end
+	}
+	// The window ends on the following day, so the early part of the day
+	// belongs to a window which started the day before.
+	previous := (day + 6) % 7
+	return (w.days[day] && minute >= w.start) || (w.days[previous] && minute < w.end)
+}
+
+// Parse validates the configured windows.
+func Parse(windows []*bpb.RateLimitWindow) ([]window, error) {
+	var parsed []window
+	for i, w := range windows {
+		days, err := parseDays(w.GetDays())
+		if err != nil {
+			return nil, fmt.Errorf("rate_limit_windows[%d]: %v", i, err)
+		}
+		start, err := parseTime(w.GetStartTime())
+		if err != nil {
+			return nil, fmt.Errorf("rate_limit_windows[%d] start_time: %v", i, err)
+		}
+		end, err := parseTime(w.GetEndTime())
+		if err != nil {
+			return nil, fmt.Errorf("rate_limit_windows[%d] end_time: %v", i, err)
+		}
+		if w.GetRateLimitMb() < 0 {
+			return nil, fmt.Errorf("rate_limit_windows[%d] rate_limit_mb (%d) must not be negative", i, w.GetRateLimitMb())
+		}
+		parsed = append(parsed, window{days: days, start: start, end: end, bytes: w.GetRateLimitMb() * 1024 * 1024})
+	}
+	return parsed, nil
+}
+
+// parseDays parses days such as "Mon-Fri" or "Sat,Sun". Ranges may wrap
+// around the end of the week. An empty string selects every day.
+func parseDays(s string) ([7]bool, error) {
+	var days [7]bool
+	if strings.TrimSpace(s) == "" {
+		return [7]bool{true, true, true, true, true, true, true}, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, ok := dayNames[strings.ToLower(strings.TrimSpace(from))]
+		if !ok {
+			return days, fmt.Errorf("unknown day %q in days %q, want Mon, Tue, Wed, Thu, Fri, Sat or Sun", from, s)
+		}
+		last := first
+		if isRange {
+			if last, ok = dayNames[strings.ToLower(strings.TrimSpace(to))]; !ok {
+				return days, fmt.Errorf("unknown day %q in days %q, want Mon, Tue, Wed, Thu, Fri, Sat or Sun", to, s)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseTime returns the minutes after midnight of a "HH:MM" time.
+func parseTime(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q must be in the format HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Bypassed returns true if the function is not rate limited.
+func Bypassed(config *bpb.BackintConfiguration) bool {
+	return config.GetFunction() == bpb.Function_RESTORE && config.GetRestoreBypassRateLimit()
+}
+
+// StaticBytes returns the rate limit in bytes per second for storage.ReadWriter.
+// It is 0, meaning unlimited, when the limit is applied by a Schedule instead
+// or bypassed.
+func StaticBytes(config *bpb.BackintConfiguration) int64 {
+	if Bypassed(config) || len(config.GetRateLimitWindows()) > 0 {
+		return 0
+	}
+	return config.GetRateLimitMb() * 1024 * 1024
+}
+
+// Schedule selects the rate limit by time of day.
+type Schedule struct {
+	windows      []window
+	defaultBytes int64
+	now          func() time.Time
+}
+
+// New returns the schedule for the configuration, or nil if no windows are
+// configured or the function bypasses rate limits. The windows are expected
+// to be validated with the configuration.
+func New(config *bpb.BackintConfiguration) *Schedule {
+	if Bypassed(config) || len(config.GetRateLimitWindows()) == 0 {
+		return nil
+	}
+	windows, _ := Parse(config.GetRateLimitWindows())
+	return &Schedule{windows: windows, defaultBytes: config.GetRateLimitMb() * 1024 * 1024, now: time.Now}
+}
+
+// Limit returns the rate limit in bytes per second at the time, 0 for unlimited.
+func (s *Schedule) Limit(t time.Time) int64 {
+	for _, w := range s.windows {
+		if w.contains(t) {
+			return w.bytes
+		}
+	}
+	return s.defaultBytes
+}
+
+// limiter is a token bucket following the schedule. The bucket is nil while
+// the transfer is unlimited.
+type limiter struct {
+	ctx      context.Context
+	schedule *Schedule
+	bucket   *rate.Limiter
+}
+
+func (s *Schedule) newLimiter(ctx context.Context) *limiter {
+	l := &limiter{ctx: ctx, schedule: s}
+	l.update()
+	return l
+}
+
+// update applies the limit of the current window, allowing one second of burst.
+func (l *limiter) update() {
+	limit := l.schedule.Limit(l.schedule.now())
+	switch {
+	case limit <= 0:
+		l.bucket = nil
+	case l.bucket == nil:
+		l.bucket = rate.NewLimiter(rate.Limit(limit), int(limit))
+	case l.bucket.Limit() != rate.Limit(limit):
+		l.bucket.SetLimit(rate.Limit(limit))
+		l.bucket.SetBurst(int(limit))
+	}
+}
+
+// wait blocks until n bytes may be transferred.
+func (l *limiter) wait(n int) error {
+	l.update()
+	if l.bucket == nil {
+		return nil
+	}
+	for n > 0 {
+		size := min(n, l.bucket.Burst())
+		if err := l.bucket.WaitN(l.ctx, size); err != nil {
+			return err
+		}
+		n -= size
+	}
+	return nil
+}
+
+type reader struct {
+	r io.Reader
+	l *limiter
+}
+
+// Reader returns a reader limited by the schedule.
+func (s *Schedule) Reader(ctx context.Context, r io.Reader) io.Reader {
+	return &reader{r: r, l: s.newLimiter(ctx)}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if waitErr := r.l.wait(n); waitErr != nil && err == nil {
+			err = waitErr
+		}
+	}
+	return n, err
+}
+
+type writer struct {
+	w io.Writer
+	l *limiter
+}
+
+// Writer returns a writer limited by the schedule.
+func (s *Schedule) Writer(ctx context.Context, w io.Writer) io.Writer {
+	return &writer{w: w, l: s.newLimiter(ctx)}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if err := w.l.wait(len(p)); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+// 2026-10-12 is a Monday.
+func at(day int, hour, minute int) time.Time {
+	return time.Date(2026, 10, 12+day, hour, minute, 0, 0, time.Local)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []*bpb.RateLimitWindow
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			windows: []*bpb.RateLimitWindow{
+				{Days: "Mon-Fri", StartTime: "08:00", EndTime: "18:00", RateLimitMb: 200},
+				{Days: "sat, Sun", StartTime: "22:00", EndTime: "06:00"},
+				{StartTime: "00:00", EndTime: "00:00", RateLimitMb: 50},
+			},
+		},
+		{
+			name:    "UnknownDay",
+			windows: []*bpb.RateLimitWindow{{Days: "Mon-Fry", StartTime: "08:00", EndTime: "18:00"}},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidStartTime",
+			windows: []*bpb.RateLimitWindow{{StartTime: "8am", EndTime: "18:00"}},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidEndTime",
+			windows: []*bpb.RateLimitWindow{{StartTime: "08:00", EndTime: "24:00"}},
+			wantErr: true,
+		},
+		{
+			name:    "NegativeRateLimit",
+			windows: []*bpb.RateLimitWindow{{StartTime: "08:00", EndTime: "18:00", RateLimitMb: -1}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.windows)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("Parse() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	s := New(&bpb.BackintConfiguration{
+		RateLimitMb: 10,
+		RateLimitWindows: []*bpb.RateLimitWindow{
+			{Days: "Mon-Fri", StartTime: "08:00", EndTime: "18:00", RateLimitMb: 200},
+			{Days: "Fri-Sat", StartTime: "22:00", EndTime: "06:00", RateLimitMb: 0},
+		},
+	})
+	tests := []struct {
+		name string
+		t    time.Time
+		want int64
+	}{
+		{name: "MondayBusinessHours", t: at(0, 8, 0), want: 200 * 1024 * 1024},
+		{name: "FridayEndOfBusiness", t: at(4, 17, 59), want: 200 * 1024 * 1024},
+		{name: "MondayEvening", t: at(0, 18, 0), want: 10 * 1024 * 1024},
+		{name: "SaturdayBusinessHours", t: at(5, 12, 0), want: 10 * 1024 * 1024},
+		{name: "FridayNight", t: at(4, 23, 0), want: 0},
+		{name: "SaturdayMorningFromFriday", t: at(5, 5, 59), want: 0},
+		{name: "SundayMorningFromSaturday", t: at(6, 1, 0), want: 0},
+		{name: "MondayMorning", t: at(7, 1, 0), want: 10 * 1024 * 1024},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := s.Limit(test.t); got != test.want {
+				t.Errorf("Limit(%v) = %d, want %d", test.t, got, test.want)
+			}
+		})
+	}
+}
+
+func TestStaticBytes(t *testing.T) {
+	windows := []*bpb.RateLimitWindow{{StartTime: "08:00", EndTime: "18:00", RateLimitMb: 200}}
+	tests := []struct {
+		name         string
+		config       *bpb.BackintConfiguration
+		want         int64
+		wantSchedule bool
+	}{
+		{
+			name:   "NoWindows",
+			config: &bpb.BackintConfiguration{Function: bpb.Function_BACKUP, RateLimitMb: 10},
+			want:   10 * 1024 * 1024,
+		},
+		{
+			name:         "Windows",
+			config:       &bpb.BackintConfiguration{Function: bpb.Function_BACKUP, RateLimitMb: 10, RateLimitWindows: windows},
+			wantSchedule: true,
+		},
+		{
+			name:         "RestoreWithoutBypass",
+			config:       &bpb.BackintConfiguration{Function: bpb.Function_RESTORE, RateLimitMb: 10, RateLimitWindows: windows},
+			wantSchedule: true,
+		},
+		{
+			name:   "RestoreBypass",
+			config: &bpb.BackintConfiguration{Function: bpb.Function_RESTORE, RateLimitMb: 10, RateLimitWindows: windows, RestoreBypassRateLimit: true},
+		},
+		{
+			name:   "BackupIgnoresBypass",
+			config: &bpb.BackintConfiguration{Function: bpb.Function_BACKUP, RateLimitMb: 10, RestoreBypassRateLimit: true},
+			want:   10 * 1024 * 1024,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := StaticBytes(test.config); got != test.want {
+				t.Errorf("StaticBytes() = %d, want %d", got, test.want)
+			}
+			if got := New(test.config) != nil; got != test.wantSchedule {
+				t.Errorf("New() returned a schedule: %v, want %v", got, test.wantSchedule)
+			}
+		})
+	}
+}
+
+func TestLimiterFollowsSchedule(t *testing.T) {
+	now := at(0, 7, 59)
+	s := New(&bpb.BackintConfiguration{
+		RateLimitWindows: []*bpb.RateLimitWindow{{StartTime: "08:00", EndTime: "18:00", RateLimitMb: 1}},
+	})
+	s.now = func() time.Time { return now }
+
+	data := bytes.Repeat([]byte("backint"), 1000)
+	out := &bytes.Buffer{}
+	w := s.Writer(context.Background(), out).(*writer)
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if w.l.bucket != nil {
+		t.Errorf("limit before the window = %v, want unlimited", w.l.bucket.Limit())
+	}
+
+	// A long transfer picks up the window once it starts.
+	now = at(0, 8, 0)
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if w.l.bucket == nil || w.l.bucket.Limit() != rate.Limit(1024*1024) {
+		t.Errorf("limit in the window = %v, want %v", w.l.bucket, rate.Limit(1024*1024))
+	}
+	if !bytes.Equal(out.Bytes(), append(bytes.Clone(data), data...)) {
+		t.Error("Write() did not pass the data through unchanged")
+	}
+
+	r := s.Reader(context.Background(), bytes.NewReader(data))
+	got, err := io.ReadAll(r)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Errorf("ReadAll() = (%d bytes, %v), want the data unchanged", len(got), err)
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	s := New(&bpb.BackintConfiguration{
+		RateLimitWindows: []*bpb.RateLimitWindow{{StartTime: "00:00", EndTime: "00:00", RateLimitMb: 1}},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Writer(ctx, io.Discard).Write(make([]byte, 2*1024*1024)); err == nil {
+		t.Error("Write() with a cancelled context succeeded, want error")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// StartDrain starts REPLICATE for the queue in a background process, which
+// keeps running after BACKUP exits. The results are written to replicate.out
+// in the queue directory.
+func StartDrain(ctx context.Context, config *bpb.BackintConfiguration) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating the agent binary: %v", err)
+	}
+	cmd := drainCommand(exe, config)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting %s: %v", cmd.String(), err)
+	}
+	log.CtxLogger(ctx).Infow("Started REPLICATE in the background", "pid", cmd.Process.Pid, "queue", config.GetReplicationQueueDirectory())
+	return cmd.Process.Release()
+}
+
+// drainCommand returns the REPLICATE command for the queue. It runs in its own
+// session so it is not stopped along with the BACKUP process group.
+func drainCommand(exe string, config *bpb.BackintConfiguration) *exec.Cmd {
+	cmd := exec.Command(exe, "backint",
+		"-u", config.GetUserId(),
+		"-p", config.GetParamFile(),
+		"-f", "replicate",
+		"-i", os.DevNull,
+		"-o", filepath.Join(config.GetReplicationQueueDirectory(), "replicate.out"))
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	return cmd
+}
+
+// lockQueue takes an exclusive lock on the queue so that only one REPLICATE
+// drains it at a time. Returns errQueueLocked without waiting if another
+// process holds the lock.
+func lockQueue(config *bpb.BackintConfiguration) (func(), error) {
+	if err := os.MkdirAll(config.GetReplicationQueueDirectory(), 0750); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(config.GetReplicationQueueDirectory(), ".lock")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("opening replication queue lock %s: %v", path, err)
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, errQueueLocked
+		}
+		return nil, fmt.Errorf("locking replication queue %s: %v", path, err)
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+func TestDrainCommand(t *testing.T) {
+	config := &bpb.BackintConfiguration{
+		UserId:                    "DB@SID",
+		ParamFile:                 "/usr/sap/SID/SYS/global/hdb/opt/backint/parameters.json",
+		ReplicationQueueDirectory: "/usr/sap/SID/SYS/global/hdb/opt/backint/replication",
+	}
+	cmd := drainCommand("/usr/bin/google_cloud_sap_agent", config)
+	want := []string{
+		"/usr/bin/google_cloud_sap_agent", "backint",
+		"-u", "DB@SID",
+		"-p", "/usr/sap/SID/SYS/global/hdb/opt/backint/parameters.json",
+		"-f", "replicate",
+		"-i", "/dev/null",
+		"-o", "/usr/sap/SID/SYS/global/hdb/opt/backint/replication/replicate.out",
+	}
+	if diff := cmp.Diff(want, cmd.Args); diff != "" {
+		t.Errorf("drainCommand() args had unexpected diff (-want +got):\n%s", diff)
+	}
+	if cmd.SysProcAttr == nil || !cmd.SysProcAttr.Setsid {
+		t.Errorf("drainCommand() SysProcAttr = %+v, want a new session", cmd.SysProcAttr)
+	}
+}
+
+func TestLockQueue(t *testing.T) {
+	config := &bpb.BackintConfiguration{ReplicationQueueDirectory: t.TempDir()}
+	unlock, err := lockQueue(config)
+	if err != nil {
+		t.Fatalf("lockQueue() failed: %v", err)
+	}
+	if _, err := lockQueue(config); !errors.Is(err, errQueueLocked) {
+		t.Errorf("second lockQueue() while the lock is held = %v, want %v", err, errQueueLocked)
+	}
+	unlock()
+	unlockSecond, err := lockQueue(config)
+	if err != nil {
+		t.Fatalf("lockQueue() once released failed: %v", err)
+	}
+	unlockSecond()
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"context"
+	"fmt"
+
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+)
+
+// StartDrain is not supported on Windows, queued objects are replicated by running REPLICATE.
+func StartDrain(ctx context.Context, config *bpb.BackintConfiguration) error {
+	return fmt.Errorf("background replication is not supported on Windows")
+}
+
+// lockQueue is a no-op on Windows.
+func lockQueue(config *bpb.BackintConfiguration) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/metrics"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+// errQueueLocked is returned by lockQueue if another REPLICATE holds the lock.
+var errQueueLocked = errors.New("replication queue is locked by another process")
+
+// queueEntry records an object waiting for replication, or an object deleted
+// by DELETE whose replica is waiting for removal.
+type queueEntry struct {
+	Object   string    `json:"object"`
+	Queued   time.Time `json:"queued"`
+	Attempts int       `json:"attempts"`
+	Remove   bool      `json:"remove,omitempty"`
+
+	path string
+}
+
+// queuePath returns the location of the queue entry for an object.
+func queuePath(config *bpb.BackintConfiguration, object string) string {
+	sum := sha256.Sum256([]byte(object))
+	return filepath.Join(config.GetReplicationQueueDirectory(), hex.EncodeToString(sum[:])+".json")
+}
+
+// Enqueue records the object for replication by the REPLICATE function.
+func Enqueue(config *bpb.BackintConfiguration, object string, now time.Time) error {
+	return writeEntry(&queueEntry{Object: object, Queued: now, path: queuePath(config, object)})
+}
+
+// EnqueueRemoval records the removal of the object's replica for the REPLICATE
+// function. It replaces a pending replication of the object.
+func EnqueueRemoval(config *bpb.BackintConfiguration, object string, now time.Time) error {
+	return writeEntry(&queueEntry{Object: object, Queued: now, Remove: true, path: queuePath(config, object)})
+}
+
+// removalQueued returns true if the removal of the object's replica is queued.
+func removalQueued(config *bpb.BackintConfiguration, object string) bool {
+	data, err := os.ReadFile(queuePath(config, object))
+	if err != nil {
+		return false
+	}
+	e := &queueEntry{}
+	return json.Unmarshal(data, e) == nil && e.Remove
+}
+
+// writeEntry persists the entry. The data is written to a temporary file
+// and renamed so a crash never leaves a partially written entry.
+func writeEntry(e *queueEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(e.path), 0750); err != nil {
+		return err
+	}
+	tmp := e.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, e.path)
+}
+
+// readQueue returns the queued entries, oldest first.
+func readQueue(config *bpb.BackintConfiguration) ([]*queueEntry, error) {
+	files, err := os.ReadDir(config.GetReplicationQueueDirectory())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []*queueEntry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(config.GetReplicationQueueDirectory(), f.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		e := &queueEntry{path: path}
+		if err := json.Unmarshal(data, e); err != nil {
+			return nil, fmt.Errorf("malformed replication queue entry %s: %v", path, err)
+		}
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Queued.Before(entries[j].Queued)
+	})
+	return entries, nil
+}
+
+// QueueStats returns the number of queued objects and when the oldest one was queued.
+func QueueStats(config *bpb.BackintConfiguration) (depth int, oldest time.Time, err error) {
+	entries, err := readQueue(config)
+	if err != nil || len(entries) == 0 {
+		return 0, time.Time{}, err
+	}
+	return len(entries), entries[0].Queued, nil
+}
+
+// ReportQueue logs the replication queue and sends its depth and age to Cloud Monitoring.
+func ReportQueue(ctx context.Context, config *bpb.BackintConfiguration, cloudProps *ipb.CloudProperties, now time.Time) {
+	depth, oldest, err := QueueStats(config)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Unable to read replication queue", "queue", config.GetReplicationQueueDirectory(), "err", err)
+		return
+	}
+	var age time.Duration
+	if depth > 0 {
+		age = now.Sub(oldest)
+	}
+	log.CtxLogger(ctx).Infow("Replication queue", "queue", config.GetReplicationQueueDirectory(), "objects", depth, "oldestAge", age.Round(time.Second).String())
+	metrics.SendReplicationQueue(ctx, int64(depth), age, config, cloudProps, cloudmonitoring.NoBackOff(), metrics.DefaultMetricClient)
+}
+
+// Execute replicates the objects queued by BACKUP and removes the replicas
+// queued by DELETE. Results for each object are written to the output.
+// Returns false on failures. Only one run drains the queue at a time: runs
+// started while the queue is being drained, such as the ones started in the
+// background after each BACKUP, exit since the running drain picks up their
+// objects.
+func Execute(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, output io.Writer, cloudProps *ipb.CloudProperties) bool {
+	log.CtxLogger(ctx).Infow("REPLICATE starting", "outFile", config.GetOutputFile(), "queue", config.GetReplicationQueueDirectory())
+	unlock, err := lockQueue(config)
+	if errors.Is(err, errQueueLocked) {
+		log.CtxLogger(ctx).Infow("REPLICATE is already draining the queue, exiting", "queue", config.GetReplicationQueueDirectory())
+		return true
+	}
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("REPLICATE failed", "err", err)
+		return false
+	}
+	defer unlock()
+	defer ReportQueue(ctx, config, cloudProps, time.Now())
+	r, err := New(ctx, config, connectParams)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("REPLICATE failed", "err", err)
+		return false
+	}
+	if err := drain(ctx, config, r, output); err != nil {
+		log.CtxLogger(ctx).Errorw("REPLICATE failed", "err", err)
+		return false
+	}
+	log.CtxLogger(ctx).Infow("REPLICATE finished", "outFile", config.GetOutputFile())
+	return true
+}
+
+// drain replicates each queued object, removing it from the queue once
+// replicated. Objects deleted since they were queued are removed from the
+// queue and reported as not found. Replicas queued for removal are deleted.
+// Failed objects stay queued. Objects queued while draining are drained
+// before returning.
+func drain(ctx context.Context, config *bpb.BackintConfiguration, r *Replicator, output io.Writer) error {
+	attempted := make(map[string]bool)
+	failed := 0
+	for {
+		entries, err := readQueue(config)
+		if err != nil {
+			return fmt.Errorf("reading replication queue %s: %v", config.GetReplicationQueueDirectory(), err)
+		}
+		var pending []*queueEntry
+		for _, e := range entries {
+			key := fmt.Sprintf("%s %t", e.path, e.Remove)
+			if !attempted[key] {
+				attempted[key] = true
+				pending = append(pending, e)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		log.CtxLogger(ctx).Infow("Replicating queued objects", "queue", config.GetReplicationQueueDirectory(), "objects", len(pending))
+		failed += drainEntries(ctx, config, r, pending, output)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to replicate %d objects", failed)
+	}
+	return nil
+}
+
+// drainEntries processes the entries and returns the number which failed.
+func drainEntries(ctx context.Context, config *bpb.BackintConfiguration, r *Replicator, entries []*queueEntry, output io.Writer) int {
+	wp := workerpool.New(int(config.GetThreads()))
+	mu := &sync.Mutex{}
+	failed := 0
+	for _, e := range entries {
+		wp.Submit(func() {
+			result := "#REPLICATED"
+			var err error
+			if e.Remove {
+				result, err = "#DELETED", r.remove(ctx, e.Object)
+			} else if _, err = r.src.Attrs(ctx, e.Object); errors.Is(err, backend.ErrObjectNotExist) {
+				log.CtxLogger(ctx).Infow("Queued object no longer exists", "obj", e.Object, "queued", e.Queued)
+				result, err = "#NOTFOUND", nil
+			} else if err == nil {
+				err = r.Replicate(ctx, e.Object)
+			}
+			// DELETE may have queued the removal of the replica meanwhile, which
+			// replaces this entry and must be kept.
+			superseded := !e.Remove && removalQueued(config, e.Object)
+			if err != nil {
+				log.CtxLogger(ctx).Errorw("Error replicating object", "obj", e.Object, "attempts", e.Attempts+1, "err", err)
+				result = "#ERROR"
+				e.Attempts++
+			}
+			switch {
+			case superseded:
+			case err == nil:
+				if removeErr := os.Remove(e.path); removeErr != nil && !os.IsNotExist(removeErr) {
+					log.CtxLogger(ctx).Warnw("Unable to remove replication queue entry", "entry", e.path, "err", removeErr)
+				}
+			default:
+				if writeErr := writeEntry(e); writeErr != nil {
+					log.CtxLogger(ctx).Warnw("Unable to update replication queue entry", "entry", e.path, "err", writeErr)
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if result == "#ERROR" {
+				failed++
+			}
+			output.Write([]byte(fmt.Sprintf("%s %q\n", result, e.Object)))
+		})
+	}
+	wp.StopWait()
+	return failed
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replication copies completed Backint objects to the recovery bucket
+// so backups remain available if the primary bucket's region is lost.
+//
+// Objects are replicated synchronously by BACKUP or queued on disk for the
+// REPLICATE function, which BACKUP starts in the background once finished.
+// The replication status is recorded in the metadata of both the original
+// object and its replica. DELETE removes the replica along with the object,
+// or queues its removal if the recovery bucket cannot be reached.
+package replication
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	store "cloud.google.com/go/storage"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+const (
+	// StatusMetadataKey holds the replication status of an object.
+	StatusMetadataKey = "X-Backint-Replication"
+	// LocationMetadataKey holds the gs:// location of the replica on the
+	// original object, and of the original object on the replica.
+	LocationMetadataKey = "X-Backint-Replication-Location"
+
+	// StatusPending marks objects which have not been replicated yet.
+	StatusPending = "PENDING"
+	// StatusReplicated marks objects with a complete replica.
+	StatusReplicated = "REPLICATED"
+	// StatusFailed marks objects whose last replication attempt failed.
+	StatusFailed = "FAILED"
+	// StatusReplica marks the replica objects.
+	StatusReplica = "REPLICA"
+)
+
+// Enabled returns true if BACKUP replicates objects to the recovery bucket.
+func Enabled(config *bpb.BackintConfiguration) bool {
+	return config.GetReplicationMode() != bpb.ReplicationMode_REPLICATION_MODE_UNSPECIFIED
+}
+
+// ReplicaConfig returns a copy of the configuration which reads the recovery
+// bucket and recovery folder prefix in place of the bucket and folder prefix.
+func ReplicaConfig(config *bpb.BackintConfiguration) *bpb.BackintConfiguration {
+	replica := proto.Clone(config).(*bpb.BackintConfiguration)
+	replica.Bucket = config.GetRecoveryBucket()
+	replica.FolderPrefix = config.GetRecoveryFolderPrefix()
+	return replica
+}
+
+// ReplicaConnectParams returns connection parameters for the recovery bucket.
+func ReplicaConnectParams(config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters) *storage.ConnectParameters {
+	replica := *connectParams
+	replica.BucketName = config.GetRecoveryBucket()
+	return &replica
+}
+
+// ReplicaObject returns the name of the object's replica, replacing the
+// folder prefix with the recovery folder prefix.
+func ReplicaObject(config *bpb.BackintConfiguration, object string) string {
+	return config.GetRecoveryFolderPrefix() + strings.TrimPrefix(object, config.GetFolderPrefix())
+}
+
+// originalObject returns the name of the object a replica was copied from.
+func originalObject(config *bpb.BackintConfiguration, replica string) string {
+	return config.GetFolderPrefix() + strings.TrimPrefix(replica, config.GetRecoveryFolderPrefix())
+}
+
+// RemoveReplica deletes the replica of an object deleted by DELETE. If the
+// recovery bucket cannot be reached, the removal is queued for REPLICATE and
+// the replica is hidden from restores until then. Returns true if a replica
+// was deleted.
+func RemoveReplica(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, object string) (bool, error) {
+	replica := ReplicaObject(config, object)
+	dst, err := backend.New(ctx, ReplicaConfig(config), ReplicaConnectParams(config, connectParams))
+	if err == nil {
+		err = dst.Delete(ctx, replica)
+	}
+	if errors.Is(err, backend.ErrObjectNotExist) {
+		return false, nil
+	}
+	if err == nil {
+		log.CtxLogger(ctx).Infow("Replica deleted", "obj", object, "replica", Location(ReplicaConfig(config), replica))
+		return true, nil
+	}
+	log.CtxLogger(ctx).Warnw("Unable to delete replica, queueing its removal", "obj", object, "replica", Location(ReplicaConfig(config), replica), "err", err)
+	if queueErr := EnqueueRemoval(config, object, time.Now()); queueErr != nil {
+		return false, fmt.Errorf("deleting replica %s: %v, queueing its removal: %v", replica, err, queueErr)
+	}
+	return false, nil
+}
+
+// replicaBackend hides replicas whose removal is queued, so that objects
+// deleted by DELETE are not restored from the recovery bucket.
+type replicaBackend struct {
+	backend.Backend
+	config *bpb.BackintConfiguration
+}
+
+// HideRemoved wraps the recovery bucket's backend so List skips replicas
+// whose removal is queued.
+func HideRemoved(config *bpb.BackintConfiguration, b backend.Backend) backend.Backend {
+	return &replicaBackend{Backend: b, config: config}
+}
+
+// List returns the replicas which are not queued for removal.
+func (r *replicaBackend) List(ctx context.Context, prefix, filter string) ([]*backend.ObjectAttrs, error) {
+	objects, err := r.Backend.List(ctx, prefix, filter)
+	if err != nil {
+		return nil, err
+	}
+	var kept []*backend.ObjectAttrs
+	for _, o := range objects {
+		if removalQueued(r.config, originalObject(r.config, o.Name)) {
+			log.CtxLogger(ctx).Infow("Skipping replica of a deleted object", "replica", o.Name)
+			continue
+		}
+		kept = append(kept, o)
+	}
+	return kept, nil
+}
+
+// copyFunc copies the object to the replica.
+type copyFunc func(ctx context.Context, object, replica string) error
+
+// Replicator copies objects from the bucket to the recovery bucket.
+type Replicator struct {
+	config *bpb.BackintConfiguration
+	src    backend.Backend
+	dst    backend.Backend
+	copy   copyFunc
+}
+
+// New connects to the bucket and the recovery bucket.
+func New(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters) (*Replicator, error) {
+	src, ok := storage.ConnectToBucket(ctx, connectParams)
+	if !ok {
+		return nil, fmt.Errorf("failed to connect to bucket: %s", config.GetBucket())
+	}
+	dst, ok := storage.ConnectToBucket(ctx, ReplicaConnectParams(config, connectParams))
+	if !ok {
+		return nil, fmt.Errorf("failed to connect to recovery_bucket: %s", config.GetRecoveryBucket())
+	}
+	return &Replicator{
+		config: config,
+		src:    backend.NewGCS(src, config),
+		dst:    backend.NewGCS(dst, ReplicaConfig(config)),
+		copy:   rewrite(config, src, dst),
+	}, nil
+}
+
+// rewrite copies objects between buckets within Cloud Storage, so the data
+// is not transferred through the host.
+func rewrite(config *bpb.BackintConfiguration, src, dst *store.BucketHandle) copyFunc {
+	return func(ctx context.Context, object, replica string) error {
+		srcObject, dstObject := src.Object(object), dst.Object(replica)
+		if config.GetEncryptionKey() != "" {
+			key, err := base64.StdEncoding.DecodeString(config.GetEncryptionKey())
+			if err != nil {
+				return fmt.Errorf("malformed encryption_key: %v", err)
+			}
+			srcObject, dstObject = srcObject.Key(key), dstObject.Key(key)
+		}
+		copier := dstObject.CopierFrom(srcObject)
+		copier.DestinationKMSKeyName = config.GetKmsKey()
+		_, err := copier.Run(ctx)
+		return err
+	}
+}
+
+// Location returns the gs:// URL of the object in the configured bucket.
+func Location(config *bpb.BackintConfiguration, object string) string {
+	return fmt.Sprintf("gs://%s/%s", config.GetBucket(), object)
+}
+
+// Replicate copies the object to the recovery bucket and records the
+// status in the metadata of the object and its replica. A failed attempt
+// is recorded as FAILED so it can be found and retried.
+func (r *Replicator) Replicate(ctx context.Context, object string) error {
+	replica := ReplicaObject(r.config, object)
+	if err := r.copy(ctx, object, replica); err != nil {
+		if statusErr := setStatus(ctx, r.src, object, StatusFailed, ""); statusErr != nil {
+			log.CtxLogger(ctx).Warnw("Unable to record failed replication", "obj", object, "err", statusErr)
+		}
+		return fmt.Errorf("copying %s to %s: %w", object, Location(ReplicaConfig(r.config), replica), err)
+	}
+	if err := setStatus(ctx, r.dst, replica, StatusReplica, Location(r.config, object)); err != nil {
+		return fmt.Errorf("updating metadata of replica %s: %w", replica, err)
+	}
+	if err := setStatus(ctx, r.src, object, StatusReplicated, Location(ReplicaConfig(r.config), replica)); err != nil {
+		return fmt.Errorf("updating metadata of %s: %w", object, err)
+	}
+	log.CtxLogger(ctx).Infow("Object replicated", "obj", object, "replica", Location(ReplicaConfig(r.config), replica))
+	return nil
+}
+
+// remove deletes the replica of an object. A missing replica is not an error.
+func (r *Replicator) remove(ctx context.Context, object string) error {
+	replica := ReplicaObject(r.config, object)
+	if err := r.dst.Delete(ctx, replica); err != nil && !errors.Is(err, backend.ErrObjectNotExist) {
+		return fmt.Errorf("deleting replica %s: %w", Location(ReplicaConfig(r.config), replica), err)
+	}
+	log.CtxLogger(ctx).Infow("Replica deleted", "obj", object, "replica", Location(ReplicaConfig(r.config), replica))
+	return nil
+}
+
+// setStatus records the replication status, keeping the other metadata.
+func setStatus(ctx context.Context, b backend.Backend, object, status, location string) error {
+	attrs, err := b.Attrs(ctx, object)
+	if err != nil {
+		return err
+	}
+	metadata := make(map[string]string)
+	for k, v := range attrs.Metadata {
+		metadata[k] = v
+	}
+	metadata[StatusMetadataKey] = status
+	if location != "" {
+		metadata[LocationMetadataKey] = location
+	}
+	return b.UpdateMetadata(ctx, object, metadata)
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+func TestMain(t *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(t.Run())
+}
+
+// streamCopy copies objects between backends, standing in for a Cloud
+// Storage rewrite. Objects named in fail return an error.
+func streamCopy(src, dst backend.Backend, fail string) copyFunc {
+	return func(ctx context.Context, object, replica string) error {
+		if object == fail {
+			return errors.New("copy failed")
+		}
+		attrs, err := src.Attrs(ctx, object)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if _, err := src.Download(ctx, object, &buf, 0, -1); err != nil {
+			return err
+		}
+		_, err = dst.Upload(ctx, replica, &buf, attrs.Metadata)
+		return err
+	}
+}
+
+func newReplicator(t *testing.T, config *bpb.BackintConfiguration, fail string) *Replicator {
+	t.Helper()
+	src, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	dst, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	return &Replicator{config: config, src: src, dst: dst, copy: streamCopy(src, dst, fail)}
+}
+
+func upload(t *testing.T, b backend.Backend, object string) {
+	t.Helper()
+	metadata := map[string]string{"X-Backup-Type": "FILE", StatusMetadataKey: StatusPending}
+	if _, err := b.Upload(context.Background(), object, strings.NewReader("data"), metadata); err != nil {
+		t.Fatalf("Upload(%s) failed: %v", object, err)
+	}
+}
+
+func TestReplicaObject(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *bpb.BackintConfiguration
+		object string
+		want   string
+	}{
+		{
+			name:   "NoPrefixes",
+			config: &bpb.BackintConfiguration{},
+			object: "DB@SID/file/1.bak",
+			want:   "DB@SID/file/1.bak",
+		},
+		{
+			name:   "RecoveryFolderPrefix",
+			config: &bpb.BackintConfiguration{FolderPrefix: "primary/", RecoveryFolderPrefix: "dr/"},
+			object: "primary/DB@SID/file/1.bak",
+			want:   "dr/DB@SID/file/1.bak",
+		},
+		{
+			name:   "NoRecoveryFolderPrefix",
+			config: &bpb.BackintConfiguration{FolderPrefix: "primary/"},
+			object: "primary/DB@SID/file/1.bak",
+			want:   "DB@SID/file/1.bak",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ReplicaObject(test.config, test.object); got != test.want {
+				t.Errorf("ReplicaObject(%s) = %s, want %s", test.object, got, test.want)
+			}
+		})
+	}
+}
+
+func TestReplicate(t *testing.T) {
+	ctx := context.Background()
+	config := &bpb.BackintConfiguration{Bucket: "primary", FolderPrefix: "p/", RecoveryBucket: "recovery", RecoveryFolderPrefix: "r/"}
+	r := newReplicator(t, config, "p/DB@SID/failed/1.bak")
+	upload(t, r.src, "p/DB@SID/file/1.bak")
+	upload(t, r.src, "p/DB@SID/failed/1.bak")
+
+	if err := r.Replicate(ctx, "p/DB@SID/file/1.bak"); err != nil {
+		t.Fatalf("Replicate() failed: %v", err)
+	}
+	src, _ := r.src.Attrs(ctx, "p/DB@SID/file/1.bak")
+	wantSrc := map[string]string{"X-Backup-Type": "FILE", StatusMetadataKey: StatusReplicated, LocationMetadataKey: "gs://recovery/r/DB@SID/file/1.bak"}
+	if diff := cmp.Diff(wantSrc, src.Metadata); diff != "" {
+		t.Errorf("Replicate() object metadata had unexpected diff (-want +got):\n%s", diff)
+	}
+	dst, err := r.dst.Attrs(ctx, "r/DB@SID/file/1.bak")
+	if err != nil {
+		t.Fatalf("Attrs() of replica failed: %v", err)
+	}
+	wantDst := map[string]string{"X-Backup-Type": "FILE", StatusMetadataKey: StatusReplica, LocationMetadataKey: "gs://primary/p/DB@SID/file/1.bak"}
+	if diff := cmp.Diff(wantDst, dst.Metadata); diff != "" {
+		t.Errorf("Replicate() replica metadata had unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if err := r.Replicate(ctx, "p/DB@SID/failed/1.bak"); err == nil {
+		t.Error("Replicate() with a failed copy succeeded, want error")
+	}
+	failed, _ := r.src.Attrs(ctx, "p/DB@SID/failed/1.bak")
+	if got := failed.Metadata[StatusMetadataKey]; got != StatusFailed {
+		t.Errorf("Replicate() with a failed copy status = %s, want %s", got, StatusFailed)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	ctx := context.Background()
+	config := &bpb.BackintConfiguration{RecoveryBucket: "recovery", ReplicationQueueDirectory: t.TempDir(), Threads: 2}
+	r := newReplicator(t, config, "DB@SID/failed/1.bak")
+	upload(t, r.src, "DB@SID/file/1.bak")
+	upload(t, r.src, "DB@SID/failed/1.bak")
+	now := time.Now()
+	for i, object := range []string{"DB@SID/file/1.bak", "DB@SID/failed/1.bak", "DB@SID/deleted/1.bak"} {
+		if err := Enqueue(config, object, now.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("Enqueue(%s) failed: %v", object, err)
+		}
+	}
+
+	output := &bytes.Buffer{}
+	if err := drain(ctx, config, r, output); err == nil {
+		t.Error("drain() with a failed copy succeeded, want error")
+	}
+	got := strings.Split(strings.TrimSpace(output.String()), "\n")
+	sort.Strings(got)
+	want := []string{`#ERROR "DB@SID/failed/1.bak"`, `#NOTFOUND "DB@SID/deleted/1.bak"`, `#REPLICATED "DB@SID/file/1.bak"`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("drain() output had unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// Only the failed object stays queued, with the attempt recorded.
+	entries, err := readQueue(config)
+	if err != nil {
+		t.Fatalf("readQueue() failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Object != "DB@SID/failed/1.bak" || entries[0].Attempts != 1 {
+		t.Errorf("readQueue() after drain = %+v, want only DB@SID/failed/1.bak with 1 attempt", entries)
+	}
+}
+
+func TestDrainRemovesReplicas(t *testing.T) {
+	ctx := context.Background()
+	config := &bpb.BackintConfiguration{ReplicationQueueDirectory: t.TempDir(), Threads: 1}
+	r := newReplicator(t, config, "")
+	upload(t, r.dst, "DB@SID/file/1.bak")
+	// The removal replaces the pending replication of the deleted object.
+	if err := Enqueue(config, "DB@SID/file/1.bak", time.Now()); err != nil {
+		t.Fatalf("Enqueue() failed: %v", err)
+	}
+	if err := EnqueueRemoval(config, "DB@SID/file/1.bak", time.Now()); err != nil {
+		t.Fatalf("EnqueueRemoval() failed: %v", err)
+	}
+	if err := EnqueueRemoval(config, "DB@SID/missing/1.bak", time.Now()); err != nil {
+		t.Fatalf("EnqueueRemoval() failed: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	if err := drain(ctx, config, r, output); err != nil {
+		t.Errorf("drain() failed: %v", err)
+	}
+	got := strings.Split(strings.TrimSpace(output.String()), "\n")
+	sort.Strings(got)
+	want := []string{`#DELETED "DB@SID/file/1.bak"`, `#DELETED "DB@SID/missing/1.bak"`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("drain() output had unexpected diff (-want +got):\n%s", diff)
+	}
+	if _, err := r.dst.Attrs(ctx, "DB@SID/file/1.bak"); !errors.Is(err, backend.ErrObjectNotExist) {
+		t.Errorf("Attrs() of removed replica = %v, want %v", err, backend.ErrObjectNotExist)
+	}
+	if depth, _, _ := QueueStats(config); depth != 0 {
+		t.Errorf("QueueStats() after drain = %d, want 0", depth)
+	}
+}
+
+func TestRemoveReplica(t *testing.T) {
+	ctx := context.Background()
+	config := &bpb.BackintConfiguration{
+		StorageBackend:            bpb.StorageBackend_FILESYSTEM,
+		FilesystemPath:            t.TempDir(),
+		FolderPrefix:              "p/",
+		RecoveryFolderPrefix:      "r/",
+		ReplicationQueueDirectory: t.TempDir(),
+	}
+	b, err := backend.NewFilesystem(config.GetFilesystemPath())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	upload(t, b, "r/DB@SID/file/1.bak")
+
+	if removed, err := RemoveReplica(ctx, config, nil, "p/DB@SID/file/1.bak"); !removed || err != nil {
+		t.Errorf("RemoveReplica() = (%t, %v), want (true, nil)", removed, err)
+	}
+	if removed, err := RemoveReplica(ctx, config, nil, "p/DB@SID/file/1.bak"); removed || err != nil {
+		t.Errorf("RemoveReplica() of a missing replica = (%t, %v), want (false, nil)", removed, err)
+	}
+	if depth, _, _ := QueueStats(config); depth != 0 {
+		t.Errorf("QueueStats() = %d, want 0", depth)
+	}
+}
+
+func TestRemoveReplicaUnreachableQueuesRemoval(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	// An unset filesystem_path makes the recovery bucket unreachable.
+	config := &bpb.BackintConfiguration{
+		StorageBackend:            bpb.StorageBackend_FILESYSTEM,
+		FolderPrefix:              "p/",
+		RecoveryFolderPrefix:      "r/",
+		ReplicationQueueDirectory: t.TempDir(),
+	}
+	if removed, err := RemoveReplica(ctx, config, nil, "p/DB@SID/file/1.bak"); removed || err != nil {
+		t.Errorf("RemoveReplica() = (%t, %v), want (false, nil)", removed, err)
+	}
+	if !removalQueued(config, "p/DB@SID/file/1.bak") {
+		t.Error("removalQueued() = false, want true")
+	}
+
+	// The queued replica is hidden from restores.
+	b, err := backend.NewFilesystem(root)
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	upload(t, b, "r/DB@SID/file/1.bak")
+	upload(t, b, "r/DB@SID/file/2.bak")
+	objects, err := HideRemoved(config, b).List(ctx, "r/DB@SID/file/", "")
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	var got []string
+	for _, o := range objects {
+		got = append(got, o.Name)
+	}
+	if diff := cmp.Diff([]string{"r/DB@SID/file/2.bak"}, got); diff != "" {
+		t.Errorf("HideRemoved().List() had unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadQueueMissingDirectory(t *testing.T) {
+	entries, err := readQueue(&bpb.BackintConfiguration{ReplicationQueueDirectory: "/does/not/exist"})
+	if err != nil || len(entries) != 0 {
+		t.Errorf("readQueue() = (%v, %v), want an empty queue", entries, err)
+	}
+	if err := drain(context.Background(), &bpb.BackintConfiguration{ReplicationQueueDirectory: "/does/not/exist", Threads: 1}, nil, io.Discard); err != nil {
+		t.Errorf("drain() of an empty queue = %v, want nil", err)
+	}
+}
+
+func TestQueueStats(t *testing.T) {
+	config := &bpb.BackintConfiguration{ReplicationQueueDirectory: t.TempDir()}
+	if depth, oldest, err := QueueStats(config); depth != 0 || !oldest.IsZero() || err != nil {
+		t.Errorf("QueueStats() of an empty queue = (%d, %v, %v), want (0, zero time, nil)", depth, oldest, err)
+	}
+	now := time.Unix(1700000000, 0).UTC()
+	for i, object := range []string{"DB@SID/file/2.bak", "DB@SID/file/1.bak"} {
+		if err := Enqueue(config, object, now.Add(time.Duration(-i)*time.Minute)); err != nil {
+			t.Fatalf("Enqueue(%s) failed: %v", object, err)
+		}
+	}
+	depth, oldest, err := QueueStats(config)
+	if depth != 2 || !oldest.Equal(now.Add(-time.Minute)) || err != nil {
+		t.Errorf("QueueStats() = (%d, %v, %v), want (2, %v, nil)", depth, oldest, err, now.Add(-time.Minute))
+	}
+}
@@ -19,6 +19,7 @@ package restore
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -31,8 +32,14 @@ import (
 
 	store "cloud.google.com/go/storage"
 	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/compression"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/dedup"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/envelope"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/metrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/parse"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/ratelimit"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/replication"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
@@ -83,8 +90,7 @@ func restore(ctx context.Context, config *bpb.BackintConfiguration, connectParam
 				destName = s[2]
 			}
 			restoreFunc := func() {
-				bucketHandle, _ := storage.ConnectToBucket(ctx, connectParams)
-				out := restoreFile(ctx, config, connectParams, bucketHandle, io.Copy, fileName, destName, "", cloudProps)
+				out := restoreWithFallback(ctx, config, connectParams, fileName, destName, "", cloudProps)
 				mu.Lock()
 				defer mu.Unlock()
 				output.Write(out)
@@ -109,8 +115,7 @@ func restore(ctx context.Context, config *bpb.BackintConfiguration, connectParam
 				destName = s[3]
 			}
 			restoreFunc := func() {
-				bucketHandle, _ := storage.ConnectToBucket(ctx, connectParams)
-				out := restoreFile(ctx, config, connectParams, bucketHandle, io.Copy, fileName, destName, externalBackupID, cloudProps)
+				out := restoreWithFallback(ctx, config, connectParams, fileName, destName, externalBackupID, cloudProps)
 				mu.Lock()
 				defer mu.Unlock()
 				output.Write(out)
@@ -133,17 +138,42 @@ func restore(ctx context.Context, config *bpb.BackintConfiguration, connectParam
 	return nil
 }
 
-// restoreFile queries the bucket to see if the backup exists.
+// restoreWithFallback restores the file from the configured storage backend.
+// With replication enabled, the replica in the recovery bucket is restored
+// if the bucket cannot be reached, returns an error or does not hold the
+// backup. Replicas of backups deleted by DELETE are not restored.
+func restoreWithFallback(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, fileName, destName, externalBackupID string, cloudProps *ipb.CloudProperties) []byte {
+	b, err := backend.New(ctx, config, connectParams)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Error connecting to storage backend", "fileName", fileName, "err", err)
+		b = backend.NewGCS(nil, config)
+	}
+	out := restoreFile(ctx, config, connectParams, b, io.Copy, fileName, destName, externalBackupID, cloudProps)
+	if !replication.Enabled(config) || (err == nil && !bytes.HasPrefix(out, []byte("#NOTFOUND")) && !bytes.HasPrefix(out, []byte("#ERROR"))) {
+		return out
+	}
+	replicaConfig := replication.ReplicaConfig(config)
+	replicaParams := replication.ReplicaConnectParams(config, connectParams)
+	replica, err := backend.New(ctx, replicaConfig, replicaParams)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Error connecting to the recovery bucket", "fileName", fileName, "recoveryBucket", replicaConfig.GetBucket(), "err", err)
+		return out
+	}
+	log.CtxLogger(ctx).Infow("Restoring from the replica in the recovery bucket", "fileName", fileName, "recoveryBucket", replicaConfig.GetBucket(), "recoveryFolderPrefix", replicaConfig.GetFolderPrefix())
+	return restoreFile(ctx, replicaConfig, replicaParams, replication.HideRemoved(config, replica), io.Copy, fileName, destName, externalBackupID, cloudProps)
+}
+
+// restoreFile queries the storage backend to see if the backup exists.
 // If externalBackupID is not specified, the latest backup for fileName is used.
 // If found, the file is downloaded and saved to destName.
-func restoreFile(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, bucketHandle *store.BucketHandle, copier storage.IOFileCopier, fileName, destName, externalBackupID string, cloudProps *ipb.CloudProperties) []byte {
+func restoreFile(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, b backend.Backend, copier storage.IOFileCopier, fileName, destName, externalBackupID string, cloudProps *ipb.CloudProperties) []byte {
 	prefix := parse.CreateObjectPath(config, parse.TrimAndClean(fileName), "", "")
 	if externalBackupID != "" {
 		prefix += fmt.Sprintf("%s.bak", externalBackupID)
 	}
 
 	log.CtxLogger(ctx).Infow("Restoring file", "userID", config.GetUserId(), "fileName", fileName, "destName", destName, "prefix", prefix, "externalBackupID", externalBackupID)
-	objects, err := storage.ListObjects(ctx, bucketHandle, prefix, "", config.GetRetries())
+	objects, err := b.List(ctx, prefix, "")
 	if err != nil {
 		log.CtxLogger(ctx).Errorw("Error listing objects", "fileName", fileName, "prefix", prefix, "err", err, "externalBackupID", externalBackupID)
 		return []byte(fmt.Sprintf("#ERROR %s\n", fileName))
@@ -179,8 +209,84 @@ func restoreFile(ctx context.Context, config *bpb.BackintConfiguration, connectP
 		return []byte(fmt.Sprintf("#ERROR %s\n", fileName))
 	}
 
+	startTime := time.Now()
+	bytesWritten, err := download(ctx, config, connectParams, b, copier, object, destFile, config.GetParallelRecoveryStreams())
+	downloadTime := time.Since(startTime)
+	defer metrics.SendToCloudMonitoring(ctx, "restore", fileName, bytesWritten, downloadTime, config, err == nil, cloudProps, cloudmonitoring.NoBackOff(), metrics.DefaultMetricClient)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Error downloading file", "bucket", config.GetBucket(), "destName", destName, "obj", object.Name, "err", err)
+		return []byte(fmt.Sprintf("#ERROR %s\n", fileName))
+	}
+	log.CtxLogger(ctx).Infow("File restored", "bucket", config.GetBucket(), "destName", destName, "obj", object.Name, "bytesWritten", bytesWritten, "downloadTimeSec", downloadTime.Round(time.Millisecond))
+	externalBackupID = strings.TrimSuffix(filepath.Base(object.Name), ".bak")
+	return []byte(fmt.Sprintf("#RESTORED %q %s\n", externalBackupID, fileName))
+}
+
+// decryptingWriter unwraps the data key recorded in the object metadata
+// and returns a writer which decrypts into w.
+func decryptingWriter(ctx context.Context, config *bpb.BackintConfiguration, metadata map[string]string, w io.Writer) (io.WriteCloser, error) {
+	keys, err := envelope.NewKeys(config, os.ReadFile)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := keys.DataKey(ctx, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return envelope.NewDecryptWriter(w, dataKey)
+}
+
+// ReadObject downloads the object into w the same way as a restore,
+// decompressing and decrypting as needed, using a sequential download.
+// Returns the number of bytes written to w.
+func ReadObject(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, b backend.Backend, object *backend.ObjectAttrs, w io.Writer) (int64, error) {
+	return download(ctx, config, connectParams, b, io.Copy, object, w, 0)
+}
+
+// download writes the object to w, returning the number of bytes written.
+func download(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, b backend.Backend, copier storage.IOFileCopier, object *backend.ObjectAttrs, w io.Writer, parallelWorkers int64) (int64, error) {
+	// Zstd and lz4 compressed objects are decompressed and envelope encrypted
+	// objects are decrypted as they are written, which requires a sequential
+	// download. Gzip objects are decompressed by Cloud Storage transcoding.
+	var err error
+	writer := w
+	var decompressWriter io.WriteCloser
+	if codec := object.Metadata[compression.MetadataKey]; codec != "" && codec != compression.Name(bpb.CompressionCodec_GZIP) {
+		if decompressWriter, err = compression.NewDecompressWriter(writer, codec); err != nil {
+			return 0, fmt.Errorf("preparing %s decompression: %v", codec, err)
+		}
+		writer, parallelWorkers = decompressWriter, 0
+	}
+	var decryptWriter io.WriteCloser
+	if envelope.IsEncrypted(object.Metadata) {
+		if decryptWriter, err = decryptingWriter(ctx, config, object.Metadata, writer); err != nil {
+			if decompressWriter != nil {
+				decompressWriter.Close()
+			}
+			return 0, fmt.Errorf("preparing envelope decryption with %s: %v", object.Metadata[envelope.KEKMetadataKey], err)
+		}
+		writer, parallelWorkers = decryptWriter, 0
+	}
+	// Rate limit windows are applied to the downloaded bytes, so the limit
+	// follows the schedule for the duration of the download. This requires a
+	// sequential download, set restore_bypass_rate_limit to restore in parallel.
+	dedupWriter := w
+	if schedule := ratelimit.New(config); schedule != nil {
+		if parallelWorkers > 1 {
+			log.CtxLogger(ctx).Warnw("Rate limit windows are configured, parallel_recovery_streams is ignored and the object is downloaded sequentially", "obj", object.Name, "parallelRecoveryStreams", parallelWorkers)
+		}
+		writer, parallelWorkers = schedule.Writer(ctx, writer), 0
+		dedupWriter = schedule.Writer(ctx, w)
+	}
+
+	// Cloud Storage restores use the storage package for parallel and rate limited downloads.
+	gcs, isGCS := b.(*backend.GCS)
+	var bucketHandle *store.BucketHandle
+	if isGCS {
+		bucketHandle = gcs.BucketHandle()
+	}
 	rw := storage.ReadWriter{
-		Writer:                        destFile,
+		Writer:                        writer,
 		Copier:                        copier,
 		BucketHandle:                  bucketHandle,
 		BucketName:                    config.GetBucket(),
@@ -188,25 +294,41 @@ func restoreFile(ctx context.Context, config *bpb.BackintConfiguration, connectP
 		ObjectName:                    object.Name,
 		TotalBytes:                    object.Size,
 		LogDelay:                      time.Duration(config.GetLogDelaySec()) * time.Second,
-		RateLimitBytes:                config.GetRateLimitMb() * 1024 * 1024,
+		RateLimitBytes:                ratelimit.StaticBytes(config),
 		EncryptionKey:                 config.GetEncryptionKey(),
 		KMSKey:                        config.GetKmsKey(),
 		MaxRetries:                    config.GetRetries(),
 		RetryBackoffInitial:           time.Duration(config.GetRetryBackoffInitial()) * time.Second,
 		RetryBackoffMax:               time.Duration(config.GetRetryBackoffMax()) * time.Second,
 		RetryBackoffMultiplier:        float64(config.GetRetryBackoffMultiplier()),
-		ParallelDownloadWorkers:       config.GetParallelRecoveryStreams(),
+		ParallelDownloadWorkers:       parallelWorkers,
 		ParallelDownloadConnectParams: connectParams,
 	}
-	startTime := time.Now()
-	bytesWritten, err := rw.Download(ctx)
-	downloadTime := time.Since(startTime)
-	defer metrics.SendToCloudMonitoring(ctx, "restore", fileName, bytesWritten, downloadTime, config, err == nil, cloudProps, cloudmonitoring.NoBackOff(), metrics.DefaultMetricClient)
-	if err != nil {
-		log.CtxLogger(ctx).Errorw("Error downloading file", "bucket", config.GetBucket(), "destName", destName, "obj", object.Name, "err", err)
-		return []byte(fmt.Sprintf("#ERROR %s\n", fileName))
+	var bytesWritten int64
+	if dedup.IsManifest(object.Metadata) {
+		log.CtxLogger(ctx).Infow("Restoring deduplicated backup from manifest", "obj", object.Name)
+		bytesWritten, err = dedup.Download(ctx, dedup.Parameters{
+			Config:       config,
+			BucketHandle: bucketHandle,
+			Copier:       copier,
+			ObjectName:   object.Name,
+		}, dedupWriter)
+	} else if isGCS {
+		bytesWritten, err = rw.Download(ctx)
+	} else {
+		bytesWritten, err = b.Download(ctx, object.Name, writer, 0, -1)
 	}
-	log.CtxLogger(ctx).Infow("File restored", "bucket", config.GetBucket(), "destName", destName, "obj", object.Name, "bytesWritten", bytesWritten, "downloadTimeSec", downloadTime.Round(time.Millisecond))
-	externalBackupID = strings.TrimSuffix(filepath.Base(object.Name), ".bak")
-	return []byte(fmt.Sprintf("#RESTORED %q %s\n", externalBackupID, fileName))
+	if decryptWriter != nil && err == nil {
+		// Closing verifies the final segment so truncated objects are not restored silently.
+		err = decryptWriter.Close()
+		bytesWritten = envelope.DecryptedBytes(decryptWriter)
+	}
+	if decompressWriter != nil {
+		// Always close to stop the decoder, keeping the first error seen.
+		if closeErr := decompressWriter.Close(); err == nil {
+			err = closeErr
+		}
+		bytesWritten = compression.DecompressedBytes(decompressWriter)
+	}
+	return bytesWritten, err
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/fsouza/fake-gcs-server/fakestorage"
 	"google.golang.org/api/option"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
@@ -274,7 +275,7 @@ func TestRestoreFileBackupTypePipe(t *testing.T) {
 				defer f.Close()
 			}
 
-			got := restoreFile(context.Background(), defaultConfig, defaultConnectParameters, test.bucket, test.copier, test.fileName, test.destName, test.externalBackupID, defaultCloudProperties)
+			got := restoreFile(context.Background(), defaultConfig, defaultConnectParameters, backend.NewGCS(test.bucket, defaultConfig), test.copier, test.fileName, test.destName, test.externalBackupID, defaultCloudProperties)
 			if !strings.HasPrefix(string(got), test.wantPrefix) {
 				t.Errorf("restoreFile(%s, %s) = %s, wantPrefix: %s", test.fileName, test.externalBackupID, got, test.wantPrefix)
 			}
@@ -289,7 +290,7 @@ func TestRestoreFileBackupTypeFile(t *testing.T) {
 	destName := t.TempDir() + "/file-object.txt"
 	wantPrefix := "#RESTORED"
 
-	got := restoreFile(context.Background(), defaultConfig, defaultConnectParameters, defaultBucketHandle, io.Copy, fileName, destName, externalBackupID, defaultCloudProperties)
+	got := restoreFile(context.Background(), defaultConfig, defaultConnectParameters, backend.NewGCS(defaultBucketHandle, defaultConfig), io.Copy, fileName, destName, externalBackupID, defaultCloudProperties)
 	if !strings.HasPrefix(string(got), wantPrefix) {
 		t.Errorf("restoreFile(%s, %s) = %s, wantPrefix: %s", fileName, externalBackupID, got, wantPrefix)
 	}
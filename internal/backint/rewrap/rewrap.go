@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rewrap re-wraps the data keys of envelope encrypted Backint objects
+// with the primary key-encryption key, without downloading the data. Replicas
+// in the recovery bucket are re-wrapped as well.
+package rewrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/envelope"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/replication"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+// Execute re-wraps the data keys of all encrypted objects for the user ID, and
+// of their replicas in the recovery bucket when replication is enabled.
+// Results for each object are written to the output. Returns false on failures.
+func Execute(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, output io.Writer, cloudProps *ipb.CloudProperties) bool {
+	log.CtxLogger(ctx).Infow("REWRAP starting", "outFile", config.GetOutputFile())
+	keys, err := envelope.NewKeys(config, os.ReadFile)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("REWRAP failed", "err", err)
+		return false
+	}
+	ok := true
+	b, err := backend.New(ctx, config, connectParams)
+	if err == nil {
+		err = rewrap(ctx, config, b, keys, output)
+	}
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("REWRAP failed", "err", err)
+		ok = false
+	}
+	// Replicas carry the wrapped data key of their object, so they must follow a
+	// rotated or revoked KEK as well. They are re-wrapped even if the primary
+	// bucket failed, as the recovery bucket may be all that is left.
+	if replication.Enabled(config) {
+		replicaConfig := replication.ReplicaConfig(config)
+		rb, err := backend.New(ctx, replicaConfig, replication.ReplicaConnectParams(config, connectParams))
+		if err == nil {
+			err = rewrap(ctx, replicaConfig, rb, keys, output)
+		}
+		if err != nil {
+			log.CtxLogger(ctx).Errorw("REWRAP of replicas failed", "recoveryBucket", config.GetRecoveryBucket(), "err", err)
+			ok = false
+		}
+	}
+	if !ok {
+		return false
+	}
+	log.CtxLogger(ctx).Infow("REWRAP finished", "outFile", config.GetOutputFile())
+	return true
+}
+
+// rewrap updates the metadata of each encrypted backup object with its data
+// key wrapped by the primary KEK. Objects already wrapped by the primary key
+// are reported as unchanged and unencrypted objects are skipped.
+func rewrap(ctx context.Context, config *bpb.BackintConfiguration, b backend.Backend, keys *envelope.Keys, output io.Writer) error {
+	prefix := config.GetFolderPrefix() + config.GetUserId() + "/"
+	objects, err := b.List(ctx, prefix, ".bak")
+	if err != nil {
+		return fmt.Errorf("listing objects with prefix %s: %v", prefix, err)
+	}
+	log.CtxLogger(ctx).Infow("Re-wrapping data keys", "prefix", prefix, "objects", len(objects))
+
+	wp := workerpool.New(int(config.GetThreads()))
+	mu := &sync.Mutex{}
+	failed := 0
+	for _, object := range objects {
+		if !envelope.IsEncrypted(object.Metadata) {
+			log.CtxLogger(ctx).Debugw("Skipping object without envelope encryption", "object", object.Name)
+			continue
+		}
+		wp.Submit(func() {
+			result, err := rewrapObject(ctx, b, keys, object)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.CtxLogger(ctx).Errorw("Error re-wrapping data key", "object", object.Name, "kek", object.Metadata[envelope.KEKMetadataKey], "err", err)
+				failed++
+				result = "#ERROR"
+			}
+			output.Write([]byte(fmt.Sprintf("%s %q\n", result, object.Name)))
+		})
+	}
+	wp.StopWait()
+	if failed > 0 {
+		return fmt.Errorf("failed to re-wrap %d objects", failed)
+	}
+	return nil
+}
+
+func rewrapObject(ctx context.Context, b backend.Backend, keys *envelope.Keys, object *backend.ObjectAttrs) (string, error) {
+	metadata, changed, err := keys.Rewrap(ctx, object.Metadata)
+	if err != nil {
+		return "", err
+	}
+	if !changed {
+		log.CtxLogger(ctx).Infow("Data key already wrapped by the primary KEK", "object", object.Name, "kek", object.Metadata[envelope.KEKMetadataKey])
+		return "#UNCHANGED", nil
+	}
+	if err := b.UpdateMetadata(ctx, object.Name, metadata); err != nil {
+		return "", err
+	}
+	log.CtxLogger(ctx).Infow("Data key re-wrapped", "object", object.Name, "oldKEK", object.Metadata[envelope.KEKMetadataKey], "newKEK", metadata[envelope.KEKMetadataKey])
+	return "#REWRAPPED", nil
+}
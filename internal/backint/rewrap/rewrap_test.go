@@ -0,0 +1,189 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rewrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/envelope"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+func TestMain(t *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(t.Run())
+}
+
+var (
+	key1 = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	key2 = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+)
+
+func keys(t *testing.T, keyring string) *envelope.Keys {
+	t.Helper()
+	k, err := envelope.NewKeys(&bpb.BackintConfiguration{EnvelopeKeyringFile: "keyring.json"}, func(string) ([]byte, error) {
+		return []byte(keyring), nil
+	})
+	if err != nil {
+		t.Fatalf("NewKeys() failed: %v", err)
+	}
+	return k
+}
+
+func upload(t *testing.T, b backend.Backend, k *envelope.Keys, object string) {
+	t.Helper()
+	metadata := map[string]string{"X-Backup-Type": "FILE"}
+	if k != nil {
+		_, keyMetadata, err := k.NewDataKey(context.Background())
+		if err != nil {
+			t.Fatalf("NewDataKey() failed: %v", err)
+		}
+		for key, value := range keyMetadata {
+			metadata[key] = value
+		}
+	}
+	if _, err := b.Upload(context.Background(), object, strings.NewReader("data"), metadata); err != nil {
+		t.Fatalf("Upload(%s) failed: %v", object, err)
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	ctx := context.Background()
+	b, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	oldKeys := keys(t, fmt.Sprintf(`{"primary": "k1", "keys": {"k1": %q}}`, key1))
+	newKeys := keys(t, fmt.Sprintf(`{"primary": "k2", "keys": {"k1": %q, "k2": %q}}`, key1, key2))
+	upload(t, b, oldKeys, "test@TST/data.txt/1.bak")
+	upload(t, b, newKeys, "test@TST/data.txt/2.bak")
+	upload(t, b, nil, "test@TST/plain.txt/3.bak")
+	upload(t, b, oldKeys, "other@OTH/data.txt/4.bak")
+
+	output := &bytes.Buffer{}
+	if err := rewrap(ctx, &bpb.BackintConfiguration{UserId: "test@TST", Threads: 1}, b, newKeys, output); err != nil {
+		t.Fatalf("rewrap() failed: %v", err)
+	}
+	want := "#UNCHANGED \"test@TST/data.txt/2.bak\"\n#REWRAPPED \"test@TST/data.txt/1.bak\"\n"
+	if diff := cmp.Diff(want, output.String()); diff != "" {
+		t.Errorf("rewrap() had unexpected output diff (-want +got):\n%s", diff)
+	}
+
+	wantKEK := map[string]string{
+		"test@TST/data.txt/1.bak":  "keyring/k2",
+		"test@TST/data.txt/2.bak":  "keyring/k2",
+		"test@TST/plain.txt/3.bak": "",
+		"other@OTH/data.txt/4.bak": "keyring/k1",
+	}
+	for object, kek := range wantKEK {
+		attrs, err := b.Attrs(ctx, object)
+		if err != nil {
+			t.Fatalf("Attrs(%s) failed: %v", object, err)
+		}
+		if got := attrs.Metadata[envelope.KEKMetadataKey]; got != kek {
+			t.Errorf("Attrs(%s) KEK = %q, want %q", object, got, kek)
+		}
+		if got := attrs.Metadata["X-Backup-Type"]; got != "FILE" {
+			t.Errorf("Attrs(%s) X-Backup-Type = %q, want FILE", object, got)
+		}
+	}
+}
+
+func TestRewrapMissingKey(t *testing.T) {
+	ctx := context.Background()
+	b, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	upload(t, b, keys(t, fmt.Sprintf(`{"primary": "k1", "keys": {"k1": %q}}`, key1)), "test@TST/data.txt/1.bak")
+
+	// The keyring no longer holds k1, so the data key cannot be unwrapped.
+	output := &bytes.Buffer{}
+	err = rewrap(ctx, &bpb.BackintConfiguration{UserId: "test@TST"}, b, keys(t, fmt.Sprintf(`{"primary": "k2", "keys": {"k2": %q}}`, key2)), output)
+	if err == nil {
+		t.Error("rewrap() succeeded, want error")
+	}
+	if got, want := output.String(), "#ERROR \"test@TST/data.txt/1.bak\"\n"; got != want {
+		t.Errorf("rewrap() output = %q, want %q", got, want)
+	}
+}
+
+func TestExecuteNoKeys(t *testing.T) {
+	config := &bpb.BackintConfiguration{
+		UserId:         "test@TST",
+		StorageBackend: bpb.StorageBackend_FILESYSTEM,
+		FilesystemPath: filepath.Join(t.TempDir(), "backups"),
+	}
+	if Execute(context.Background(), config, nil, &bytes.Buffer{}, nil) {
+		t.Error("Execute() without envelope keys succeeded, want failure")
+	}
+}
+
+func TestExecuteReplicas(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	keyring := filepath.Join(dir, "keyring.json")
+	if err := os.WriteFile(keyring, []byte(fmt.Sprintf(`{"primary": "k2", "keys": {"k1": %q, "k2": %q}}`, key1, key2)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config := &bpb.BackintConfiguration{
+		UserId:               "test@TST",
+		StorageBackend:       bpb.StorageBackend_FILESYSTEM,
+		FilesystemPath:       filepath.Join(dir, "backups"),
+		FolderPrefix:         "primary/",
+		RecoveryFolderPrefix: "recovery/",
+		ReplicationMode:      bpb.ReplicationMode_ASYNC,
+		EnvelopeKeyringFile:  keyring,
+		Threads:              1,
+	}
+	b, err := backend.NewFilesystem(config.GetFilesystemPath())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	oldKeys := keys(t, fmt.Sprintf(`{"primary": "k1", "keys": {"k1": %q}}`, key1))
+	upload(t, b, oldKeys, "primary/test@TST/data.txt/1.bak")
+	upload(t, b, oldKeys, "recovery/test@TST/data.txt/1.bak")
+
+	output := &bytes.Buffer{}
+	if !Execute(ctx, config, &storage.ConnectParameters{}, output, nil) {
+		t.Fatal("Execute() failed, want success")
+	}
+	want := "#REWRAPPED \"primary/test@TST/data.txt/1.bak\"\n#REWRAPPED \"recovery/test@TST/data.txt/1.bak\"\n"
+	if diff := cmp.Diff(want, output.String()); diff != "" {
+		t.Errorf("Execute() had unexpected output diff (-want +got):\n%s", diff)
+	}
+	for _, object := range []string{"primary/test@TST/data.txt/1.bak", "recovery/test@TST/data.txt/1.bak"} {
+		attrs, err := b.Attrs(ctx, object)
+		if err != nil {
+			t.Fatalf("Attrs(%s) failed: %v", object, err)
+		}
+		if got := attrs.Metadata[envelope.KEKMetadataKey]; got != "keyring/k2" {
+			t.Errorf("Attrs(%s) KEK = %q, want keyring/k2", object, got)
+		}
+	}
+}
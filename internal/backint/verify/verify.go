@@ -0,0 +1,174 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package verify re-reads Backint objects and checks them against the
+// integrity manifests recorded by BACKUP, without writing to disk.
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/integrity"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/restore"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/storage"
+)
+
+// readFunc reads an object the same way as a restore into the writer.
+type readFunc func(ctx context.Context, object *backend.ObjectAttrs, w io.Writer) (int64, error)
+
+// sampleFunc returns true if the entry should be verified.
+type sampleFunc func() bool
+
+// Execute verifies the objects recorded in the integrity manifests for the
+// user ID. Results for each object are written to the output. Returns false
+// if any object could not be read or does not match its manifest entry.
+func Execute(ctx context.Context, config *bpb.BackintConfiguration, connectParams *storage.ConnectParameters, output io.Writer, cloudProps *ipb.CloudProperties) bool {
+	log.CtxLogger(ctx).Infow("VERIFY starting", "outFile", config.GetOutputFile(), "samplePercent", samplePercent(config))
+	b, err := backend.New(ctx, config, connectParams)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("VERIFY failed", "err", err)
+		return false
+	}
+	read := func(ctx context.Context, object *backend.ObjectAttrs, w io.Writer) (int64, error) {
+		return restore.ReadObject(ctx, config, connectParams, b, object, w)
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	percent := samplePercent(config)
+	sample := func() bool { return rng.Intn(100) < percent }
+	if err := verify(ctx, config, b, read, sample, output); err != nil {
+		log.CtxLogger(ctx).Errorw("VERIFY failed", "err", err)
+		return false
+	}
+	log.CtxLogger(ctx).Infow("VERIFY finished", "outFile", config.GetOutputFile())
+	return true
+}
+
+func samplePercent(config *bpb.BackintConfiguration) int {
+	if config.GetVerifySamplePercent() <= 0 {
+		return 100
+	}
+	return int(config.GetVerifySamplePercent())
+}
+
+// verify checks the sampled entries of every integrity manifest. Objects
+// which no longer exist, such as backups deleted by HANA, are reported as
+// not found without failing the verification. Manifests whose objects have
+// all been deleted are removed, so they are not read again.
+func verify(ctx context.Context, config *bpb.BackintConfiguration, b backend.Backend, read readFunc, sample sampleFunc, output io.Writer) error {
+	prefix := integrity.Prefix(config)
+	manifests, err := b.List(ctx, prefix, ".json")
+	if err != nil {
+		return fmt.Errorf("listing integrity manifests with prefix %s: %v", prefix, err)
+	}
+	if len(manifests) == 0 {
+		return fmt.Errorf("no integrity manifests found with prefix %s, check integrity_manifest is enabled for BACKUP", prefix)
+	}
+	log.CtxLogger(ctx).Infow("Verifying backups", "prefix", prefix, "manifests", len(manifests))
+
+	wp := workerpool.New(int(config.GetThreads()))
+	mu := &sync.Mutex{}
+	verified, failed, removed := 0, 0, 0
+	for _, object := range manifests {
+		m, err := integrity.Read(ctx, b, object.Name)
+		if err != nil {
+			log.CtxLogger(ctx).Errorw("Error reading integrity manifest", "manifest", object.Name, "err", err)
+			failed++
+			output.Write([]byte(fmt.Sprintf("#ERROR %q\n", object.Name)))
+			continue
+		}
+		if allDeleted(ctx, b, m) {
+			if err := b.Delete(ctx, object.Name); err != nil && !errors.Is(err, backend.ErrObjectNotExist) {
+				log.CtxLogger(ctx).Warnw("Error removing integrity manifest of deleted backup", "manifest", object.Name, "backupID", m.BackupID, "err", err)
+			} else {
+				log.CtxLogger(ctx).Infow("Removed integrity manifest, all objects of the backup have been deleted", "manifest", object.Name, "backupID", m.BackupID)
+				removed++
+			}
+			continue
+		}
+		for _, entry := range m.Entries {
+			if !sample() {
+				log.CtxLogger(ctx).Debugw("Object not sampled for verification", "object", entry.Object, "backupID", m.BackupID)
+				continue
+			}
+			wp.Submit(func() {
+				result, err := verifyEntry(ctx, b, read, entry)
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err != nil:
+					log.CtxLogger(ctx).Errorw("Error verifying object", "object", entry.Object, "backupID", m.BackupID, "err", err)
+					failed++
+				case result == "#VERIFIED":
+					verified++
+				}
+				output.Write([]byte(fmt.Sprintf("%s %q\n", result, entry.Object)))
+			})
+		}
+	}
+	wp.StopWait()
+	log.CtxLogger(ctx).Infow("Verification complete", "verified", verified, "failed", failed, "removedManifests", removed)
+	if failed > 0 {
+		return fmt.Errorf("failed to verify %d objects", failed)
+	}
+	return nil
+}
+
+// allDeleted returns true if none of the manifest's objects exist. Errors
+// other than a missing object are treated as the object existing, so the
+// manifest is only removed when every deletion is confirmed.
+func allDeleted(ctx context.Context, b backend.Backend, m *integrity.Manifest) bool {
+	for _, entry := range m.Entries {
+		if _, err := b.Attrs(ctx, entry.Object); !errors.Is(err, backend.ErrObjectNotExist) {
+			return false
+		}
+	}
+	return len(m.Entries) > 0
+}
+
+// verifyEntry reads the object and compares it with the manifest entry.
+// The result is #VERIFIED, #NOTFOUND, #CORRUPT or #ERROR, with an error
+// returned for the last two.
+func verifyEntry(ctx context.Context, b backend.Backend, read readFunc, entry integrity.Entry) (string, error) {
+	attrs, err := b.Attrs(ctx, entry.Object)
+	if errors.Is(err, backend.ErrObjectNotExist) {
+		log.CtxLogger(ctx).Infow("Object in integrity manifest no longer exists", "object", entry.Object)
+		return "#NOTFOUND", nil
+	}
+	if err != nil {
+		return "#ERROR", err
+	}
+	h := entry.NewHasher()
+	startTime := time.Now()
+	if _, err := read(ctx, attrs, h); err != nil {
+		return "#ERROR", err
+	}
+	if err := entry.Check(h); err != nil {
+		return "#CORRUPT", err
+	}
+	log.CtxLogger(ctx).Infow("Object verified", "object", entry.Object, "size", entry.Size, "verifyTimeSec", time.Since(startTime).Round(time.Millisecond))
+	return "#VERIFIED", nil
+}
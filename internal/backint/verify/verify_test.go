@@ -0,0 +1,185 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/integrity"
+	bpb "github.com/GoogleCloudPlatform/sapagent/protos/backint"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+func TestMain(t *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(t.Run())
+}
+
+var defaultConfig = &bpb.BackintConfiguration{UserId: "DB@SID", Threads: 2}
+
+func sum(data string) string {
+	s := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(s[:])
+}
+
+// readObject downloads the stored data directly, standing in for a restore.
+func readObject(b backend.Backend) readFunc {
+	return func(ctx context.Context, object *backend.ObjectAttrs, w io.Writer) (int64, error) {
+		return b.Download(ctx, object.Name, w, 0, -1)
+	}
+}
+
+func sortedLines(s string) []string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	sort.Strings(lines)
+	return lines
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	b, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	for object, data := range map[string]string{"DB@SID/good/1.bak": "backint", "DB@SID/corrupt/1.bak": "tampered"} {
+		if _, err := b.Upload(ctx, object, strings.NewReader(data), nil); err != nil {
+			t.Fatalf("Upload(%s) failed: %v", object, err)
+		}
+	}
+	m := integrity.New("1", time.Now())
+	m.Add(integrity.Entry{Object: "DB@SID/good/1.bak", FileName: "/good", Size: 7, SHA256: []string{sum("backint")}})
+	m.Add(integrity.Entry{Object: "DB@SID/corrupt/1.bak", FileName: "/corrupt", Size: 7, SHA256: []string{sum("backint")}})
+	m.Add(integrity.Entry{Object: "DB@SID/deleted/1.bak", FileName: "/deleted", Size: 7, SHA256: []string{sum("backint")}})
+	if _, err := m.Write(ctx, b, defaultConfig); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		sample     sampleFunc
+		wantOutput []string
+		wantErr    bool
+	}{
+		{
+			name:   "AllObjects",
+			sample: func() bool { return true },
+			wantOutput: []string{
+				`#CORRUPT "DB@SID/corrupt/1.bak"`,
+				`#NOTFOUND "DB@SID/deleted/1.bak"`,
+				`#VERIFIED "DB@SID/good/1.bak"`,
+			},
+			wantErr: true,
+		},
+		{
+			name:       "NoneSampled",
+			sample:     func() bool { return false },
+			wantOutput: []string{""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			err := verify(ctx, defaultConfig, b, readObject(b), test.sample, output)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("verify() = %v, wantErr %v", err, test.wantErr)
+			}
+			if diff := cmp.Diff(test.wantOutput, sortedLines(output.String())); diff != "" {
+				t.Errorf("verify() output had unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVerifyRemovesDeletedManifests(t *testing.T) {
+	ctx := context.Background()
+	b, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	if _, err := b.Upload(ctx, "DB@SID/good/1.bak", strings.NewReader("backint"), nil); err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	kept := integrity.New("1", time.Now())
+	kept.Add(integrity.Entry{Object: "DB@SID/good/1.bak", FileName: "/good", Size: 7, SHA256: []string{sum("backint")}})
+	kept.Add(integrity.Entry{Object: "DB@SID/deleted/1.bak", FileName: "/deleted", Size: 7, SHA256: []string{sum("backint")}})
+	deleted := integrity.New("2", time.Now())
+	deleted.Add(integrity.Entry{Object: "DB@SID/deleted/2.bak", FileName: "/deleted", Size: 7, SHA256: []string{sum("backint")}})
+	for _, m := range []*integrity.Manifest{kept, deleted} {
+		if _, err := m.Write(ctx, b, defaultConfig); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+
+	output := &bytes.Buffer{}
+	if err := verify(ctx, defaultConfig, b, readObject(b), func() bool { return true }, output); err != nil {
+		t.Errorf("verify() = %v, want nil", err)
+	}
+	wantOutput := []string{
+		`#NOTFOUND "DB@SID/deleted/1.bak"`,
+		`#VERIFIED "DB@SID/good/1.bak"`,
+	}
+	if diff := cmp.Diff(wantOutput, sortedLines(output.String())); diff != "" {
+		t.Errorf("verify() output had unexpected diff (-want +got):\n%s", diff)
+	}
+	manifests, err := b.List(ctx, integrity.Prefix(defaultConfig), ".json")
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	var got []string
+	for _, m := range manifests {
+		got = append(got, m.Name)
+	}
+	if diff := cmp.Diff([]string{integrity.Prefix(defaultConfig) + "1.json"}, got); diff != "" {
+		t.Errorf("verify() kept unexpected manifests (-want +got):\n%s", diff)
+	}
+}
+
+func TestVerifyNoManifests(t *testing.T) {
+	b, err := backend.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFilesystem() failed: %v", err)
+	}
+	if err := verify(context.Background(), defaultConfig, b, readObject(b), func() bool { return true }, io.Discard); err == nil {
+		t.Error("verify() with no manifests succeeded, want error")
+	}
+}
+
+func TestSamplePercent(t *testing.T) {
+	tests := []struct {
+		percent int32
+		want    int
+	}{
+		{percent: 0, want: 100},
+		{percent: 25, want: 25},
+	}
+	for _, test := range tests {
+		if got := samplePercent(&bpb.BackintConfiguration{VerifySamplePercent: test.percent}); got != test.want {
+			t.Errorf("samplePercent(%d) = %d, want %d", test.percent, got, test.want)
+		}
+	}
+}
@@ -299,6 +299,17 @@ func applyDefaultCollectionConfiguration(configFromFile *cpb.CollectionConfigura
 	if cc.GetWorkloadValidationCollectionDefinition().GetFetchLatestConfig() == nil {
 		cc.WorkloadValidationCollectionDefinition.FetchLatestConfig = &wpb.BoolValue{Value: true}
 	}
+	if cc.GetMetricSpool().GetEnabled() {
+		if cc.GetMetricSpool().GetDirectory() == "" {
+			cc.MetricSpool.Directory = "/var/lib/google-cloud-sap-agent/spool"
+		}
+		if cc.GetMetricSpool().GetMaxSizeMb() <= 0 {
+			cc.MetricSpool.MaxSizeMb = 100
+		}
+		if cc.GetMetricSpool().GetMaxAgeSec() <= 0 {
+			cc.MetricSpool.MaxAgeSec = 86400
+		}
+	}
 	return cc
 }
 
@@ -315,6 +326,9 @@ func applyDefaultHMConfiguration(configFromFile *cpb.HANAMonitoringConfiguration
 	if hmConfig != nil && hmConfig.GetExecutionThreads() <= 0 {
 		hmConfig.ExecutionThreads = 10
 	}
+	if hmConfig != nil && hmConfig.GetPrometheusExporter().GetEnabled() && hmConfig.GetPrometheusExporter().GetListenAddress() == "" {
+		hmConfig.PrometheusExporter.ListenAddress = "localhost:9668"
+	}
 
 	if hmConfig != nil && hmConfig.GetConnectionTimeout() == nil {
 		hmConfig.ConnectionTimeout = dpb.New(time.Duration(2 * time.Minute))
@@ -396,6 +410,15 @@ func validateAgentConfiguration(config *cpb.Configuration) bool {
 		valid = false
 	}
 
+	// Validate the HANA Monitoring instances. An hdbuserstore key always connects to the database
+	// stored in the key, so discovered tenants need their own keys.
+	for _, i := range config.GetHanaMonitoringConfiguration().GetHanaInstances() {
+		if i.GetDiscoverTenants() && i.GetHdbuserstoreKey() != "" && i.GetTenantHdbuserstoreKeyPattern() == "" {
+			log.Logger.Infow("For hana_monitoring_configuration, if discover_tenants and hdbuserstore_key are set, then tenant_hdbuserstore_key_pattern must also be set.", "instance", i.GetName())
+			valid = false
+		}
+	}
+
 	return valid
 }
 
@@ -437,6 +460,9 @@ func applyOverrides(defaultHMQueriesList, customHMQueriesList []*cpb.Query) []*c
 				log.Logger.Debugw("Overriding query", "Query", query, "enabled", customQuery.GetEnabled())
 				q.Enabled = customQuery.GetEnabled()
 				q.RunOn = customQuery.GetRunOn()
+				if customQuery.GetResponseTimeBudgetMs() > 0 {
+					q.ResponseTimeBudgetMs = customQuery.GetResponseTimeBudgetMs()
+				}
 				break
 			}
 		}
@@ -528,6 +554,17 @@ func validateColumnTypes(col *cpb.Column) error {
 	if col.MetricType == cpb.MetricType_METRIC_CUMULATIVE && (col.ValueType == cpb.ValueType_VALUE_STRING || col.ValueType == cpb.ValueType_VALUE_BOOL) {
 		return errors.New("the value type is not supported for CUMULATIVE custom metrics on column")
 	}
+	if col.MetricType == cpb.MetricType_METRIC_DISTRIBUTION && col.ValueType != cpb.ValueType_VALUE_INT64 && col.ValueType != cpb.ValueType_VALUE_DOUBLE {
+		return errors.New("the value type is not supported for DISTRIBUTION custom metrics on column")
+	}
+	if len(col.GetBucketBounds()) > 0 && col.MetricType != cpb.MetricType_METRIC_DISTRIBUTION {
+		return errors.New("bucket bounds are only supported for DISTRIBUTION custom metrics on column")
+	}
+	for i := 1; i < len(col.GetBucketBounds()); i++ {
+		if col.GetBucketBounds()[i] <= col.GetBucketBounds()[i-1] {
+			return errors.New("bucket bounds must be in increasing order on column")
+		}
+	}
 	return nil
 }
 
@@ -521,6 +521,63 @@ func TestApplyDefaults(t *testing.T) {
 				SupportConfiguration:   &cpb.SupportConfiguration{},
 			},
 		},
+		{
+			name: "ConfigWithPrometheusExporterDefaults",
+			configFromFile: &cpb.Configuration{
+				ProvideSapHostAgentMetrics: &wpb.BoolValue{Value: true},
+				LogToCloud:                 &wpb.BoolValue{Value: true},
+				CloudProperties:            testCloudProps,
+				HanaMonitoringConfiguration: &cpb.HANAMonitoringConfiguration{
+					HanaInstances: []*cpb.HANAInstance{
+						testHANAInstance,
+					},
+					Queries: []*cpb.Query{
+						&cpb.Query{
+							Name: "host_query",
+							Sql:  "sample sql",
+						},
+					},
+					PrometheusExporter:    &cpb.PrometheusExporter{Enabled: true},
+					SendToCloudMonitoring: &wpb.BoolValue{Value: false},
+				},
+			},
+			want: &cpb.Configuration{
+				ProvideSapHostAgentMetrics: &wpb.BoolValue{Value: true},
+				LogToCloud:                 &wpb.BoolValue{Value: true},
+				AgentProperties:            testAgentProps,
+				CloudProperties:            testCloudProps,
+				HanaMonitoringConfiguration: &cpb.HANAMonitoringConfiguration{
+					SampleIntervalSec: 300,
+					QueryTimeoutSec:   300,
+					ExecutionThreads:  10,
+					HanaInstances: []*cpb.HANAInstance{
+						testHANAInstance,
+					},
+					Queries: []*cpb.Query{
+						&cpb.Query{
+							Name: "host_query",
+							Sql:  "sample sql",
+						},
+					},
+					ConnectionTimeout:     &dpb.Duration{Seconds: 120},
+					MaxConnectRetries:     &wpb.Int32Value{Value: 1},
+					PrometheusExporter:    &cpb.PrometheusExporter{Enabled: true, ListenAddress: "localhost:9668"},
+					SendToCloudMonitoring: &wpb.BoolValue{Value: false},
+				},
+				CollectionConfiguration: &cpb.CollectionConfiguration{
+					CollectWorkloadValidationMetrics:     &wpb.BoolValue{Value: true},
+					WorkloadValidationMetricsFrequency:   300,
+					WorkloadValidationDbMetricsFrequency: 3600,
+					DataWarehouseEndpoint:                "https://workloadmanager-datawarehouse.googleapis.com/",
+					WorkloadValidationCollectionDefinition: &cpb.WorkloadValidationCollectionDefinition{
+						FetchLatestConfig:       &wpb.BoolValue{Value: true},
+						ConfigTargetEnvironment: cpb.TargetEnvironment_PRODUCTION,
+					},
+				},
+				DiscoveryConfiguration: defaultDiscoveryProps,
+				SupportConfiguration:   &cpb.SupportConfiguration{},
+			},
+		},
 		{
 			name: "HasSapSystemNoEnableDiscovery",
 			configFromFile: &cpb.Configuration{
@@ -724,6 +781,43 @@ func TestApplyDefaults(t *testing.T) {
 				SupportConfiguration: &cpb.SupportConfiguration{},
 			},
 		},
+		{
+			name: "MetricSpoolDefaults",
+			configFromFile: &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{
+					MetricSpool: &cpb.MetricSpool{Enabled: true},
+				},
+			},
+			want: &cpb.Configuration{
+				ProvideSapHostAgentMetrics: &wpb.BoolValue{Value: true},
+				LogToCloud:                 &wpb.BoolValue{Value: true},
+				AgentProperties:            testAgentProps,
+				CloudProperties:            testCloudProps,
+				CollectionConfiguration: &cpb.CollectionConfiguration{
+					CollectWorkloadValidationMetrics:     &wpb.BoolValue{Value: true},
+					WorkloadValidationMetricsFrequency:   300,
+					WorkloadValidationDbMetricsFrequency: 3600,
+					DataWarehouseEndpoint:                "https://workloadmanager-datawarehouse.googleapis.com/",
+					WorkloadValidationCollectionDefinition: &cpb.WorkloadValidationCollectionDefinition{
+						FetchLatestConfig:       &wpb.BoolValue{Value: true},
+						ConfigTargetEnvironment: cpb.TargetEnvironment_PRODUCTION,
+					},
+					MetricSpool: &cpb.MetricSpool{
+						Enabled:   true,
+						Directory: "/var/lib/google-cloud-sap-agent/spool",
+						MaxSizeMb: 100,
+						MaxAgeSec: 86400,
+					},
+				},
+				DiscoveryConfiguration: &cpb.DiscoveryConfiguration{
+					EnableDiscovery:                &wpb.BoolValue{Value: true},
+					SapInstancesUpdateFrequency:    &dpb.Duration{Seconds: 60},
+					SystemDiscoveryUpdateFrequency: &dpb.Duration{Seconds: 14400},
+					EnableWorkloadDiscovery:        &wpb.BoolValue{Value: true},
+				},
+				SupportConfiguration: &cpb.SupportConfiguration{},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -928,6 +1022,30 @@ func TestApplyOverrides(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "DefaultQueryResponseTimeBudgetOverride",
+			defaultQueryList: []*cpb.Query{
+				&cpb.Query{
+					Name: "host_query",
+					Sql:  "sample sql",
+				},
+			},
+			customQueryList: []*cpb.Query{
+				&cpb.Query{
+					Name:                 "default_host_query",
+					Enabled:              true,
+					ResponseTimeBudgetMs: 2000,
+				},
+			},
+			want: []*cpb.Query{
+				&cpb.Query{
+					Name:                 "host_query",
+					Sql:                  "sample sql",
+					Enabled:              true,
+					ResponseTimeBudgetMs: 2000,
+				},
+			},
+		},
 		{
 			name: "OnlyCustomQueryEnabled",
 			defaultQueryList: []*cpb.Query{
@@ -1323,6 +1441,65 @@ func TestValidateCustomQueries(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "ValidDistribution",
+			queries: []*cpb.Query{
+				&cpb.Query{
+					Columns: []*cpb.Column{
+						&cpb.Column{
+							MetricType:   cpb.MetricType_METRIC_DISTRIBUTION,
+							ValueType:    cpb.ValueType_VALUE_DOUBLE,
+							BucketBounds: []float64{1, 10, 100},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "MetricTypeDistributionValueTypeBool",
+			queries: []*cpb.Query{
+				&cpb.Query{
+					Columns: []*cpb.Column{
+						&cpb.Column{
+							MetricType: cpb.MetricType_METRIC_DISTRIBUTION,
+							ValueType:  cpb.ValueType_VALUE_BOOL,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "DistributionBucketBoundsNotIncreasing",
+			queries: []*cpb.Query{
+				&cpb.Query{
+					Columns: []*cpb.Column{
+						&cpb.Column{
+							MetricType:   cpb.MetricType_METRIC_DISTRIBUTION,
+							ValueType:    cpb.ValueType_VALUE_INT64,
+							BucketBounds: []float64{10, 10},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "BucketBoundsOnGauge",
+			queries: []*cpb.Query{
+				&cpb.Query{
+					Columns: []*cpb.Column{
+						&cpb.Column{
+							MetricType:   cpb.MetricType_METRIC_GAUGE,
+							ValueType:    cpb.ValueType_VALUE_INT64,
+							BucketBounds: []float64{1, 10},
+						},
+					},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "MetricTypeLabelValueTypeInt",
 			queries: []*cpb.Query{
@@ -1588,6 +1765,37 @@ func TestValidateAgentConfiguration(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "hana_monitoring_discover_tenants_hdbuserstore_key_without_tenant_pattern",
+			config: &cpb.Configuration{
+				HanaMonitoringConfiguration: &cpb.HANAMonitoringConfiguration{
+					HanaInstances: []*cpb.HANAInstance{
+						{
+							Name:            "sap-hana",
+							HdbuserstoreKey: "SYSKEY",
+							DiscoverTenants: true,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "hana_monitoring_discover_tenants_hdbuserstore_key_with_tenant_pattern",
+			config: &cpb.Configuration{
+				HanaMonitoringConfiguration: &cpb.HANAMonitoringConfiguration{
+					HanaInstances: []*cpb.HANAInstance{
+						{
+							Name:                         "sap-hana",
+							HdbuserstoreKey:              "SYSKEY",
+							DiscoverTenants:              true,
+							TenantHdbuserstoreKeyPattern: "MON_{tenant}",
+						},
+					},
+				},
+			},
+			want: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -239,6 +239,21 @@ func (db *DBHandle) Query(ctx context.Context, query string, exec commandlineexe
 	}, nil
 }
 
+// Exec runs the statements in order, stopping at the first error. Statements such as
+// ALTER SYSTEM commit implicitly, so the statements which ran before a failed one stay applied.
+// Exec is only supported by handles using the go-hdb driver.
+func (db *DBHandle) Exec(ctx context.Context, statements ...string) error {
+	if db.useCMD {
+		return fmt.Errorf("running statements is not supported with the hdbsql command-line, connect with a username and password instead")
+	}
+	for _, statement := range statements {
+		if _, err := db.goHDBHandle.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Ping pings the database via the goHDB driver or command-line accordingly.
 func (db *DBHandle) Ping(ctx context.Context) error {
 	if !db.useCMD {
@@ -251,6 +266,14 @@ func (db *DBHandle) Ping(ctx context.Context) error {
 	return err
 }
 
+// Close closes the go-hdb driver connections. Handles using the hdbsql command-line hold no connections.
+func (db *DBHandle) Close() error {
+	if db.useCMD || db.goHDBHandle == nil {
+		return nil
+	}
+	return db.goHDBHandle.Close()
+}
+
 // Next iterates to the next row of result. Returns false if no more rows remain or the next row could not be read.
 func (qr *QueryResults) Next() bool {
 	if !qr.useCMD {
@@ -187,6 +187,18 @@ func TestCreateDBHandle(t *testing.T) {
 	}
 }
 
+func TestClose(t *testing.T) {
+	for _, p := range []Params{{Password: "my-pass"}, {HDBUserKey: "test-key", SID: "TST"}} {
+		db, err := CreateDBHandle(context.Background(), p)
+		if err != nil {
+			t.Fatalf("CreateDBHandle(%v) failed: %v", p, err)
+		}
+		if err := db.Close(); err != nil {
+			t.Errorf("Close() = %v, want nil", err)
+		}
+	}
+}
+
 func TestQueryValid(t *testing.T) {
 	testCMDDBHandle, _ := NewCMDDBHandle(Params{
 		SID:        "testSID",
@@ -250,6 +262,16 @@ func TestQueryInvalid(t *testing.T) {
 	})
 }
 
+func TestExecCMD(t *testing.T) {
+	db, err := NewCMDDBHandle(Params{SID: "testSID", HDBUserKey: "testHDBUserKey"})
+	if err != nil {
+		t.Fatalf("NewCMDDBHandle() failed: %v", err)
+	}
+	if err := db.Exec(context.Background(), "ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'SYSTEM') SET ('persistence', 'log_mode') = 'normal'"); err == nil {
+		t.Error("Exec() with hdbsql command-line = nil, want error")
+	}
+}
+
 func TestReadRow(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -29,6 +29,8 @@ import (
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 )
 
+const knowledgeBaseID = "knowledgebase"
+
 var (
 	//go:embed rules/*.json testrules/*.json rules/ha_dr/*.json rules/maintenance/*.json rules/performance/*.json rules/scaleout/*.json rules/security/*.json
 	rulesDir embed.FS
@@ -84,6 +86,14 @@ var (
 
 	// KnowledgeBasePattern matches the knowledge base scalar referencing functions of the form query_name:column_name.
 	KnowledgeBasePattern = regexp.MustCompile(`^(\w+):(\w+)$`)
+
+	// AggregatePattern matches the functions over all the values read for a column: min(), max()
+	// and sum() which aggregate them, and any() and all() which compare each of them.
+	AggregatePattern = regexp.MustCompile(`^(min|max|sum|any|all)\((\w+:\w+)\)$`)
+
+	// readOnlyPattern and lockingPattern restrict the queries of custom rules to read-only statements.
+	readOnlyPattern = regexp.MustCompile(`(?i)^\(*\s*(SELECT|WITH)\b`)
+	lockingPattern  = regexp.MustCompile(`(?i)\b(FOR\s+(UPDATE|SHARE)|INTO)\b`)
 )
 
 // ReadRules returns preprocessed rules ready for execution by rule engine.
@@ -93,38 +103,92 @@ var (
 //   - Order the rules starting global rules(knowledgebase.json) first.
 //   - Within each rule, queries are ordered for a successful execution.
 func ReadRules(files []string) ([]*rpb.Rule, error) {
-	var rules []*rpb.Rule
-
-	ruleIds := make(map[string]bool)
-	globalKBKeys := make(map[string]bool)
+	r := newRuleReader(nil)
 	for _, filename := range files {
-		rule := &rpb.Rule{}
 		c, err := rulesDir.ReadFile(filename)
 		if err != nil {
 			log.Logger.Infow("Could not read file", "filename", filename)
 			return nil, err
 		}
-		if err := protojson.Unmarshal(c, rule); err != nil {
-			log.Logger.Infow("Could not unmarshal rule from", "filename", filename)
+		if err := r.add(filename, c, false); err != nil {
 			return nil, err
 		}
-		if rule.GetId() == "knowledgebase" {
-			globalKBKeys = buildQueryNameToCols(rule)
+	}
+	log.Logger.Debugw("Pre-processed rules", "rules", r.rules)
+	return r.rules, nil
+}
+
+// ruleReader accumulates the preprocessed rules read from built-in and custom rule files.
+type ruleReader struct {
+	rules        []*rpb.Rule
+	ruleIds      map[string]bool
+	ruleSources  map[string]string
+	globalKBKeys map[string]bool
+	disabled     map[string]bool
+	skipped      map[string]bool
+}
+
+func newRuleReader(disabledRuleIDs []string) *ruleReader {
+	r := &ruleReader{
+		ruleIds:      make(map[string]bool),
+		ruleSources:  make(map[string]string),
+		globalKBKeys: make(map[string]bool),
+		disabled:     make(map[string]bool),
+		skipped:      make(map[string]bool),
+	}
+	for _, id := range disabledRuleIDs {
+		r.disabled[id] = true
+	}
+	return r
+}
+
+// add preprocesses the rule in content read from source. Invalid built-in rules are skipped,
+// while invalid custom rules fail the load so that a broken rule pack is not mistaken for a
+// clean one. The queries of custom rules must be read-only.
+func (r *ruleReader) add(source string, content []byte, custom bool) error {
+	rule := &rpb.Rule{}
+	if err := protojson.Unmarshal(content, rule); err != nil {
+		log.Logger.Infow("Could not unmarshal rule from", "filename", source)
+		if custom {
+			return fmt.Errorf("could not unmarshal rule from %s: %v", source, err)
 		}
-		err = validateRule(rule, ruleIds, globalKBKeys)
-		if err != nil {
-			log.Logger.Warnf("Skipping rule: ", "id", rule.GetId(), "err", err.Error())
-			continue
+		return err
+	}
+	if !custom && r.disabled[rule.GetId()] && rule.GetId() != knowledgeBaseID {
+		log.Logger.Infow("Skipping disabled rule", "id", rule.GetId())
+		r.skipped[rule.GetId()] = true
+		return nil
+	}
+	if other, ok := r.ruleSources[rule.GetId()]; ok && custom {
+		return fmt.Errorf("rule %s in %s has the same ID as the rule in %s - ruleID must be unique", rule.GetId(), source, other)
+	}
+	if rule.GetId() == knowledgeBaseID {
+		r.globalKBKeys = buildQueryNameToCols(rule)
+	}
+	if custom {
+		if err := validateReadOnlyQueries(rule.GetQueries()); err != nil {
+			return fmt.Errorf("invalid rule %s in %s: %v", rule.GetId(), source, err)
+		}
+	}
+	err := validateRule(rule, r.ruleIds, r.globalKBKeys)
+	if err != nil {
+		if custom {
+			return fmt.Errorf("invalid rule %s in %s: %v", rule.GetId(), source, err)
 		}
+		log.Logger.Warnw("Skipping rule", "id", rule.GetId(), "source", source, "err", err.Error())
+		return nil
+	}
+	r.ruleSources[rule.GetId()] = source
 
-		if rule.Queries, err = QueryExecutionOrder(rule.Queries); err != nil {
-			log.Logger.Errorf("Error ordering queries", "rule", rule.Id, "error", err)
-			continue
+	if rule.Queries, err = QueryExecutionOrder(rule.Queries); err != nil {
+		if custom {
+			return fmt.Errorf("invalid rule %s in %s: %v", rule.GetId(), source, err)
 		}
-		rules = append(rules, rule)
+		log.Logger.Errorw("Error ordering queries", "rule", rule.Id, "source", source, "error", err)
+		return nil
 	}
-	log.Logger.Debugw("Pre-processed rules", "rules", rules)
-	return rules, nil
+	r.rules = append(r.rules, rule)
+	return nil
 }
 
 // validateRule checks if a rule is valid or not. The following validations are ran to ensure validity:
@@ -138,15 +202,20 @@ func ReadRules(files []string) ([]*rpb.Rule, error) {
 //   - Each recommendation should have a unique Id.
 //
 //   - The trigger condition should be valid for each recommendation.
+//
+// The ID of a valid rule is added to ruleIds.
 func validateRule(rule *rpb.Rule, ruleIds map[string]bool, globalKBKeys map[string]bool) error {
 	if _, ok := ruleIds[rule.GetId()]; ok {
 		return fmt.Errorf("rule with ruleID %s already exists - ruleID must be unique", rule.GetId())
 	}
-	ruleIds[rule.GetId()] = true
 	if err := validateQueries(rule.GetQueries()); err != nil {
 		return err
 	}
-	return validateRecommendations(rule.GetRecommendations(), buildQueryNameToCols(rule), globalKBKeys)
+	if err := validateRecommendations(rule.GetRecommendations(), buildQueryNameToCols(rule), globalKBKeys); err != nil {
+		return err
+	}
+	ruleIds[rule.GetId()] = true
+	return nil
 }
 
 // validateQueries checks if each query in a rule is valid.
@@ -170,6 +239,53 @@ func validateQueries(queries []*rpb.Query) error {
 	return nil
 }
 
+// validateReadOnlyQueries checks that each query is a single SELECT statement, optionally
+// starting with a WITH clause, which does not lock rows. Custom rules are run by the agent with
+// the privileges of its database user, so they must not be able to change the database.
+func validateReadOnlyQueries(queries []*rpb.Query) error {
+	for _, q := range queries {
+		sql := strings.TrimSuffix(strings.TrimSpace(stripLiterals(q.GetSql())), ";")
+		switch {
+		case strings.Contains(sql, ";"):
+			return fmt.Errorf("query %s must be a single statement", q.GetName())
+		case !readOnlyPattern.MatchString(sql):
+			return fmt.Errorf("query %s must be a SELECT statement", q.GetName())
+		case lockingPattern.MatchString(sql):
+			return fmt.Errorf("query %s must not lock rows or select into variables", q.GetName())
+		}
+	}
+	return nil
+}
+
+// stripLiterals replaces the string literals, quoted identifiers and comments in sql with a
+// space, so that the keywords and separators they contain are ignored.
+func stripLiterals(sql string) string {
+	var b strings.Builder
+	for i := 0; i < len(sql); i++ {
+		var end string
+		switch {
+		case sql[i] == '\'' || sql[i] == '"':
+			end = sql[i : i+1]
+		case strings.HasPrefix(sql[i:], "--"):
+			end = "\n"
+		case strings.HasPrefix(sql[i:], "/*"):
+			end = "*/"
+			i++
+		default:
+			b.WriteByte(sql[i])
+			continue
+		}
+		// Quotes are escaped by doubling them, which reads as two adjacent literals.
+		j := strings.Index(sql[i+1:], end)
+		if j < 0 {
+			break
+		}
+		i += j + len(end)
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
 // validateRecommendations checks if the recommendations to a rule are valid or not.
 func validateRecommendations(recs []*rpb.Recommendation, queryNameToCols map[string]bool, globalKBKeys map[string]bool) error {
 	recsIds := make(map[string]bool)
@@ -189,21 +305,44 @@ func validateTriggerCondition(node *rpb.EvalNode, queryNameToCols map[string]boo
 	if node == nil {
 		return nil
 	}
+	op := node.GetOperation()
 	if len(node.GetChildEvals()) == 0 {
 		// a leaf node should have a trigger condition evaluated i.e. lhs, rhs and operation.
-		if node.GetLhs() == "" || node.GetRhs() == "" || node.GetOperation() == rpb.EvalNode_UNDEFINED {
-			return fmt.Errorf("invalid eval node with lhs: %s, rhs: %s, operation: %s", node.GetLhs(), node.GetRhs(), node.GetOperation().String())
+		if node.GetLhs() == "" || node.GetRhs() == "" || op == rpb.EvalNode_UNDEFINED {
+			return fmt.Errorf("invalid eval node with lhs: %s, rhs: %s, operation: %s", node.GetLhs(), node.GetRhs(), op.String())
+		}
+		if op == rpb.EvalNode_OR || op == rpb.EvalNode_AND || op == rpb.EvalNode_NOT {
+			return fmt.Errorf("invalid eval node operation: %s, it requires child evals", op.String())
 		}
 		if !matchTriggerKeys(node.GetLhs(), queryNameToCols, globalKBKeys) {
 			return fmt.Errorf("invalid lhs condition in the trigger condition lhs: %s", node.GetLhs())
 		}
 		if !matchTriggerKeys(node.GetRhs(), queryNameToCols, globalKBKeys) {
+			if op == rpb.EvalNode_MATCHES {
+				return fmt.Errorf("invalid rhs condition in the trigger condition rhs: %s, it is read from the knowledge base as query_name:column_name, write the regular expression as (?:%s) to match it literally", node.GetRhs(), node.GetRhs())
+			}
 			return fmt.Errorf("invalid rhs condition in the trigger condition rhs: %s", node.GetRhs())
 		}
+		if match := AggregatePattern.FindStringSubmatch(node.GetRhs()); len(match) == 3 && (match[1] == "any" || match[1] == "all") {
+			return fmt.Errorf("invalid rhs condition in the trigger condition rhs: %s, %s() is only allowed in lhs", node.GetRhs(), match[1])
+		}
+		// A MATCHES rhs of the form query_name:column_name reads the regular expression from the
+		// knowledge base, others are the regular expression itself.
+		if op == rpb.EvalNode_MATCHES && !isKBReference(node.GetRhs()) {
+			if _, err := regexp.Compile(node.GetRhs()); err != nil {
+				return fmt.Errorf("invalid regular expression in the trigger condition rhs: %s, %v", node.GetRhs(), err)
+			}
+		}
 		return nil
 	}
-	if node.GetOperation() != rpb.EvalNode_OR && node.GetOperation() != rpb.EvalNode_AND {
-		return fmt.Errorf("invalid eval node operation: %s, in case of trigger condition having multiple child evals, allowed operations are AND|OR", node.GetOperation().String())
+	switch op {
+	case rpb.EvalNode_OR, rpb.EvalNode_AND:
+	case rpb.EvalNode_NOT:
+		if len(node.GetChildEvals()) != 1 {
+			return fmt.Errorf("invalid eval node operation: NOT requires exactly one child eval, got %d", len(node.GetChildEvals()))
+		}
+	default:
+		return fmt.Errorf("invalid eval node operation: %s, in case of trigger condition having child evals, allowed operations are AND|OR|NOT", op.String())
 	}
 	for _, child := range node.GetChildEvals() {
 		if err := validateTriggerCondition(child, queryNameToCols, globalKBKeys); err != nil {
@@ -342,6 +481,13 @@ func matchTriggerKeys(cond string, queryNameToCols map[string]bool, globalKBKeys
 		return false
 	}
 
+	// Checking for functions over all the values of a column.
+	match = AggregatePattern.FindStringSubmatch(cond)
+	if len(match) == 3 {
+		key := match[2]
+		return queryNameToCols[key] || globalKBKeys[key]
+	}
+
 	// Checking for a scalar reference.
 	match = KnowledgeBasePattern.FindStringSubmatch(cond)
 	if len(match) == 3 {
@@ -356,3 +502,8 @@ func matchTriggerKeys(cond string, queryNameToCols map[string]bool, globalKBKeys
 	}
 	return true
 }
+
+// isKBReference reports whether the condition reads its value from the knowledge base.
+func isKBReference(cond string) bool {
+	return CountPattern.MatchString(cond) || AggregatePattern.MatchString(cond) || KnowledgeBasePattern.MatchString(cond)
+}
@@ -428,6 +428,83 @@ func TestValidateRule(t *testing.T) {
 	}
 }
 
+func TestValidateRuleRecordsValidIDs(t *testing.T) {
+	ruleIds := make(map[string]bool)
+	invalid := &rpb.Rule{Id: "r_invalid", Queries: []*rpb.Query{{Name: "q", Sql: "SELECT 1 FROM DUMMY", Columns: []string{"one"}}}}
+	if err := validateRule(invalid, ruleIds, nil); err == nil {
+		t.Fatalf("validateRule(%v) succeeded, want error", invalid)
+	}
+	if ruleIds["r_invalid"] {
+		t.Errorf("validateRule(%v) recorded the ID of an invalid rule", invalid)
+	}
+	// The ID of a rule which failed validation is still free for a valid rule.
+	valid := &rpb.Rule{Id: "r_invalid"}
+	if err := validateRule(valid, ruleIds, nil); err != nil {
+		t.Fatalf("validateRule(%v) failed: %v", valid, err)
+	}
+	if !ruleIds["r_invalid"] {
+		t.Errorf("validateRule(%v) did not record the ID of a valid rule", valid)
+	}
+}
+
+func TestValidateReadOnlyQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		wantErr bool
+	}{
+		{
+			name: "Select",
+			sql:  "SELECT COUNT(*) AS count FROM SYS.USERS WHERE USER_NAME LIKE 'DBA%';",
+		},
+		{
+			name: "WithClause",
+			sql:  "  with t AS (SELECT 1 AS one FROM DUMMY) SELECT one FROM t",
+		},
+		{
+			name: "Parenthesized",
+			sql:  "(SELECT 1 AS one FROM DUMMY) UNION (SELECT 2 AS one FROM DUMMY)",
+		},
+		{
+			name: "KeywordsInLiteralsAndComments",
+			sql:  "SELECT 'it''s; DELETE' AS \"FOR UPDATE\" FROM DUMMY -- ; INTO\n/* DROP; */",
+		},
+		{
+			name:    "Update",
+			sql:     "UPDATE USERS SET X = 1",
+			wantErr: true,
+		},
+		{
+			name:    "CommentedSelect",
+			sql:     "/* SELECT */ CALL DROP_ALL()",
+			wantErr: true,
+		},
+		{
+			name:    "MultipleStatements",
+			sql:     "SELECT 1 AS one FROM DUMMY; DELETE FROM USERS",
+			wantErr: true,
+		},
+		{
+			name:    "ForUpdate",
+			sql:     "SELECT * FROM USERS FOR UPDATE",
+			wantErr: true,
+		},
+		{
+			name:    "Into",
+			sql:     "SELECT 1 INTO x FROM DUMMY",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateReadOnlyQueries([]*rpb.Query{{Name: "q", Sql: tc.sql}})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("validateReadOnlyQueries(%q) = %v, want error: %t", tc.sql, err, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateRecommendations(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -616,6 +693,185 @@ func TestValidateRecommendations(t *testing.T) {
 			},
 			want: cmpopts.AnyError,
 		},
+		{
+			name: "ValidNot",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_NOT,
+						ChildEvals: []*rpb.EvalNode{
+							&rpb.EvalNode{
+								Lhs:       "sample_lhs:value",
+								Operation: rpb.EvalNode_IN,
+								Rhs:       "a,b",
+							},
+						},
+					},
+				},
+			},
+			queryNametoCols: map[string]bool{
+				"sample_lhs:value": true,
+			},
+			want: nil,
+		},
+		{
+			name: "NotWithMultipleChildEvals",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_NOT,
+						ChildEvals: []*rpb.EvalNode{
+							&rpb.EvalNode{
+								Lhs:       "sample_lhs",
+								Operation: rpb.EvalNode_EQ,
+								Rhs:       "sample_rhs",
+							},
+							&rpb.EvalNode{
+								Lhs:       "sample_lhs",
+								Operation: rpb.EvalNode_EQ,
+								Rhs:       "sample_rhs",
+							},
+						},
+					},
+				},
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "LeafNodeWithLogicalOperation",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_NOT,
+						Lhs:       "sample_lhs",
+						Rhs:       "sample_rhs",
+					},
+				},
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "ValidRegex",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_MATCHES,
+						Lhs:       "sample_lhs:value",
+						Rhs:       "^SAP_[A-Z]+$",
+					},
+				},
+			},
+			queryNametoCols: map[string]bool{
+				"sample_lhs:value": true,
+			},
+			want: nil,
+		},
+		{
+			name: "InvalidRegex",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_MATCHES,
+						Lhs:       "sample_lhs:value",
+						Rhs:       "SAP_(",
+					},
+				},
+			},
+			queryNametoCols: map[string]bool{
+				"sample_lhs:value": true,
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "RegexReadAsKnowledgeBaseKey",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_MATCHES,
+						Lhs:       "sample_lhs:value",
+						Rhs:       "host:port",
+					},
+				},
+			},
+			queryNametoCols: map[string]bool{
+				"sample_lhs:value": true,
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "EscapedRegexWithColon",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_MATCHES,
+						Lhs:       "sample_lhs:value",
+						Rhs:       "(?:host:port)",
+					},
+				},
+			},
+			queryNametoCols: map[string]bool{
+				"sample_lhs:value": true,
+			},
+			want: nil,
+		},
+		{
+			name: "ValidAggregate",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_GT,
+						Lhs:       "max(sample_lhs:value)",
+						Rhs:       "sum(sample_rhs:value)",
+					},
+				},
+			},
+			queryNametoCols: map[string]bool{
+				"sample_lhs:value": true,
+			},
+			globalKB: map[string]bool{
+				"sample_rhs:value": true,
+			},
+			want: nil,
+		},
+		{
+			name: "InvalidAggregateColumn",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_GT,
+						Lhs:       "min(sample_lhs:value)",
+						Rhs:       "1",
+					},
+				},
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "QuantifierInRHS",
+			recomms: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id: "r_sap_hana_internal_support_role",
+					Trigger: &rpb.EvalNode{
+						Operation: rpb.EvalNode_LT,
+						Lhs:       "1",
+						Rhs:       "all(sample_rhs:value)",
+					},
+				},
+			},
+			queryNametoCols: map[string]bool{
+				"sample_rhs:value": true,
+			},
+			want: cmpopts.AnyError,
+		},
 	}
 
 	for _, tc := range tests {
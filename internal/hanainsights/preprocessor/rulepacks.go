@@ -0,0 +1,179 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package preprocessor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/iterator"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	s "cloud.google.com/go/storage"
+	rpb "github.com/GoogleCloudPlatform/sapagent/protos/hanainsights"
+)
+
+const gcsPrefix = "gs://"
+
+type (
+	// ReadGCSFunc returns the content of the objects in bucket whose name starts with prefix, by object name.
+	ReadGCSFunc func(ctx context.Context, bucket, prefix string) (map[string][]byte, error)
+
+	// RulePackOptions describes the custom rule packs read in addition to the built-in rules.
+	RulePackOptions struct {
+		// RulePaths are rule files, directories of rule files or gs://<bucket>/<prefix> paths.
+		RulePaths []string
+		// DisabledRuleIDs are the IDs of built-in rules which are skipped.
+		DisabledRuleIDs []string
+		ReadFile        func(string) ([]byte, error)
+		WalkDir         func(string, fs.WalkDirFunc) error
+		ReadGCS         ReadGCSFunc
+	}
+)
+
+// ReadRulesWithPacks returns the built-in rules which are not disabled followed by the rules
+// read from the custom rule packs, preprocessed the same way as by ReadRules.
+// Custom rules may use the built-in knowledge base, but not reuse the ID of another rule.
+func ReadRulesWithPacks(ctx context.Context, opts RulePackOptions) ([]*rpb.Rule, error) {
+	if opts.ReadFile == nil {
+		opts.ReadFile = os.ReadFile
+	}
+	if opts.WalkDir == nil {
+		opts.WalkDir = filepath.WalkDir
+	}
+	if opts.ReadGCS == nil {
+		opts.ReadGCS = readGCS
+	}
+	r := newRuleReader(opts.DisabledRuleIDs)
+	for _, filename := range RuleFilenames {
+		c, err := rulesDir.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("could not read built-in rule file %s: %v", filename, err)
+		}
+		if err := r.add(filename, c, false); err != nil {
+			return nil, fmt.Errorf("could not read built-in rule file %s: %v", filename, err)
+		}
+	}
+	for _, id := range opts.DisabledRuleIDs {
+		if !r.skipped[id] {
+			log.CtxLogger(ctx).Warnw("Cannot disable rule, it is not a built-in rule", "id", id)
+		}
+	}
+
+	for _, path := range opts.RulePaths {
+		files, err := readRulePack(ctx, path, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			if err := r.add(f.source, f.content, true); err != nil {
+				return nil, err
+			}
+		}
+		log.CtxLogger(ctx).Infow("Read HANA Insights rule pack", "path", path, "files", len(files))
+	}
+	return r.rules, nil
+}
+
+// ruleFile is the content of a rule file in a custom rule pack.
+type ruleFile struct {
+	source  string
+	content []byte
+}
+
+// readRulePack returns the JSON rule files at path sorted by name.
+func readRulePack(ctx context.Context, path string, opts RulePackOptions) ([]ruleFile, error) {
+	var files []ruleFile
+	if strings.HasPrefix(path, gcsPrefix) {
+		bucket, prefix, _ := strings.Cut(strings.TrimPrefix(path, gcsPrefix), "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("invalid rule pack path %s, expected gs://<bucket>/<prefix>", path)
+		}
+		objects, err := opts.ReadGCS(ctx, bucket, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("could not read rule pack %s: %v", path, err)
+		}
+		for name, content := range objects {
+			if strings.HasSuffix(name, ".json") {
+				files = append(files, ruleFile{source: gcsPrefix + bucket + "/" + name, content: content})
+			}
+		}
+	} else {
+		err := opts.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// Files given explicitly are read whatever their extension.
+			if d.IsDir() || (p != path && filepath.Ext(p) != ".json") {
+				return nil
+			}
+			content, err := opts.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			files = append(files, ruleFile{source: p, content: content})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not read rule pack %s: %v", path, err)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].source < files[j].source })
+	return files, nil
+}
+
+// readGCS reads the objects under prefix in the bucket using the default credentials.
+func readGCS(ctx context.Context, bucket, prefix string) (map[string][]byte, error) {
+	client, err := s.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	bh := client.Bucket(bucket)
+	objects := make(map[string][]byte)
+	it := bh.Objects(ctx, &s.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasSuffix(attrs.Name, ".json") {
+			continue
+		}
+		r, err := bh.Object(attrs.Name).NewReader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+		objects[attrs.Name] = content
+	}
+	return objects, nil
+}
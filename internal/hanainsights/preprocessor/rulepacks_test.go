@@ -0,0 +1,261 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package preprocessor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	customRule = `{
+  "id": "r_custom_dba_check",
+  "queries": [
+    {
+      "name": "q_dba_users",
+      "sql": "SELECT COUNT(*) as count FROM SYS.USERS WHERE USER_NAME LIKE 'DBA%'",
+      "columns": ["count"]
+    }
+  ],
+  "recommendations": [
+    {
+      "id": "rec_1",
+      "trigger": {"lhs": "q_dba_users:count", "operation": "GT", "rhs": "0"}
+    }
+  ]
+}`
+	customRuleUsingKB = `{
+  "id": "r_custom_kb_check",
+  "recommendations": [
+    {
+      "id": "rec_1",
+      "trigger": {"lhs": "count(q_system_usage:value)", "operation": "EQ", "rhs": "Production"}
+    }
+  ]
+}`
+	collidingRule = `{
+  "id": "r_backup_encryption",
+  "queries": [{"name": "q", "sql": "SELECT 1 as one FROM DUMMY", "columns": ["one"]}]
+}`
+	invalidCustomRule = `{
+  "id": "r_custom_invalid",
+  "queries": [{"name": "q", "sql": "SELECT 1 FROM DUMMY", "columns": ["one"]}]
+}`
+	writingCustomRule = `{
+  "id": "r_custom_writing",
+  "queries": [{"name": "q", "sql": "SELECT 1 as one FROM DUMMY; DROP TABLE USERS", "columns": ["one"]}]
+}`
+)
+
+func writeRules(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func ruleIDs(t *testing.T, opts RulePackOptions) (map[string]bool, error) {
+	t.Helper()
+	rules, err := ReadRulesWithPacks(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool)
+	for _, r := range rules {
+		ids[r.GetId()] = true
+	}
+	return ids, nil
+}
+
+func TestReadRulesWithPacks(t *testing.T) {
+	builtIn, err := ReadRules(RuleFilenames)
+	if err != nil {
+		t.Fatalf("ReadRules(RuleFilenames) failed: %v", err)
+	}
+	dir := writeRules(t, map[string]string{
+		"dba/custom.json":     customRule,
+		"dba/kb.json":         customRuleUsingKB,
+		"dba/README.md":       "not a rule",
+		"invalid/rule.json":   invalidCustomRule,
+		"writing/rule.json":   writingCustomRule,
+		"collision/rule.json": collidingRule,
+	})
+
+	tests := []struct {
+		name      string
+		opts      RulePackOptions
+		wantCount int
+		wantIDs   []string
+		wantNoIDs []string
+		wantErr   bool
+	}{
+		{
+			name:      "BuiltInOnly",
+			wantCount: len(builtIn),
+		},
+		{
+			name:      "DisabledRules",
+			opts:      RulePackOptions{DisabledRuleIDs: []string{"r_backup_encryption", "r_log_encryption", "knowledgebase", "r_unknown"}},
+			wantCount: len(builtIn) - 2,
+			wantIDs:   []string{"knowledgebase"},
+			wantNoIDs: []string{"r_backup_encryption", "r_log_encryption"},
+		},
+		{
+			name:      "Directory",
+			opts:      RulePackOptions{RulePaths: []string{filepath.Join(dir, "dba")}},
+			wantCount: len(builtIn) + 2,
+			wantIDs:   []string{"r_custom_dba_check", "r_custom_kb_check"},
+		},
+		{
+			name:      "SingleFile",
+			opts:      RulePackOptions{RulePaths: []string{filepath.Join(dir, "dba", "custom.json")}},
+			wantCount: len(builtIn) + 1,
+			wantIDs:   []string{"r_custom_dba_check"},
+		},
+		{
+			name: "GCS",
+			opts: RulePackOptions{
+				RulePaths: []string{"gs://dba-bucket/hana/rules"},
+				ReadGCS: func(ctx context.Context, bucket, prefix string) (map[string][]byte, error) {
+					if bucket != "dba-bucket" || prefix != "hana/rules" {
+						return nil, errors.New("unexpected object path")
+					}
+					return map[string][]byte{"hana/rules/custom.json": []byte(customRule), "hana/rules/notes.txt": []byte("notes")}, nil
+				},
+			},
+			wantCount: len(builtIn) + 1,
+			wantIDs:   []string{"r_custom_dba_check"},
+		},
+		{
+			name:    "InvalidRule",
+			opts:    RulePackOptions{RulePaths: []string{filepath.Join(dir, "dba"), filepath.Join(dir, "invalid")}},
+			wantErr: true,
+		},
+		{
+			name:    "QueryNotReadOnly",
+			opts:    RulePackOptions{RulePaths: []string{filepath.Join(dir, "writing")}},
+			wantErr: true,
+		},
+		{
+			name:    "CollisionWithBuiltInRule",
+			opts:    RulePackOptions{RulePaths: []string{filepath.Join(dir, "collision")}},
+			wantErr: true,
+		},
+		{
+			name: "CollisionWithDisabledBuiltInRule",
+			opts: RulePackOptions{
+				RulePaths:       []string{filepath.Join(dir, "collision")},
+				DisabledRuleIDs: []string{"r_backup_encryption"},
+			},
+			wantCount: len(builtIn),
+			wantIDs:   []string{"r_backup_encryption"},
+		},
+		{
+			name:    "CollisionBetweenPacks",
+			opts:    RulePackOptions{RulePaths: []string{filepath.Join(dir, "dba"), filepath.Join(dir, "dba", "custom.json")}},
+			wantErr: true,
+		},
+		{
+			name:    "MissingDirectory",
+			opts:    RulePackOptions{RulePaths: []string{filepath.Join(dir, "missing")}},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidGCSPath",
+			opts:    RulePackOptions{RulePaths: []string{"gs://"}},
+			wantErr: true,
+		},
+		{
+			name: "GCSError",
+			opts: RulePackOptions{
+				RulePaths: []string{"gs://dba-bucket"},
+				ReadGCS: func(ctx context.Context, bucket, prefix string) (map[string][]byte, error) {
+					return nil, errors.New("permission denied")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "InvalidJSON",
+			opts: RulePackOptions{
+				RulePaths: []string{"gs://dba-bucket"},
+				ReadGCS: func(ctx context.Context, bucket, prefix string) (map[string][]byte, error) {
+					return map[string][]byte{"bad.json": []byte("{")}, nil
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ruleIDs(t, tc.opts)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ReadRulesWithPacks(%v) error = %v, want error: %t", tc.opts, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if len(got) != tc.wantCount {
+				t.Errorf("ReadRulesWithPacks(%v) returned %d rules, want %d", tc.opts, len(got), tc.wantCount)
+			}
+			for _, id := range tc.wantIDs {
+				if !got[id] {
+					t.Errorf("ReadRulesWithPacks(%v) did not return rule %s", tc.opts, id)
+				}
+			}
+			for _, id := range tc.wantNoIDs {
+				if got[id] {
+					t.Errorf("ReadRulesWithPacks(%v) returned rule %s", tc.opts, id)
+				}
+			}
+		})
+	}
+}
+
+func TestReadRulePackOrder(t *testing.T) {
+	dir := writeRules(t, map[string]string{
+		"b.json":     customRuleUsingKB,
+		"a/z.json":   customRule,
+		"c.JSON.bak": customRule,
+	})
+	files, err := readRulePack(context.Background(), dir, RulePackOptions{ReadFile: os.ReadFile, WalkDir: filepath.WalkDir})
+	if err != nil {
+		t.Fatalf("readRulePack(%s) failed: %v", dir, err)
+	}
+	var got []string
+	for _, f := range files {
+		got = append(got, f.source)
+	}
+	want := []string{filepath.Join(dir, "a", "z.json"), filepath.Join(dir, "b.json")}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("readRulePack(%s) returned diff (-want +got):\n%s", dir, diff)
+	}
+}
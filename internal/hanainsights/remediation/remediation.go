@@ -0,0 +1,422 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+// Package remediation runs the statements of the actions of triggered HANA Insights
+// recommendations, and records them in an undo journal to roll them back later.
+//
+// Statements and rollback statements may reference the values read by the queries of the
+// rule before the remediation as {{query_name:column_name}}, which allows rollback
+// statements to restore the previous parameter values.
+package remediation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/ruleengine"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	rpb "github.com/GoogleCloudPlatform/sapagent/protos/hanainsights"
+)
+
+// DefaultJournalDir is where the undo journals of the remediation runs are stored.
+const DefaultJournalDir = "/var/lib/google-cloud-sap-agent/hanainsights/remediation"
+
+const runIDFormat = "20060102T150405Z"
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{\{\s*(\w+:\w+)\s*\}\}`)
+	runIDPattern       = regexp.MustCompile(`^\d{8}T\d{6}Z(-\d+)?$`)
+	// unsetPattern matches a parameter assignment SET ('section', 'key') = '{{query_name:column_name}}'.
+	unsetPattern = regexp.MustCompile(`(?i)\bSET\s*(\([^()]*\))\s*=\s*'\{\{\s*(\w+:\w+)\s*\}\}'`)
+)
+
+type (
+	// Executor runs statements on the database in order, stopping at the first error.
+	// ALTER SYSTEM statements commit implicitly, so the statements which ran before a failed
+	// one stay applied and are undone through the journal.
+	Executor interface {
+		Exec(ctx context.Context, statements ...string) error
+	}
+
+	// EvaluateFunc executes a rule and returns its validation results along with the values
+	// read from the database by <query_name:column_name>.
+	EvaluateFunc func(ctx context.Context, rule *rpb.Rule) ([]ruleengine.ValidationResult, map[string][]string, error)
+
+	// ConfirmFunc asks the user whether to proceed, returning true if they agree.
+	ConfirmFunc func(prompt string) bool
+
+	// Journal records the statements run by a remediation so they can be rolled back.
+	Journal struct {
+		RunID    string    `json:"run_id"`
+		SID      string    `json:"sid"`
+		Database string    `json:"database"`
+		Started  time.Time `json:"started"`
+		Entries  []Entry   `json:"entries"`
+	}
+
+	// Entry is an action run for a recommendation. An action runs one statement per row of
+	// the values it references, and has one rollback statement per row as well.
+	Entry struct {
+		RuleID           string              `json:"rule_id"`
+		RecommendationID string              `json:"recommendation_id"`
+		Action           string              `json:"action"`
+		Statements       []string            `json:"statements"`
+		Rollbacks        []string            `json:"rollbacks,omitempty"`
+		Previous         map[string][]string `json:"previous,omitempty"`
+		Applied          time.Time           `json:"applied"`
+		Error            string              `json:"error,omitempty"`
+		Cleared          bool                `json:"cleared"`
+		RolledBack       bool                `json:"rolled_back"`
+	}
+
+	// Remediator runs and rolls back the action statements of HANA Insights recommendations.
+	Remediator struct {
+		Executor Executor
+		Evaluate EvaluateFunc
+		// AllowedRules are run without confirmation. If empty, Confirm is asked for every statement.
+		AllowedRules map[string]bool
+		Confirm      ConfirmFunc
+		Out          io.Writer
+		SID          string
+		// Database identifies the connected database as host:port/database_name. Runs are only
+		// rolled back against the database they were made against.
+		Database   string
+		JournalDir string
+		Now        func() time.Time
+		ReadFile   func(string) ([]byte, error)
+		WriteFile  func(string, []byte, os.FileMode) error
+		MkdirAll   func(string, os.FileMode) error
+	}
+)
+
+// Remediate runs the action statements of the recommendations triggered in insights, after
+// confirmation, and checks that each recommendation is cleared afterwards.
+// It returns the journal of the run, which has no entries if nothing was run.
+func (r *Remediator) Remediate(ctx context.Context, rules []*rpb.Rule, insights ruleengine.Insights) (*Journal, error) {
+	r.setDefaults()
+	started := r.Now().UTC()
+	journal := &Journal{RunID: r.newRunID(started), SID: r.SID, Database: r.Database, Started: started}
+	for _, rule := range rules {
+		if len(r.AllowedRules) > 0 && !r.AllowedRules[rule.GetId()] {
+			continue
+		}
+		recs := triggered(insights[rule.GetId()])
+		for _, rec := range rule.GetRecommendations() {
+			if !recs[rec.GetId()] || !hasStatements(rec) {
+				continue
+			}
+			if err := r.remediate(ctx, journal, rule, rec); err != nil {
+				return journal, err
+			}
+		}
+	}
+	return journal, nil
+}
+
+// remediate runs the action statements of a recommendation and re-runs its rule to confirm it cleared.
+func (r *Remediator) remediate(ctx context.Context, journal *Journal, rule *rpb.Rule, rec *rpb.Recommendation) error {
+	results, previous, err := r.Evaluate(ctx, rule)
+	if err != nil {
+		return fmt.Errorf("evaluating rule %s: %v", rule.GetId(), err)
+	}
+	if !triggered(results)[rec.GetId()] {
+		fmt.Fprintf(r.Out, "Rule %s recommendation %s is no longer triggered, skipping.\n", rule.GetId(), rec.GetId())
+		return nil
+	}
+
+	first := len(journal.Entries)
+	for _, action := range rec.GetActions() {
+		if action.GetStatement() == "" {
+			continue
+		}
+		statements, err := resolve(action.GetStatement(), previous, false)
+		if err != nil {
+			return fmt.Errorf("rule %s recommendation %s statement: %v", rule.GetId(), rec.GetId(), err)
+		}
+		rollbacks, err := resolve(action.GetRollback(), previous, true)
+		if err != nil {
+			return fmt.Errorf("rule %s recommendation %s rollback: %v", rule.GetId(), rec.GetId(), err)
+		}
+		fmt.Fprintf(r.Out, "\nRule %s recommendation %s: %s\n", rule.GetId(), rec.GetId(), action.GetDescription())
+		for _, statement := range statements {
+			fmt.Fprintf(r.Out, "  SQL: %s\n", statement)
+		}
+		for _, rollback := range rollbacks {
+			fmt.Fprintf(r.Out, "  Rollback SQL: %s\n", rollback)
+		}
+		if len(rollbacks) == 0 {
+			fmt.Fprintln(r.Out, "  This action has no rollback and cannot be undone.")
+		}
+		if !r.approved(rule.GetId()) {
+			fmt.Fprintln(r.Out, "  Skipped.")
+			continue
+		}
+		entry := Entry{
+			RuleID:           rule.GetId(),
+			RecommendationID: rec.GetId(),
+			Action:           action.GetName(),
+			Statements:       statements,
+			Rollbacks:        rollbacks,
+			Previous:         previous,
+			Applied:          r.Now().UTC(),
+		}
+		// A failed statement does not undo the ones which ran before it, so the action is
+		// journalled either way.
+		execErr := r.Executor.Exec(ctx, statements...)
+		if execErr != nil {
+			entry.Error = execErr.Error()
+		}
+		journal.Entries = append(journal.Entries, entry)
+		// Persist the journal after every action so that a failure later in the run can still be rolled back.
+		if err := r.writeJournal(journal); err != nil {
+			return err
+		}
+		if execErr != nil {
+			return fmt.Errorf("running statements for rule %s recommendation %s: %v, statements which ran before the failure can be rolled back with -rollback=%s", rule.GetId(), rec.GetId(), execErr, journal.RunID)
+		}
+		log.CtxLogger(ctx).Infow("Applied HANA Insights remediation", "runID", journal.RunID, "rule", rule.GetId(), "recommendation", rec.GetId())
+	}
+	if len(journal.Entries) == first {
+		return nil
+	}
+
+	results, _, err = r.Evaluate(ctx, rule)
+	cleared := err == nil && !triggered(results)[rec.GetId()]
+	for i := first; i < len(journal.Entries); i++ {
+		journal.Entries[i].Cleared = cleared
+	}
+	switch {
+	case err != nil:
+		fmt.Fprintf(r.Out, "  Could not re-run rule %s to confirm the remediation: %v\n", rule.GetId(), err)
+	case !cleared:
+		fmt.Fprintf(r.Out, "  Recommendation %s is still triggered, it can be rolled back with -rollback=%s\n", rec.GetId(), journal.RunID)
+	default:
+		fmt.Fprintf(r.Out, "  Recommendation %s cleared.\n", rec.GetId())
+	}
+	return r.writeJournal(journal)
+}
+
+// Rollback runs the rollback statements of the remediation run runID in reverse order,
+// after confirmation. Actions without a rollback are reported and left in place. The run
+// must have been made against the same database as the remediator.
+func (r *Remediator) Rollback(ctx context.Context, runID string) (*Journal, error) {
+	r.setDefaults()
+	if !runIDPattern.MatchString(runID) {
+		return nil, fmt.Errorf("invalid run ID %q, expected the format %s", runID, runIDFormat)
+	}
+	data, err := r.ReadFile(r.journalPath(runID))
+	if err != nil {
+		return nil, fmt.Errorf("reading journal of run %s: %v", runID, err)
+	}
+	journal := &Journal{}
+	if err := json.Unmarshal(data, journal); err != nil {
+		return nil, fmt.Errorf("parsing journal of run %s: %v", runID, err)
+	}
+	if journal.Database == "" || !strings.EqualFold(journal.Database, r.Database) {
+		return nil, fmt.Errorf("run %s was made against database %q, not the connected database %q", runID, journal.Database, r.Database)
+	}
+	var pending []*Entry
+	for i := len(journal.Entries) - 1; i >= 0; i-- {
+		e := &journal.Entries[i]
+		if e.RolledBack {
+			continue
+		}
+		if len(e.Rollbacks) == 0 {
+			fmt.Fprintf(r.Out, "Rule %s recommendation %s has no rollback statement, leaving in place: %s\n", e.RuleID, e.RecommendationID, strings.Join(e.Statements, "; "))
+			continue
+		}
+		fmt.Fprintf(r.Out, "Rule %s recommendation %s rollback:\n", e.RuleID, e.RecommendationID)
+		for _, rollback := range e.Rollbacks {
+			fmt.Fprintf(r.Out, "  SQL: %s\n", rollback)
+		}
+		pending = append(pending, e)
+	}
+	if len(pending) == 0 {
+		fmt.Fprintf(r.Out, "Nothing to roll back for run %s.\n", runID)
+		return journal, nil
+	}
+	if r.Confirm == nil || !r.Confirm("Run these rollback statements? [y/N]: ") {
+		return journal, fmt.Errorf("rollback of run %s was not confirmed", runID)
+	}
+	for _, e := range pending {
+		if err := r.Executor.Exec(ctx, e.Rollbacks...); err != nil {
+			return journal, fmt.Errorf("rolling back rule %s recommendation %s: %v", e.RuleID, e.RecommendationID, err)
+		}
+		e.RolledBack = true
+		if err := r.writeJournal(journal); err != nil {
+			return journal, err
+		}
+	}
+	return journal, nil
+}
+
+func (r *Remediator) setDefaults() {
+	if r.Out == nil {
+		r.Out = os.Stdout
+	}
+	if r.JournalDir == "" {
+		r.JournalDir = DefaultJournalDir
+	}
+	if r.Now == nil {
+		r.Now = time.Now
+	}
+	if r.ReadFile == nil {
+		r.ReadFile = os.ReadFile
+	}
+	if r.WriteFile == nil {
+		r.WriteFile = os.WriteFile
+	}
+	if r.MkdirAll == nil {
+		r.MkdirAll = os.MkdirAll
+	}
+}
+
+// approved reports whether the statements of the rule may run.
+func (r *Remediator) approved(ruleID string) bool {
+	if len(r.AllowedRules) > 0 {
+		return r.AllowedRules[ruleID]
+	}
+	return r.Confirm != nil && r.Confirm("  Run this statement? [y/N]: ")
+}
+
+// newRunID returns the ID of a run started at started. Runs started in the same second
+// get a numbered suffix so they do not overwrite each other's journal.
+func (r *Remediator) newRunID(started time.Time) string {
+	base := started.Format(runIDFormat)
+	runID := base
+	for i := 2; ; i++ {
+		if _, err := r.ReadFile(r.journalPath(runID)); err != nil {
+			return runID
+		}
+		runID = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+func (r *Remediator) journalPath(runID string) string {
+	return filepath.Join(r.JournalDir, runID+".json")
+}
+
+func (r *Remediator) writeJournal(journal *Journal) error {
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling journal of run %s: %v", journal.RunID, err)
+	}
+	if err := r.MkdirAll(r.JournalDir, 0700); err != nil {
+		return fmt.Errorf("creating journal directory: %v", err)
+	}
+	if err := r.WriteFile(r.journalPath(journal.RunID), data, 0600); err != nil {
+		return fmt.Errorf("writing journal of run %s: %v", journal.RunID, err)
+	}
+	return nil
+}
+
+// resolve expands statement into one statement per row of the values it references as
+// {{query_name:column_name}}, so that parameters read per host or layer are all covered.
+// References with a single value are used for every row, and identical statements are
+// only returned once. Single quotes are doubled as references are meant to be used in
+// string literals.
+//
+// In rollback statements, an assignment SET (...) = '{{reference}}' whose reference read no
+// value is turned into UNSET (...), which restores the default of the parameter.
+func resolve(statement string, kb map[string][]string, rollback bool) ([]string, error) {
+	if statement == "" {
+		return nil, nil
+	}
+	rows := 1
+	for _, m := range placeholderPattern.FindAllStringSubmatch(statement, -1) {
+		switch n := len(kb[m[1]]); {
+		case n == 0:
+			return nil, fmt.Errorf("no value read for %s", m[1])
+		case n == 1 || n == rows:
+		case rows == 1:
+			rows = n
+		default:
+			return nil, fmt.Errorf("%s read %d values, which does not match the %d values of the other references", m[1], n, rows)
+		}
+	}
+	var statements []string
+	seen := make(map[string]bool)
+	for row := 0; row < rows; row++ {
+		s, err := resolveRow(statement, kb, row, rollback)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[s] {
+			seen[s] = true
+			statements = append(statements, s)
+		}
+	}
+	return statements, nil
+}
+
+// resolveRow replaces the references in statement with the values of row.
+func resolveRow(statement string, kb map[string][]string, row int, rollback bool) (string, error) {
+	value := func(key string) string {
+		if values := kb[key]; len(values) > 1 {
+			return values[row]
+		}
+		return kb[key][0]
+	}
+	if rollback {
+		statement = unsetPattern.ReplaceAllStringFunc(statement, func(m string) string {
+			sub := unsetPattern.FindStringSubmatch(m)
+			if value(sub[2]) != "nilstring" {
+				return m
+			}
+			return "UNSET " + sub[1]
+		})
+	}
+	var err error
+	resolved := placeholderPattern.ReplaceAllStringFunc(statement, func(m string) string {
+		key := placeholderPattern.FindStringSubmatch(m)[1]
+		v := value(key)
+		if v == "nilstring" {
+			err = fmt.Errorf("no value read for %s", key)
+			return m
+		}
+		return strings.ReplaceAll(v, "'", "''")
+	})
+	return resolved, err
+}
+
+// triggered returns the IDs of the recommendations which evaluated to true.
+func triggered(results []ruleengine.ValidationResult) map[string]bool {
+	ids := make(map[string]bool)
+	for _, vr := range results {
+		if vr.Result {
+			ids[vr.RecommendationID] = true
+		}
+	}
+	return ids
+}
+
+func hasStatements(rec *rpb.Recommendation) bool {
+	for _, a := range rec.GetActions() {
+		if a.GetStatement() != "" {
+			return true
+		}
+	}
+	return false
+}
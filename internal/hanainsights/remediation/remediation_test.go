@@ -0,0 +1,515 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package remediation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/ruleengine"
+
+	rpb "github.com/GoogleCloudPlatform/sapagent/protos/hanainsights"
+)
+
+const (
+	setStatement      = "ALTER SYSTEM ALTER CONFIGURATION ('indexserver.ini', 'SYSTEM') SET ('indexing', 'parallel_merge_threads') = '8' WITH RECONFIGURE"
+	rollbackStatement = "ALTER SYSTEM ALTER CONFIGURATION ('indexserver.ini', 'SYSTEM') SET ('indexing', 'parallel_merge_threads') = '{{q_threads:VALUE}}' WITH RECONFIGURE"
+	resolvedRollback  = "ALTER SYSTEM ALTER CONFIGURATION ('indexserver.ini', 'SYSTEM') SET ('indexing', 'parallel_merge_threads') = '2' WITH RECONFIGURE"
+)
+
+var (
+	testTime = time.Date(2026, 10, 17, 10, 15, 0, 0, time.UTC)
+
+	testRule = &rpb.Rule{
+		Id: "r_parallel_merge_threads",
+		Recommendations: []*rpb.Recommendation{
+			{
+				Id: "rec_1",
+				Actions: []*rpb.Action{
+					{Name: "set_threads", Description: "Increase parallel_merge_threads.", Statement: setStatement, Rollback: rollbackStatement},
+					{Description: "Monitor the CPU usage."},
+				},
+			},
+			{
+				Id:      "rec_2",
+				Actions: []*rpb.Action{{Description: "No statement."}},
+			},
+		},
+	}
+	noRollbackRule = &rpb.Rule{
+		Id: "r_no_rollback",
+		Recommendations: []*rpb.Recommendation{
+			{Id: "rec_1", Actions: []*rpb.Action{{Statement: "ALTER SYSTEM RECLAIM LOG"}}},
+		},
+	}
+	testInsights = ruleengine.Insights{
+		"r_parallel_merge_threads": {{RecommendationID: "rec_1", Result: true}, {RecommendationID: "rec_2", Result: true}},
+		"r_no_rollback":            {{RecommendationID: "rec_1", Result: true}},
+	}
+	previousValues = map[string][]string{"q_threads:VALUE": {"2"}}
+)
+
+type fakeExecutor struct {
+	statements []string
+	err        error
+}
+
+func (f *fakeExecutor) Exec(ctx context.Context, statements ...string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.statements = append(f.statements, statements...)
+	return nil
+}
+
+// fakeEvaluate returns the rule as triggered for the first triggeredRuns evaluations.
+func fakeEvaluate(triggeredRuns int, err error) EvaluateFunc {
+	runs := 0
+	return func(ctx context.Context, rule *rpb.Rule) ([]ruleengine.ValidationResult, map[string][]string, error) {
+		if err != nil {
+			return nil, nil, err
+		}
+		runs++
+		var results []ruleengine.ValidationResult
+		for _, rec := range rule.GetRecommendations() {
+			results = append(results, ruleengine.ValidationResult{RecommendationID: rec.GetId(), Result: runs <= triggeredRuns})
+		}
+		return results, previousValues, nil
+	}
+}
+
+type fakeFS struct {
+	files map[string][]byte
+}
+
+func (f *fakeFS) writeFile(name string, data []byte, perm os.FileMode) error {
+	f.files[name] = data
+	return nil
+}
+
+func (f *fakeFS) readFile(name string) ([]byte, error) {
+	data, ok := f.files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+func newRemediator(fs *fakeFS, exec *fakeExecutor, evaluate EvaluateFunc) *Remediator {
+	return &Remediator{
+		Executor:   exec,
+		Evaluate:   evaluate,
+		Out:        &bytes.Buffer{},
+		SID:        "DEV",
+		Database:   "hanahost:30015/DEV",
+		JournalDir: "/journal",
+		Now:        func() time.Time { return testTime },
+		ReadFile:   fs.readFile,
+		WriteFile:  fs.writeFile,
+		MkdirAll:   func(string, os.FileMode) error { return nil },
+	}
+}
+
+func TestRemediate(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          []*rpb.Rule
+		allowed        map[string]bool
+		confirm        ConfirmFunc
+		evaluate       EvaluateFunc
+		execErr        error
+		wantStatements []string
+		wantEntries    []Entry
+		wantErr        error
+	}{
+		{
+			name:           "ConfirmedAndCleared",
+			rules:          []*rpb.Rule{testRule},
+			confirm:        func(string) bool { return true },
+			evaluate:       fakeEvaluate(1, nil),
+			wantStatements: []string{setStatement},
+			wantEntries: []Entry{{
+				RuleID:           "r_parallel_merge_threads",
+				RecommendationID: "rec_1",
+				Action:           "set_threads",
+				Statements:       []string{setStatement},
+				Rollbacks:        []string{resolvedRollback},
+				Previous:         previousValues,
+				Applied:          testTime,
+				Cleared:          true,
+			}},
+		},
+		{
+			name:           "StillTriggered",
+			rules:          []*rpb.Rule{noRollbackRule},
+			confirm:        func(string) bool { return true },
+			evaluate:       fakeEvaluate(2, nil),
+			wantStatements: []string{"ALTER SYSTEM RECLAIM LOG"},
+			wantEntries: []Entry{{
+				RuleID:           "r_no_rollback",
+				RecommendationID: "rec_1",
+				Statements:       []string{"ALTER SYSTEM RECLAIM LOG"},
+				Previous:         previousValues,
+				Applied:          testTime,
+			}},
+		},
+		{
+			name:     "Declined",
+			rules:    []*rpb.Rule{testRule, noRollbackRule},
+			confirm:  func(string) bool { return false },
+			evaluate: fakeEvaluate(1, nil),
+		},
+		{
+			name:     "NoConfirmationWithoutAllowList",
+			rules:    []*rpb.Rule{testRule},
+			evaluate: fakeEvaluate(1, nil),
+		},
+		{
+			name:           "AllowList",
+			rules:          []*rpb.Rule{testRule, noRollbackRule},
+			allowed:        map[string]bool{"r_no_rollback": true},
+			confirm:        func(string) bool { return true },
+			evaluate:       fakeEvaluate(1, nil),
+			wantStatements: []string{"ALTER SYSTEM RECLAIM LOG"},
+			wantEntries: []Entry{{
+				RuleID:           "r_no_rollback",
+				RecommendationID: "rec_1",
+				Statements:       []string{"ALTER SYSTEM RECLAIM LOG"},
+				Previous:         previousValues,
+				Applied:          testTime,
+				Cleared:          true,
+			}},
+		},
+		{
+			name:     "NoLongerTriggered",
+			rules:    []*rpb.Rule{testRule},
+			confirm:  func(string) bool { return true },
+			evaluate: fakeEvaluate(0, nil),
+		},
+		{
+			name:     "EvaluateError",
+			rules:    []*rpb.Rule{testRule},
+			confirm:  func(string) bool { return true },
+			evaluate: fakeEvaluate(0, errors.New("connection lost")),
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "ExecError",
+			rules:    []*rpb.Rule{testRule},
+			confirm:  func(string) bool { return true },
+			evaluate: fakeEvaluate(1, nil),
+			execErr:  errors.New("insufficient privilege"),
+			wantEntries: []Entry{{
+				RuleID:           "r_parallel_merge_threads",
+				RecommendationID: "rec_1",
+				Action:           "set_threads",
+				Statements:       []string{setStatement},
+				Rollbacks:        []string{resolvedRollback},
+				Previous:         previousValues,
+				Applied:          testTime,
+				Error:            "insufficient privilege",
+			}},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := &fakeFS{files: make(map[string][]byte)}
+			exec := &fakeExecutor{err: tc.execErr}
+			r := newRemediator(fs, exec, tc.evaluate)
+			r.AllowedRules = tc.allowed
+			r.Confirm = tc.confirm
+
+			got, err := r.Remediate(context.Background(), tc.rules, testInsights)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Remediate() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantStatements, exec.statements); diff != "" {
+				t.Errorf("Remediate() ran statements diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEntries, got.Entries); diff != "" {
+				t.Errorf("Remediate() journal entries diff (-want +got):\n%s", diff)
+			}
+			_, written := fs.files["/journal/20261017T101500Z.json"]
+			if wantWritten := len(tc.wantEntries) > 0; written != wantWritten {
+				t.Errorf("Remediate() wrote journal: %t, want %t", written, wantWritten)
+			}
+		})
+	}
+}
+
+func TestRollback(t *testing.T) {
+	entries := []Entry{
+		{RuleID: "r_a", RecommendationID: "rec_1", Statements: []string{"SET A1", "SET A2"}, Rollbacks: []string{"UNSET A1", "UNSET A2"}},
+		{RuleID: "r_b", RecommendationID: "rec_1", Statements: []string{"SET B"}},
+		{RuleID: "r_c", RecommendationID: "rec_1", Statements: []string{"SET C"}, Rollbacks: []string{"UNSET C"}},
+		{RuleID: "r_d", RecommendationID: "rec_1", Statements: []string{"SET D"}, Rollbacks: []string{"UNSET D"}, RolledBack: true},
+	}
+	marshal := func(j *Journal) []byte {
+		data, err := json.Marshal(j)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	data := marshal(&Journal{RunID: "20261017T101500Z", SID: "DEV", Database: "hanahost:30015/DEV", Entries: entries})
+	// Tenants of the same system share the SID, so the database must match as well.
+	otherTenant := marshal(&Journal{RunID: "20261017T101500Z", SID: "DEV", Database: "hanahost:30015/TENANT2", Entries: entries})
+	noDatabase := marshal(&Journal{RunID: "20261017T101500Z", SID: "DEV", Entries: entries})
+	nothingPending := marshal(&Journal{RunID: "20261017T101500Z", SID: "DEV", Database: "hanahost:30015/DEV", Entries: entries[1:2]})
+	confirmed := func(string) bool { return true }
+	tests := []struct {
+		name           string
+		runID          string
+		files          map[string][]byte
+		confirm        ConfirmFunc
+		execErr        error
+		wantStatements []string
+		wantRolledBack []bool
+		wantErr        error
+	}{
+		{
+			name:           "Success",
+			runID:          "20261017T101500Z",
+			files:          map[string][]byte{"/journal/20261017T101500Z.json": data},
+			confirm:        confirmed,
+			wantStatements: []string{"UNSET C", "UNSET A1", "UNSET A2"},
+			wantRolledBack: []bool{true, false, true, true},
+		},
+		{
+			name:           "SuffixedRunID",
+			runID:          "20261017T101500Z-2",
+			files:          map[string][]byte{"/journal/20261017T101500Z-2.json": data},
+			confirm:        confirmed,
+			wantStatements: []string{"UNSET C", "UNSET A1", "UNSET A2"},
+			wantRolledBack: []bool{true, false, true, true},
+		},
+		{
+			name:           "Declined",
+			runID:          "20261017T101500Z",
+			files:          map[string][]byte{"/journal/20261017T101500Z.json": data},
+			confirm:        func(string) bool { return false },
+			wantRolledBack: []bool{false, false, false, true},
+			wantErr:        cmpopts.AnyError,
+		},
+		{
+			name:           "NoConfirmation",
+			runID:          "20261017T101500Z",
+			files:          map[string][]byte{"/journal/20261017T101500Z.json": data},
+			wantRolledBack: []bool{false, false, false, true},
+			wantErr:        cmpopts.AnyError,
+		},
+		{
+			name:           "NothingPending",
+			runID:          "20261017T101500Z",
+			files:          map[string][]byte{"/journal/20261017T101500Z.json": nothingPending},
+			wantRolledBack: []bool{false},
+		},
+		{
+			name:    "InvalidRunID",
+			runID:   "../../etc/passwd",
+			confirm: confirmed,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "OtherTenant",
+			runID:   "20261017T101500Z",
+			files:   map[string][]byte{"/journal/20261017T101500Z.json": otherTenant},
+			confirm: confirmed,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "NoDatabaseInJournal",
+			runID:   "20261017T101500Z",
+			files:   map[string][]byte{"/journal/20261017T101500Z.json": noDatabase},
+			confirm: confirmed,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "MissingJournal",
+			runID:   "20261017T101500Z",
+			files:   map[string][]byte{},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "InvalidJournal",
+			runID:   "20261017T101500Z",
+			files:   map[string][]byte{"/journal/20261017T101500Z.json": []byte("{")},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:           "ExecError",
+			runID:          "20261017T101500Z",
+			files:          map[string][]byte{"/journal/20261017T101500Z.json": data},
+			confirm:        confirmed,
+			execErr:        errors.New("insufficient privilege"),
+			wantRolledBack: []bool{false, false, false, true},
+			wantErr:        cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := &fakeFS{files: tc.files}
+			exec := &fakeExecutor{err: tc.execErr}
+			r := newRemediator(fs, exec, nil)
+			r.Confirm = tc.confirm
+
+			got, err := r.Rollback(context.Background(), tc.runID)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Rollback(%s) error = %v, want %v", tc.runID, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantStatements, exec.statements); diff != "" {
+				t.Errorf("Rollback(%s) ran statements diff (-want +got):\n%s", tc.runID, diff)
+			}
+			var gotRolledBack []bool
+			if got != nil {
+				for _, e := range got.Entries {
+					gotRolledBack = append(gotRolledBack, e.RolledBack)
+				}
+			}
+			if diff := cmp.Diff(tc.wantRolledBack, gotRolledBack); diff != "" {
+				t.Errorf("Rollback(%s) rolled back entries diff (-want +got):\n%s", tc.runID, diff)
+			}
+		})
+	}
+}
+
+func TestNewRunID(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string][]byte
+		want  string
+	}{
+		{
+			name:  "NoJournal",
+			files: map[string][]byte{},
+			want:  "20261017T101500Z",
+		},
+		{
+			name: "JournalsInSameSecond",
+			files: map[string][]byte{
+				"/journal/20261017T101500Z.json":   []byte("{}"),
+				"/journal/20261017T101500Z-2.json": []byte("{}"),
+			},
+			want: "20261017T101500Z-3",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRemediator(&fakeFS{files: tc.files}, &fakeExecutor{}, nil)
+			if got := r.newRunID(testTime); got != tc.want {
+				t.Errorf("newRunID(%v) = %q, want %q", testTime, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	kb := map[string][]string{
+		"q:VALUE":    {"it's"},
+		"q:EMPTY":    {"nilstring"},
+		"q:HOST":     {"host1", "host2", "host3"},
+		"q:PER_HOST": {"4", "nilstring", "4"},
+		"q:PAIR":     {"a", "b"},
+	}
+	tests := []struct {
+		name      string
+		statement string
+		rollback  bool
+		want      []string
+		wantErr   error
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:      "NoReferences",
+			statement: "ALTER SYSTEM RECLAIM LOG",
+			want:      []string{"ALTER SYSTEM RECLAIM LOG"},
+		},
+		{
+			name:      "EscapesQuotes",
+			statement: "SET 'x' = '{{ q:VALUE }}'",
+			want:      []string{"SET 'x' = 'it''s'"},
+		},
+		{
+			name:      "OneStatementPerRow",
+			statement: "ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'HOST', '{{q:HOST}}') SET ('s', 'k') = '{{q:VALUE}}'",
+			want: []string{
+				"ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'HOST', 'host1') SET ('s', 'k') = 'it''s'",
+				"ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'HOST', 'host2') SET ('s', 'k') = 'it''s'",
+				"ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'HOST', 'host3') SET ('s', 'k') = 'it''s'",
+			},
+		},
+		{
+			name:      "IdenticalRowsOnce",
+			statement: "SET ('s', 'k') = '{{q:PER_HOST}}' {{q:VALUE}}",
+			rollback:  true,
+			want:      []string{"SET ('s', 'k') = '4' it''s", "UNSET ('s', 'k') it''s"},
+		},
+		{
+			name:      "RollbackUnsetsParameterWithoutValue",
+			statement: "ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'HOST', '{{q:HOST}}') SET ('s', 'k') = '{{ q:PER_HOST }}' WITH RECONFIGURE",
+			rollback:  true,
+			want: []string{
+				"ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'HOST', 'host1') SET ('s', 'k') = '4' WITH RECONFIGURE",
+				"ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'HOST', 'host2') UNSET ('s', 'k') WITH RECONFIGURE",
+				"ALTER SYSTEM ALTER CONFIGURATION ('global.ini', 'HOST', 'host3') SET ('s', 'k') = '4' WITH RECONFIGURE",
+			},
+		},
+		{
+			name:      "StatementWithoutValue",
+			statement: "SET ('s', 'k') = '{{q:EMPTY}}'",
+			wantErr:   cmpopts.AnyError,
+		},
+		{
+			name:      "RollbackWithoutValueOutsideAssignment",
+			statement: "CALL RESTORE('{{q:EMPTY}}')",
+			rollback:  true,
+			wantErr:   cmpopts.AnyError,
+		},
+		{
+			name:      "UnknownReference",
+			statement: "SET 'x' = '{{q:MISSING}}'",
+			wantErr:   cmpopts.AnyError,
+		},
+		{
+			name:      "MismatchedRows",
+			statement: "SET '{{q:HOST}}' = '{{q:PAIR}}'",
+			wantErr:   cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolve(tc.statement, kb, tc.rollback)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("resolve(%q, %t) error = %v, want %v", tc.statement, tc.rollback, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("resolve(%q, %t) returned diff (-want +got):\n%s", tc.statement, tc.rollback, diff)
+			}
+		})
+	}
+}
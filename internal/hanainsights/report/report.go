@@ -0,0 +1,335 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report builds machine readable reports of HANA Insights findings, in JSON and
+// SARIF, and compares the findings of a report with those of a previous run.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/ruleengine"
+
+	rpb "github.com/GoogleCloudPlatform/sapagent/protos/hanainsights"
+)
+
+// Report formats.
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	FormatSARIF    = "sarif"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "Agent for SAP HANA Insights"
+	toolURI      = "https://cloud.google.com/solutions/sap/docs/agent-for-sap/latest/all-guides"
+)
+
+type (
+	// Report has the findings of a HANA Insights run.
+	Report struct {
+		SID       string    `json:"sid,omitempty"`
+		Generated time.Time `json:"generated"`
+		Findings  []Finding `json:"findings"`
+	}
+
+	// Finding is a recommendation triggered by a rule.
+	Finding struct {
+		RuleID           string      `json:"rule_id"`
+		RuleName         string      `json:"rule_name,omitempty"`
+		RecommendationID string      `json:"recommendation_id"`
+		Description      string      `json:"description,omitempty"`
+		Labels           []string    `json:"labels,omitempty"`
+		Actions          []string    `json:"actions,omitempty"`
+		References       []string    `json:"references,omitempty"`
+		Conditions       []Condition `json:"conditions,omitempty"`
+	}
+
+	// Condition is a trigger condition of a finding with the values evaluated for it.
+	Condition struct {
+		LHS       string   `json:"lhs"`
+		Operation string   `json:"operation"`
+		RHS       string   `json:"rhs"`
+		LHSValues []string `json:"lhs_values,omitempty"`
+		RHSValues []string `json:"rhs_values,omitempty"`
+		Result    bool     `json:"result"`
+		// Path lists the operators above the condition in the trigger, ex: "AND > NOT".
+		Path string `json:"path,omitempty"`
+	}
+
+	// Comparison has the findings of a report classified against those of a previous report.
+	Comparison struct {
+		New       []Finding
+		Resolved  []Finding
+		Unchanged []Finding
+	}
+)
+
+// New returns the report of the recommendations triggered in insights, in the order of rules.
+func New(sid string, rules []*rpb.Rule, insights ruleengine.Insights, generated time.Time) *Report {
+	r := &Report{SID: sid, Generated: generated.UTC(), Findings: []Finding{}}
+	for _, rule := range rules {
+		recs := make(map[string]*rpb.Recommendation)
+		for _, rec := range rule.GetRecommendations() {
+			recs[rec.GetId()] = rec
+		}
+		for _, vr := range insights[rule.GetId()] {
+			rec, ok := recs[vr.RecommendationID]
+			if !vr.Result || !ok {
+				continue
+			}
+			r.Findings = append(r.Findings, newFinding(rule, rec, vr.Conditions))
+		}
+	}
+	return r
+}
+
+func newFinding(rule *rpb.Rule, rec *rpb.Recommendation, conditions []ruleengine.Condition) Finding {
+	f := Finding{
+		RuleID:           rule.GetId(),
+		RuleName:         rule.GetName(),
+		RecommendationID: rec.GetId(),
+		Description:      rec.GetDescription(),
+		Labels:           rule.GetLabels(),
+		References:       rec.GetReferences(),
+	}
+	if f.Description == "" {
+		f.Description = rule.GetDescription()
+	}
+	for _, a := range rec.GetActions() {
+		f.Actions = append(f.Actions, a.GetDescription())
+	}
+	for _, c := range conditions {
+		f.Conditions = append(f.Conditions, Condition{
+			LHS:       c.LHS,
+			Operation: c.Operation,
+			RHS:       c.RHS,
+			LHSValues: c.LHSValues,
+			RHSValues: c.RHSValues,
+			Result:    c.Result,
+			Path:      c.Path,
+		})
+	}
+	return f
+}
+
+// Key identifies the finding across runs.
+func (f Finding) Key() string {
+	return f.RuleID + "/" + f.RecommendationID
+}
+
+// JSON returns the report as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Read parses a report previously written in JSON or SARIF.
+func Read(data []byte) (*Report, error) {
+	var s sarifLog
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing report: %v", err)
+	}
+	if len(s.Runs) > 0 {
+		return s.report(), nil
+	}
+	r := &Report{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("parsing report: %v", err)
+	}
+	return r, nil
+}
+
+// Compare classifies the findings of current as new, or unchanged when they are also in
+// previous, and the findings of previous that are no longer in current as resolved.
+func Compare(previous, current *Report) Comparison {
+	var c Comparison
+	seen := make(map[string]bool)
+	for _, f := range previous.Findings {
+		seen[f.Key()] = true
+	}
+	found := make(map[string]bool)
+	for _, f := range current.Findings {
+		found[f.Key()] = true
+		if seen[f.Key()] {
+			c.Unchanged = append(c.Unchanged, f)
+		} else {
+			c.New = append(c.New, f)
+		}
+	}
+	for _, f := range previous.Findings {
+		if !found[f.Key()] {
+			c.Resolved = append(c.Resolved, f)
+		}
+	}
+	return c
+}
+
+// String returns a summary of the comparison listing the findings by status.
+func (c Comparison) String() string {
+	sb := new(strings.Builder)
+	fmt.Fprintf(sb, "%d new, %d resolved, %d unchanged findings\n", len(c.New), len(c.Resolved), len(c.Unchanged))
+	for _, s := range []struct {
+		status   string
+		findings []Finding
+	}{{"New", c.New}, {"Resolved", c.Resolved}, {"Unchanged", c.Unchanged}} {
+		if len(s.findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "%s:\n", s.status)
+		for _, f := range s.findings {
+			fmt.Fprintf(sb, "  - %s\n", f.Key())
+		}
+	}
+	return sb.String()
+}
+
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool        sarifTool         `json:"tool"`
+		Invocations []sarifInvocation `json:"invocations,omitempty"`
+		Results     []sarifResult     `json:"results"`
+		Properties  *sarifRunProps    `json:"properties,omitempty"`
+	}
+
+	sarifRunProps struct {
+		SID string `json:"sid,omitempty"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version,omitempty"`
+		InformationURI string      `json:"informationUri,omitempty"`
+		Rules          []sarifRule `json:"rules,omitempty"`
+	}
+
+	sarifRule struct {
+		ID               string         `json:"id"`
+		Name             string         `json:"name,omitempty"`
+		ShortDescription *sarifMessage  `json:"shortDescription,omitempty"`
+		HelpURI          string         `json:"helpUri,omitempty"`
+		Properties       *sarifRuleTags `json:"properties,omitempty"`
+	}
+
+	sarifRuleTags struct {
+		Tags []string `json:"tags,omitempty"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifInvocation struct {
+		EndTimeUTC          time.Time `json:"endTimeUtc"`
+		ExecutionSuccessful bool      `json:"executionSuccessful"`
+	}
+
+	sarifResult struct {
+		RuleID     string       `json:"ruleId"`
+		Level      string       `json:"level"`
+		Message    sarifMessage `json:"message"`
+		Properties Finding      `json:"properties"`
+	}
+)
+
+// SARIF returns the report as a SARIF 2.1.0 log. Each finding is a result of its rule,
+// with the finding itself as the result properties.
+func (r *Report) SARIF() ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			Version:        configuration.AgentVersion,
+			InformationURI: toolURI,
+		}},
+		Invocations: []sarifInvocation{{EndTimeUTC: r.Generated, ExecutionSuccessful: true}},
+		Results:     []sarifResult{},
+	}
+	if r.SID != "" {
+		run.Properties = &sarifRunProps{SID: r.SID}
+	}
+	rules := make(map[string]bool)
+	for _, f := range r.Findings {
+		if !rules[f.RuleID] {
+			rules[f.RuleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRuleOf(f))
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:     f.RuleID,
+			Level:      "warning",
+			Message:    sarifMessage{Text: message(f)},
+			Properties: f,
+		})
+	}
+	return json.MarshalIndent(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}, "", "  ")
+}
+
+func sarifRuleOf(f Finding) sarifRule {
+	rule := sarifRule{ID: f.RuleID, Name: f.RuleName}
+	if len(f.Labels) > 0 {
+		rule.Properties = &sarifRuleTags{Tags: f.Labels}
+	}
+	if len(f.References) > 0 {
+		rule.HelpURI = f.References[0]
+	}
+	return rule
+}
+
+// message describes the finding along with its actions.
+func message(f Finding) string {
+	text := f.Description
+	if text == "" {
+		text = fmt.Sprintf("Recommendation %s of rule %s was triggered.", f.RecommendationID, f.RuleID)
+	}
+	if len(f.Actions) > 0 {
+		text += " Actions: " + strings.Join(f.Actions, " ")
+	}
+	return text
+}
+
+// report returns the report of the results of the first run of the SARIF log.
+func (s sarifLog) report() *Report {
+	run := s.Runs[0]
+	r := &Report{Findings: []Finding{}}
+	if run.Properties != nil {
+		r.SID = run.Properties.SID
+	}
+	if len(run.Invocations) > 0 {
+		r.Generated = run.Invocations[0].EndTimeUTC
+	}
+	for _, res := range run.Results {
+		f := res.Properties
+		if f.RuleID == "" {
+			f.RuleID = res.RuleID
+		}
+		r.Findings = append(r.Findings, f)
+	}
+	return r
+}
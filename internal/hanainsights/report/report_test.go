@@ -0,0 +1,240 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/ruleengine"
+
+	rpb "github.com/GoogleCloudPlatform/sapagent/protos/hanainsights"
+)
+
+var (
+	testGenerated = time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	testRules = []*rpb.Rule{
+		&rpb.Rule{
+			Id:          "r_log_mode",
+			Name:        "Log mode",
+			Description: "Checks the log mode.",
+			Labels:      []string{"reliability", "backup"},
+			Recommendations: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id:          "rec_normal",
+					Description: "Log mode should be normal.",
+					Actions:     []*rpb.Action{&rpb.Action{Description: "Set log_mode to normal."}},
+					References:  []string{"https://example.com/log_mode"},
+				},
+				&rpb.Recommendation{Id: "rec_not_triggered"},
+			},
+		},
+		&rpb.Rule{
+			Id:              "r_no_description",
+			Description:     "Rule without recommendation description.",
+			Recommendations: []*rpb.Recommendation{&rpb.Recommendation{Id: "rec_1"}},
+		},
+	}
+
+	testInsights = ruleengine.Insights{
+		"r_log_mode": {
+			{
+				RecommendationID: "rec_normal",
+				Result:           true,
+				Conditions: []ruleengine.Condition{
+					{LHS: "q:log_mode", Operation: "NEQ", RHS: "normal", LHSValues: []string{"overwrite"}, RHSValues: []string{"normal"}, Result: true},
+				},
+			},
+			{RecommendationID: "rec_not_triggered"},
+		},
+		"r_no_description": {{RecommendationID: "rec_1", Result: true}},
+		"r_unknown":        {{RecommendationID: "rec_1", Result: true}},
+	}
+
+	testFindings = []Finding{
+		{
+			RuleID:           "r_log_mode",
+			RuleName:         "Log mode",
+			RecommendationID: "rec_normal",
+			Description:      "Log mode should be normal.",
+			Labels:           []string{"reliability", "backup"},
+			Actions:          []string{"Set log_mode to normal."},
+			References:       []string{"https://example.com/log_mode"},
+			Conditions: []Condition{
+				{LHS: "q:log_mode", Operation: "NEQ", RHS: "normal", LHSValues: []string{"overwrite"}, RHSValues: []string{"normal"}, Result: true},
+			},
+		},
+		{
+			RuleID:           "r_no_description",
+			RecommendationID: "rec_1",
+			Description:      "Rule without recommendation description.",
+		},
+	}
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []*rpb.Rule
+		insights ruleengine.Insights
+		want     *Report
+	}{
+		{
+			name:     "Findings",
+			rules:    testRules,
+			insights: testInsights,
+			want:     &Report{SID: "HDB", Generated: testGenerated, Findings: testFindings},
+		},
+		{
+			name:  "NoInsights",
+			rules: testRules,
+			want:  &Report{SID: "HDB", Generated: testGenerated, Findings: []Finding{}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := New("HDB", tc.rules, tc.insights, testGenerated.In(time.FixedZone("PST", -8*3600)))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("New() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	want := &Report{SID: "HDB", Generated: testGenerated, Findings: testFindings}
+	data, err := want.JSON()
+	if err != nil {
+		t.Fatalf("JSON() failed: %v", err)
+	}
+	got, err := Read(data)
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read(JSON()) returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	r := &Report{SID: "HDB", Generated: testGenerated, Findings: testFindings}
+	data, err := r.SARIF()
+	if err != nil {
+		t.Fatalf("SARIF() failed: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("json.Unmarshal(SARIF()) failed: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("SARIF() = version %q with %d runs, want version 2.1.0 with 1 run", log.Version, len(log.Runs))
+	}
+	wantRules := []sarifRule{
+		{ID: "r_log_mode", Name: "Log mode", HelpURI: "https://example.com/log_mode", Properties: &sarifRuleTags{Tags: []string{"reliability", "backup"}}},
+		{ID: "r_no_description"},
+	}
+	if diff := cmp.Diff(wantRules, log.Runs[0].Tool.Driver.Rules); diff != "" {
+		t.Errorf("SARIF() rules returned diff (-want +got):\n%s", diff)
+	}
+	wantMessages := []string{
+		"Log mode should be normal. Actions: Set log_mode to normal.",
+		"Rule without recommendation description.",
+	}
+	var gotMessages []string
+	for _, res := range log.Runs[0].Results {
+		gotMessages = append(gotMessages, res.Message.Text)
+	}
+	if diff := cmp.Diff(wantMessages, gotMessages); diff != "" {
+		t.Errorf("SARIF() result messages returned diff (-want +got):\n%s", diff)
+	}
+
+	got, err := Read(data)
+	if err != nil {
+		t.Fatalf("Read(SARIF()) failed: %v", err)
+	}
+	if diff := cmp.Diff(r, got); diff != "" {
+		t.Errorf("Read(SARIF()) returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Report
+		wantErr error
+	}{
+		{
+			name: "JSON",
+			data: `{"sid": "HDB", "findings": [{"rule_id": "r_a", "recommendation_id": "rec_1"}]}`,
+			want: &Report{SID: "HDB", Findings: []Finding{{RuleID: "r_a", RecommendationID: "rec_1"}}},
+		},
+		{
+			name: "SARIFWithoutProperties",
+			data: `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "other"}}, "results": [{"ruleId": "r_a"}]}]}`,
+			want: &Report{Findings: []Finding{{RuleID: "r_a"}}},
+		},
+		{
+			name:    "InvalidJSON",
+			data:    `# Recommendations`,
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Read([]byte(tc.data))
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Read() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Read() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := Finding{RuleID: "r_a", RecommendationID: "rec_1"}
+	b := Finding{RuleID: "r_b", RecommendationID: "rec_1"}
+	b2 := Finding{RuleID: "r_b", RecommendationID: "rec_2"}
+	c := Finding{RuleID: "r_c", RecommendationID: "rec_1"}
+	previous := &Report{Findings: []Finding{a, b}}
+	current := &Report{Findings: []Finding{b, b2, c}}
+
+	got := Compare(previous, current)
+	want := Comparison{New: []Finding{b2, c}, Resolved: []Finding{a}, Unchanged: []Finding{b}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Compare() returned diff (-want +got):\n%s", diff)
+	}
+
+	wantSummary := `2 new, 1 resolved, 1 unchanged findings
+New:
+  - r_b/rec_2
+  - r_c/rec_1
+Resolved:
+  - r_a/rec_1
+Unchanged:
+  - r_b/rec_1
+`
+	if diff := cmp.Diff(wantSummary, got.String()); diff != "" {
+		t.Errorf("Comparison.String() returned diff (-want +got):\n%s", diff)
+	}
+}
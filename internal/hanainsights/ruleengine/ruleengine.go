@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/preprocessor"
@@ -31,6 +33,32 @@ import (
 	rpb "github.com/GoogleCloudPlatform/sapagent/protos/hanainsights"
 )
 
+const (
+	quantifierAny = "any"
+	quantifierAll = "all"
+)
+
+// versionSeparator splits versions into their numeric parts.
+var versionSeparator = regexp.MustCompile(`[^0-9]+`)
+
+// truth is the result of a trigger condition. A condition whose values could not be read or
+// compared, ex: after a failed query, is unknown rather than false, so that negating it does
+// not trigger a recommendation.
+type truth int
+
+const (
+	unknown truth = iota
+	isFalse
+	isTrue
+)
+
+func truthOf(b bool) truth {
+	if b {
+		return isTrue
+	}
+	return isFalse
+}
+
 type (
 	// queryFunc provides an easily testable translation to the DBHandle query.
 	queryFunc func(ctx context.Context, query string, exec commandlineexecutor.Execute) (*databaseconnector.QueryResults, error)
@@ -41,7 +69,19 @@ type (
 	// ValidationResult has results of each of the recommendation evaluation.
 	ValidationResult struct {
 		RecommendationID string
-		Result           bool // True if validation failed, false otherwise.
+		Result           bool        // True if validation failed, false otherwise.
+		Conditions       []Condition // Leaf conditions which triggered the recommendation, set when Result is true.
+	}
+
+	// Condition is a leaf condition of a trigger along with the values read for it from the
+	// knowledge base, showing why a recommendation was triggered. Path lists the operators
+	// above the leaf, ex: "AND > NOT", so that a false leaf under NOT reads as a reason.
+	Condition struct {
+		LHS, RHS             string
+		Operation            string
+		LHSValues, RHSValues []string
+		Result               bool
+		Path                 string
 	}
 
 	// Insights is a map of rules that evaluated to true with key=<rule-id> and value=<slice of recommendation-ids that evaluated to true>
@@ -74,6 +114,18 @@ func Run(ctx context.Context, db DBCHandle, rules []*rpb.Rule) (Insights, error)
 	return insights, nil
 }
 
+// RunRule executes a single rule after the global knowledge base found in rules, and returns
+// its validation results along with the values read from the database by <query_name:column_name>.
+func RunRule(ctx context.Context, db DBCHandle, rules []*rpb.Rule, rule *rpb.Rule) ([]ValidationResult, map[string][]string, error) {
+	kb := buildGlobalKnowledgeBase(ctx, db, rules)
+	if err := buildKnowledgeBase(ctx, db, rule.GetQueries(), kb); err != nil {
+		return nil, nil, fmt.Errorf("building knowledge base for rule %s: %v", rule.GetId(), err)
+	}
+	insights := make(Insights)
+	buildInsights(rule, kb, insights)
+	return insights[rule.GetId()], kb, nil
+}
+
 func buildGlobalKnowledgeBase(ctx context.Context, db DBCHandle, rules []*rpb.Rule) knowledgeBase {
 	gkb := make(knowledgeBase)
 	for _, rule := range rules {
@@ -169,6 +221,7 @@ func buildInsights(rule *rpb.Rule, kb knowledgeBase, insights Insights) {
 		}
 		if r.GetForceTrigger() || evaluateTrigger(r.Trigger, kb) {
 			vr.Result = true
+			vr.Conditions = conditions(r.Trigger, kb)
 		}
 		log.Logger.Debugw("Recommendation evaluation result", "rule", rule.Id, "recommendation", r.Id, "result", vr.Result)
 		insights[rule.Id] = append(insights[rule.Id], vr)
@@ -176,10 +229,17 @@ func buildInsights(rule *rpb.Rule, kb knowledgeBase, insights Insights) {
 	log.Logger.Debugw("Insights successfully generated", "insights", insights)
 }
 
-// evaluateTrigger recursively evaluates the trigger condition tree and returns boolean result.
+// evaluateTrigger evaluates the trigger condition tree and reports whether it is true.
+// An unknown trigger is not true.
 func evaluateTrigger(t *rpb.EvalNode, kb knowledgeBase) bool {
+	return evaluate(t, kb) == isTrue
+}
+
+// evaluate recursively evaluates the trigger condition tree. NOT of an unknown condition is
+// unknown, OR and AND are unknown when the known conditions do not decide them.
+func evaluate(t *rpb.EvalNode, kb knowledgeBase) truth {
 	if t == nil {
-		return false
+		return isFalse
 	}
 	if len(t.ChildEvals) == 0 {
 		return compare(t.Lhs, t.Rhs, t.Operation, kb)
@@ -189,75 +249,321 @@ func evaluateTrigger(t *rpb.EvalNode, kb knowledgeBase) bool {
 		return evaluateOR(t, kb)
 	case rpb.EvalNode_AND:
 		return evaluateAND(t, kb)
+	case rpb.EvalNode_NOT:
+		if len(t.ChildEvals) != 1 {
+			return unknown
+		}
+		switch evaluate(t.ChildEvals[0], kb) {
+		case isTrue:
+			return isFalse
+		case isFalse:
+			return isTrue
+		}
+		return unknown
 	}
-	return false
+	return isFalse
+}
+
+// conditions returns the leaf conditions which determined that the trigger is true, with
+// their evaluated values. Leaves which did not decide the outcome, ex: the false children of
+// a true OR, are left out.
+func conditions(t *rpb.EvalNode, kb knowledgeBase) []Condition {
+	return decisiveConditions(t, kb, isTrue, nil)
+}
+
+// decisiveConditions returns the leaf conditions which make the trigger tree evaluate to want.
+// path holds the operators above t.
+func decisiveConditions(t *rpb.EvalNode, kb knowledgeBase, want truth, path []string) []Condition {
+	if t == nil {
+		return nil
+	}
+	if len(t.ChildEvals) > 0 {
+		path = append(path[:len(path):len(path)], t.Operation.String())
+		if t.Operation == rpb.EvalNode_NOT {
+			if len(t.ChildEvals) != 1 {
+				return nil
+			}
+			negated := isTrue
+			if want == isTrue {
+				negated = isFalse
+			}
+			return decisiveConditions(t.ChildEvals[0], kb, negated, path)
+		}
+		var c []Condition
+		for _, child := range t.ChildEvals {
+			if evaluate(child, kb) == want {
+				c = append(c, decisiveConditions(child, kb, want, path)...)
+			}
+		}
+		return c
+	}
+	c := Condition{
+		LHS:       t.Lhs,
+		RHS:       t.Rhs,
+		Operation: t.Operation.String(),
+		Result:    compare(t.Lhs, t.Rhs, t.Operation, kb) == isTrue,
+		Path:      strings.Join(path, " > "),
+	}
+	if l, err := resolveOperand(t.Lhs, kb); err == nil {
+		c.LHSValues = l.values
+	}
+	if r, err := resolveOperand(t.Rhs, kb); err == nil {
+		c.RHSValues = r.values
+	}
+	return []Condition{c}
 }
 
-func evaluateOR(t *rpb.EvalNode, kb knowledgeBase) bool {
+func evaluateOR(t *rpb.EvalNode, kb knowledgeBase) truth {
+	result := isFalse
 	for _, c := range t.ChildEvals {
-		if evaluateTrigger(c, kb) {
-			return true
+		switch evaluate(c, kb) {
+		case isTrue:
+			return isTrue
+		case unknown:
+			result = unknown
 		}
 	}
-	return false
+	return result
 }
 
-func evaluateAND(t *rpb.EvalNode, kb knowledgeBase) bool {
+func evaluateAND(t *rpb.EvalNode, kb knowledgeBase) truth {
+	result := isTrue
 	for _, c := range t.ChildEvals {
-		if !evaluateTrigger(c, kb) {
-			return false
+		switch evaluate(c, kb) {
+		case isFalse:
+			return isFalse
+		case unknown:
+			result = unknown
 		}
 	}
-	return true
+	return result
 }
 
-// compare does the leaf node evaluation of the eval tree.
-func compare(lhs, rhs string, op rpb.EvalNode_EvalType, kb knowledgeBase) bool {
+// compare does the leaf node evaluation of the eval tree. The result is unknown when the
+// operands cannot be read from the knowledge base or compared with op.
+// With any() or all() in lhs, each value read for the column is compared to rhs.
+func compare(lhs, rhs string, op rpb.EvalNode_EvalType, kb knowledgeBase) truth {
 	// Replace values from knowledge base before comparison
-	var err error
-	if lhs, err = insertFromKB(lhs, kb); err != nil {
+	l, err := resolveOperand(lhs, kb)
+	if err != nil {
 		log.Logger.Error(err)
-		return false
+		return unknown
 	}
-	if rhs, err = insertFromKB(rhs, kb); err != nil {
+	r, err := resolveOperand(rhs, kb)
+	if err != nil {
 		log.Logger.Error(err)
-		return false
+		return unknown
+	}
+	if r.quantifier != "" {
+		log.Logger.Errorw("Quantifiers are only allowed in lhs", "rhs", rhs)
+		return unknown
+	}
+
+	log.Logger.Debugw("Comparison parameters", "lhs", l.values, "rhs", r.values, "op", op)
+	switch l.quantifier {
+	case quantifierAny:
+		result := isFalse
+		for _, v := range l.values {
+			switch compareValues(v, r.values[0], op) {
+			case isTrue:
+				return isTrue
+			case unknown:
+				result = unknown
+			}
+		}
+		return result
+	case quantifierAll:
+		// all() of a column without values is false, as with any().
+		if len(l.values) == 0 {
+			return isFalse
+		}
+		result := isTrue
+		for _, v := range l.values {
+			switch compareValues(v, r.values[0], op) {
+			case isFalse:
+				return isFalse
+			case unknown:
+				result = unknown
+			}
+		}
+		return result
 	}
+	return compareValues(l.values[0], r.values[0], op)
+}
 
-	log.Logger.Debugw("Comparison parameters", "lhs", lhs, "rhs", rhs, "op", op)
-	// TODO: strengthen the EQ/NEQ comparisons for numerical values.
-	// Evaluate equality comparisons.
+// compareValues compares two scalar values with op. The result is unknown when the values
+// cannot be compared with op, ex: non numeric values with LT.
+func compareValues(lhs, rhs string, op rpb.EvalNode_EvalType) truth {
 	switch op {
 	case rpb.EvalNode_UNDEFINED:
-		return false
+		return unknown
 	case rpb.EvalNode_EQ:
-		return lhs == rhs
+		return truthOf(equal(lhs, rhs))
 	case rpb.EvalNode_NEQ:
-		return lhs != rhs
+		return truthOf(!equal(lhs, rhs))
+	case rpb.EvalNode_IN:
+		return truthOf(inList(lhs, rhs))
+	case rpb.EvalNode_NOT_IN:
+		return truthOf(!inList(lhs, rhs))
+	case rpb.EvalNode_MATCHES:
+		re, err := regexp.Compile(rhs)
+		if err != nil {
+			log.Logger.Errorw("Invalid regular expression", "regex", rhs, "error", err)
+			return unknown
+		}
+		return truthOf(re.MatchString(lhs))
+	case rpb.EvalNode_VERSION_LT, rpb.EvalNode_VERSION_LTE, rpb.EvalNode_VERSION_GT, rpb.EvalNode_VERSION_GTE:
+		c, ok := compareVersions(lhs, rhs)
+		if !ok {
+			return unknown
+		}
+		switch op {
+		case rpb.EvalNode_VERSION_LT:
+			return truthOf(c < 0)
+		case rpb.EvalNode_VERSION_LTE:
+			return truthOf(c <= 0)
+		case rpb.EvalNode_VERSION_GT:
+			return truthOf(c > 0)
+		}
+		return truthOf(c >= 0)
 	}
 
 	// Evaluate float comparisons.
-	var l, r float64
-	if l, err = strconv.ParseFloat(lhs, 64); err != nil {
-		return false
+	l, lok := parseFloat(lhs)
+	r, rok := parseFloat(rhs)
+	if !lok || !rok {
+		return unknown
 	}
-	if r, err = strconv.ParseFloat(rhs, 64); err != nil {
-		return false
-	}
-
 	switch op {
 	case rpb.EvalNode_LT:
-		return l < r
+		return truthOf(l < r)
 	case rpb.EvalNode_LTE:
-		return l <= r
+		return truthOf(l <= r)
 	case rpb.EvalNode_GT:
-		return l > r
+		return truthOf(l > r)
 	case rpb.EvalNode_GTE:
-		return l >= r
+		return truthOf(l >= r)
+	}
+	return unknown
+}
+
+// equal compares the values as numbers if both are numeric, ex: "1.0" equals "1", and as
+// strings otherwise.
+func equal(lhs, rhs string) bool {
+	if lhs == rhs {
+		return true
+	}
+	l, lok := parseFloat(lhs)
+	r, rok := parseFloat(rhs)
+	return lok && rok && l == r
+}
+
+// inList reports whether the value equals one of the comma separated values in list.
+func inList(value, list string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if equal(value, strings.TrimSpace(item)) {
+			return true
+		}
 	}
 	return false
 }
 
+func parseFloat(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f, err == nil
+}
+
+// compareVersions compares versions made of numbers separated by dots or other characters,
+// ex: "2.00.059.04.1650000000", returning -1, 0 or 1. Missing parts count as zero.
+func compareVersions(lhs, rhs string) (int, bool) {
+	l := versionParts(lhs)
+	r := versionParts(rhs)
+	if len(l) == 0 || len(r) == 0 {
+		return 0, false
+	}
+	for i := 0; i < len(l) || i < len(r); i++ {
+		var lp, rp int64
+		if i < len(l) {
+			lp = l[i]
+		}
+		if i < len(r) {
+			rp = r[i]
+		}
+		switch {
+		case lp < rp:
+			return -1, true
+		case lp > rp:
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+func versionParts(v string) []int64 {
+	var parts []int64
+	for _, p := range versionSeparator.Split(strings.TrimSpace(v), -1) {
+		if p == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			return nil
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// operand holds the values of one side of a comparison. Operands using any() or all() hold
+// every value read for the column, others a single value.
+type operand struct {
+	values     []string
+	quantifier string
+}
+
+// resolveOperand resolves the knowledge base functions of a comparison side, including
+// min(), max(), sum(), any() and all() over the values of a column.
+func resolveOperand(s string, kb knowledgeBase) (operand, error) {
+	match := preprocessor.AggregatePattern.FindStringSubmatch(s)
+	if len(match) != 3 {
+		v, err := insertFromKB(s, kb)
+		return operand{values: []string{v}}, err
+	}
+	values, ok := kb[match[2]]
+	if !ok {
+		return operand{}, fmt.Errorf("could not insert values from knowledge base for function: %s", s)
+	}
+	var rows []string
+	for _, v := range values {
+		if v != "nilstring" {
+			rows = append(rows, v)
+		}
+	}
+	switch match[1] {
+	case quantifierAny, quantifierAll:
+		return operand{values: rows, quantifier: match[1]}, nil
+	}
+	if len(rows) == 0 {
+		return operand{}, fmt.Errorf("no values to aggregate for function: %s", s)
+	}
+	var result float64
+	for i, v := range rows {
+		f, ok := parseFloat(v)
+		if !ok {
+			return operand{}, fmt.Errorf("non numeric value %q for function: %s", v, s)
+		}
+		switch {
+		case i == 0:
+			result = f
+		case match[1] == "sum":
+			result += f
+		case match[1] == "min" && f < result, match[1] == "max" && f > result:
+			result = f
+		}
+	}
+	return operand{values: []string{strconv.FormatFloat(result, 'f', -1, 64)}}, nil
+}
+
 // insertFromKB  checks if the string uses a set of predefined functions to access the knowledgebase.
 // For these cases return the values from knowledgebase.
 // Possible functions include:
@@ -123,7 +123,7 @@ func TestEvaluateTrigger(t *testing.T) {
 			want: true,
 		},
 		{
-			// Trigger -> ( (2 > 1) OR (1 >= 0) ) AND ( (1.5 < 1) OR (1.99 <= 1.0) OR (1.0 != 1) OR ("xyz" == "xyz") )
+			// Trigger -> ( (2 > 1) OR (1 >= 0) ) AND ( (1.5 < 1) OR (1.99 <= 1.0) OR (1.0 != 2) OR ("xyz" == "xyz") )
 			name: "NestedLogicalConditionWithCompares",
 			trigger: &rpb.EvalNode{
 				Operation: rpb.EvalNode_AND,
@@ -140,7 +140,7 @@ func TestEvaluateTrigger(t *testing.T) {
 						ChildEvals: []*rpb.EvalNode{
 							&rpb.EvalNode{Lhs: "1.5", Rhs: "1", Operation: rpb.EvalNode_LT},
 							&rpb.EvalNode{Lhs: "1.99", Rhs: "1.0", Operation: rpb.EvalNode_LTE},
-							&rpb.EvalNode{Lhs: "1.0", Rhs: "1", Operation: rpb.EvalNode_NEQ},
+							&rpb.EvalNode{Lhs: "1.0", Rhs: "2", Operation: rpb.EvalNode_NEQ},
 							&rpb.EvalNode{Lhs: "xyz", Rhs: "xyz", Operation: rpb.EvalNode_LTE},
 						},
 					},
@@ -151,6 +151,51 @@ func TestEvaluateTrigger(t *testing.T) {
 		{
 			name: "TriggerNil",
 		},
+		{
+			name: "NumericEquality", // Trigger -> 1.0 == 1
+			trigger: &rpb.EvalNode{
+				Lhs:       "1.0",
+				Rhs:       "1",
+				Operation: rpb.EvalNode_EQ,
+			},
+			want: true,
+		},
+		{
+			name: "Not", // Trigger -> NOT ("abc" IN ("abc", "def"))
+			trigger: &rpb.EvalNode{
+				Operation: rpb.EvalNode_NOT,
+				ChildEvals: []*rpb.EvalNode{
+					&rpb.EvalNode{Lhs: "abc", Rhs: "abc,def", Operation: rpb.EvalNode_IN},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "NotWithLogicalOR", // Trigger -> NOT (1 > 2 OR "a" == "b")
+			trigger: &rpb.EvalNode{
+				Operation: rpb.EvalNode_NOT,
+				ChildEvals: []*rpb.EvalNode{
+					&rpb.EvalNode{
+						Operation: rpb.EvalNode_OR,
+						ChildEvals: []*rpb.EvalNode{
+							&rpb.EvalNode{Lhs: "1", Rhs: "2", Operation: rpb.EvalNode_GT},
+							&rpb.EvalNode{Lhs: "a", Rhs: "b", Operation: rpb.EvalNode_EQ},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NotWithTwoChildren",
+			trigger: &rpb.EvalNode{
+				Operation: rpb.EvalNode_NOT,
+				ChildEvals: []*rpb.EvalNode{
+					&rpb.EvalNode{Lhs: "1", Rhs: "2", Operation: rpb.EvalNode_GT},
+					&rpb.EvalNode{Lhs: "1", Rhs: "2", Operation: rpb.EvalNode_GT},
+				},
+			},
+		},
 		{
 			name: "InvalidOperation",
 			trigger: &rpb.EvalNode{
@@ -248,7 +293,13 @@ func TestBuildInsights(t *testing.T) {
 
 	want := make(Insights)
 	want["abc"] = []ValidationResult{
-		ValidationResult{RecommendationID: "my-recommendation-1", Result: true},
+		ValidationResult{
+			RecommendationID: "my-recommendation-1",
+			Result:           true,
+			Conditions: []Condition{
+				{LHS: "1", RHS: "1", Operation: "EQ", LHSValues: []string{"1"}, RHSValues: []string{"1"}, Result: true},
+			},
+		},
 		ValidationResult{RecommendationID: "my-recommendation-2", Result: false},
 		ValidationResult{RecommendationID: "my-recommendation-3", Result: true},
 	}
@@ -260,6 +311,94 @@ func TestBuildInsights(t *testing.T) {
 	}
 }
 
+func TestConditions(t *testing.T) {
+	kb := knowledgeBase{
+		"params:value": []string{"4", "16"},
+		"params:name":  []string{"log_mode"},
+	}
+	tests := []struct {
+		name    string
+		trigger *rpb.EvalNode
+		want    []Condition
+	}{
+		{
+			name: "NilTrigger",
+		},
+		{
+			name: "NestedTrigger",
+			trigger: &rpb.EvalNode{
+				Operation: rpb.EvalNode_AND,
+				ChildEvals: []*rpb.EvalNode{
+					&rpb.EvalNode{Lhs: "params:name", Rhs: "log_mode", Operation: rpb.EvalNode_EQ},
+					&rpb.EvalNode{
+						Operation: rpb.EvalNode_NOT,
+						ChildEvals: []*rpb.EvalNode{
+							&rpb.EvalNode{Lhs: "max(params:value)", Rhs: "8", Operation: rpb.EvalNode_LT},
+						},
+					},
+				},
+			},
+			want: []Condition{
+				{LHS: "params:name", RHS: "log_mode", Operation: "EQ", LHSValues: []string{"log_mode"}, RHSValues: []string{"log_mode"}, Result: true, Path: "AND"},
+				{LHS: "max(params:value)", RHS: "8", Operation: "LT", LHSValues: []string{"16"}, RHSValues: []string{"8"}, Path: "AND > NOT"},
+			},
+		},
+		{
+			name: "OnlyDecidingLeavesOfOR",
+			trigger: &rpb.EvalNode{
+				Operation: rpb.EvalNode_OR,
+				ChildEvals: []*rpb.EvalNode{
+					&rpb.EvalNode{Lhs: "params:name", Rhs: "normal", Operation: rpb.EvalNode_EQ},
+					&rpb.EvalNode{Lhs: "params:name", Rhs: "log_mode", Operation: rpb.EvalNode_EQ},
+				},
+			},
+			want: []Condition{
+				{LHS: "params:name", RHS: "log_mode", Operation: "EQ", LHSValues: []string{"log_mode"}, RHSValues: []string{"log_mode"}, Result: true, Path: "OR"},
+			},
+		},
+		{
+			name: "NotOfAND",
+			trigger: &rpb.EvalNode{
+				Operation: rpb.EvalNode_NOT,
+				ChildEvals: []*rpb.EvalNode{
+					&rpb.EvalNode{
+						Operation: rpb.EvalNode_AND,
+						ChildEvals: []*rpb.EvalNode{
+							&rpb.EvalNode{Lhs: "params:name", Rhs: "log_mode", Operation: rpb.EvalNode_EQ},
+							&rpb.EvalNode{Lhs: "max(params:value)", Rhs: "8", Operation: rpb.EvalNode_LT},
+						},
+					},
+				},
+			},
+			want: []Condition{
+				{LHS: "max(params:value)", RHS: "8", Operation: "LT", LHSValues: []string{"16"}, RHSValues: []string{"8"}, Path: "NOT > AND"},
+			},
+		},
+		{
+			name:    "AnyValues",
+			trigger: &rpb.EvalNode{Lhs: "any(params:value)", Rhs: "10", Operation: rpb.EvalNode_GT},
+			want: []Condition{
+				{LHS: "any(params:value)", RHS: "10", Operation: "GT", LHSValues: []string{"4", "16"}, RHSValues: []string{"10"}, Result: true},
+			},
+		},
+		{
+			name:    "UnknownColumn",
+			trigger: &rpb.EvalNode{Lhs: "params:unknown", Rhs: "1", Operation: rpb.EvalNode_EQ},
+			want: []Condition{
+				{LHS: "params:unknown", RHS: "1", Operation: "EQ", RHSValues: []string{"1"}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := conditions(tc.trigger, kb)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("conditions(%v) returned diff (-want +got):\n%s", tc.trigger, diff)
+			}
+		})
+	}
+}
+
 func TestEvaluateOR(t *testing.T) {
 	tests := []struct {
 		name string
@@ -291,7 +430,7 @@ func TestEvaluateOR(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := evaluateOR(test.t, nil)
+			got := evaluateOR(test.t, nil) == isTrue
 			if got != test.want {
 				t.Errorf("evaluateOR()=%v want %t", got, test.want)
 			}
@@ -330,7 +469,7 @@ func TestEvaluateAND(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := evaluateAND(test.t, nil)
+			got := evaluateAND(test.t, nil) == isTrue
 			if got != test.want {
 				t.Errorf("evaluateOR()=%v want %t", got, test.want)
 			}
@@ -367,6 +506,10 @@ func TestCountRows(t *testing.T) {
 func TestCompare(t *testing.T) {
 	kb := make(knowledgeBase)
 	kb["my_query:my_col"] = []string{"1"}
+	kb["params:value"] = []string{"4", "16", "nilstring", "8.5"}
+	kb["params:name"] = []string{"log_mode", "max_parallelism"}
+	kb["empty:value"] = []string{"nilstring"}
+	kb["version:value"] = []string{"2.00.059.04.1650000000"}
 
 	tests := []struct {
 		name string
@@ -455,10 +598,168 @@ func TestCompare(t *testing.T) {
 			op:   rpb.EvalNode_GTE,
 			want: true,
 		},
+		{
+			name: "NumericEQ",
+			lhs:  "my_query:my_col",
+			rhs:  "1.00",
+			op:   rpb.EvalNode_EQ,
+			want: true,
+		},
+		{
+			name: "NumericNEQ",
+			lhs:  "0100",
+			rhs:  "100",
+			op:   rpb.EvalNode_NEQ,
+		},
+		{
+			name: "In",
+			lhs:  "my_query:my_col",
+			rhs:  "0, 1.0, 2",
+			op:   rpb.EvalNode_IN,
+			want: true,
+		},
+		{
+			name: "NotIn",
+			lhs:  "normal",
+			rhs:  "overwrite,legacy",
+			op:   rpb.EvalNode_NOT_IN,
+			want: true,
+		},
+		{
+			name: "Matches",
+			lhs:  "hdbindexserver",
+			rhs:  "^hdb(index|name)server$",
+			op:   rpb.EvalNode_MATCHES,
+			want: true,
+		},
+		{
+			name: "MatchesInvalidRegex",
+			lhs:  "hdbindexserver",
+			rhs:  "(",
+			op:   rpb.EvalNode_MATCHES,
+		},
+		{
+			name: "VersionLT",
+			lhs:  "version:value",
+			rhs:  "2.00.060",
+			op:   rpb.EvalNode_VERSION_LT,
+			want: true,
+		},
+		{
+			name: "VersionLTEEqualWithMissingParts",
+			lhs:  "2.00.059",
+			rhs:  "2.0.59.0",
+			op:   rpb.EvalNode_VERSION_LTE,
+			want: true,
+		},
+		{
+			name: "VersionGT",
+			lhs:  "2.00.059.10",
+			rhs:  "2.00.059.9",
+			op:   rpb.EvalNode_VERSION_GT,
+			want: true,
+		},
+		{
+			name: "VersionGTEWithLabels",
+			lhs:  "2.0 SPS07",
+			rhs:  "2.0 SPS05",
+			op:   rpb.EvalNode_VERSION_GTE,
+			want: true,
+		},
+		{
+			name: "VersionNotAVersion",
+			lhs:  "latest",
+			rhs:  "2.0",
+			op:   rpb.EvalNode_VERSION_GTE,
+		},
+		{
+			name: "Max",
+			lhs:  "max(params:value)",
+			rhs:  "16",
+			op:   rpb.EvalNode_EQ,
+			want: true,
+		},
+		{
+			name: "Min",
+			lhs:  "min(params:value)",
+			rhs:  "4",
+			op:   rpb.EvalNode_LTE,
+			want: true,
+		},
+		{
+			name: "Sum",
+			lhs:  "sum(params:value)",
+			rhs:  "28.5",
+			op:   rpb.EvalNode_EQ,
+			want: true,
+		},
+		{
+			name: "SumInRHS",
+			lhs:  "30",
+			rhs:  "sum(params:value)",
+			op:   rpb.EvalNode_GT,
+			want: true,
+		},
+		{
+			name: "MaxNonNumeric",
+			lhs:  "max(params:name)",
+			rhs:  "1",
+			op:   rpb.EvalNode_GT,
+		},
+		{
+			name: "MaxNoValues",
+			lhs:  "max(empty:value)",
+			rhs:  "1",
+			op:   rpb.EvalNode_GT,
+		},
+		{
+			name: "Any",
+			lhs:  "any(params:value)",
+			rhs:  "10",
+			op:   rpb.EvalNode_GT,
+			want: true,
+		},
+		{
+			name: "AnyNotIn",
+			lhs:  "any(params:name)",
+			rhs:  "log_mode,max_parallelism",
+			op:   rpb.EvalNode_NOT_IN,
+		},
+		{
+			name: "All",
+			lhs:  "all(params:value)",
+			rhs:  "2",
+			op:   rpb.EvalNode_GT,
+			want: true,
+		},
+		{
+			name: "AllFalse",
+			lhs:  "all(params:value)",
+			rhs:  "5",
+			op:   rpb.EvalNode_GT,
+		},
+		{
+			name: "AllNoValues",
+			lhs:  "all(empty:value)",
+			rhs:  "5",
+			op:   rpb.EvalNode_NEQ,
+		},
+		{
+			name: "AnyInRHS",
+			lhs:  "10",
+			rhs:  "any(params:value)",
+			op:   rpb.EvalNode_LT,
+		},
+		{
+			name: "AggregateUnknownColumn",
+			lhs:  "any(unknown:value)",
+			rhs:  "1",
+			op:   rpb.EvalNode_EQ,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := compare(test.lhs, test.rhs, test.op, kb)
+			got := compare(test.lhs, test.rhs, test.op, kb) == isTrue
 			if got != test.want {
 				t.Errorf("compare(%s, %s, %v, %v)=%t, want=%t", test.lhs, test.rhs, test.op, kb, got, test.want)
 			}
@@ -466,6 +767,164 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+func TestCompareTruth(t *testing.T) {
+	kb := knowledgeBase{
+		"params:value": {"4", "abc", "8"},
+		"empty:value":  {"nilstring"},
+	}
+	tests := []struct {
+		name string
+		lhs  string
+		rhs  string
+		op   rpb.EvalNode_EvalType
+		want truth
+	}{
+		{
+			name: "MissingKey",
+			lhs:  "missing:value",
+			rhs:  "1",
+			op:   rpb.EvalNode_EQ,
+			want: unknown,
+		},
+		{
+			name: "NonNumeric",
+			lhs:  "abc",
+			rhs:  "1",
+			op:   rpb.EvalNode_GT,
+			want: unknown,
+		},
+		{
+			name: "NoValue",
+			lhs:  "empty:value",
+			rhs:  "1",
+			op:   rpb.EvalNode_LT,
+			want: unknown,
+		},
+		{
+			name: "NoValueEquality",
+			lhs:  "empty:value",
+			rhs:  "nilstring",
+			op:   rpb.EvalNode_EQ,
+			want: isTrue,
+		},
+		{
+			name: "InvalidVersion",
+			lhs:  "abc",
+			rhs:  "2.00",
+			op:   rpb.EvalNode_VERSION_LT,
+			want: unknown,
+		},
+		{
+			name: "InvalidRegex",
+			lhs:  "abc",
+			rhs:  "(",
+			op:   rpb.EvalNode_MATCHES,
+			want: unknown,
+		},
+		{
+			name: "AnyTrueDespiteUnknown",
+			lhs:  "any(params:value)",
+			rhs:  "5",
+			op:   rpb.EvalNode_GT,
+			want: isTrue,
+		},
+		{
+			name: "AnyUnknown",
+			lhs:  "any(params:value)",
+			rhs:  "10",
+			op:   rpb.EvalNode_GT,
+			want: unknown,
+		},
+		{
+			name: "AllFalseDespiteUnknown",
+			lhs:  "all(params:value)",
+			rhs:  "5",
+			op:   rpb.EvalNode_GT,
+			want: isFalse,
+		},
+		{
+			name: "AllUnknown",
+			lhs:  "all(params:value)",
+			rhs:  "1",
+			op:   rpb.EvalNode_GT,
+			want: unknown,
+		},
+		{
+			name: "False",
+			lhs:  "1",
+			rhs:  "2",
+			op:   rpb.EvalNode_GT,
+			want: isFalse,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := compare(test.lhs, test.rhs, test.op, kb); got != test.want {
+				t.Errorf("compare(%s, %s, %v)=%v, want=%v", test.lhs, test.rhs, test.op, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateUnknown(t *testing.T) {
+	unknownLeaf := &rpb.EvalNode{Lhs: "missing:value", Rhs: "1", Operation: rpb.EvalNode_GT}
+	trueLeaf := &rpb.EvalNode{Lhs: "2", Rhs: "1", Operation: rpb.EvalNode_GT}
+	falseLeaf := &rpb.EvalNode{Lhs: "1", Rhs: "2", Operation: rpb.EvalNode_GT}
+	tests := []struct {
+		name    string
+		trigger *rpb.EvalNode
+		want    truth
+	}{
+		{
+			name:    "NotUnknown",
+			trigger: &rpb.EvalNode{Operation: rpb.EvalNode_NOT, ChildEvals: []*rpb.EvalNode{unknownLeaf}},
+			want:    unknown,
+		},
+		{
+			name:    "NotFalse",
+			trigger: &rpb.EvalNode{Operation: rpb.EvalNode_NOT, ChildEvals: []*rpb.EvalNode{falseLeaf}},
+			want:    isTrue,
+		},
+		{
+			name:    "OrUnknownTrue",
+			trigger: &rpb.EvalNode{Operation: rpb.EvalNode_OR, ChildEvals: []*rpb.EvalNode{unknownLeaf, trueLeaf}},
+			want:    isTrue,
+		},
+		{
+			name:    "OrUnknownFalse",
+			trigger: &rpb.EvalNode{Operation: rpb.EvalNode_OR, ChildEvals: []*rpb.EvalNode{unknownLeaf, falseLeaf}},
+			want:    unknown,
+		},
+		{
+			name:    "AndUnknownFalse",
+			trigger: &rpb.EvalNode{Operation: rpb.EvalNode_AND, ChildEvals: []*rpb.EvalNode{unknownLeaf, falseLeaf}},
+			want:    isFalse,
+		},
+		{
+			name:    "AndUnknownTrue",
+			trigger: &rpb.EvalNode{Operation: rpb.EvalNode_AND, ChildEvals: []*rpb.EvalNode{unknownLeaf, trueLeaf}},
+			want:    unknown,
+		},
+		{
+			name: "NotOfAndUnknownTrue",
+			trigger: &rpb.EvalNode{Operation: rpb.EvalNode_NOT, ChildEvals: []*rpb.EvalNode{
+				{Operation: rpb.EvalNode_AND, ChildEvals: []*rpb.EvalNode{unknownLeaf, trueLeaf}},
+			}},
+			want: unknown,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := evaluate(test.trigger, nil); got != test.want {
+				t.Errorf("evaluate(%v)=%v, want=%v", test.trigger, got, test.want)
+			}
+			if got := evaluateTrigger(test.trigger, nil); got != (test.want == isTrue) {
+				t.Errorf("evaluateTrigger(%v)=%t, want=%t", test.trigger, got, test.want == isTrue)
+			}
+		})
+	}
+}
+
 func TestCopy(t *testing.T) {
 	want := make(knowledgeBase)
 	want["sample_query:sample_col"] = []string{"1", "2", "3", "4", "5"}
@@ -522,3 +981,49 @@ func TestRun(t *testing.T) {
 		t.Errorf("Run()=%v want: %v", got, want)
 	}
 }
+
+func TestRunRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *fakeDBCHandle
+		rule    *rpb.Rule
+		want    []ValidationResult
+		wantErr error
+	}{
+		{
+			name: "ForceTrigger",
+			db:   &fakeDBCHandle{},
+			rule: &rpb.Rule{
+				Id: "r_force",
+				Recommendations: []*rpb.Recommendation{
+					&rpb.Recommendation{Id: "rec_1", ForceTrigger: true},
+					&rpb.Recommendation{Id: "rec_2", Trigger: &rpb.EvalNode{Lhs: "1", Rhs: "2", Operation: rpb.EvalNode_GT}},
+				},
+			},
+			want: []ValidationResult{
+				{RecommendationID: "rec_1", Result: true},
+				{RecommendationID: "rec_2", Result: false},
+			},
+		},
+		{
+			name: "QueryError",
+			db:   &fakeDBCHandle{err: cmpopts.AnyError},
+			rule: &rpb.Rule{
+				Id:      "r_query_error",
+				Queries: []*rpb.Query{&rpb.Query{Name: "q", Sql: "SELECT 1 AS ONE FROM DUMMY", Columns: []string{"ONE"}}},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _, err := RunRule(context.Background(), tc.db, []*rpb.Rule{tc.rule}, tc.rule)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("RunRule() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RunRule() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"maps"
+	"math"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/protostruct"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/timeseries"
+
+	dpb "google.golang.org/genproto/googleapis/api/distribution"
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+const (
+	// The default buckets of METRIC_DISTRIBUTION columns have bounds 1, 2, 4, ... 2^20.
+	defaultNumFiniteBuckets = 20
+	defaultGrowthFactor     = 2
+	defaultBucketScale      = 1
+)
+
+type (
+	// distributions collects the values of METRIC_DISTRIBUTION columns from
+	// all rows of a sample, keyed by metric and labels.
+	distributions map[timeSeriesKey]*distribution
+
+	// distribution holds the values collected for one time series.
+	distribution struct {
+		metricType string
+		labels     map[string]string
+		column     *cpb.Column
+		values     []float64
+	}
+)
+
+// add collects the METRIC_DISTRIBUTION column values of a row. Rows with the
+// same METRIC_LABEL values are combined into the same distribution.
+func (d distributions) add(dbName, sid string, query *cpb.Query, cols []any) {
+	var labels map[string]string
+	for i, c := range query.GetColumns() {
+		if c.GetMetricType() != cpb.MetricType_METRIC_DISTRIBUTION {
+			continue
+		}
+		if labels == nil {
+			labels = createLabels(query, cols, map[string]string{"instance_name": dbName, "sid": sid})
+		}
+		// Type asserting to pointers due to the coupling with sql.Rows.Scan() populating the columns as such.
+		var value float64
+		switch v := cols[i].(type) {
+		case *int64:
+			value = float64(*v)
+		case *float64:
+			value = *v
+		default:
+			continue
+		}
+		metricPath := metricURL + "/" + query.GetName() + "/" + c.GetName()
+		if c.GetNameOverride() != "" {
+			metricPath = metricURL + "/" + c.GetNameOverride()
+		}
+		key := prepareKey(metricPath, mpb.MetricDescriptor_DISTRIBUTION.String(), labels)
+		if _, ok := d[key]; !ok {
+			d[key] = &distribution{metricType: metricPath, labels: maps.Clone(labels), column: c}
+		}
+		d[key].values = append(d[key].values, value)
+	}
+}
+
+// timeSeries builds a gauge distribution time series for each collected distribution.
+func (d distributions) timeSeries(params Parameters, timestamp *tspb.Timestamp) []*mrpb.TimeSeries {
+	keys := make([]timeSeriesKey, 0, len(d))
+	for key := range d {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].MetricType != keys[j].MetricType {
+			return keys[i].MetricType < keys[j].MetricType
+		}
+		return keys[i].MetricLabels < keys[j].MetricLabels
+	})
+	var metrics []*mrpb.TimeSeries
+	for _, key := range keys {
+		dist := d[key]
+		ts := timeseries.BuildFloat64(timeseries.Params{
+			CloudProp:    protostruct.ConvertCloudPropertiesToStruct(params.Config.GetCloudProperties()),
+			MetricType:   dist.metricType,
+			MetricLabels: dist.labels,
+			Timestamp:    timestamp,
+			BareMetal:    params.Config.GetBareMetal(),
+		})
+		ts.ValueType = mpb.MetricDescriptor_DISTRIBUTION
+		ts.Points[0].Value = &mrpb.TypedValue{Value: &mrpb.TypedValue_DistributionValue{DistributionValue: dist.build()}}
+		metrics = append(metrics, ts)
+	}
+	return metrics
+}
+
+// build returns the Cloud Monitoring distribution of the collected values.
+func (dist *distribution) build() *dpb.Distribution {
+	bounds := dist.column.GetBucketBounds()
+	options := &dpb.Distribution_BucketOptions{
+		Options: &dpb.Distribution_BucketOptions_ExplicitBuckets{
+			ExplicitBuckets: &dpb.Distribution_BucketOptions_Explicit{Bounds: bounds},
+		},
+	}
+	if len(bounds) == 0 {
+		bounds = bucketBounds(nil)
+		options = &dpb.Distribution_BucketOptions{
+			Options: &dpb.Distribution_BucketOptions_ExponentialBuckets{
+				ExponentialBuckets: &dpb.Distribution_BucketOptions_Exponential{
+					NumFiniteBuckets: defaultNumFiniteBuckets,
+					GrowthFactor:     defaultGrowthFactor,
+					Scale:            defaultBucketScale,
+				},
+			},
+		}
+	}
+
+	// There is an underflow bucket before the first bound and an overflow
+	// bucket after the last.
+	counts := make([]int64, len(bounds)+1)
+	var sum float64
+	for _, v := range dist.values {
+		counts[sort.Search(len(bounds), func(i int) bool { return v < bounds[i] })]++
+		sum += v
+	}
+	count := int64(len(dist.values))
+	var mean, squaredDeviation float64
+	if count > 0 {
+		mean = sum / float64(count)
+		for _, v := range dist.values {
+			squaredDeviation += (v - mean) * (v - mean)
+		}
+	}
+	return &dpb.Distribution{
+		Count:                 count,
+		Mean:                  mean,
+		SumOfSquaredDeviation: squaredDeviation,
+		BucketOptions:         options,
+		BucketCounts:          counts,
+	}
+}
+
+// bucketBounds returns the upper bounds of the finite buckets, which for
+// exponential buckets are scale * growth_factor^i.
+func bucketBounds(options *dpb.Distribution_BucketOptions) []float64 {
+	if explicit := options.GetExplicitBuckets(); explicit != nil {
+		return explicit.GetBounds()
+	}
+	exponential := options.GetExponentialBuckets()
+	if exponential == nil {
+		exponential = &dpb.Distribution_BucketOptions_Exponential{
+			NumFiniteBuckets: defaultNumFiniteBuckets,
+			GrowthFactor:     defaultGrowthFactor,
+			Scale:            defaultBucketScale,
+		}
+	}
+	bounds := make([]float64, exponential.GetNumFiniteBuckets()+1)
+	for i := range bounds {
+		bounds[i] = exponential.GetScale() * math.Pow(exponential.GetGrowthFactor(), float64(i))
+	}
+	return bounds
+}
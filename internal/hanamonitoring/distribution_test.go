@@ -0,0 +1,108 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	dpb "google.golang.org/genproto/googleapis/api/distribution"
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	configpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+func TestDistributions(t *testing.T) {
+	query := &configpb.Query{
+		Name: "plan_cache",
+		Columns: []*configpb.Column{
+			{Name: "schema", MetricType: configpb.MetricType_METRIC_LABEL, ValueType: configpb.ValueType_VALUE_STRING},
+			{Name: "execution_ms", MetricType: configpb.MetricType_METRIC_DISTRIBUTION, ValueType: configpb.ValueType_VALUE_DOUBLE, BucketBounds: []float64{10, 100}},
+			{Name: "rows", NameOverride: "plan_cache_rows", MetricType: configpb.MetricType_METRIC_DISTRIBUTION, ValueType: configpb.ValueType_VALUE_INT64},
+		},
+	}
+	rows := []struct {
+		schema string
+		ms     float64
+		rows   int64
+	}{
+		{schema: "SAPABAP1", ms: 5, rows: 1},
+		{schema: "SAPABAP1", ms: 50, rows: 3},
+		{schema: "SAPABAP1", ms: 500, rows: 3},
+		{schema: "SYS", ms: 100, rows: 0},
+	}
+	d := make(distributions)
+	for _, row := range rows {
+		schema, ms, count := row.schema, row.ms, row.rows
+		d.add("hana", "HDB", query, []any{&schema, &ms, &count})
+	}
+	metrics := d.timeSeries(defaultParams, defaultTimestamp)
+	if len(metrics) != 4 {
+		t.Fatalf("timeSeries() returned %d time series, want one per metric and schema: %v", len(metrics), metrics)
+	}
+
+	got := metrics[0]
+	if got.GetMetric().GetType() != metricURL+"/plan_cache/execution_ms" || got.GetMetric().GetLabels()["schema"] != "SAPABAP1" {
+		t.Errorf("timeSeries()[0] metric = %v, want plan_cache/execution_ms for SAPABAP1", got.GetMetric())
+	}
+	if got.GetValueType() != mpb.MetricDescriptor_DISTRIBUTION {
+		t.Errorf("timeSeries()[0] value type = %v, want DISTRIBUTION", got.GetValueType())
+	}
+	wantDist := &dpb.Distribution{
+		Count:                 3,
+		Mean:                  185,
+		SumOfSquaredDeviation: 180*180 + 135*135 + 315*315,
+		BucketOptions: &dpb.Distribution_BucketOptions{
+			Options: &dpb.Distribution_BucketOptions_ExplicitBuckets{
+				ExplicitBuckets: &dpb.Distribution_BucketOptions_Explicit{Bounds: []float64{10, 100}},
+			},
+		},
+		BucketCounts: []int64{1, 1, 1},
+	}
+	if diff := cmp.Diff(wantDist, got.GetPoints()[0].GetValue().GetDistributionValue(), protocmp.Transform()); diff != "" {
+		t.Errorf("timeSeries()[0] distribution returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// The value 100 falls into the overflow bucket, as bounds are exclusive.
+	if got := metrics[1].GetPoints()[0].GetValue().GetDistributionValue().GetBucketCounts(); !cmp.Equal(got, []int64{0, 0, 1}) {
+		t.Errorf("timeSeries()[1] bucket counts = %v, want [0 0 1]", got)
+	}
+
+	rowsDist := metrics[2].GetPoints()[0].GetValue().GetDistributionValue()
+	if metrics[2].GetMetric().GetType() != metricURL+"/plan_cache_rows" || rowsDist.GetBucketOptions().GetExponentialBuckets() == nil {
+		t.Errorf("timeSeries()[2] = %v, want plan_cache_rows with exponential buckets", metrics[2])
+	}
+	// 1 is in the bucket [1, 2) and 3 in [2, 4).
+	if got := rowsDist.GetBucketCounts()[:4]; !cmp.Equal(got, []int64{0, 1, 2, 0}) {
+		t.Errorf("timeSeries()[2] bucket counts = %v, want [0 1 2 0 ...]", got)
+	}
+}
+
+func TestBucketBounds(t *testing.T) {
+	got := bucketBounds(&dpb.Distribution_BucketOptions{
+		Options: &dpb.Distribution_BucketOptions_ExponentialBuckets{
+			ExponentialBuckets: &dpb.Distribution_BucketOptions_Exponential{NumFiniteBuckets: 3, GrowthFactor: 10, Scale: 0.5},
+		},
+	})
+	if want := []float64{0.5, 5, 50, 500}; !cmp.Equal(got, want) {
+		t.Errorf("bucketBounds() = %v, want %v", got, want)
+	}
+	if got := len(bucketBounds(nil)); got != defaultNumFiniteBuckets+1 {
+		t.Errorf("len(bucketBounds(nil)) = %d, want %d", got, defaultNumFiniteBuckets+1)
+	}
+}
@@ -14,20 +14,24 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package hanamonitoring queries HANA databases and sends the results as metrics to Cloud Monitoring.
+// Package hanamonitoring queries HANA databases and sends the results as metrics to Cloud Monitoring
+// and, optionally, serves them on a local endpoint for Prometheus.
 package hanamonitoring
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gammazero/workerpool"
 	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
+	"github.com/GoogleCloudPlatform/sapagent/internal/metricspool"
 	"github.com/GoogleCloudPlatform/sapagent/internal/system"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/utils/protostruct"
@@ -89,6 +93,9 @@ type (
 		HRC                     hanaReplicationConfig
 		SystemDiscovery         system.SapSystemDiscoveryInterface
 		ConnectionRetryInterval time.Duration
+		Spool                   *metricspool.Spool
+		exporter                *exporter
+		exporterRoutine         *recovery.RecoverableRoutine
 	}
 
 	// queryOptions holds parameters for the queryAndSend workflows.
@@ -97,6 +104,7 @@ type (
 		query           *cpb.Query
 		timeout         int64
 		sampleInterval  int64
+		currentInterval int64
 		failCount       int64
 		params          Parameters
 		wp              *workerpool.WorkerPool
@@ -108,6 +116,10 @@ type (
 	database struct {
 		queryFunc queryFunc
 		instance  *cpb.HANAInstance
+		// The latest CPU utilization reading used for adaptive scheduling.
+		cpuMu      sync.Mutex
+		cpuPercent float64
+		cpuReadAt  time.Time
 	}
 
 	// submitQueriesToWorkerPoolArgs holds the parameters necessary to invoke the routine submitQueriesToWorkerPool().
@@ -138,6 +150,11 @@ func Start(ctx context.Context, params Parameters) bool {
 		return false
 	}
 
+	if !SendToCloudMonitoring(params.Config) && !cfg.GetPrometheusExporter().GetEnabled() {
+		log.CtxLogger(ctx).Info("HANA Monitoring enabled but neither Cloud Monitoring nor the Prometheus exporter are enabled, not starting HANA Monitoring.")
+		return false
+	}
+
 	// Log usagemetric if any one of the HANA instances has hdbuserstore key configured.
 	for _, i := range params.Config.GetHanaMonitoringConfiguration().GetHanaInstances() {
 		if i.GetHdbuserstoreKey() != "" {
@@ -156,6 +173,20 @@ func Start(ctx context.Context, params Parameters) bool {
 	}
 	params.dailyMetricsRoutine.StartRoutine(ctx)
 
+	if cfg.GetPrometheusExporter().GetEnabled() {
+		params.exporter = newExporter()
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", params.exporter)
+		params.exporterRoutine = &recovery.RecoverableRoutine{
+			Routine:             runExporter,
+			RoutineArg:          &http.Server{Addr: cfg.GetPrometheusExporter().GetListenAddress(), Handler: mux},
+			ErrorCode:           usagemetrics.LocalHTTPListenerCreateFailure,
+			UsageLogger:         *usagemetrics.Logger,
+			ExpectedMinDuration: time.Minute,
+		}
+		params.exporterRoutine.StartRoutine(ctx)
+	}
+
 	createWorkerPoolRoutine := &recovery.RecoverableRoutine{
 		Routine: func(ctx context.Context, a any) {
 			if params, ok := a.(Parameters); ok {
@@ -229,6 +260,7 @@ func invokeSubmitQueriesToWorkerPool(ctx context.Context, params Parameters, wp
 
 // submitQueriesToWorkerPool creates a job for each query on each database. If the SID
 // is not present in the config, the database will be queried to populate it.
+// Tenant discovery is started for databases configured with discover_tenants.
 func submitQueriesToWorkerPool(ctx context.Context, a any) {
 	var args submitQueriesToWorkerPoolArgs
 	var ok bool
@@ -299,6 +331,8 @@ func submitQueriesToWorkerPool(ctx context.Context, a any) {
 			})
 		}
 	}
+	// Tenants are cloned from their SYSTEMDB, so discovery starts once its SID and queries are set.
+	startTenantDiscovery(ctx, args.params, wp, args.databases)
 }
 
 // queryMap prepares a queryName to *cpb.Query Map data structure.
@@ -326,8 +360,48 @@ func queryAndSend(ctx context.Context, opts queryOptions) (bool, error) {
 		cancel()
 		return false, ctx.Err()
 	default:
-		sent, batchCount, err := queryAndSendOnce(ctxTimeout, opts.db, opts.query, opts.params, opts.runningSum)
+		var (
+			metrics        []*mrpb.TimeSeries
+			responseTimeMs int64
+			err            error
+		)
+		due := matchQueryAndInstanceType(ctx, opts)
+		if due {
+			metrics, responseTimeMs, err = queryMetrics(ctxTimeout, opts.db, opts.query, opts.params, opts.runningSum)
+		} else {
+			log.CtxLogger(ctx).Infow("Query should not run on this instance type in this cycle ", "query", queryName, "host", host, "user", user, "port", port)
+		}
+
+		// With adaptive scheduling, the interval follows the response time and database load.
+		// It is reported in the same batch as the query results. When the query is not due on
+		// this instance, or after an authentication error, the database is not queried for its
+		// load; after an authentication error the query is not restarted either.
+		interval := opts.sampleInterval
+		if opts.currentInterval > 0 {
+			interval = opts.currentInterval
+		}
+		var intervalMetric *mrpb.TimeSeries
+		if opts.params.Config.GetHanaMonitoringConfiguration().GetAdaptiveScheduling().GetEnabled() && due && !opts.isAuthErrorFunc(err) {
+			interval, intervalMetric = adaptInterval(ctx, opts, responseTimeMs)
+			opts.currentInterval = interval
+		}
+		// The results of a failed query are no longer exported, rather than exporting the last ones.
+		// Results of a query cancelled meanwhile, for example of a removed tenant, are not exported.
+		if opts.params.exporter != nil && due && ctx.Err() == nil {
+			if err != nil {
+				opts.params.exporter.remove(exportSource(opts.db, queryName))
+			} else {
+				opts.params.exporter.update(exportSource(opts.db, queryName), metrics, time.Duration(interval)*time.Second)
+			}
+		}
+		if intervalMetric != nil {
+			metrics = append(metrics, intervalMetric)
+		}
+		sent, batchCount, sendErr := sendMetrics(ctxTimeout, opts.params, metrics)
 		cancel()
+		if err == nil {
+			err = sendErr
+		}
 		if err != nil {
 			opts.failCount++
 			log.CtxLogger(ctx).Errorw("Error querying database or sending metrics", "user", user, "host", host, "port", port, "query", queryName, "failCount", opts.failCount, "error", err)
@@ -342,9 +416,9 @@ func queryAndSend(ctx context.Context, opts queryOptions) (bool, error) {
 			return false, err
 		}
 
-		// Schedule to insert this query back into the task queue after the sampleInterval.
+		// Schedule to insert this query back into the task queue after the interval.
 		// Also release this worker back to the pool since AfterFunc() is non-blocking.
-		time.AfterFunc(time.Duration(opts.sampleInterval)*time.Second, func() {
+		time.AfterFunc(time.Duration(interval)*time.Second, func() {
 			opts.wp.Submit(func() {
 				queryAndSend(ctx, opts)
 			})
@@ -390,30 +464,40 @@ func matchQueryAndInstanceType(ctx context.Context, opts queryOptions) bool {
 	}
 }
 
-// queryAndSendOnce queries the database, packages the results into time series, and sends those results as metrics to cloud monitoring.
-func queryAndSendOnce(ctx context.Context, db *database, query *cpb.Query, params Parameters, runningSum map[timeSeriesKey]prevVal) (sent, batchCount int, err error) {
-	if !matchQueryAndInstanceType(ctx, queryOptions{db: db, query: query, params: params}) {
-		log.CtxLogger(ctx).Infow("Query should not run on this instance type in this cycle ", "query", query.GetName(), "host", db.instance.GetHost(), "user", db.instance.GetUser(), "port", db.instance.GetPort())
-		return 0, 0, nil
-	}
+// queryMetrics queries the database and packages the results into time series.
+// The query response time is returned even if the query fails.
+func queryMetrics(ctx context.Context, db *database, query *cpb.Query, params Parameters, runningSum map[timeSeriesKey]prevVal) (metrics []*mrpb.TimeSeries, responseTime int64, err error) {
 	queryStartTime := time.Now()
 	rows, cols, err := queryDatabase(ctx, db.queryFunc, query)
-	responseTime := time.Since(queryStartTime).Milliseconds()
+	responseTime = time.Since(queryStartTime).Milliseconds()
 	if err != nil {
-		return 0, 0, err
+		return nil, responseTime, err
 	}
 	log.CtxLogger(ctx).Infow("Successfully executed: ", "query", query.GetName(), "host", db.instance.GetHost(), "user", db.instance.GetUser(), "port", db.instance.GetPort(), "response time", responseTime)
-	var metrics []*mrpb.TimeSeries
 	if params.Config.GetHanaMonitoringConfiguration().GetSendQueryResponseTime() {
 		metrics = append(metrics, createQueryResponseTimeMetric(ctx, db.instance.GetName(), db.instance.GetSid(), query, params, responseTime, tspb.Now()))
 	}
+	dists := make(distributions)
 	for rows.Next() {
 		if err := rows.ReadRow(cols...); err != nil {
-			return 0, 0, err
+			return nil, responseTime, err
 		}
 		metrics = append(metrics, createMetricsForRow(ctx, db.instance.GetName(), db.instance.GetSid(), query, cols, params, runningSum)...)
+		dists.add(db.instance.GetName(), db.instance.GetSid(), query, cols)
 	}
-	return cloudmonitoring.SendTimeSeries(ctx, metrics, params.TimeSeriesCreator, params.BackOffs, params.Config.GetCloudProperties().GetProjectId())
+	metrics = append(metrics, dists.timeSeries(params, tspb.Now())...)
+	return metrics, responseTime, nil
+}
+
+// sendMetrics sends the time series of a query run to cloud monitoring in one batch.
+func sendMetrics(ctx context.Context, params Parameters, metrics []*mrpb.TimeSeries) (sent, batchCount int, err error) {
+	if len(metrics) == 0 {
+		return 0, 0, nil
+	}
+	if !SendToCloudMonitoring(params.Config) {
+		return len(metrics), 0, nil
+	}
+	return params.Spool.Send(ctx, metrics, params.TimeSeriesCreator, params.BackOffs, params.Config.GetCloudProperties().GetProjectId())
 }
 
 // createColumns creates pointers to the types defined in the configuration for each column in a query.
@@ -471,32 +555,7 @@ func connectToDatabases(ctx context.Context, params Parameters, connectedDBs map
 			log.CtxLogger(ctx).Debugw("Instance is misconfigured with wrong credentials, not retrying to connect to the instance", "name", i.GetName())
 			continue
 		}
-		hanaMonitoringConfig := params.Config.GetHanaMonitoringConfiguration()
-
-		dbp := databaseconnector.Params{
-			Username:       i.GetUser(),
-			Host:           i.GetHost(),
-			Password:       i.GetPassword(),
-			PasswordSecret: i.GetSecretName(),
-			Port:           i.GetPort(),
-			EnableSSL:      i.GetEnableSsl(),
-			HostNameInCert: i.GetHostNameInCertificate(),
-			RootCAFile:     i.GetTlsRootCaFile(),
-			HDBUserKey:     i.GetHdbuserstoreKey(),
-			SID:            i.GetSid(),
-			GCEService:     params.GCEService,
-			Project:        params.Config.GetCloudProperties().GetProjectId(),
-		}
-
-		connectTimeout := hanaMonitoringConfig.GetConnectionTimeout()
-		if connectTimeout.GetSeconds() > 0 {
-			dbp.PingSpec = &databaseconnector.PingSpec{
-				Timeout:    time.Duration(connectTimeout.GetSeconds()) * time.Second,
-				MaxRetries: int(hanaMonitoringConfig.GetMaxConnectRetries().GetValue()),
-			}
-		}
-
-		handle, err := databaseconnector.CreateDBHandle(ctx, dbp)
+		handle, err := databaseconnector.CreateDBHandle(ctx, dbParams(params, i))
 		if err != nil {
 			log.CtxLogger(ctx).Errorw("Error connecting to database", "name", i.GetName(), "error", err.Error())
 			usagemetrics.Error(usagemetrics.HANAMonitoringCollectionFailure)
@@ -512,6 +571,34 @@ func connectToDatabases(ctx context.Context, params Parameters, connectedDBs map
 	return databases
 }
 
+// dbParams returns the connection parameters for a HANA instance.
+func dbParams(params Parameters, i *cpb.HANAInstance) databaseconnector.Params {
+	hanaMonitoringConfig := params.Config.GetHanaMonitoringConfiguration()
+	dbp := databaseconnector.Params{
+		Username:       i.GetUser(),
+		Host:           i.GetHost(),
+		Password:       i.GetPassword(),
+		PasswordSecret: i.GetSecretName(),
+		Port:           i.GetPort(),
+		EnableSSL:      i.GetEnableSsl(),
+		HostNameInCert: i.GetHostNameInCertificate(),
+		RootCAFile:     i.GetTlsRootCaFile(),
+		HDBUserKey:     i.GetHdbuserstoreKey(),
+		SID:            i.GetSid(),
+		GCEService:     params.GCEService,
+		Project:        params.Config.GetCloudProperties().GetProjectId(),
+	}
+
+	connectTimeout := hanaMonitoringConfig.GetConnectionTimeout()
+	if connectTimeout.GetSeconds() > 0 {
+		dbp.PingSpec = &databaseconnector.PingSpec{
+			Timeout:    time.Duration(connectTimeout.GetSeconds()) * time.Second,
+			MaxRetries: int(hanaMonitoringConfig.GetMaxConnectRetries().GetValue()),
+		}
+	}
+	return dbp
+}
+
 // createQueryResponseTimeMetric builds a cloud monitoring time series with an int point value for the time taken by query.
 func createQueryResponseTimeMetric(ctx context.Context, dbName, sid string, query *cpb.Query, params Parameters, timeTaken int64, timestamp *tspb.Timestamp) *mrpb.TimeSeries {
 	labels := map[string]string{
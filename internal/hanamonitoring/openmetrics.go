@@ -0,0 +1,325 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	dpb "google.golang.org/genproto/googleapis/api/distribution"
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+const (
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	// staleIntervals is the number of sample intervals after which the series of a
+	// source which was not updated are no longer exported.
+	staleIntervals = 3
+)
+
+type (
+	// exporter holds the latest time series of each query and serves them in
+	// the OpenMetrics text format for Prometheus.
+	exporter struct {
+		mu  sync.Mutex
+		now func() time.Time
+		// series is keyed by the database and query which produced it, so rows
+		// which are no longer returned by a query stop being exported.
+		series map[string]exportedSeries
+	}
+
+	// exportedSeries holds the time series last collected from a source, and when
+	// they become stale.
+	exportedSeries struct {
+		series  []*mrpb.TimeSeries
+		expires time.Time
+	}
+
+	// metricFamily groups the samples of a metric for the OpenMetrics output.
+	metricFamily struct {
+		metricType string
+		samples    []metricSamples
+	}
+
+	// metricSamples holds the output lines of one time series, which for
+	// histograms are several samples in a fixed order.
+	metricSamples struct {
+		labels string
+		lines  []string
+	}
+)
+
+func newExporter() *exporter {
+	return &exporter{now: time.Now, series: make(map[string]exportedSeries)}
+}
+
+// exportSource returns the exporter source of a query on a database.
+func exportSource(db *database, queryName string) string {
+	return exportPrefix(db) + queryName
+}
+
+// exportPrefix returns the prefix of the exporter sources of the queries on a database.
+func exportPrefix(db *database) string {
+	return fmt.Sprintf("%s:%s:%s/", db.instance.GetHost(), db.instance.GetUser(), db.instance.GetPort())
+}
+
+// SendToCloudMonitoring returns true if HANA Monitoring query results are
+// sent to Cloud Monitoring, which is the default.
+func SendToCloudMonitoring(config *cpb.Configuration) bool {
+	send := config.GetHanaMonitoringConfiguration().GetSendToCloudMonitoring()
+	return send == nil || send.GetValue()
+}
+
+// update replaces the time series last collected from source, which is collected
+// every interval. The series are no longer exported once they were not updated for
+// staleIntervals intervals, for example when the source was removed.
+func (e *exporter) update(source string, series []*mrpb.TimeSeries, interval time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.series[source] = exportedSeries{series: series, expires: e.now().Add(staleIntervals * interval)}
+}
+
+// remove stops exporting the time series of source, for example when its query fails.
+func (e *exporter) remove(source string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.series, source)
+}
+
+// removeDatabase stops exporting the time series of all queries on db, for example
+// when a tenant database is no longer active.
+func (e *exporter) removeDatabase(db *database) {
+	prefix := exportPrefix(db)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for source := range e.series {
+		if strings.HasPrefix(source, prefix) {
+			delete(e.series, source)
+		}
+	}
+}
+
+// ServeHTTP writes the latest time series of all queries.
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", openMetricsContentType)
+	if err := e.write(w); err != nil {
+		log.Logger.Debugw("Error writing OpenMetrics response", "error", err)
+	}
+}
+
+// write writes the time series as gauges, counters and histograms in the
+// OpenMetrics text format.
+func (e *exporter) write(w io.Writer) error {
+	families := make(map[string]*metricFamily)
+	e.mu.Lock()
+	now := e.now()
+	for source, exported := range e.series {
+		if now.After(exported.expires) {
+			delete(e.series, source)
+			continue
+		}
+		for _, ts := range exported.series {
+			name := openMetricsName(ts.GetMetric().GetType())
+			metricType := "gauge"
+			if ts.GetMetricKind() == mpb.MetricDescriptor_CUMULATIVE {
+				metricType = "counter"
+			}
+			var lines []string
+			if dist := pointDistribution(ts); dist != nil {
+				metricType = "histogram"
+				lines = histogramLines(name, ts.GetMetric().GetLabels(), dist)
+			} else if value, ok := pointValue(ts); ok {
+				sampleName := name
+				if metricType == "counter" {
+					sampleName += "_total"
+				}
+				lines = []string{sampleName + openMetricsLabels(ts.GetMetric().GetLabels()) + " " + value}
+			} else {
+				continue
+			}
+			family, ok := families[name]
+			if !ok {
+				family = &metricFamily{metricType: metricType}
+				families[name] = family
+			}
+			if family.metricType != metricType {
+				continue
+			}
+			family.samples = append(family.samples, metricSamples{labels: openMetricsLabels(ts.GetMetric().GetLabels()), lines: lines})
+		}
+	}
+	e.mu.Unlock()
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		family := families[name]
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, family.metricType)
+		sort.Slice(family.samples, func(i, j int) bool { return family.samples[i].labels < family.samples[j].labels })
+		for _, samples := range family.samples {
+			for _, line := range samples.lines {
+				b.WriteString(line + "\n")
+			}
+		}
+	}
+	b.WriteString("# EOF\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// pointDistribution returns the distribution value of the latest point, if any.
+func pointDistribution(ts *mrpb.TimeSeries) *dpb.Distribution {
+	if len(ts.GetPoints()) == 0 {
+		return nil
+	}
+	return ts.GetPoints()[0].GetValue().GetDistributionValue()
+}
+
+// histogramLines formats a distribution as cumulative histogram buckets
+// followed by the count and sum. Cloud Monitoring bucket bounds are exclusive,
+// so a value equal to a bound is counted in the following le bucket.
+func histogramLines(name string, labels map[string]string, dist *dpb.Distribution) []string {
+	bounds := bucketBounds(dist.GetBucketOptions())
+	bucketLabels := maps.Clone(labels)
+	if bucketLabels == nil {
+		bucketLabels = make(map[string]string)
+	}
+	var lines []string
+	var cumulative int64
+	for i, count := range dist.GetBucketCounts() {
+		cumulative += count
+		le := "+Inf"
+		if i < len(bounds) {
+			le = strconv.FormatFloat(bounds[i], 'g', -1, 64)
+		}
+		bucketLabels["le"] = le
+		lines = append(lines, fmt.Sprintf("%s_bucket%s %d", name, openMetricsLabels(bucketLabels), cumulative))
+		if le == "+Inf" {
+			break
+		}
+	}
+	// The overflow bucket may be omitted when it is empty.
+	if len(dist.GetBucketCounts()) <= len(bounds) {
+		bucketLabels["le"] = "+Inf"
+		lines = append(lines, fmt.Sprintf("%s_bucket%s %d", name, openMetricsLabels(bucketLabels), dist.GetCount()))
+	}
+	labelString := openMetricsLabels(labels)
+	lines = append(lines, fmt.Sprintf("%s_count%s %d", name, labelString, dist.GetCount()))
+	lines = append(lines, fmt.Sprintf("%s_sum%s %s", name, labelString, strconv.FormatFloat(dist.GetMean()*float64(dist.GetCount()), 'g', -1, 64)))
+	return lines
+}
+
+// pointValue formats the value of the latest point, with booleans as 0 or 1.
+func pointValue(ts *mrpb.TimeSeries) (string, bool) {
+	if len(ts.GetPoints()) == 0 {
+		return "", false
+	}
+	switch v := ts.GetPoints()[0].GetValue().GetValue().(type) {
+	case *mrpb.TypedValue_Int64Value:
+		return strconv.FormatInt(v.Int64Value, 10), true
+	case *mrpb.TypedValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'g', -1, 64), true
+	case *mrpb.TypedValue_BoolValue:
+		if v.BoolValue {
+			return "1", true
+		}
+		return "0", true
+	default:
+		return "", false
+	}
+}
+
+// openMetricsName converts a Cloud Monitoring metric type such as
+// workload.googleapis.com/sap/hanamonitoring/query/column to a valid metric
+// name such as sap_hanamonitoring_query_column.
+func openMetricsName(metricType string) string {
+	return sanitizeName(strings.TrimPrefix(metricType, "workload.googleapis.com/"), true)
+}
+
+// openMetricsLabelName converts a Cloud Monitoring label key to a valid label
+// name, which unlike a metric name may not contain colons.
+func openMetricsLabelName(key string) string {
+	return sanitizeName(key, false)
+}
+
+// sanitizeName replaces the characters not allowed in an OpenMetrics name with
+// underscores.
+func sanitizeName(s string, allowColon bool) string {
+	name := []byte(s)
+	for i, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' && i > 0 || c == '_' || c == ':' && allowColon) {
+			name[i] = '_'
+		}
+	}
+	return string(name)
+}
+
+// openMetricsLabels formats the labels sorted by name, escaping their values.
+func openMetricsLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, openMetricsLabelName(k), escaper.Replace(labels[k])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// runExporter serves the exporter until the context is cancelled.
+func runExporter(ctx context.Context, a any) {
+	server, ok := a.(*http.Server)
+	if !ok {
+		log.CtxLogger(ctx).Info("Exporter arg is not an *http.Server")
+		return
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+	log.CtxLogger(ctx).Infow("Serving HANA Monitoring metrics for Prometheus", "address", server.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		usagemetrics.Error(usagemetrics.LocalHTTPListenerCreateFailure)
+		log.CtxLogger(ctx).Errorw("Could not start the HANA Monitoring Prometheus exporter", "address", server.Addr, "error", err)
+	}
+}
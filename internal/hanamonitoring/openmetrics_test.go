@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	wpb "google.golang.org/protobuf/types/known/wrapperspb"
+	configpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+func TestExporterWrite(t *testing.T) {
+	ctx := context.Background()
+	labels := map[string]string{"instance_name": "hana", "sid": "HDB", "host": `a"b`}
+	query := &configpb.Query{Name: "host"}
+	runningSum := make(map[timeSeriesKey]prevVal)
+	cumulativeColumn := &configpb.Column{Name: "reads", MetricType: configpb.MetricType_METRIC_CUMULATIVE, ValueType: configpb.ValueType_VALUE_INT64}
+	reads := int64(5)
+	// The first cumulative value only initializes the running sum.
+	createCumulativeMetric(ctx, cumulativeColumn, &reads, labels, query.GetName(), defaultParams, defaultTimestamp, runningSum)
+	counter, _ := createCumulativeMetric(ctx, cumulativeColumn, &reads, labels, query.GetName(), defaultParams, defaultTimestamp, runningSum)
+	memory := 1.5
+	gauge, _ := createGaugeMetric(&configpb.Column{Name: "memory", ValueType: configpb.ValueType_VALUE_DOUBLE}, &memory, labels, query.GetName(), defaultParams, defaultTimestamp)
+	active := true
+	renamed, _ := createGaugeMetric(&configpb.Column{Name: "active", NameOverride: "host/is-active", ValueType: configpb.ValueType_VALUE_BOOL}, &active, labels, query.GetName(), defaultParams, defaultTimestamp)
+	responseTime := createQueryResponseTimeMetric(ctx, "hana", "HDB", query, defaultParams, 42, defaultTimestamp)
+
+	e := newExporter()
+	e.update("db1/host", []*mrpb.TimeSeries{counter, gauge, renamed, responseTime}, time.Minute)
+	e.update("db2/host", nil, time.Minute)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if got := rec.Header().Get("Content-Type"); got != openMetricsContentType {
+		t.Errorf("ServeHTTP() Content-Type = %q, want %q", got, openMetricsContentType)
+	}
+	want := strings.Join([]string{
+		"# TYPE sap_hanamonitoring_host_is_active gauge",
+		`sap_hanamonitoring_host_is_active{host="a\"b",instance_name="hana",sid="HDB"} 1`,
+		"# TYPE sap_hanamonitoring_host_memory gauge",
+		`sap_hanamonitoring_host_memory{host="a\"b",instance_name="hana",sid="HDB"} 1.5`,
+		"# TYPE sap_hanamonitoring_host_reads counter",
+		`sap_hanamonitoring_host_reads_total{host="a\"b",instance_name="hana",sid="HDB"} 10`,
+		"# TYPE sap_hanamonitoring_host_time_taken_ms gauge",
+		`sap_hanamonitoring_host_time_taken_ms{instance_name="hana",sid="HDB"} 42`,
+		"# EOF",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("ServeHTTP() returned unexpected body (-want +got):\n%s", diff)
+	}
+
+	// Series which are no longer returned by the query are removed.
+	e.update("db1/host", []*mrpb.TimeSeries{responseTime}, time.Minute)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if strings.Contains(rec.Body.String(), "memory") {
+		t.Errorf("ServeHTTP() = %q, want series from the previous collection removed", rec.Body.String())
+	}
+}
+
+func TestExporterStaleSeries(t *testing.T) {
+	responseTime := createQueryResponseTimeMetric(context.Background(), "hana", "HDB", &configpb.Query{Name: "host"}, defaultParams, 42, defaultTimestamp)
+	now := time.Now()
+	e := newExporter()
+	e.now = func() time.Time { return now }
+	e.update("db1/host", []*mrpb.TimeSeries{responseTime}, time.Minute)
+	e.update("db2/host", []*mrpb.TimeSeries{responseTime}, 10*time.Minute)
+	e.update("db3/host", []*mrpb.TimeSeries{responseTime}, 10*time.Minute)
+	// The query on db3 failed.
+	e.remove("db3/host")
+
+	now = now.Add(5 * time.Minute)
+	var b strings.Builder
+	if err := e.write(&b); err != nil {
+		t.Fatalf("write() failed: %v", err)
+	}
+	if got := strings.Count(b.String(), "time_taken_ms{"); got != 1 {
+		t.Errorf("write() = %q, want the series of db2 only", b.String())
+	}
+	if _, ok := e.series["db1/host"]; ok {
+		t.Errorf("write() kept the stale series of db1")
+	}
+}
+
+func TestExporterHistogram(t *testing.T) {
+	query := &configpb.Query{
+		Name: "plan_cache",
+		Columns: []*configpb.Column{
+			{Name: "execution_ms", MetricType: configpb.MetricType_METRIC_DISTRIBUTION, ValueType: configpb.ValueType_VALUE_DOUBLE, BucketBounds: []float64{10, 100}},
+		},
+	}
+	d := make(distributions)
+	for _, ms := range []float64{5, 50, 500} {
+		value := ms
+		d.add("hana", "HDB", query, []any{&value})
+	}
+	e := newExporter()
+	e.update("db1/plan_cache", d.timeSeries(defaultParams, defaultTimestamp), time.Minute)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	want := strings.Join([]string{
+		"# TYPE sap_hanamonitoring_plan_cache_execution_ms histogram",
+		`sap_hanamonitoring_plan_cache_execution_ms_bucket{instance_name="hana",le="10",sid="HDB"} 1`,
+		`sap_hanamonitoring_plan_cache_execution_ms_bucket{instance_name="hana",le="100",sid="HDB"} 2`,
+		`sap_hanamonitoring_plan_cache_execution_ms_bucket{instance_name="hana",le="+Inf",sid="HDB"} 3`,
+		`sap_hanamonitoring_plan_cache_execution_ms_count{instance_name="hana",sid="HDB"} 3`,
+		`sap_hanamonitoring_plan_cache_execution_ms_sum{instance_name="hana",sid="HDB"} 555`,
+		"# EOF",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("ServeHTTP() returned unexpected body (-want +got):\n%s", diff)
+	}
+}
+
+func TestOpenMetricsName(t *testing.T) {
+	tests := []struct {
+		metricType string
+		want       string
+	}{
+		{metricType: "workload.googleapis.com/sap/hanamonitoring/query/column", want: "sap_hanamonitoring_query_column"},
+		{metricType: "workload.googleapis.com/sap/hanamonitoring/disk-usage.pct", want: "sap_hanamonitoring_disk_usage_pct"},
+		{metricType: "1st", want: "_st"},
+	}
+	for _, test := range tests {
+		if got := openMetricsName(test.metricType); got != test.want {
+			t.Errorf("openMetricsName(%q) = %q, want %q", test.metricType, got, test.want)
+		}
+	}
+}
+
+func TestOpenMetricsLabelName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "instance_name", want: "instance_name"},
+		{key: "host:port", want: "host_port"},
+		{key: "1st", want: "_st"},
+	}
+	for _, test := range tests {
+		if got := openMetricsLabelName(test.key); got != test.want {
+			t.Errorf("openMetricsLabelName(%q) = %q, want %q", test.key, got, test.want)
+		}
+	}
+}
+
+func TestSendToCloudMonitoring(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *configpb.HANAMonitoringConfiguration
+		want   bool
+	}{
+		{name: "Default", config: &configpb.HANAMonitoringConfiguration{}, want: true},
+		{name: "Enabled", config: &configpb.HANAMonitoringConfiguration{SendToCloudMonitoring: wpb.Bool(true)}, want: true},
+		{name: "Disabled", config: &configpb.HANAMonitoringConfiguration{SendToCloudMonitoring: wpb.Bool(false)}, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := SendToCloudMonitoring(&configpb.Configuration{HanaMonitoringConfiguration: test.config}); got != test.want {
+				t.Errorf("SendToCloudMonitoring() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/protostruct"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/timeseries"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+const (
+	defaultResponseTimeBudgetMs = 10000
+	defaultCPUBusyPercent       = 80
+	defaultCPUIdlePercent       = 50
+	defaultBackoffMultiplier    = 2
+	defaultMaxSampleIntervalSec = 3600
+
+	// cpuQuery reads the peak CPU utilization of the database hosts over the last five minutes.
+	cpuQuery = "SELECT TO_DOUBLE(IFNULL(MAX(CPU), 0)) FROM M_LOAD_HISTORY_HOST WHERE TIME > ADD_SECONDS(CURRENT_TIMESTAMP, -300);"
+	// cpuCacheDuration is how long a CPU reading is shared by the queries on a database.
+	cpuCacheDuration = time.Minute
+)
+
+// cpuUtilization returns the recent CPU utilization of the database hosts in
+// percent, or -1 if it cannot be read. Readings are cached so that queries on
+// the same database do not each add load.
+func (db *database) cpuUtilization(ctx context.Context) float64 {
+	db.cpuMu.Lock()
+	defer db.cpuMu.Unlock()
+	if !db.cpuReadAt.IsZero() && time.Since(db.cpuReadAt) < cpuCacheDuration {
+		return db.cpuPercent
+	}
+	db.cpuReadAt = time.Now()
+	db.cpuPercent = -1
+	ctxTimeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	rows, err := db.queryFunc(ctxTimeout, cpuQuery, commandlineexecutor.ExecuteCommand)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Could not read the database CPU utilization for adaptive scheduling", "host", db.instance.GetHost(), "error", err)
+		return db.cpuPercent
+	}
+	var cpu float64
+	if rows.Next() {
+		if err := rows.ReadRow(&cpu); err != nil {
+			log.CtxLogger(ctx).Debugw("Could not read the database CPU utilization for adaptive scheduling", "host", db.instance.GetHost(), "error", err)
+			return db.cpuPercent
+		}
+		db.cpuPercent = cpu
+	}
+	return db.cpuPercent
+}
+
+// nextInterval returns the sample interval in seconds to use after a query
+// ran for responseTimeMs while the database CPU utilization was cpuPercent,
+// which is negative if unknown.
+// The interval is backed off while the query exceeds its budget or the
+// database is busy, and sped up towards the configured interval while the
+// query is within budget and the database is idle. An unknown utilization,
+// for example without privilege on M_LOAD_HISTORY_HOST, counts as idle so
+// that a query which backed off returns to its configured interval.
+func nextInterval(cfg *cpb.AdaptiveScheduling, query *cpb.Query, configured, current, responseTimeMs int64, cpuPercent float64) int64 {
+	budget := query.GetResponseTimeBudgetMs()
+	if budget <= 0 {
+		budget = cfg.GetResponseTimeBudgetMs()
+	}
+	if budget <= 0 {
+		budget = defaultResponseTimeBudgetMs
+	}
+	busy, idle := float64(cfg.GetCpuBusyPercent()), float64(cfg.GetCpuIdlePercent())
+	if busy <= 0 {
+		busy = defaultCPUBusyPercent
+	}
+	if idle <= 0 {
+		idle = defaultCPUIdlePercent
+	}
+	multiplier := cfg.GetBackoffMultiplier()
+	if multiplier <= 1 {
+		multiplier = defaultBackoffMultiplier
+	}
+	maxInterval := cfg.GetMaxSampleIntervalSec()
+	if maxInterval <= 0 {
+		maxInterval = defaultMaxSampleIntervalSec
+	}
+	maxInterval = max(maxInterval, configured)
+
+	switch {
+	case responseTimeMs > budget || cpuPercent >= busy:
+		return min(int64(math.Ceil(float64(current)*multiplier)), maxInterval)
+	case cpuPercent < idle:
+		return max(int64(float64(current)/multiplier), configured)
+	default:
+		return current
+	}
+}
+
+// adaptInterval returns the sample interval for the next run of the query and
+// the metric reporting it, which is sent along with the query results.
+func adaptInterval(ctx context.Context, opts queryOptions, responseTimeMs int64) (int64, *mrpb.TimeSeries) {
+	current := opts.currentInterval
+	if current <= 0 {
+		current = opts.sampleInterval
+	}
+	cpu := opts.db.cpuUtilization(ctx)
+	next := nextInterval(opts.params.Config.GetHanaMonitoringConfiguration().GetAdaptiveScheduling(), opts.query, opts.sampleInterval, current, responseTimeMs, cpu)
+	if next != current {
+		log.CtxLogger(ctx).Infow("Adjusted HANA Monitoring query sample interval", "query", opts.query.GetName(), "host", opts.db.instance.GetHost(), "responseTimeMs", responseTimeMs, "cpuPercent", cpu, "previousIntervalSec", current, "intervalSec", next)
+	}
+	return next, sampleIntervalMetric(opts, next)
+}
+
+// sampleIntervalMetric returns the metric reporting the effective sample interval of
+// the query, and exports it. All queries share the metric type, labeled by query.
+func sampleIntervalMetric(opts queryOptions, interval int64) *mrpb.TimeSeries {
+	ts := timeseries.BuildInt(timeseries.Params{
+		CloudProp:  protostruct.ConvertCloudPropertiesToStruct(opts.params.Config.GetCloudProperties()),
+		MetricType: metricURL + "/sample_interval_sec",
+		MetricLabels: map[string]string{
+			"instance_name": opts.db.instance.GetName(),
+			"sid":           opts.db.instance.GetSid(),
+			"query":         opts.query.GetName(),
+		},
+		Timestamp:  tspb.Now(),
+		BareMetal:  opts.params.Config.GetBareMetal(),
+		Int64Value: interval,
+	})
+	if opts.params.exporter != nil {
+		opts.params.exporter.update(exportSource(opts.db, opts.query.GetName()+"/schedule"), []*mrpb.TimeSeries{ts}, time.Duration(interval)*time.Second)
+	}
+	return ts
+}
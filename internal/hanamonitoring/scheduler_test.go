@@ -0,0 +1,269 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gammazero/workerpool"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+
+	mpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	wpb "google.golang.org/protobuf/types/known/wrapperspb"
+	configpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+// recordingTimeSeriesCreator records the CreateTimeSeries requests it receives.
+type recordingTimeSeriesCreator struct {
+	mu       sync.Mutex
+	requests []*mpb.CreateTimeSeriesRequest
+}
+
+func (r *recordingTimeSeriesCreator) CreateTimeSeries(ctx context.Context, req *mpb.CreateTimeSeriesRequest, opts ...gax.CallOption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	return nil
+}
+
+func TestNextInterval(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            *configpb.AdaptiveScheduling
+		query          *configpb.Query
+		current        int64
+		responseTimeMs int64
+		cpuPercent     float64
+		want           int64
+	}{
+		{
+			name:           "WithinBudget",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true},
+			current:        60,
+			responseTimeMs: 500,
+			cpuPercent:     60,
+			want:           60,
+		},
+		{
+			name:           "OverDefaultBudget",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true},
+			current:        60,
+			responseTimeMs: 10001,
+			cpuPercent:     -1,
+			want:           120,
+		},
+		{
+			name:           "OverQueryBudget",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true, ResponseTimeBudgetMs: 5000},
+			query:          &configpb.Query{ResponseTimeBudgetMs: 1000},
+			current:        60,
+			responseTimeMs: 2000,
+			cpuPercent:     10,
+			want:           120,
+		},
+		{
+			name:           "QueryBudgetTakesPrecedence",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true, ResponseTimeBudgetMs: 1000},
+			query:          &configpb.Query{ResponseTimeBudgetMs: 5000},
+			current:        60,
+			responseTimeMs: 2000,
+			cpuPercent:     60,
+			want:           60,
+		},
+		{
+			name:           "DatabaseBusy",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true, CpuBusyPercent: 90, BackoffMultiplier: 1.5},
+			current:        60,
+			responseTimeMs: 100,
+			cpuPercent:     95,
+			want:           90,
+		},
+		{
+			name:           "BackoffCapped",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true, MaxSampleIntervalSec: 600},
+			current:        400,
+			responseTimeMs: 100,
+			cpuPercent:     85,
+			want:           600,
+		},
+		{
+			name:           "DatabaseIdle",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true},
+			current:        240,
+			responseTimeMs: 100,
+			cpuPercent:     20,
+			want:           120,
+		},
+		{
+			name:           "IdleNotBelowConfigured",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true},
+			current:        70,
+			responseTimeMs: 100,
+			cpuPercent:     20,
+			want:           60,
+		},
+		{
+			name:           "UnknownCPUTreatedAsIdle",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true},
+			current:        240,
+			responseTimeMs: 100,
+			cpuPercent:     -1,
+			want:           120,
+		},
+		{
+			name:           "ModerateCPUKeepsInterval",
+			cfg:            &configpb.AdaptiveScheduling{Enabled: true},
+			current:        240,
+			responseTimeMs: 100,
+			cpuPercent:     60,
+			want:           240,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := nextInterval(test.cfg, test.query, 60, test.current, test.responseTimeMs, test.cpuPercent)
+			if got != test.want {
+				t.Errorf("nextInterval() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCPUUtilization(t *testing.T) {
+	calls := 0
+	db := &database{
+		instance: &configpb.HANAInstance{Host: "localhost"},
+		queryFunc: func(ctx context.Context, query string, exec commandlineexecutor.Execute) (*databaseconnector.QueryResults, error) {
+			calls++
+			return fakeQueryFuncError(ctx, query, exec)
+		},
+	}
+	if got := db.cpuUtilization(context.Background()); got != -1 {
+		t.Errorf("cpuUtilization() = %v, want -1 when the query fails", got)
+	}
+	// The reading is shared for a minute.
+	db.cpuUtilization(context.Background())
+	if calls != 1 {
+		t.Errorf("cpuUtilization() queried the database %d times, want 1", calls)
+	}
+}
+
+func TestQueryAndSendAdaptive(t *testing.T) {
+	params := defaultParams
+	params.Config = &configpb.Configuration{
+		CloudProperties: defaultParams.Config.GetCloudProperties(),
+		HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
+			Enabled:               true,
+			AdaptiveScheduling:    &configpb.AdaptiveScheduling{Enabled: true},
+			SendToCloudMonitoring: wpb.Bool(false),
+			PrometheusExporter:    &configpb.PrometheusExporter{Enabled: true},
+		},
+	}
+	params.exporter = newExporter()
+	opts := queryOptions{
+		db:             &database{queryFunc: fakeQueryFuncError, instance: &configpb.HANAInstance{Name: "hana", Sid: "HDB"}},
+		query:          &configpb.Query{Name: "host", ResponseTimeBudgetMs: 1},
+		sampleInterval: 60,
+		params:         params,
+		wp:             workerpool.New(1),
+	}
+	if _, err := queryAndSend(context.Background(), opts); err == nil {
+		t.Fatal("queryAndSend() succeeded, want the query error")
+	}
+	rec := httptest.NewRecorder()
+	params.exporter.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), `sap_hanamonitoring_sample_interval_sec{instance_name="hana",query="host",sid="HDB"}`) {
+		t.Errorf("exporter output = %q, want the sample interval metric", rec.Body.String())
+	}
+}
+
+func TestQueryAndSendAdaptiveSendsOneRequest(t *testing.T) {
+	client := &recordingTimeSeriesCreator{}
+	params := defaultParams
+	params.TimeSeriesCreator = client
+	params.Config = &configpb.Configuration{
+		CloudProperties: defaultParams.Config.GetCloudProperties(),
+		HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
+			Enabled:            true,
+			AdaptiveScheduling: &configpb.AdaptiveScheduling{Enabled: true},
+		},
+	}
+	opts := queryOptions{
+		db:             &database{queryFunc: fakeQueryFuncError, instance: &configpb.HANAInstance{Name: "hana", Sid: "HDB"}},
+		query:          &configpb.Query{Name: "host"},
+		sampleInterval: 60,
+		params:         params,
+		wp:             workerpool.New(1),
+	}
+	queryAndSend(context.Background(), opts)
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.requests) != 1 {
+		t.Fatalf("queryAndSend() sent %d CreateTimeSeries requests, want 1", len(client.requests))
+	}
+	series := client.requests[0].GetTimeSeries()
+	if len(series) != 1 {
+		t.Fatalf("queryAndSend() sent %d time series, want the sample interval only", len(series))
+	}
+	if got, want := series[0].GetMetric().GetType(), "workload.googleapis.com/sap/hanamonitoring/sample_interval_sec"; got != want {
+		t.Errorf("queryAndSend() sent metric type %q, want %q", got, want)
+	}
+	if got := series[0].GetMetric().GetLabels()["query"]; got != "host" {
+		t.Errorf("queryAndSend() sent query label %q, want %q", got, "host")
+	}
+}
+
+func TestQueryAndSendNotDueSkipsCPUQuery(t *testing.T) {
+	params := defaultParams
+	params.HRC = fakeHRCSucessSecondary
+	params.Config = &configpb.Configuration{
+		CloudProperties: defaultParams.Config.GetCloudProperties(),
+		HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
+			Enabled:               true,
+			AdaptiveScheduling:    &configpb.AdaptiveScheduling{Enabled: true},
+			SendToCloudMonitoring: wpb.Bool(false),
+		},
+	}
+	calls := 0
+	opts := queryOptions{
+		db: &database{
+			instance: &configpb.HANAInstance{Name: "hana", Sid: "HDB", IsLocal: true, InstanceNum: "00"},
+			queryFunc: func(ctx context.Context, query string, exec commandlineexecutor.Execute) (*databaseconnector.QueryResults, error) {
+				calls++
+				return fakeQueryFuncError(ctx, query, exec)
+			},
+		},
+		query:           &configpb.Query{Name: "host", RunOn: configpb.RunOn_PRIMARY},
+		sampleInterval:  60,
+		currentInterval: 240,
+		params:          params,
+		wp:              workerpool.New(1),
+	}
+	if _, err := queryAndSend(context.Background(), opts); err != nil {
+		t.Errorf("queryAndSend() returned error %v, want nil", err)
+	}
+	if calls != 0 {
+		t.Errorf("queryAndSend() queried the database %d times, want 0 when the query is not due", calls)
+	}
+}
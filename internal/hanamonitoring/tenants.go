@@ -0,0 +1,237 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gammazero/workerpool"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
+	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
+
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+// tenantQuery lists the active tenant databases with the SQL port of their coordinator indexserver.
+const tenantQuery = "SELECT D.DATABASE_NAME, TO_VARCHAR(S.SQL_PORT) FROM SYS.M_DATABASES D JOIN SYS_DATABASES.M_SERVICES S ON D.DATABASE_NAME = S.DATABASE_NAME WHERE D.ACTIVE_STATUS = 'YES' AND D.DATABASE_NAME <> 'SYSTEMDB' AND S.SERVICE_NAME = 'indexserver' AND S.COORDINATOR_TYPE = 'MASTER' AND S.SQL_PORT <> 0 ORDER BY D.DATABASE_NAME;"
+
+type (
+	// Tenant is an active tenant database found through the SYSTEMDB.
+	Tenant struct {
+		Name string
+		Port string
+	}
+
+	// connectFunc opens a connection to a HANA instance, returning its query function and a function closing it.
+	connectFunc func(ctx context.Context, i *cpb.HANAInstance) (queryFunc, func() error, error)
+
+	// monitoredTenant is a tenant database whose queries are running.
+	monitoredTenant struct {
+		db     *database
+		cancel context.CancelFunc
+		close  func() error
+	}
+
+	// tenantDiscovery keeps the monitored tenant databases of a SYSTEMDB in step with M_DATABASES.
+	tenantDiscovery struct {
+		systemDB   *database
+		list       func(ctx context.Context) ([]Tenant, error)
+		connect    connectFunc
+		submit     func(ctx context.Context, db *database)
+		exporter   *exporter
+		isAuthErr  isAuthErrorFunc
+		tenants    map[string]*monitoredTenant
+		authErrors map[string]bool
+	}
+)
+
+// ListTenants returns the active tenant databases and their SQL ports, queried through a SYSTEMDB connection.
+func ListTenants(ctx context.Context, query func(context.Context, string, commandlineexecutor.Execute) (*databaseconnector.QueryResults, error)) ([]Tenant, error) {
+	rows, err := query(ctx, tenantQuery, commandlineexecutor.ExecuteCommand)
+	if err != nil {
+		return nil, err
+	}
+	var tenants []Tenant
+	for rows.Next() {
+		var t Tenant
+		if err := rows.ReadRow(&t.Name, &t.Port); err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, t)
+	}
+	return tenants, nil
+}
+
+// tenantInstance returns the configuration of a tenant database, based on its SYSTEMDB.
+// The SYSTEMDB hdbuserstore key is never reused: hdbsql ignores the port when a key is set,
+// so the tenant would connect to the SYSTEMDB again.
+func tenantInstance(systemDB *cpb.HANAInstance, t Tenant) *cpb.HANAInstance {
+	i := proto.Clone(systemDB).(*cpb.HANAInstance)
+	i.Name = systemDB.GetName() + "/" + t.Name
+	i.Port = t.Port
+	i.DiscoverTenants = false
+	i.TenantHdbuserstoreKeyPattern = ""
+	i.HdbuserstoreKey = ""
+	if pattern := systemDB.GetTenantHdbuserstoreKeyPattern(); pattern != "" {
+		i.HdbuserstoreKey = strings.ReplaceAll(pattern, "{tenant}", t.Name)
+	}
+	return i
+}
+
+// canDiscoverTenants reports whether the tenants of a SYSTEMDB can be connected to.
+// A SYSTEMDB connected through an hdbuserstore key has no credentials usable for its tenants
+// unless a tenant key pattern is configured.
+func canDiscoverTenants(systemDB *cpb.HANAInstance) bool {
+	return systemDB.GetHdbuserstoreKey() == "" || systemDB.GetTenantHdbuserstoreKeyPattern() != ""
+}
+
+// startTenantDiscovery starts discovering the tenants of each SYSTEMDB configured with discover_tenants.
+func startTenantDiscovery(ctx context.Context, params Parameters, wp *workerpool.WorkerPool, databases []*database) {
+	for _, db := range databases {
+		if !db.instance.GetDiscoverTenants() {
+			continue
+		}
+		if !canDiscoverTenants(db.instance) {
+			log.CtxLogger(ctx).Errorw("Not discovering tenants, tenant_hdbuserstore_key_pattern is required when the SYSTEMDB uses an hdbuserstore_key", "name", db.instance.GetName())
+			continue
+		}
+		systemDB := db
+		d := &tenantDiscovery{
+			systemDB: systemDB,
+			list: func(ctx context.Context) ([]Tenant, error) {
+				return ListTenants(ctx, systemDB.queryFunc)
+			},
+			connect: func(ctx context.Context, i *cpb.HANAInstance) (queryFunc, func() error, error) {
+				handle, err := databaseconnector.CreateDBHandle(ctx, dbParams(params, i))
+				if err != nil {
+					return nil, nil, err
+				}
+				return handle.Query, handle.Close, nil
+			},
+			submit: func(ctx context.Context, db *database) {
+				submitQueriesToWorkerPool(ctx, submitQueriesToWorkerPoolArgs{params: params, wp: wp, databases: []*database{db}})
+			},
+			exporter:   params.exporter,
+			isAuthErr:  databaseconnector.IsAuthError,
+			tenants:    make(map[string]*monitoredTenant),
+			authErrors: make(map[string]bool),
+		}
+		routine := &recovery.RecoverableRoutine{
+			Routine: func(ctx context.Context, a any) {
+				d.run(ctx, params.ConnectionRetryInterval)
+			},
+			ErrorCode:           usagemetrics.HANAMonitoringCreateWorkerPoolFailure,
+			UsageLogger:         *usagemetrics.Logger,
+			ExpectedMinDuration: time.Minute,
+		}
+		routine.StartRoutine(ctx)
+	}
+}
+
+// run refreshes the tenants on each interval until the context is cancelled.
+func (d *tenantDiscovery) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		d.update(ctx)
+		select {
+		case <-ctx.Done():
+			log.CtxLogger(ctx).Info("HANA Monitoring context cancelled, stopping tenant discovery")
+			for name, mt := range d.tenants {
+				d.stop(ctx, name, mt)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update starts the queries of new tenants and stops those of tenants which
+// are no longer active or have moved to a different port.
+func (d *tenantDiscovery) update(ctx context.Context) {
+	system := d.systemDB.instance.GetName()
+	found, err := d.list(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Error discovering tenant databases", "instance", system, "error", err)
+		usagemetrics.Error(usagemetrics.HANAMonitoringCollectionFailure)
+		return
+	}
+	active := make(map[string]Tenant)
+	for _, t := range found {
+		active[t.Name] = t
+	}
+	for name, mt := range d.tenants {
+		if t, ok := active[name]; ok && t.Port == mt.db.instance.GetPort() {
+			continue
+		}
+		log.CtxLogger(ctx).Infow("Tenant database is no longer active, stopping its queries", "instance", system, "tenant", name)
+		d.stop(ctx, name, mt)
+	}
+	for _, t := range found {
+		if _, ok := d.tenants[t.Name]; ok || d.authErrors[t.Name] {
+			continue
+		}
+		instance := tenantInstance(d.systemDB.instance, t)
+		query, closeFunc, err := d.connect(ctx, instance)
+		if err != nil {
+			log.CtxLogger(ctx).Errorw("Error connecting to tenant database", "instance", system, "tenant", t.Name, "port", t.Port, "error", err)
+			usagemetrics.Error(usagemetrics.HANAMonitoringCollectionFailure)
+			if d.isAuthErr(err) {
+				// Retrying with the wrong credentials could lock out the user.
+				d.authErrors[t.Name] = true
+			}
+			continue
+		}
+		tenantCtx, cancel := context.WithCancel(ctx)
+		db := &database{queryFunc: query, instance: instance}
+		d.tenants[t.Name] = &monitoredTenant{db: db, cancel: cancel, close: closeFunc}
+		log.CtxLogger(ctx).Infow("Discovered tenant database, starting its queries", "instance", system, "tenant", t.Name, "port", t.Port)
+		d.submit(tenantCtx, db)
+	}
+	log.CtxLogger(ctx).Debugw("HANA tenant topology", "instance", system, "tenants", d.tenantNames())
+}
+
+// stop cancels the queries of a tenant, closes its connection and stops exporting its time series.
+func (d *tenantDiscovery) stop(ctx context.Context, name string, mt *monitoredTenant) {
+	mt.cancel()
+	if mt.close != nil {
+		if err := mt.close(); err != nil {
+			log.CtxLogger(ctx).Debugw("Error closing tenant database connection", "instance", d.systemDB.instance.GetName(), "tenant", name, "error", err)
+		}
+	}
+	if d.exporter != nil {
+		d.exporter.removeDatabase(mt.db)
+	}
+	delete(d.tenants, name)
+}
+
+// tenantNames returns the names of the monitored tenants.
+func (d *tenantDiscovery) tenantNames() []string {
+	var names []string
+	for name := range d.tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
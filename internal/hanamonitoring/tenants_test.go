@@ -0,0 +1,367 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package hanamonitoring
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+
+	configpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+var errAuth = errors.New("authentication failed")
+
+func TestTenantInstance(t *testing.T) {
+	tests := []struct {
+		name     string
+		systemDB *configpb.HANAInstance
+		tenant   Tenant
+		want     *configpb.HANAInstance
+	}{
+		{
+			name: "SystemDBCredentials",
+			systemDB: &configpb.HANAInstance{
+				Name:            "sap-hana",
+				Sid:             "HDB",
+				Host:            "localhost",
+				Port:            "30013",
+				User:            "SYSTEM",
+				SecretName:      "hana-password",
+				DiscoverTenants: true,
+			},
+			tenant: Tenant{Name: "DB1", Port: "30041"},
+			want: &configpb.HANAInstance{
+				Name:       "sap-hana/DB1",
+				Sid:        "HDB",
+				Host:       "localhost",
+				Port:       "30041",
+				User:       "SYSTEM",
+				SecretName: "hana-password",
+			},
+		},
+		{
+			name: "SystemDBKeyNotReused",
+			systemDB: &configpb.HANAInstance{
+				Name:            "sap-hana",
+				Host:            "localhost",
+				Port:            "30013",
+				HdbuserstoreKey: "SYSKEY",
+				DiscoverTenants: true,
+			},
+			tenant: Tenant{Name: "DB1", Port: "30041"},
+			want: &configpb.HANAInstance{
+				Name: "sap-hana/DB1",
+				Host: "localhost",
+				Port: "30041",
+			},
+		},
+		{
+			name: "TenantKeyPattern",
+			systemDB: &configpb.HANAInstance{
+				Name:                         "sap-hana",
+				Port:                         "30013",
+				HdbuserstoreKey:              "SYSKEY",
+				DiscoverTenants:              true,
+				TenantHdbuserstoreKeyPattern: "MON_{tenant}",
+			},
+			tenant: Tenant{Name: "DB1", Port: "30041"},
+			want: &configpb.HANAInstance{
+				Name:            "sap-hana/DB1",
+				Port:            "30041",
+				HdbuserstoreKey: "MON_DB1",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tenantInstance(tc.systemDB, tc.tenant)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("tenantInstance(%v, %v) returned unexpected diff (-want +got):\n%s", tc.systemDB, tc.tenant, diff)
+			}
+		})
+	}
+}
+
+func TestTenantDBParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		systemDB *configpb.HANAInstance
+		wantKey  string
+		wantPort string
+	}{
+		{
+			name:     "UserAndSecret",
+			systemDB: &configpb.HANAInstance{Name: "sap-hana", Host: "localhost", Port: "30013", User: "SYSTEM", SecretName: "hana-password", DiscoverTenants: true},
+			wantPort: "30041",
+		},
+		{
+			name:     "SystemDBKey",
+			systemDB: &configpb.HANAInstance{Name: "sap-hana", Host: "localhost", Port: "30013", HdbuserstoreKey: "SYSKEY", DiscoverTenants: true},
+			wantPort: "30041",
+		},
+		{
+			name:     "TenantKeyPattern",
+			systemDB: &configpb.HANAInstance{Name: "sap-hana", Host: "localhost", Port: "30013", HdbuserstoreKey: "SYSKEY", DiscoverTenants: true, TenantHdbuserstoreKeyPattern: "MON_{tenant}"},
+			wantKey:  "MON_DB1",
+			wantPort: "30041",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := dbParams(Parameters{Config: &configpb.Configuration{}}, tenantInstance(tc.systemDB, Tenant{Name: "DB1", Port: "30041"}))
+			if got.HDBUserKey != tc.wantKey {
+				t.Errorf("dbParams() HDBUserKey = %q, want %q", got.HDBUserKey, tc.wantKey)
+			}
+			if got.Port != tc.wantPort {
+				t.Errorf("dbParams() Port = %q, want %q", got.Port, tc.wantPort)
+			}
+		})
+	}
+}
+
+func TestCanDiscoverTenants(t *testing.T) {
+	tests := []struct {
+		name     string
+		systemDB *configpb.HANAInstance
+		want     bool
+	}{
+		{
+			name:     "UserAndPassword",
+			systemDB: &configpb.HANAInstance{User: "SYSTEM", Password: "password", DiscoverTenants: true},
+			want:     true,
+		},
+		{
+			name:     "KeyWithoutPattern",
+			systemDB: &configpb.HANAInstance{HdbuserstoreKey: "SYSKEY", DiscoverTenants: true},
+			want:     false,
+		},
+		{
+			name:     "KeyWithPattern",
+			systemDB: &configpb.HANAInstance{HdbuserstoreKey: "SYSKEY", DiscoverTenants: true, TenantHdbuserstoreKeyPattern: "MON_{tenant}"},
+			want:     true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := canDiscoverTenants(tc.systemDB); got != tc.want {
+				t.Errorf("canDiscoverTenants(%v) = %t, want %t", tc.systemDB, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTenantDiscoveryUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		rounds      [][]Tenant
+		listErr     error
+		connectErrs map[string]error
+		wantTenants []string
+		wantPorts   map[string]string
+		wantSubmits int
+		wantClosed  []string
+	}{
+		{
+			name:        "NewTenants",
+			rounds:      [][]Tenant{{{Name: "DB1", Port: "30041"}, {Name: "DB2", Port: "30044"}}},
+			wantTenants: []string{"DB1", "DB2"},
+			wantPorts:   map[string]string{"DB1": "30041", "DB2": "30044"},
+			wantSubmits: 2,
+		},
+		{
+			name:        "TenantRemoved",
+			rounds:      [][]Tenant{{{Name: "DB1", Port: "30041"}, {Name: "DB2", Port: "30044"}}, {{Name: "DB2", Port: "30044"}}},
+			wantTenants: []string{"DB2"},
+			wantPorts:   map[string]string{"DB2": "30044"},
+			wantSubmits: 2,
+			wantClosed:  []string{"sap-hana/DB1"},
+		},
+		{
+			name:        "TenantPortChanged",
+			rounds:      [][]Tenant{{{Name: "DB1", Port: "30041"}}, {{Name: "DB1", Port: "30047"}}},
+			wantTenants: []string{"DB1"},
+			wantPorts:   map[string]string{"DB1": "30047"},
+			wantSubmits: 2,
+			wantClosed:  []string{"sap-hana/DB1"},
+		},
+		{
+			name:        "UnchangedTenants",
+			rounds:      [][]Tenant{{{Name: "DB1", Port: "30041"}}, {{Name: "DB1", Port: "30041"}}},
+			wantTenants: []string{"DB1"},
+			wantPorts:   map[string]string{"DB1": "30041"},
+			wantSubmits: 1,
+		},
+		{
+			name:        "ConnectionRetried",
+			rounds:      [][]Tenant{{{Name: "DB1", Port: "30041"}}, {{Name: "DB1", Port: "30041"}}},
+			connectErrs: map[string]error{"sap-hana/DB1": errors.New("connection refused")},
+			wantPorts:   map[string]string{},
+		},
+		{
+			name:        "AuthErrorNotRetried",
+			rounds:      [][]Tenant{{{Name: "DB1", Port: "30041"}}, {{Name: "DB1", Port: "30041"}}},
+			connectErrs: map[string]error{"sap-hana/DB1": errAuth},
+			wantPorts:   map[string]string{},
+		},
+		{
+			name:      "ListError",
+			rounds:    [][]Tenant{{}},
+			listErr:   errors.New("query failed"),
+			wantPorts: map[string]string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			round, submits := 0, 0
+			connects := map[string]int{}
+			var closed []string
+			d := &tenantDiscovery{
+				systemDB: &database{instance: &configpb.HANAInstance{Name: "sap-hana", Port: "30013", DiscoverTenants: true}},
+				list: func(context.Context) ([]Tenant, error) {
+					return tc.rounds[round], tc.listErr
+				},
+				connect: func(_ context.Context, i *configpb.HANAInstance) (queryFunc, func() error, error) {
+					connects[i.GetName()]++
+					if err := tc.connectErrs[i.GetName()]; err != nil {
+						return nil, nil, err
+					}
+					name := i.GetName()
+					return fakeQueryFunc, func() error { closed = append(closed, name); return nil }, nil
+				},
+				submit:     func(context.Context, *database) { submits++ },
+				isAuthErr:  func(err error) bool { return err == errAuth },
+				tenants:    make(map[string]*monitoredTenant),
+				authErrors: make(map[string]bool),
+			}
+			for round = range tc.rounds {
+				d.update(context.Background())
+			}
+
+			if diff := cmp.Diff(tc.wantTenants, d.tenantNames()); diff != "" {
+				t.Errorf("update() returned unexpected tenants (-want +got):\n%s", diff)
+			}
+			gotPorts := map[string]string{}
+			for name, mt := range d.tenants {
+				gotPorts[name] = mt.db.instance.GetPort()
+			}
+			if diff := cmp.Diff(tc.wantPorts, gotPorts); diff != "" {
+				t.Errorf("update() returned unexpected tenant ports (-want +got):\n%s", diff)
+			}
+			if submits != tc.wantSubmits {
+				t.Errorf("update() submitted queries %d times, want %d", submits, tc.wantSubmits)
+			}
+			if diff := cmp.Diff(tc.wantClosed, closed); diff != "" {
+				t.Errorf("update() closed unexpected connections (-want +got):\n%s", diff)
+			}
+			if tc.connectErrs != nil {
+				wantConnects := len(tc.rounds)
+				if tc.connectErrs["sap-hana/DB1"] == errAuth {
+					wantConnects = 1
+				}
+				if got := connects["sap-hana/DB1"]; got != wantConnects {
+					t.Errorf("update() connected %d times, want %d", got, wantConnects)
+				}
+			}
+		})
+	}
+}
+
+func TestTenantDiscoveryUpdateCancelsRemovedTenants(t *testing.T) {
+	var tenantCtx context.Context
+	tenants := []Tenant{{Name: "DB1", Port: "30041"}}
+	d := &tenantDiscovery{
+		systemDB: &database{instance: &configpb.HANAInstance{Name: "sap-hana", Host: "localhost", Port: "30013"}},
+		list:     func(context.Context) ([]Tenant, error) { return tenants, nil },
+		connect: func(context.Context, *configpb.HANAInstance) (queryFunc, func() error, error) {
+			return fakeQueryFunc, nil, nil
+		},
+		submit:     func(ctx context.Context, _ *database) { tenantCtx = ctx },
+		exporter:   newExporter(),
+		isAuthErr:  func(error) bool { return false },
+		tenants:    make(map[string]*monitoredTenant),
+		authErrors: make(map[string]bool),
+	}
+	d.update(context.Background())
+	if tenantCtx == nil || tenantCtx.Err() != nil {
+		t.Fatalf("update() did not submit queries with an active context")
+	}
+	tenantSource := exportSource(d.tenants["DB1"].db, "host")
+	systemSource := exportSource(d.systemDB, "host")
+	d.exporter.update(tenantSource, nil, time.Minute)
+	d.exporter.update(tenantSource+"/schedule", nil, time.Minute)
+	d.exporter.update(systemSource, nil, time.Minute)
+
+	tenants = nil
+	d.update(context.Background())
+	if tenantCtx.Err() == nil {
+		t.Errorf("update() did not cancel the queries of the removed tenant")
+	}
+	var got []string
+	for source := range d.exporter.series {
+		got = append(got, source)
+	}
+	if diff := cmp.Diff([]string{systemSource}, got); diff != "" {
+		t.Errorf("update() left unexpected exported sources (-want +got):\n%s", diff)
+	}
+}
+
+func TestTenantDiscoveryRunClosesTenants(t *testing.T) {
+	var closed []string
+	d := &tenantDiscovery{
+		systemDB: &database{instance: &configpb.HANAInstance{Name: "sap-hana"}},
+		list: func(context.Context) ([]Tenant, error) {
+			return []Tenant{{Name: "DB1", Port: "30041"}, {Name: "DB2", Port: "30044"}}, nil
+		},
+		connect: func(_ context.Context, i *configpb.HANAInstance) (queryFunc, func() error, error) {
+			name := i.GetName()
+			return fakeQueryFunc, func() error { closed = append(closed, name); return nil }, nil
+		},
+		submit:     func(context.Context, *database) {},
+		isAuthErr:  func(error) bool { return false },
+		tenants:    make(map[string]*monitoredTenant),
+		authErrors: make(map[string]bool),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.run(ctx, time.Minute)
+
+	sort.Strings(closed)
+	if diff := cmp.Diff([]string{"sap-hana/DB1", "sap-hana/DB2"}, closed); diff != "" {
+		t.Errorf("run() closed unexpected connections (-want +got):\n%s", diff)
+	}
+	if len(d.tenants) != 0 {
+		t.Errorf("run() kept tenants %v after the context was cancelled", d.tenantNames())
+	}
+}
+
+func TestListTenantsError(t *testing.T) {
+	query := func(context.Context, string, commandlineexecutor.Execute) (*databaseconnector.QueryResults, error) {
+		return nil, errors.New("query failed")
+	}
+	if _, err := ListTenants(context.Background(), query); err == nil {
+		t.Errorf("ListTenants() succeeded, want error")
+	}
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+// Package metricspool buffers time series which could not be sent to Cloud Monitoring
+// in a bounded write-ahead log on disk, and replays them in order once sending succeeds.
+//
+// Each failed CreateTimeSeries request is stored as its own segment file, so points keep
+// their original timestamps. While segments are pending, new time series are appended
+// behind them instead of being sent, as Cloud Monitoring rejects points written out of
+// order for a time series.
+package metricspool
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+const (
+	maxTSPerRequest = 200 // Reference: https://cloud.google.com/monitoring/quotas
+	// maxPointAge is the age after which Cloud Monitoring no longer accepts points.
+	maxPointAge   = 25 * time.Hour
+	segmentSuffix = ".tspb"
+	tmpSuffix     = ".tmp"
+)
+
+type (
+	// Spool is a disk-backed buffer of CreateTimeSeries requests, bounded by size and age.
+	// A nil Spool sends time series directly.
+	Spool struct {
+		mu       sync.Mutex
+		dir      string
+		maxBytes int64
+		maxAge   time.Duration
+		now      func() time.Time
+		create   func(ctx context.Context, client cloudmonitoring.TimeSeriesCreator, req *mrpb.CreateTimeSeriesRequest, bo *cloudmonitoring.BackOffIntervals) error
+		seq      uint64
+		size     int64
+		segments []segment
+
+		// replaying is set while a caller replays the spool without holding mu.
+		replaying bool
+	}
+
+	// segment is a spooled request, oldest first in the spool.
+	segment struct {
+		name    string
+		size    int64
+		created time.Time
+	}
+)
+
+// New opens the spool directory, picking up segments left by a previous run.
+func New(config *cpb.MetricSpool) (*Spool, error) {
+	maxAge := time.Duration(config.GetMaxAgeSec()) * time.Second
+	if maxAge <= 0 || maxAge > maxPointAge {
+		maxAge = maxPointAge
+	}
+	s := &Spool{
+		dir:      config.GetDirectory(),
+		maxBytes: config.GetMaxSizeMb() * 1024 * 1024,
+		maxAge:   maxAge,
+		now:      time.Now,
+		create:   cloudmonitoring.CreateTimeSeriesWithRetry,
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating spool directory %s: %w", s.dir, err)
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading spool directory %s: %w", s.dir, err)
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasSuffix(name, tmpSuffix) {
+			// An interrupted write, the request was never spooled.
+			os.Remove(filepath.Join(s.dir, name))
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		s.segments = append(s.segments, segment{name: name, size: info.Size(), created: info.ModTime()})
+		s.size += info.Size()
+		if seq >= s.seq {
+			s.seq = seq + 1
+		}
+	}
+	sort.Slice(s.segments, func(i, j int) bool { return s.segments[i].name < s.segments[j].name })
+	return s, nil
+}
+
+// Send sends the time series to Cloud Monitoring in batches. Spooled requests are
+// replayed first; batches which fail with a retryable error are spooled along with
+// the batches after them. While another caller replays the spool, the time series
+// are spooled behind it. The return values follow cloudmonitoring.SendTimeSeries.
+func (s *Spool) Send(ctx context.Context, timeSeries []*mrpb.TimeSeries, client cloudmonitoring.TimeSeriesCreator, bo *cloudmonitoring.BackOffIntervals, project string) (sent, batchCount int, err error) {
+	if s == nil {
+		return cloudmonitoring.SendTimeSeries(ctx, timeSeries, client, bo, project)
+	}
+	requests := batch(timeSeries, project)
+	s.mu.Lock()
+	if s.replaying {
+		s.append(ctx, requests)
+		s.mu.Unlock()
+		return 0, 0, nil
+	}
+	if len(s.segments) > 0 {
+		s.replaying = true
+		err := s.replay(ctx, client, bo)
+		s.replaying = false
+		if err != nil {
+			s.append(ctx, requests)
+			s.mu.Unlock()
+			return 0, 0, err
+		}
+	}
+	s.mu.Unlock()
+
+	var sendErr error
+	for i, req := range requests {
+		if err := s.create(ctx, client, req, bo); err != nil {
+			if retryable(err) {
+				s.mu.Lock()
+				s.append(ctx, requests[i:])
+				s.mu.Unlock()
+				return sent, batchCount, err
+			}
+			log.CtxLogger(ctx).Debugw("Time series rejected by Cloud Monitoring, not spooling", "error", err)
+			sendErr = err
+			continue
+		}
+		sent += len(req.GetTimeSeries())
+		batchCount++
+	}
+	return sent, batchCount, sendErr
+}
+
+// batch splits the time series into requests of at most maxTSPerRequest.
+func batch(timeSeries []*mrpb.TimeSeries, project string) []*mrpb.CreateTimeSeriesRequest {
+	var requests []*mrpb.CreateTimeSeriesRequest
+	for len(timeSeries) > 0 {
+		n := min(len(timeSeries), maxTSPerRequest)
+		requests = append(requests, &mrpb.CreateTimeSeriesRequest{
+			Name:       fmt.Sprintf("projects/%s", project),
+			TimeSeries: timeSeries[:n],
+		})
+		timeSeries = timeSeries[n:]
+	}
+	return requests
+}
+
+// retryable reports whether a failed request may succeed later, and is worth spooling.
+// Only transient failures are; permission, authentication and quota errors persist
+// and would fill the spool.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// replay sends the spooled requests, oldest first, removing each once it is sent, expired,
+// or rejected. Returns an error without removing the segment if sending should be retried.
+// Must be called with s.mu held and s.replaying set. s.mu is released while a request is
+// read and sent, so that other callers can spool behind the replay instead of waiting.
+func (s *Spool) replay(ctx context.Context, client cloudmonitoring.TimeSeriesCreator, bo *cloudmonitoring.BackOffIntervals) error {
+	replayed := 0
+	for len(s.segments) > 0 {
+		seg := s.segments[0]
+		if s.now().Sub(seg.created) > s.maxAge {
+			log.CtxLogger(ctx).Warnw("Dropping expired spooled time series", "segment", seg.name, "created", seg.created)
+			s.remove(seg.name)
+			continue
+		}
+		s.mu.Unlock()
+		err := s.replaySegment(ctx, client, bo, seg)
+		s.mu.Lock()
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Cloud Monitoring still unreachable, keeping spooled time series", "pending", len(s.segments), "error", err)
+			return err
+		}
+		// The segment may already have been dropped by append if the spool filled up.
+		s.remove(seg.name)
+		replayed++
+	}
+	if replayed > 0 {
+		log.CtxLogger(ctx).Infow("Replayed spooled time series", "requests", replayed)
+	}
+	return nil
+}
+
+// replaySegment sends a spooled request. Returns an error only if sending should be retried;
+// unreadable and rejected segments are logged and reported as done.
+func (s *Spool) replaySegment(ctx context.Context, client cloudmonitoring.TimeSeriesCreator, bo *cloudmonitoring.BackOffIntervals, seg segment) error {
+	data, err := os.ReadFile(filepath.Join(s.dir, seg.name))
+	req := &mrpb.CreateTimeSeriesRequest{}
+	if err == nil {
+		err = proto.Unmarshal(data, req)
+	}
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Dropping unreadable spooled time series", "segment", seg.name, "error", err)
+		return nil
+	}
+	if err := s.create(ctx, client, req, bo); err != nil {
+		if retryable(err) {
+			return err
+		}
+		log.CtxLogger(ctx).Warnw("Dropping spooled time series rejected by Cloud Monitoring", "segment", seg.name, "error", err)
+	}
+	return nil
+}
+
+// append writes each request to a new segment, then drops the oldest segments
+// until the spool fits its size limit. Must be called with s.mu held.
+func (s *Spool) append(ctx context.Context, requests []*mrpb.CreateTimeSeriesRequest) {
+	for _, req := range requests {
+		data, err := proto.Marshal(req)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Could not spool time series", "error", err)
+			continue
+		}
+		name := fmt.Sprintf("%020d%s", s.seq, segmentSuffix)
+		tmp := filepath.Join(s.dir, name+tmpSuffix)
+		// Writing to a temporary file first keeps a crash from leaving a partial segment.
+		if err := os.WriteFile(tmp, data, 0600); err != nil {
+			log.CtxLogger(ctx).Warnw("Could not spool time series", "error", err)
+			os.Remove(tmp)
+			continue
+		}
+		if err := os.Rename(tmp, filepath.Join(s.dir, name)); err != nil {
+			log.CtxLogger(ctx).Warnw("Could not spool time series", "error", err)
+			os.Remove(tmp)
+			continue
+		}
+		s.seq++
+		s.segments = append(s.segments, segment{name: name, size: int64(len(data)), created: s.now()})
+		s.size += int64(len(data))
+	}
+	for s.size > s.maxBytes && len(s.segments) > 0 {
+		log.CtxLogger(ctx).Warnw("Spool is full, dropping oldest spooled time series", "segment", s.segments[0].name, "maxBytes", s.maxBytes)
+		s.remove(s.segments[0].name)
+	}
+	log.CtxLogger(ctx).Debugw("Spooled time series", "requests", len(requests), "pending", len(s.segments), "bytes", s.size)
+}
+
+// remove deletes the named segment, if it is still spooled. Must be called with s.mu held.
+func (s *Spool) remove(name string) {
+	for i, seg := range s.segments {
+		if seg.name != name {
+			continue
+		}
+		os.Remove(filepath.Join(s.dir, seg.name))
+		s.segments = append(s.segments[:i], s.segments[i+1:]...)
+		s.size -= seg.size
+		return
+	}
+}
+
+// Pending returns the number of spooled requests waiting to be replayed.
+func (s *Spool) Pending() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.segments)
+}
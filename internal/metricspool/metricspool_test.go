@@ -0,0 +1,263 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package metricspool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+var (
+	errUnavailable = status.Error(codes.Unavailable, "unavailable")
+	errInvalid     = status.Error(codes.InvalidArgument, "points must be written in order")
+	errPermission  = status.Error(codes.PermissionDenied, "permission denied")
+)
+
+// fakeMonitoring records the metric types of each request it accepts, failing while err is set.
+type fakeMonitoring struct {
+	err  error
+	sent [][]string
+}
+
+func (f *fakeMonitoring) create(_ context.Context, _ cloudmonitoring.TimeSeriesCreator, req *mrpb.CreateTimeSeriesRequest, _ *cloudmonitoring.BackOffIntervals) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, metricTypes(req.GetTimeSeries()))
+	return nil
+}
+
+func metricTypes(timeSeries []*mrpb.TimeSeries) []string {
+	var types []string
+	for _, ts := range timeSeries {
+		types = append(types, ts.GetMetric().GetType())
+	}
+	return types
+}
+
+func series(types ...string) []*mrpb.TimeSeries {
+	var timeSeries []*mrpb.TimeSeries
+	for _, t := range types {
+		timeSeries = append(timeSeries, &mrpb.TimeSeries{Metric: &mpb.Metric{Type: t}})
+	}
+	return timeSeries
+}
+
+func newTestSpool(t *testing.T, maxSizeMb int64, f *fakeMonitoring) *Spool {
+	t.Helper()
+	s, err := New(&cpb.MetricSpool{Enabled: true, Directory: t.TempDir(), MaxSizeMb: maxSizeMb, MaxAgeSec: 3600})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	s.create = f.create
+	return s
+}
+
+func TestSend(t *testing.T) {
+	tests := []struct {
+		name        string
+		sends       [][]*mrpb.TimeSeries
+		errs        []error
+		wantSent    [][]string
+		wantPending int
+		wantErr     error
+	}{
+		{
+			name:     "Sent",
+			sends:    [][]*mrpb.TimeSeries{series("a", "b")},
+			errs:     []error{nil},
+			wantSent: [][]string{{"a", "b"}},
+		},
+		{
+			name:        "UnavailableSpooled",
+			sends:       [][]*mrpb.TimeSeries{series("a")},
+			errs:        []error{errUnavailable},
+			wantPending: 1,
+			wantErr:     errUnavailable,
+		},
+		{
+			name:    "RejectedNotSpooled",
+			sends:   [][]*mrpb.TimeSeries{series("a")},
+			errs:    []error{errInvalid},
+			wantErr: errInvalid,
+		},
+		{
+			name:    "PermissionDeniedNotSpooled",
+			sends:   [][]*mrpb.TimeSeries{series("a")},
+			errs:    []error{errPermission},
+			wantErr: errPermission,
+		},
+		{
+			name:     "ReplayedInOrder",
+			sends:    [][]*mrpb.TimeSeries{series("a1"), series("a2"), series("a3")},
+			errs:     []error{errUnavailable, errUnavailable, nil},
+			wantSent: [][]string{{"a1"}, {"a2"}, {"a3"}},
+		},
+		{
+			name:        "NewSeriesKeptBehindSpool",
+			sends:       [][]*mrpb.TimeSeries{series("a1"), series("a2")},
+			errs:        []error{errUnavailable, errUnavailable},
+			wantPending: 2,
+			wantErr:     errUnavailable,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &fakeMonitoring{}
+			s := newTestSpool(t, 1, f)
+			var err error
+			for i, ts := range tc.sends {
+				f.err = tc.errs[i]
+				_, _, err = s.Send(context.Background(), ts, nil, nil, "test-project")
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Send() returned error %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantSent, f.sent); diff != "" {
+				t.Errorf("Send() sent unexpected time series (-want +got):\n%s", diff)
+			}
+			if got := s.Pending(); got != tc.wantPending {
+				t.Errorf("Pending() = %d, want %d", got, tc.wantPending)
+			}
+		})
+	}
+}
+
+func TestSendDuringReplay(t *testing.T) {
+	f := &fakeMonitoring{err: errUnavailable}
+	s := newTestSpool(t, 1, f)
+	s.Send(context.Background(), series("a1"), nil, nil, "test-project")
+
+	// The replay blocks in create until released, as it would while backing off.
+	replaying := make(chan struct{})
+	release := make(chan struct{})
+	s.create = func(ctx context.Context, client cloudmonitoring.TimeSeriesCreator, req *mrpb.CreateTimeSeriesRequest, bo *cloudmonitoring.BackOffIntervals) error {
+		if metricTypes(req.GetTimeSeries())[0] == "a1" {
+			close(replaying)
+			<-release
+		}
+		f.err = nil
+		return f.create(ctx, client, req, bo)
+	}
+	done := make(chan error)
+	go func() {
+		_, _, err := s.Send(context.Background(), series("a2"), nil, nil, "test-project")
+		done <- err
+	}()
+	<-replaying
+
+	// Sending while the spool is replayed must not wait for the replay.
+	if _, _, err := s.Send(context.Background(), series("a3"), nil, nil, "test-project"); err != nil {
+		t.Errorf("Send() during replay returned error %v, want nil", err)
+	}
+	if got := s.Pending(); got != 2 {
+		t.Errorf("Pending() during replay = %d, want 2", got)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Send() returned error %v, want nil", err)
+	}
+	if diff := cmp.Diff([][]string{{"a1"}, {"a3"}, {"a2"}}, f.sent); diff != "" {
+		t.Errorf("Send() sent unexpected time series (-want +got):\n%s", diff)
+	}
+}
+
+func TestSendBatches(t *testing.T) {
+	f := &fakeMonitoring{}
+	s := newTestSpool(t, 1, f)
+	var types []string
+	for i := 0; i < 450; i++ {
+		types = append(types, fmt.Sprintf("m%d", i))
+	}
+	sent, batchCount, err := s.Send(context.Background(), series(types...), nil, nil, "test-project")
+	if err != nil || sent != 450 || batchCount != 3 {
+		t.Errorf("Send() = (%d, %d, %v), want (450, 3, nil)", sent, batchCount, err)
+	}
+}
+
+func TestSendNilSpool(t *testing.T) {
+	var s *Spool
+	if got := s.Pending(); got != 0 {
+		t.Errorf("Pending() = %d, want 0", got)
+	}
+}
+
+func TestSpoolLimits(t *testing.T) {
+	f := &fakeMonitoring{err: errUnavailable}
+	s := newTestSpool(t, 1, f)
+	s.maxBytes = 100
+	for i := 0; i < 10; i++ {
+		s.Send(context.Background(), series(fmt.Sprintf("metric_type_%d", i)), nil, nil, "test-project")
+	}
+	if s.size > s.maxBytes {
+		t.Errorf("spool size = %d, want at most %d", s.size, s.maxBytes)
+	}
+
+	// Segments older than the maximum age are dropped instead of replayed.
+	now := time.Now()
+	s.now = func() time.Time { return now.Add(2 * time.Hour) }
+	f.err = nil
+	s.Send(context.Background(), series("new"), nil, nil, "test-project")
+	if diff := cmp.Diff([][]string{{"new"}}, f.sent); diff != "" {
+		t.Errorf("Send() sent unexpected time series (-want +got):\n%s", diff)
+	}
+	if got := s.Pending(); got != 0 {
+		t.Errorf("Pending() = %d, want 0", got)
+	}
+}
+
+func TestNewPicksUpSegments(t *testing.T) {
+	f := &fakeMonitoring{err: errUnavailable}
+	s := newTestSpool(t, 1, f)
+	s.Send(context.Background(), series("a1"), nil, nil, "test-project")
+	s.Send(context.Background(), series("a2"), nil, nil, "test-project")
+	if err := os.WriteFile(filepath.Join(s.dir, "00000000000000000009.tspb.tmp"), []byte("partial"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := New(&cpb.MetricSpool{Enabled: true, Directory: s.dir, MaxSizeMb: 1, MaxAgeSec: 3600})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if got.Pending() != 2 || got.seq != 2 {
+		t.Errorf("New() found %d segments with next sequence %d, want 2 and 2", got.Pending(), got.seq)
+	}
+	if _, err := os.Stat(filepath.Join(s.dir, "00000000000000000009.tspb.tmp")); !os.IsNotExist(err) {
+		t.Errorf("New() did not remove the interrupted write: %v", err)
+	}
+	f.err = nil
+	got.create = f.create
+	got.Send(context.Background(), series("a3"), nil, nil, "test-project")
+	if diff := cmp.Diff([][]string{{"a1"}, {"a2"}, {"a3"}}, f.sent); diff != "" {
+		t.Errorf("Send() after New() sent unexpected time series (-want +got):\n%s", diff)
+	}
+}
@@ -26,12 +26,17 @@ import (
 	"flag"
 	s "cloud.google.com/go/storage"
 	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backend"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/backup"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/delete"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/diagnose"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/housekeeping"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/inquire"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/replication"
 	"github.com/GoogleCloudPlatform/sapagent/internal/backint/restore"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/rewrap"
+	"github.com/GoogleCloudPlatform/sapagent/internal/backint/verify"
 	cfg "github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/supportbundle"
@@ -71,7 +76,7 @@ func (*Backint) Synopsis() string { return "backup, restore, inquire, or delete
 
 // Usage implements the subcommand interface for backint.
 func (*Backint) Usage() string {
-	return `Usage: backint -function=<backup|restore|inquire|delete|diagnose>
+	return `Usage: backint -function=<backup|restore|inquire|delete|diagnose|rewrap|housekeeping|verify|replicate>
 	-paramfile=<path-to-file> [-v] [-h] -user=<DBNAME@SID> [-input=<path-to-file>]
 	[-output=<path-to-file>] [-backupid=<database-backup-id>] [-count=<number-of-objects>]
 	[-level=<backup-level>] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]` + "\n"
@@ -182,8 +187,12 @@ func (b *Backint) backintHandler(ctx context.Context, cloudProps *ipb.CloudPrope
 		Endpoint:         config.GetClientEndpoint(),
 		UserAgent:        cfg.StorageAgentName(),
 	}
-	if _, ok := storage.ConnectToBucket(ctx, connectParams); !ok {
-		return fmt.Sprintf("Failed to connect to bucket: %s", connectParams.BucketName), subcommands.ExitFailure
+	if backend.IsGCS(config) {
+		if _, ok := storage.ConnectToBucket(ctx, connectParams); !ok {
+			return fmt.Sprintf("Failed to connect to bucket: %s", connectParams.BucketName), subcommands.ExitFailure
+		}
+	} else if _, err := backend.New(ctx, config, connectParams); err != nil {
+		return fmt.Sprintf("Failed to initialize %s storage backend: %v", config.GetStorageBackend(), err), subcommands.ExitFailure
 	}
 
 	b.oteLogger.LogUsageAction(usagemetrics.BackintRunning)
@@ -233,6 +242,14 @@ func (b *Backint) runBackint(ctx context.Context, config *bpb.BackintConfigurati
 		return restore.Execute(ctx, config, connectParams, inFile, outFile, cloudProps)
 	case bpb.Function_DIAGNOSE:
 		return diagnose.Execute(ctx, config, connectParams, outFile, cloudProps)
+	case bpb.Function_REWRAP:
+		return rewrap.Execute(ctx, config, connectParams, outFile, cloudProps)
+	case bpb.Function_HOUSEKEEPING:
+		return housekeeping.Execute(ctx, config, connectParams, outFile, cloudProps)
+	case bpb.Function_VERIFY:
+		return verify.Execute(ctx, config, connectParams, outFile, cloudProps)
+	case bpb.Function_REPLICATE:
+		return replication.Execute(ctx, config, connectParams, outFile, cloudProps)
 	default:
 		log.CtxLogger(ctx).Errorw("Unsupported Backint function", "function", config.GetFunction().String())
 		return false
@@ -19,6 +19,7 @@ limitations under the License.
 package hanainsights
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
@@ -28,8 +29,11 @@ import (
 
 	"flag"
 	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/preprocessor"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/remediation"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/report"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/ruleengine"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
@@ -45,8 +49,15 @@ type HANAInsights struct {
 	gceService                                      onetime.GCEInterface
 	status                                          bool
 	db                                              *databaseconnector.DBHandle
+	database                                        string
 	help                                            bool
 	logLevel, logPath                               string
+	rulePaths, disabledRules                        string
+	remediate                                       bool
+	remediateRules, rollbackRunID                   string
+	confirm                                         remediation.ConfirmFunc
+	formats, compare                                string
+	readFile                                        func(string) ([]byte, error)
 	oteLogger                                       *onetime.OTELogger
 }
 
@@ -67,7 +78,10 @@ func (*HANAInsights) Synopsis() string { return "invoke HANA local insights work
 // Usage implements the subcommand interface for hanainsights.
 func (*HANAInsights) Usage() string {
 	return `Usage: hanainsights -project=<project-name> -host=<hostname> -port=<port-number> -sid=<HANA-SID> -user=<user-name>
-	[-password=<passwd> | -password-secret=<secret-name>] [-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]` + "\n"
+	[-password=<passwd> | -password-secret=<secret-name>] [-rule-paths=<path>[,<path>...]] [-disable-rules=<rule-id>[,<rule-id>...]]
+	[-remediate [-remediate-rules=<rule-id>[,<rule-id>...]] | -rollback=<run-id>]
+	[-format=<markdown|json|sarif>[,<format>...]] [-compare=<previous-report>]
+	[-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]` + "\n"
 }
 
 // SetFlags implements the subcommand interface for hanainsights.
@@ -80,6 +94,13 @@ func (h *HANAInsights) SetFlags(fs *flag.FlagSet) {
 	fs.StringVar(&h.password, "password", "", "HANA password. (discouraged - use password-secret instead)")
 	fs.StringVar(&h.passwordSecret, "password-secret", "", "Secret Manager secret name that holds HANA Password")
 	fs.StringVar(&h.hdbuserstoreKey, "hdbuserstore-key", "", "HANA Userstore key specific to HANA instance")
+	fs.StringVar(&h.rulePaths, "rule-paths", "", "Comma separated rule files, directories of rule files or gs://<bucket>/<prefix> paths to read custom rules from (optional)")
+	fs.StringVar(&h.disabledRules, "disable-rules", "", "Comma separated IDs of built-in rules to skip (optional)")
+	fs.BoolVar(&h.remediate, "remediate", false, "Run the SQL statements of the triggered recommendations after confirmation, recording an undo journal (optional)")
+	fs.StringVar(&h.remediateRules, "remediate-rules", "", "Comma separated IDs of the rules to remediate without confirmation, other rules are not remediated (optional)")
+	fs.StringVar(&h.rollbackRunID, "rollback", "", "Roll back the statements run by the remediation with this run ID (optional)")
+	fs.StringVar(&h.formats, "format", report.FormatMarkdown, "Comma separated formats of the reports to write: markdown, json or sarif (optional)")
+	fs.StringVar(&h.compare, "compare", "", "Previous JSON or SARIF report to show the new, resolved and unchanged findings against (optional)")
 	fs.StringVar(&h.logPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/hanainsights.log")
 	fs.BoolVar(&h.help, "h", false, "Display help")
 	fs.StringVar(&h.logLevel, "loglevel", "info", "Sets the logging level for a log file")
@@ -119,6 +140,15 @@ func (h *HANAInsights) validateParameters(os string) error {
 		return fmt.Errorf("%s", "required arguments not passed. Usage:"+h.Usage())
 	case h.password == "" && h.passwordSecret == "" && h.hdbuserstoreKey == "":
 		return fmt.Errorf("%s", "either -password, -password-secret or -hdbuserstore-key is required. Usage:"+h.Usage())
+	case h.remediate && h.rollbackRunID != "":
+		return fmt.Errorf("%s", "-remediate and -rollback cannot be used together. Usage:"+h.Usage())
+	case h.remediateRules != "" && !h.remediate:
+		return fmt.Errorf("%s", "-remediate-rules requires -remediate. Usage:"+h.Usage())
+	case (h.remediate || h.rollbackRunID != "") && h.hdbuserstoreKey != "":
+		return fmt.Errorf("%s", "-remediate and -rollback run statements with the go-hdb driver and require -password or -password-secret instead of -hdbuserstore-key. Usage:"+h.Usage())
+	}
+	if _, err := reportFormats(h.formats); err != nil {
+		return fmt.Errorf("%v. Usage:%s", err, h.Usage())
 	}
 
 	log.Logger.Info("Parameter validation successful.")
@@ -132,6 +162,14 @@ func (h *HANAInsights) hanaInsightsHandler(ctx context.Context, gceServiceCreato
 		return subcommands.ExitUsageError
 	}
 
+	var previous *report.Report
+	if h.compare != "" {
+		if previous, err = h.readReport(h.compare); err != nil {
+			h.oteLogger.LogErrorToFileAndConsole(ctx, "ERROR: Failed to read the report to compare with", err)
+			return subcommands.ExitFailure
+		}
+	}
+
 	h.oteLogger.LogUsageAction(usagemetrics.HANAInsightsOTEStarted)
 	h.gceService, err = gceServiceCreator(ctx)
 	if err != nil {
@@ -157,8 +195,24 @@ func (h *HANAInsights) hanaInsightsHandler(ctx context.Context, gceServiceCreato
 		h.oteLogger.LogErrorToFileAndConsole(ctx, "ERROR: Failed to connect to database", err)
 		return subcommands.ExitFailure
 	}
+	if h.remediate || h.rollbackRunID != "" {
+		if h.database, err = h.connectedDatabase(ctx); err != nil {
+			h.oteLogger.LogErrorToFileAndConsole(ctx, "ERROR: Failed to read the name of the connected database", err)
+			return subcommands.ExitFailure
+		}
+	}
 
-	rules, err := preprocessor.ReadRules(preprocessor.RuleFilenames)
+	if h.rollbackRunID != "" {
+		journal, err := h.remediator(nil).Rollback(ctx, h.rollbackRunID)
+		if err != nil {
+			h.oteLogger.LogErrorToFileAndConsole(ctx, "ERROR: Failed to roll back HANA Insights remediation", err)
+			return subcommands.ExitFailure
+		}
+		h.oteLogger.LogMessageToConsole(fmt.Sprintf("Rolled back HANA Insights remediation %s", journal.RunID))
+		return subcommands.ExitSuccess
+	}
+
+	rules, err := h.readRules(ctx)
 	if err != nil {
 		log.CtxLogger(ctx).Errorw("Failure to read HANA rules", "error", err)
 		return subcommands.ExitFailure
@@ -170,14 +224,175 @@ func (h *HANAInsights) hanaInsightsHandler(ctx context.Context, gceServiceCreato
 		return subcommands.ExitFailure
 	}
 	log.CtxLogger(ctx).Infow("Generating HANA insights", insights)
-	if err = generateLocalHANAInsights(rules, insights, wf, c); err != nil {
+	current, err := h.writeReports(rules, insights, wf, c)
+	if err != nil {
 		log.CtxLogger(ctx).Errorw("ERROR: Failed to generate local HANA insights", "error", err)
 		return subcommands.ExitFailure
 	}
+	if previous != nil {
+		h.oteLogger.LogMessageToConsole(fmt.Sprintf("HANA Insights findings compared with the report generated at %s: %s", previous.Generated.Format(time.RFC3339), report.Compare(previous, current)))
+	}
+	if h.remediate {
+		journal, err := h.remediator(rules).Remediate(ctx, rules, insights)
+		if len(journal.Entries) > 0 {
+			h.oteLogger.LogMessageToConsole(fmt.Sprintf("HANA Insights remediation %s ran %d actions, roll back with -rollback=%s", journal.RunID, len(journal.Entries), journal.RunID))
+		}
+		if err != nil {
+			h.oteLogger.LogErrorToFileAndConsole(ctx, "ERROR: Failed to remediate HANA insights", err)
+			return subcommands.ExitFailure
+		}
+	}
 	h.oteLogger.LogUsageAction(usagemetrics.HANAInsightsOTEFinished)
 	return subcommands.ExitSuccess
 }
 
+// remediator returns a remediator running statements on the database of the command.
+func (h *HANAInsights) remediator(rules []*rpb.Rule) *remediation.Remediator {
+	r := &remediation.Remediator{
+		Executor: h.db,
+		Evaluate: func(ctx context.Context, rule *rpb.Rule) ([]ruleengine.ValidationResult, map[string][]string, error) {
+			return ruleengine.RunRule(ctx, h.db, rules, rule)
+		},
+		Confirm:  h.confirm,
+		SID:      h.sid,
+		Database: h.database,
+	}
+	if r.Confirm == nil {
+		r.Confirm = confirmFromStdin
+	}
+	if ids := splitList(h.remediateRules); len(ids) > 0 {
+		r.AllowedRules = make(map[string]bool)
+		for _, id := range ids {
+			r.AllowedRules[id] = true
+		}
+	}
+	return r
+}
+
+// connectedDatabase identifies the connected database as host:port/database_name. Tenants of
+// a multitenant system share the SID, so the SID alone does not tell them apart.
+func (h *HANAInsights) connectedDatabase(ctx context.Context) (string, error) {
+	rows, err := h.db.Query(ctx, "SELECT DATABASE_NAME FROM M_DATABASE", commandlineexecutor.ExecuteCommand)
+	if err != nil {
+		return "", err
+	}
+	if !rows.Next() {
+		return "", fmt.Errorf("no rows returned from M_DATABASE")
+	}
+	var name string
+	if err := rows.ReadRow(&name); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s/%s", h.host, h.port, name), nil
+}
+
+// confirmFromStdin prints the prompt and reads a yes or no answer from the terminal.
+func confirmFromStdin(prompt string) bool {
+	fmt.Print(prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// readRules reads the built-in rules, along with the custom rule packs if any are given.
+func (h *HANAInsights) readRules(ctx context.Context) ([]*rpb.Rule, error) {
+	if h.rulePaths == "" && h.disabledRules == "" {
+		return preprocessor.ReadRules(preprocessor.RuleFilenames)
+	}
+	return preprocessor.ReadRulesWithPacks(ctx, preprocessor.RulePackOptions{
+		RulePaths:       splitList(h.rulePaths),
+		DisabledRuleIDs: splitList(h.disabledRules),
+	})
+}
+
+// splitList splits a comma separated flag value, ignoring empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// reportFormats returns the report formats of the -format flag, markdown if none are given.
+func reportFormats(s string) ([]string, error) {
+	formats := splitList(strings.ToLower(s))
+	if len(formats) == 0 {
+		return []string{report.FormatMarkdown}, nil
+	}
+	for _, f := range formats {
+		switch f {
+		case report.FormatMarkdown, report.FormatJSON, report.FormatSARIF:
+		default:
+			return nil, fmt.Errorf("invalid report format: %s, allowed formats are markdown, json and sarif", f)
+		}
+	}
+	return formats, nil
+}
+
+// readReport reads a previous JSON or SARIF report, which must have been generated for the SID
+// of the command.
+func (h *HANAInsights) readReport(path string) (*report.Report, error) {
+	readFile := h.readFile
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := report.Read(data)
+	if err != nil {
+		return nil, err
+	}
+	if r.SID != "" && !strings.EqualFold(r.SID, h.sid) {
+		return nil, fmt.Errorf("report %s was generated for SID %s, not %s", path, r.SID, h.sid)
+	}
+	return r, nil
+}
+
+// writeReports writes the insights under /var/log/google-cloud-sap-agent/ in each of the
+// report formats, and returns the report of the findings. Unlike the markdown file, JSON and
+// SARIF reports are written even without findings, to record clean runs.
+func (h *HANAInsights) writeReports(rules []*rpb.Rule, insights ruleengine.Insights, wf writeFile, c createDir) (*report.Report, error) {
+	r := report.New(h.sid, rules, insights, time.Now())
+	formats, err := reportFormats(h.formats)
+	if err != nil {
+		return nil, err
+	}
+	for _, format := range formats {
+		var content []byte
+		switch format {
+		case report.FormatMarkdown:
+			if err := generateLocalHANAInsights(rules, insights, wf, c); err != nil {
+				return nil, err
+			}
+			continue
+		case report.FormatJSON:
+			content, err = r.JSON()
+		case report.FormatSARIF:
+			content, err = r.SARIF()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := createDirHelper(c, localInsightsDir, os.FileMode(0755)); err != nil {
+			return nil, err
+		}
+		file := fmt.Sprintf("%s/local-hana-insights-%s.%s", localInsightsDir, r.Generated.Format(time.RFC3339), format)
+		if err := writeFileHelper(wf, file, content, os.FileMode(0644)); err != nil {
+			return nil, err
+		}
+		log.Logger.Infow("HANA Insights report written", "file", file)
+	}
+	return r, nil
+}
+
 // generateLocalHANAInsights will create the HANA Insights in a markdown file stored under the
 // directory /var/log/google-cloud-sap-agent/.
 func generateLocalHANAInsights(rules []*rpb.Rule, insights ruleengine.Insights, wf writeFile, c createDir) error {
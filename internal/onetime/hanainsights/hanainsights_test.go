@@ -19,12 +19,14 @@ package hanainsights
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"flag"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/report"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hanainsights/ruleengine"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
 	rpb "github.com/GoogleCloudPlatform/sapagent/protos/hanainsights"
@@ -148,6 +150,86 @@ func TestValidateParametersHANAInsights(t *testing.T) {
 			},
 			want: cmpopts.AnyError,
 		},
+		{
+			name: "RemediateAndRollback",
+			hanainsights: HANAInsights{
+				host:          "localhost",
+				port:          "123",
+				sid:           "HDB",
+				user:          "system",
+				password:      "password",
+				remediate:     true,
+				rollbackRunID: "20261017T101500Z",
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "RemediateRulesWithoutRemediate",
+			hanainsights: HANAInsights{
+				host:           "localhost",
+				port:           "123",
+				sid:            "HDB",
+				user:           "system",
+				password:       "password",
+				remediateRules: "r_log_encryption",
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "Remediate",
+			hanainsights: HANAInsights{
+				host:           "localhost",
+				port:           "123",
+				sid:            "HDB",
+				user:           "system",
+				password:       "password",
+				remediate:      true,
+				remediateRules: "r_log_encryption",
+			},
+		},
+		{
+			name: "RemediateWithUserstoreKey",
+			hanainsights: HANAInsights{
+				sid:             "HDB",
+				user:            "system",
+				hdbuserstoreKey: "HDBKEY",
+				remediate:       true,
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "RollbackWithUserstoreKey",
+			hanainsights: HANAInsights{
+				sid:             "HDB",
+				user:            "system",
+				hdbuserstoreKey: "HDBKEY",
+				rollbackRunID:   "20261017T101500Z",
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "InvalidFormat",
+			hanainsights: HANAInsights{
+				host:     "localhost",
+				port:     "123",
+				sid:      "HDB",
+				user:     "system",
+				password: "password",
+				formats:  "json,html",
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "Formats",
+			hanainsights: HANAInsights{
+				host:     "localhost",
+				port:     "123",
+				sid:      "HDB",
+				user:     "system",
+				password: "password",
+				formats:  "markdown, JSON,sarif",
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -192,6 +274,21 @@ func TestHANAInsightsHandler(t *testing.T) {
 			fakeNewGCE:   func(context.Context) (*gce.GCE, error) { return &gce.GCE{}, nil },
 			want:         subcommands.ExitSuccess,
 		},
+		{
+			name: "CompareReportUnreadable",
+			hanainsights: HANAInsights{
+				project:  "my-project",
+				host:     "localhost",
+				port:     "123",
+				sid:      "HDB",
+				user:     "system",
+				password: "password",
+				compare:  "/tmp/local-hana-insights.json",
+				readFile: func(string) ([]byte, error) { return nil, cmpopts.AnyError },
+			},
+			fakeNewGCE: func(context.Context) (*gce.GCE, error) { return &gce.GCE{}, nil },
+			want:       subcommands.ExitFailure,
+		},
 	}
 	for _, test := range tests {
 		test.hanainsights.oteLogger = onetime.CreateOTELogger(false)
@@ -349,3 +446,197 @@ func TestGenerateLocalHANAInsights(t *testing.T) {
 		})
 	}
 }
+
+func TestReportFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Default",
+			want: []string{"markdown"},
+		},
+		{
+			name:    "MultipleFormats",
+			formats: "JSON, sarif,",
+			want:    []string{"json", "sarif"},
+		},
+		{
+			name:    "InvalidFormat",
+			formats: "md",
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := reportFormats(tc.formats)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("reportFormats(%q) error = %v, want %v", tc.formats, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("reportFormats(%q) returned diff (-want +got):\n%s", tc.formats, diff)
+			}
+		})
+	}
+}
+
+func TestWriteReports(t *testing.T) {
+	rules := []*rpb.Rule{
+		&rpb.Rule{
+			Id: "rule1",
+			Recommendations: []*rpb.Recommendation{
+				&rpb.Recommendation{
+					Id:      "recommendation1",
+					Actions: []*rpb.Action{&rpb.Action{Description: "action1"}},
+				},
+			},
+		},
+	}
+	insights := ruleengine.Insights{
+		"rule1": []ruleengine.ValidationResult{{RecommendationID: "recommendation1", Result: true}},
+	}
+	tests := []struct {
+		name         string
+		formats      string
+		wf           writeFile
+		wantFiles    []string
+		wantFindings int
+		wantErr      error
+	}{
+		{
+			name:         "AllFormats",
+			formats:      "markdown,json,sarif",
+			wf:           fakeWritefileSuccess,
+			wantFiles:    []string{".md", ".json", ".sarif"},
+			wantFindings: 1,
+		},
+		{
+			name:    "WriteFileFailure",
+			formats: "json",
+			wf:      fakeWritefileFail,
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotFiles []string
+			wf := func(name string, content []byte, perm os.FileMode) error {
+				gotFiles = append(gotFiles, filepath.Ext(name))
+				if filepath.Ext(name) != ".md" {
+					if _, err := report.Read(content); err != nil {
+						t.Errorf("report.Read(%s) failed: %v", name, err)
+					}
+				}
+				return tc.wf(name, content, perm)
+			}
+			h := &HANAInsights{sid: "HDB", formats: tc.formats}
+			got, err := h.writeReports(rules, insights, wf, fakeCreateDirSuccess)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("writeReports() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantFiles, gotFiles); diff != "" {
+				t.Errorf("writeReports() wrote files with diff (-want +got):\n%s", diff)
+			}
+			if len(got.Findings) != tc.wantFindings {
+				t.Errorf("writeReports() returned %d findings, want %d", len(got.Findings), tc.wantFindings)
+			}
+		})
+	}
+}
+
+func TestReadReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		readFile func(string) ([]byte, error)
+		want     *report.Report
+		wantErr  error
+	}{
+		{
+			name: "JSONReport",
+			readFile: func(string) ([]byte, error) {
+				return []byte(`{"sid": "HDB", "findings": [{"rule_id": "rule1", "recommendation_id": "recommendation1"}]}`), nil
+			},
+			want: &report.Report{SID: "HDB", Findings: []report.Finding{{RuleID: "rule1", RecommendationID: "recommendation1"}}},
+		},
+		{
+			name: "OtherSID",
+			readFile: func(string) ([]byte, error) {
+				return []byte(`{"sid": "PRD", "findings": []}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:     "MarkdownReport",
+			readFile: func(string) ([]byte, error) { return []byte("# Recommendations\n"), nil },
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "ReadFailure",
+			readFile: func(string) ([]byte, error) { return nil, cmpopts.AnyError },
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &HANAInsights{sid: "HDB", readFile: tc.readFile}
+			got, err := h.readReport("/tmp/local-hana-insights.json")
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("readReport() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("readReport() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadRules(t *testing.T) {
+	builtIn, err := (&HANAInsights{}).readRules(context.Background())
+	if err != nil {
+		t.Fatalf("readRules() failed: %v", err)
+	}
+	tests := []struct {
+		name      string
+		h         *HANAInsights
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name:      "DisabledRules",
+			h:         &HANAInsights{disabledRules: "r_backup_encryption, r_log_encryption,"},
+			wantCount: len(builtIn) - 2,
+		},
+		{
+			name:    "MissingRulePack",
+			h:       &HANAInsights{rulePaths: "/tmp/no-such-hana-insights-rules"},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.h.readRules(context.Background())
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("readRules() error = %v, want %v", err, tc.wantErr)
+			}
+			if len(got) != tc.wantCount {
+				t.Errorf("readRules() returned %d rules, want %d", len(got), tc.wantCount)
+			}
+		})
+	}
+}
+
+func TestRemediator(t *testing.T) {
+	h := &HANAInsights{sid: "HDB", remediateRules: "r_a, r_b"}
+	r := h.remediator(nil)
+	if diff := cmp.Diff(map[string]bool{"r_a": true, "r_b": true}, r.AllowedRules); diff != "" {
+		t.Errorf("remediator().AllowedRules diff (-want +got):\n%s", diff)
+	}
+	if r.SID != "HDB" || r.Confirm == nil || r.Evaluate == nil {
+		t.Errorf("remediator() = %+v, want SID HDB with Confirm and Evaluate set", r)
+	}
+}
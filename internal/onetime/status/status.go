@@ -40,10 +40,12 @@ import (
 	backintconfiguration "github.com/GoogleCloudPlatform/sapagent/internal/backint/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanamonitoring"
 	"github.com/GoogleCloudPlatform/sapagent/internal/heartbeat"
 	"github.com/GoogleCloudPlatform/sapagent/internal/iam"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/supportbundle"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/collectorhealth"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
 	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
@@ -555,9 +557,47 @@ func (s *Status) processMetricsStatus(ctx context.Context, config *cpb.Configura
 	if err := checkFilePermissions(ctx, "/usr/sap", 0111, s.stat); err != nil {
 		return logCheckFailureAndReturnStatus(ctx, status, "/usr/sap needs to be executable. Run 'sudo chmod +x /usr/sap'", spb.State_FAILURE_STATE)
 	}
+
+	healthValues, failing := s.collectorHealthStatus(ctx)
+	status.ConfigValues = append(status.ConfigValues, healthValues...)
+	if len(failing) > 0 {
+		return logCheckFailureAndReturnStatus(ctx, status, fmt.Sprintf("Collectors failing: %s", strings.Join(failing, ", ")), spb.State_FAILURE_STATE)
+	}
 	status.FullyFunctional = spb.State_SUCCESS_STATE
 	return status
 }
+
+// collectorHealthStatus summarizes the collector health persisted by the agent.
+// It returns one value per collector and the names of the collectors whose last run failed.
+func (s *Status) collectorHealthStatus(ctx context.Context) ([]*spb.ConfigValue, []string) {
+	if s.readFile == nil {
+		return nil, nil
+	}
+	stats, err := collectorhealth.ReadSnapshot(s.readFile, collectorhealth.DefaultSnapshotPath)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Could not read collector health", "error", err)
+		return nil, nil
+	}
+	var values []*spb.ConfigValue
+	var failing []string
+	for _, st := range stats {
+		lastSuccess := "never"
+		if !st.LastSuccess.IsZero() {
+			lastSuccess = st.LastSuccess.UTC().Format(time.RFC3339)
+		}
+		summary := fmt.Sprintf("ok, %d series in %s, last success %s", st.Series, st.Duration.Round(time.Millisecond), lastSuccess)
+		if st.LastSuccess.Before(st.LastRun) {
+			failing = append(failing, st.Collector)
+			summary = fmt.Sprintf("failing (%d of %d runs), last success %s: %s", st.Errors, st.Runs, lastSuccess, st.LastError)
+		}
+		values = append(values, &spb.ConfigValue{
+			Name:      fmt.Sprintf("collector_health/%s/%s", st.Group, st.Collector),
+			Value:     summary,
+			IsDefault: true,
+		})
+	}
+	return values, failing
+}
 func (s *Status) hanaMonitoringMetricsStatus(ctx context.Context, config *cpb.Configuration) *spb.ServiceStatus {
 	status := &spb.ServiceStatus{
 		Name:  "HANA Monitoring Metrics",
@@ -615,11 +655,27 @@ func (s *Status) hanaMonitoringMetricsStatus(ctx context.Context, config *cpb.Co
 				MaxRetries: 0,
 			},
 		}
-		if _, err := s.createDBHandle(ctx, dbp); err != nil {
+		handle, err := s.createDBHandle(ctx, dbp)
+		if err != nil {
 			log.CtxLogger(ctx).Errorw("Error connecting to database", "name", i.GetName(), "error", err.Error())
 			failedInstances = append(failedInstances, i.GetName())
 			continue
 		}
+		if !i.GetDiscoverTenants() {
+			continue
+		}
+		// Report the tenant topology as discovered through the SYSTEMDB.
+		tenants, err := hanamonitoring.ListTenants(ctx, handle.Query)
+		if err != nil {
+			log.CtxLogger(ctx).Errorw("Error discovering tenant databases", "name", i.GetName(), "error", err.Error())
+			failedInstances = append(failedInstances, i.GetName())
+			continue
+		}
+		var topology []string
+		for _, t := range tenants {
+			topology = append(topology, fmt.Sprintf("%s:%s", t.Name, t.Port))
+		}
+		status.ConfigValues = append(status.ConfigValues, configValue(i.GetName()+"_tenants", strings.Join(topology, ","), ""))
 	}
 	if !allSecretsGranted {
 		return logCheckFailureAndReturnStatus(ctx, status, "Secret Manager permissions not granted for some instances", spb.State_FAILURE_STATE)
@@ -715,23 +771,42 @@ func (s *Status) backintStatus(ctx context.Context) *spb.ServiceStatus {
 		configValue("buffer_size_mb", printConfig.BufferSizeMb, 100),
 		configValue("client_endpoint", printConfig.ClientEndpoint, ""),
 		configValue("compress", printConfig.Compress, false),
+		configValue("compression_codec", printConfig.CompressionCodec, "COMPRESSION_CODEC_UNSPECIFIED"),
+		configValue("compression_level", printConfig.CompressionLevel, 0),
 		configValue("custom_time", printConfig.CustomTime, ""),
+		configValue("deduplicate", printConfig.Deduplicate, false),
 		configValue("encryption_key", printConfig.EncryptionKey, ""),
+		configValue("envelope_encryption", printConfig.EnvelopeEncryption, false),
+		configValue("envelope_keyring_file", printConfig.EnvelopeKeyringFile, ""),
+		configValue("envelope_kms_key", printConfig.EnvelopeKmsKey, ""),
 		configValue("folder_prefix", printConfig.FolderPrefix, ""),
 		configValue("file_read_timeout_ms", printConfig.FileReadTimeoutMs, 60000),
+		configValue("filesystem_path", printConfig.FilesystemPath, ""),
+		configValue("housekeeping_delete", printConfig.HousekeepingDelete, false),
+		configValue("housekeeping_hdbuserstore_key", printConfig.HousekeepingHdbuserstoreKey, ""),
+		configValue("housekeeping_min_age_hours", printConfig.HousekeepingMinAgeHours, 0),
+		configValue("integrity_manifest", printConfig.IntegrityManifest, false),
 		configValue("kms_key", printConfig.KmsKey, ""),
 		configValue("metadata", printConfig.Metadata, map[string]string{}),
 		configValue("parallel_streams", printConfig.ParallelStreams, 1),
 		configValue("parallel_recovery_streams", printConfig.ParallelRecoveryStreams, 0),
 		configValue("rate_limit_mb", printConfig.RateLimitMb, 0),
+		configValue("rate_limit_windows", printConfig.RateLimitWindows, "[]"),
 		configValue("recovery_bucket", printConfig.RecoveryBucket, ""),
 		configValue("recovery_folder_prefix", printConfig.RecoveryFolderPrefix, ""),
+		configValue("replication_mode", printConfig.ReplicationMode, "REPLICATION_MODE_UNSPECIFIED"),
+		configValue("replication_queue_directory", printConfig.ReplicationQueueDirectory, ""),
+		configValue("restore_bypass_rate_limit", printConfig.RestoreBypassRateLimit, false),
+		configValue("resumable_uploads", printConfig.ResumableUploads, false),
 		configValue("retries", printConfig.Retries, 5),
+		configValue("s3_endpoint", printConfig.S3Endpoint, ""),
 		configValue("send_metrics_to_monitoring", printConfig.SendMetricsToMonitoring.GetValue(), true),
 		configValue("service_account_key", printConfig.ServiceAccountKey, ""),
 		configValue("shorten_folder_path", printConfig.ShortenFolderPath, false),
+		configValue("storage_backend", printConfig.StorageBackend, "STORAGE_BACKEND_UNSPECIFIED"),
 		configValue("storage_class", printConfig.StorageClass, "STORAGE_CLASS_UNSPECIFIED"),
 		configValue("threads", printConfig.Threads, 64),
+		configValue("verify_sample_percent", printConfig.VerifySamplePercent, 0),
 		configValue("xml_multipart_upload", printConfig.XmlMultipartUpload, false),
 		configValue("object_retention_mode", printConfig.ObjectRetentionMode, ""),
 		configValue("object_retention_time", printConfig.ObjectRetentionTime, ""),
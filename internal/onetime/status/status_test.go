@@ -960,6 +960,148 @@ func TestProcessMetricsStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "CollectorHealthOK",
+			config: &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{
+					CollectProcessMetrics:       true,
+					ProcessMetricsFrequency:     10,
+					ProcessMetricsToSkip:        []string{"test1", "test2"},
+					SlowProcessMetricsFrequency: 20,
+				},
+			},
+			s: Status{
+				iamService: &iam.IAM{},
+				permissionsStatus: func(ctx context.Context, iamService permissions.IAMService, serviceName string, r *permissions.ResourceDetails) (map[string]bool, error) {
+					return map[string]bool{
+						"monitoring.timeSeries.create": true,
+					}, nil
+				},
+				stat: func(name string) (os.FileInfo, error) {
+					return &mockFileInfo{perm: 0111}, nil
+				},
+				readFile: func(string) ([]byte, error) {
+					return []byte(`[{"collector":"netweaver/00","group":"slow","runs":3,"duration":1500000000,"series":12,"last_run":"2026-10-17T10:00:00Z","last_success":"2026-10-17T10:00:00Z"}]`), nil
+				},
+				CloudProps: &iipb.CloudProperties{
+					ProjectId: "test-project",
+					Scopes:    []string{requiredScope},
+				},
+			},
+			want: &spb.ServiceStatus{
+				Name:            "Process Metrics",
+				State:           spb.State_SUCCESS_STATE,
+				FullyFunctional: spb.State_SUCCESS_STATE,
+				IamPermissions: []*spb.IAMPermission{
+					{
+						Name:    "monitoring.timeSeries.create",
+						Granted: spb.State_SUCCESS_STATE,
+					},
+				},
+				ConfigValues: []*spb.ConfigValue{
+					{Name: "collect_process_metrics", Value: "true", IsDefault: false},
+					{Name: "process_metrics_frequency", Value: "10", IsDefault: false},
+					{Name: "process_metrics_to_skip", Value: "[test1 test2]", IsDefault: false},
+					{Name: "slow_process_metrics_frequency", Value: "20", IsDefault: false},
+					{Name: "collector_health/slow/netweaver/00", Value: "ok, 12 series in 1.5s, last success 2026-10-17T10:00:00Z", IsDefault: true},
+				},
+			},
+		},
+		{
+			name: "CollectorsFailing",
+			config: &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{
+					CollectProcessMetrics:       true,
+					ProcessMetricsFrequency:     10,
+					ProcessMetricsToSkip:        []string{"test1", "test2"},
+					SlowProcessMetricsFrequency: 20,
+				},
+			},
+			s: Status{
+				iamService: &iam.IAM{},
+				permissionsStatus: func(ctx context.Context, iamService permissions.IAMService, serviceName string, r *permissions.ResourceDetails) (map[string]bool, error) {
+					return map[string]bool{
+						"monitoring.timeSeries.create": true,
+					}, nil
+				},
+				stat: func(name string) (os.FileInfo, error) {
+					return &mockFileInfo{perm: 0111}, nil
+				},
+				readFile: func(string) ([]byte, error) {
+					return []byte(`[{"collector":"netweaver/00","group":"slow","runs":3,"duration":1500000000,"series":12,"last_run":"2026-10-17T10:00:00Z","last_success":"2026-10-17T10:00:00Z"},{"collector":"hana/10","group":"fast","runs":4,"errors":2,"duration":200000000,"last_run":"2026-10-17T10:05:00Z","last_success":"2026-10-17T09:55:00Z","last_error":"sapcontrol: connection refused"}]`), nil
+				},
+				CloudProps: &iipb.CloudProperties{
+					ProjectId: "test-project",
+					Scopes:    []string{requiredScope},
+				},
+			},
+			want: &spb.ServiceStatus{
+				Name:            "Process Metrics",
+				State:           spb.State_SUCCESS_STATE,
+				FullyFunctional: spb.State_FAILURE_STATE,
+				IamPermissions: []*spb.IAMPermission{
+					{
+						Name:    "monitoring.timeSeries.create",
+						Granted: spb.State_SUCCESS_STATE,
+					},
+				},
+				ConfigValues: []*spb.ConfigValue{
+					{Name: "collect_process_metrics", Value: "true", IsDefault: false},
+					{Name: "process_metrics_frequency", Value: "10", IsDefault: false},
+					{Name: "process_metrics_to_skip", Value: "[test1 test2]", IsDefault: false},
+					{Name: "slow_process_metrics_frequency", Value: "20", IsDefault: false},
+					{Name: "collector_health/slow/netweaver/00", Value: "ok, 12 series in 1.5s, last success 2026-10-17T10:00:00Z", IsDefault: true},
+					{Name: "collector_health/fast/hana/10", Value: "failing (2 of 4 runs), last success 2026-10-17T09:55:00Z: sapcontrol: connection refused", IsDefault: true},
+				},
+				ErrorMessage: "Collectors failing: hana/10",
+			},
+		},
+		{
+			name: "CollectorHealthUnreadable",
+			config: &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{
+					CollectProcessMetrics:       true,
+					ProcessMetricsFrequency:     10,
+					ProcessMetricsToSkip:        []string{"test1", "test2"},
+					SlowProcessMetricsFrequency: 20,
+				},
+			},
+			s: Status{
+				iamService: &iam.IAM{},
+				permissionsStatus: func(ctx context.Context, iamService permissions.IAMService, serviceName string, r *permissions.ResourceDetails) (map[string]bool, error) {
+					return map[string]bool{
+						"monitoring.timeSeries.create": true,
+					}, nil
+				},
+				stat: func(name string) (os.FileInfo, error) {
+					return &mockFileInfo{perm: 0111}, nil
+				},
+				readFile: func(string) ([]byte, error) {
+					return []byte(`not json`), nil
+				},
+				CloudProps: &iipb.CloudProperties{
+					ProjectId: "test-project",
+					Scopes:    []string{requiredScope},
+				},
+			},
+			want: &spb.ServiceStatus{
+				Name:            "Process Metrics",
+				State:           spb.State_SUCCESS_STATE,
+				FullyFunctional: spb.State_SUCCESS_STATE,
+				IamPermissions: []*spb.IAMPermission{
+					{
+						Name:    "monitoring.timeSeries.create",
+						Granted: spb.State_SUCCESS_STATE,
+					},
+				},
+				ConfigValues: []*spb.ConfigValue{
+					{Name: "collect_process_metrics", Value: "true", IsDefault: false},
+					{Name: "process_metrics_frequency", Value: "10", IsDefault: false},
+					{Name: "process_metrics_to_skip", Value: "[test1 test2]", IsDefault: false},
+					{Name: "slow_process_metrics_frequency", Value: "20", IsDefault: false},
+				},
+			},
+		},
 		{
 			name: "ProcessMetricsWithSecret_Success",
 			config: &cpb.Configuration{
@@ -0,0 +1,310 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package wlmevaluate implements the one time execution mode which evaluates the Workload
+// Manager metrics of this instance locally, against a file of expected label values.
+package wlmevaluate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"flag"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2"
+	"github.com/google/subcommands"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/sapagent/internal/collectiondefinition"
+	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/appsdiscovery"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/clouddiscovery"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/hostdiscovery"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/sapdiscovery"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system"
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/filesystem"
+	"github.com/GoogleCloudPlatform/sapagent/internal/workloadmanager"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/osinfo"
+
+	wpb "google.golang.org/protobuf/types/known/wrapperspb"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+var (
+	osStatReader = workloadmanager.OSStatReader(func(f string) (os.FileInfo, error) {
+		return os.Stat(f)
+	})
+	configFileReader = workloadmanager.ConfigFileReader(func(path string) (io.ReadCloser, error) {
+		file, err := os.Open(path)
+		var f io.ReadCloser = file
+		return f, err
+	})
+	execute = commandlineexecutor.Execute(func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+		return commandlineexecutor.ExecuteCommand(ctx, params)
+	})
+	exists = commandlineexecutor.Exists(func(exe string) bool {
+		return commandlineexecutor.CommandExists(exe)
+	})
+	defaultTokenGetter = workloadmanager.DefaultTokenGetter(func(ctx context.Context, scopes ...string) (oauth2.TokenSource, error) {
+		return google.DefaultTokenSource(ctx, scopes...)
+	})
+	jsonCredentialsGetter = workloadmanager.JSONCredentialsGetter(func(ctx context.Context, json []byte, scopes ...string) (*google.Credentials, error) {
+		return google.CredentialsFromJSON(ctx, json, scopes...)
+	})
+)
+
+// collectFunc collects the Workload Manager metrics of this instance.
+type collectFunc func(ctx context.Context, cp *iipb.CloudProperties) (workloadmanager.WorkloadMetrics, error)
+
+// WLMEvaluate has args for wlmevaluate subcommands.
+type WLMEvaluate struct {
+	rulesPath, metricsPath, configPath string
+	help                               bool
+	logLevel, logPath                  string
+	readFile                           func(string) ([]byte, error)
+	collect                            collectFunc
+	oteLogger                          *onetime.OTELogger
+}
+
+// Name implements the subcommand interface for wlmevaluate.
+func (*WLMEvaluate) Name() string { return "wlmevaluate" }
+
+// Synopsis implements the subcommand interface for wlmevaluate.
+func (*WLMEvaluate) Synopsis() string {
+	return "evaluate the Workload Manager metrics of this instance locally against expected values"
+}
+
+// Usage implements the subcommand interface for wlmevaluate.
+func (*WLMEvaluate) Usage() string {
+	return `Usage: wlmevaluate -rules=<rules-file> [-metrics=<metrics-file>] [-config=<agent-config-file>]
+	[-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]
+Exits with status 1 if any of the rules fails.` + "\n"
+}
+
+// SetFlags implements the subcommand interface for wlmevaluate.
+func (w *WLMEvaluate) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&w.rulesPath, "rules", "", "JSON file of evaluation rules with the expected label values and ranges per metric. (required)")
+	fs.StringVar(&w.metricsPath, "metrics", "", "File of previously collected metrics, one JSON time series per line as written by the remote command, to evaluate instead of collecting the metrics of this instance (optional)")
+	fs.StringVar(&w.configPath, "config", "", "Agent configuration file to collect the metrics with (optional), default value is the configuration file of the agent")
+	fs.StringVar(&w.logPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/wlmevaluate.log")
+	fs.BoolVar(&w.help, "h", false, "Display help")
+	fs.StringVar(&w.logLevel, "loglevel", "info", "Sets the logging level for a log file")
+}
+
+// Execute implements the subcommand interface for wlmevaluate.
+func (w *WLMEvaluate) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subcommands.ExitStatus {
+	_, cp, exitStatus, completed := onetime.Init(ctx, onetime.InitOptions{
+		Name:     w.Name(),
+		Help:     w.help,
+		LogLevel: w.logLevel,
+		LogPath:  w.logPath,
+		Fs:       f,
+	}, args...)
+	if !completed {
+		return exitStatus
+	}
+
+	return w.Run(ctx, onetime.CreateRunOptions(cp, false))
+}
+
+// Run executes the command and returns the status.
+func (w *WLMEvaluate) Run(ctx context.Context, runOpts *onetime.RunOptions) subcommands.ExitStatus {
+	w.oteLogger = onetime.CreateOTELogger(runOpts.DaemonMode)
+	if w.readFile == nil {
+		w.readFile = os.ReadFile
+	}
+	if w.collect == nil {
+		w.collect = w.collectMetrics
+	}
+	return w.evaluateHandler(ctx, runOpts.CloudProperties)
+}
+
+func (w *WLMEvaluate) evaluateHandler(ctx context.Context, cp *iipb.CloudProperties) subcommands.ExitStatus {
+	if w.rulesPath == "" {
+		w.oteLogger.LogMessageToConsole("-rules is required. Usage: " + w.Usage())
+		return subcommands.ExitUsageError
+	}
+	if w.metricsPath == "" && runtime.GOOS == "windows" {
+		w.oteLogger.LogMessageToConsole("Collecting Workload Manager metrics is only supported on Linux systems, use -metrics to evaluate collected metrics")
+		return subcommands.ExitUsageError
+	}
+
+	data, err := w.readFile(w.rulesPath)
+	if err != nil {
+		w.oteLogger.LogErrorToFileAndConsole(ctx, "ERROR: Failed to read the evaluation rules", err)
+		return subcommands.ExitFailure
+	}
+	rules, err := workloadmanager.ParseEvaluationRules(data)
+	if err != nil {
+		w.oteLogger.LogErrorToFileAndConsole(ctx, "ERROR: Invalid evaluation rules", err)
+		return subcommands.ExitFailure
+	}
+
+	wm, err := w.metrics(ctx, cp)
+	if err != nil {
+		w.oteLogger.LogErrorToFileAndConsole(ctx, "ERROR: Failed to get the Workload Manager metrics", err)
+		return subcommands.ExitFailure
+	}
+	results := workloadmanager.Evaluate(wm, rules)
+	summary, passed := formatResults(results)
+	w.oteLogger.LogMessageToConsole(summary)
+	if !passed {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// metrics reads the metrics file if one is given, and collects the metrics otherwise.
+func (w *WLMEvaluate) metrics(ctx context.Context, cp *iipb.CloudProperties) (workloadmanager.WorkloadMetrics, error) {
+	if w.metricsPath == "" {
+		return w.collect(ctx, cp)
+	}
+	data, err := w.readFile(w.metricsPath)
+	if err != nil {
+		return workloadmanager.WorkloadMetrics{}, err
+	}
+	return workloadmanager.MetricsFromJSON(string(data))
+}
+
+// collectMetrics collects the metrics of this instance once, with the agent configuration and
+// the collection definition the daemon uses.
+func (w *WLMEvaluate) collectMetrics(ctx context.Context, cp *iipb.CloudProperties) (workloadmanager.WorkloadMetrics, error) {
+	path := w.configPath
+	if path == "" {
+		path = configuration.Path()
+	}
+	config, err := configuration.Read(path, w.readFile)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Could not read the agent configuration, using the defaults", "path", path, "error", err)
+		config = nil
+	}
+	config = configuration.ApplyDefaults(config, cp)
+
+	cd, err := collectiondefinition.Load(ctx, collectiondefinition.LoadOptions{
+		CollectionConfig: config.GetCollectionConfiguration(),
+		ReadFile:         w.readFile,
+		OSType:           runtime.GOOS,
+		Version:          configuration.AgentVersion,
+		FetchOptions: collectiondefinition.FetchOptions{
+			OSType:     runtime.GOOS,
+			Env:        config.GetCollectionConfiguration().GetWorkloadValidationCollectionDefinition().GetConfigTargetEnvironment(),
+			Client:     storage.NewClient,
+			CreateTemp: os.CreateTemp,
+			Execute:    execute,
+		},
+	})
+	if err != nil {
+		return workloadmanager.WorkloadMetrics{}, fmt.Errorf("loading collection definition: %v", err)
+	}
+
+	gceService, err := gce.NewGCEClient(ctx)
+	if err != nil {
+		return workloadmanager.WorkloadMetrics{}, fmt.Errorf("creating GCE service: %v", err)
+	}
+	wlmService, err := wlm.NewWLMClient(ctx, config.GetCollectionConfiguration().GetDataWarehouseEndpoint())
+	if err != nil {
+		return workloadmanager.WorkloadMetrics{}, fmt.Errorf("creating WLM service: %v", err)
+	}
+	systemDiscovery := &system.Discovery{
+		WlmService:    wlmService,
+		AppsDiscovery: sapdiscovery.SAPApplications,
+		CloudDiscoveryInterface: &clouddiscovery.CloudDiscovery{
+			GceService:   gceService,
+			HostResolver: net.LookupHost,
+		},
+		HostDiscoveryInterface: &hostdiscovery.HostDiscovery{
+			Exists:  commandlineexecutor.CommandExists,
+			Execute: commandlineexecutor.ExecuteCommand,
+		},
+		SapDiscoveryInterface: &appsdiscovery.SapDiscovery{
+			Execute:    commandlineexecutor.ExecuteCommand,
+			FileSystem: filesystem.Helper{},
+		},
+		OSStatReader: osStatReader,
+		FileReader:   configFileReader,
+	}
+	discoveryCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	startDiscovery(discoveryCtx, config, systemDiscovery)
+
+	params := workloadmanager.Parameters{
+		Config:                config,
+		WorkloadConfig:        cd.GetWorkloadValidation(),
+		ConfigFileReader:      configFileReader,
+		Execute:               execute,
+		Exists:                exists,
+		InstanceInfoReader:    *instanceinfo.New(&instanceinfo.PhysicalPathReader{OS: runtime.GOOS}, gceService),
+		OSStatReader:          osStatReader,
+		DefaultTokenGetter:    defaultTokenGetter,
+		JSONCredentialsGetter: jsonCredentialsGetter,
+		OSType:                osinfo.OSName,
+		OSReleaseFilePath:     osinfo.OSReleaseFilePath,
+		InterfaceAddrsGetter:  net.InterfaceAddrs,
+		GCEService:            gceService,
+		WLMService:            wlmService,
+		Discovery:             systemDiscovery,
+	}
+	params.Init(ctx)
+	return workloadmanager.CollectMetrics(ctx, params), nil
+}
+
+// startDiscovery discovers the SAP systems of this instance for the evaluation only. The
+// discovered systems are not sent to Workload Manager, and the discovery routines stop
+// once ctx is cancelled.
+func startDiscovery(ctx context.Context, config *cpb.Configuration, d *system.Discovery) {
+	local := proto.Clone(config).(*cpb.Configuration)
+	if local.GetDiscoveryConfiguration() == nil {
+		local.DiscoveryConfiguration = &cpb.DiscoveryConfiguration{}
+	}
+	local.DiscoveryConfiguration.EnableDiscovery = &wpb.BoolValue{Value: false}
+	system.StartSAPSystemDiscovery(ctx, local, d)
+}
+
+// formatResults lists the outcome of each rule with its explanations, followed by a summary,
+// and reports whether all the rules passed.
+func formatResults(results []workloadmanager.EvaluationResult) (string, bool) {
+	sb := new(strings.Builder)
+	failed := 0
+	for _, r := range results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Fprintf(sb, "%s %s", status, r.RuleID)
+		if r.Description != "" {
+			fmt.Fprintf(sb, ": %s", r.Description)
+		}
+		fmt.Fprintf(sb, "\n")
+		for _, e := range r.Explanations {
+			fmt.Fprintf(sb, "    - %s\n", e)
+		}
+	}
+	fmt.Fprintf(sb, "%d of %d rules passed, %d failed\n", len(results)-failed, len(results), failed)
+	return sb.String(), failed == 0
+}
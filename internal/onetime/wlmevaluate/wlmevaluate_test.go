@@ -0,0 +1,292 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wlmevaluate
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"flag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/appsdiscovery"
+	appsdiscoveryfake "github.com/GoogleCloudPlatform/sapagent/internal/system/appsdiscovery/fake"
+	clouddiscoveryfake "github.com/GoogleCloudPlatform/sapagent/internal/system/clouddiscovery/fake"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/hostdiscovery"
+	hostdiscoveryfake "github.com/GoogleCloudPlatform/sapagent/internal/system/hostdiscovery/fake"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system"
+	"github.com/GoogleCloudPlatform/sapagent/internal/workloadmanager"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	dpb "google.golang.org/protobuf/types/known/durationpb"
+	wpb "google.golang.org/protobuf/types/known/wrapperspb"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	sappb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+	spb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/system"
+)
+
+const (
+	testRules = `{"rules": [
+		{"id": "os", "description": "Supported OS", "metric_type": "system", "expectations": [{"label": "os", "matches": "^(sles|rhel)-"}]},
+		{"id": "swappiness", "metric_type": "custom", "expectations": [{"label": "swappiness", "max": 10}]}
+	]}`
+	testMetrics = `{"metric":{"type":"workload.googleapis.com/sap/validation/system","labels":{"os":"sles-15"}},"metricKind":"GAUGE"}
+{"metric":{"type":"workload.googleapis.com/sap/validation/custom","labels":{"swappiness":"10"}},"metricKind":"GAUGE"}
+`
+)
+
+func TestMain(t *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(t.Run())
+}
+
+func fakeReadFile(files map[string]string) func(string) ([]byte, error) {
+	return func(path string) ([]byte, error) {
+		data, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(data), nil
+	}
+}
+
+// countingWLM records the insights written to Workload Manager.
+type countingWLM struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (w *countingWLM) WriteInsight(project, location string, req *dwpb.WriteInsightRequest) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.calls++
+	return nil
+}
+
+func fakeCollect(labels map[string]string, err error) collectFunc {
+	return func(context.Context, *iipb.CloudProperties) (workloadmanager.WorkloadMetrics, error) {
+		return workloadmanager.WorkloadMetrics{Metrics: []*mrpb.TimeSeries{
+			&mrpb.TimeSeries{Metric: &mpb.Metric{Type: "workload.googleapis.com/sap/validation/system", Labels: labels}},
+			&mrpb.TimeSeries{Metric: &mpb.Metric{Type: "workload.googleapis.com/sap/validation/custom", Labels: labels}},
+		}}, err
+	}
+}
+
+func TestExecuteWLMEvaluate(t *testing.T) {
+	tests := []struct {
+		name string
+		w    WLMEvaluate
+		want subcommands.ExitStatus
+		args []any
+	}{
+		{
+			name: "FailLengthArgs",
+			want: subcommands.ExitUsageError,
+			args: []any{},
+		},
+		{
+			name: "SuccessForHelp",
+			w:    WLMEvaluate{help: true},
+			want: subcommands.ExitSuccess,
+			args: []any{
+				"test",
+				log.Parameters{},
+				&iipb.CloudProperties{},
+			},
+		},
+		{
+			name: "MissingRules",
+			want: subcommands.ExitUsageError,
+			args: []any{
+				"test",
+				log.Parameters{},
+				&iipb.CloudProperties{},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.w.Execute(context.Background(), &flag.FlagSet{Usage: func() { return }}, tc.args...)
+			if got != tc.want {
+				t.Errorf("Execute(%v)=%v, want %v", tc.args, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateHandler(t *testing.T) {
+	files := map[string]string{
+		"/tmp/rules.json":     testRules,
+		"/tmp/invalid.json":   `{"rules": [{"id": "os"}]}`,
+		"/tmp/metrics.txt":    testMetrics,
+		"/tmp/notmetrics.txt": "# not metrics",
+	}
+	tests := []struct {
+		name string
+		w    *WLMEvaluate
+		want subcommands.ExitStatus
+	}{
+		{
+			name: "MissingRules",
+			w:    &WLMEvaluate{},
+			want: subcommands.ExitUsageError,
+		},
+		{
+			name: "RulesUnreadable",
+			w:    &WLMEvaluate{rulesPath: "/tmp/missing.json"},
+			want: subcommands.ExitFailure,
+		},
+		{
+			name: "InvalidRules",
+			w:    &WLMEvaluate{rulesPath: "/tmp/invalid.json"},
+			want: subcommands.ExitFailure,
+		},
+		{
+			name: "MetricsFilePassed",
+			w:    &WLMEvaluate{rulesPath: "/tmp/rules.json", metricsPath: "/tmp/metrics.txt"},
+			want: subcommands.ExitSuccess,
+		},
+		{
+			name: "MetricsFileUnreadable",
+			w:    &WLMEvaluate{rulesPath: "/tmp/rules.json", metricsPath: "/tmp/missing.txt"},
+			want: subcommands.ExitFailure,
+		},
+		{
+			name: "MetricsFileInvalid",
+			w:    &WLMEvaluate{rulesPath: "/tmp/rules.json", metricsPath: "/tmp/notmetrics.txt"},
+			want: subcommands.ExitFailure,
+		},
+		{
+			name: "CollectedMetricsPassed",
+			w:    &WLMEvaluate{rulesPath: "/tmp/rules.json", collect: fakeCollect(map[string]string{"os": "rhel-9", "swappiness": "1"}, nil)},
+			want: subcommands.ExitSuccess,
+		},
+		{
+			name: "CollectedMetricsFailed",
+			w:    &WLMEvaluate{rulesPath: "/tmp/rules.json", collect: fakeCollect(map[string]string{"os": "windows", "swappiness": "60"}, nil)},
+			want: subcommands.ExitFailure,
+		},
+		{
+			name: "CollectionFailure",
+			w:    &WLMEvaluate{rulesPath: "/tmp/rules.json", collect: fakeCollect(nil, os.ErrPermission)},
+			want: subcommands.ExitFailure,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.w.readFile = fakeReadFile(files)
+			tc.w.oteLogger = onetime.CreateOTELogger(false)
+			got := tc.w.evaluateHandler(context.Background(), &iipb.CloudProperties{})
+			if got != tc.want {
+				t.Errorf("evaluateHandler()=%v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		results    []workloadmanager.EvaluationResult
+		want       string
+		wantPassed bool
+	}{
+		{
+			name:       "NoRules",
+			want:       "0 of 0 rules passed, 0 failed\n",
+			wantPassed: true,
+		},
+		{
+			name: "PassedAndFailed",
+			results: []workloadmanager.EvaluationResult{
+				{RuleID: "os", Description: "Supported OS", Passed: true, Explanations: []string{`label os is "sles-15" as expected`}},
+				{RuleID: "swappiness", Explanations: []string{`label swappiness is "60", want at most 10`}},
+			},
+			want: `PASS os: Supported OS
+    - label os is "sles-15" as expected
+FAIL swappiness
+    - label swappiness is "60", want at most 10
+1 of 2 rules passed, 1 failed
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotPassed := formatResults(tc.results)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("formatResults() returned diff (-want +got):\n%s", diff)
+			}
+			if gotPassed != tc.wantPassed {
+				t.Errorf("formatResults() passed = %v, want %v", gotPassed, tc.wantPassed)
+			}
+		})
+	}
+}
+
+func TestStartDiscovery(t *testing.T) {
+	config := &cpb.Configuration{
+		CloudProperties: &iipb.CloudProperties{ProjectId: "test-project", Zone: "us-central1-a", InstanceName: "test-instance"},
+		DiscoveryConfiguration: &cpb.DiscoveryConfiguration{
+			EnableDiscovery:                &wpb.BoolValue{Value: true},
+			SapInstancesUpdateFrequency:    &dpb.Duration{Seconds: 10},
+			SystemDiscoveryUpdateFrequency: &dpb.Duration{Seconds: 10},
+		},
+	}
+	wlm := &countingWLM{}
+	d := &system.Discovery{
+		WlmService: wlm,
+		SapDiscoveryInterface: &appsdiscoveryfake.SapDiscovery{
+			DiscoverSapAppsResp: [][]appsdiscovery.SapSystemDetails{{{
+				AppComponent: &spb.SapDiscovery_Component{Sid: "ABC"},
+				DBComponent:  &spb.SapDiscovery_Component{Sid: "DEF"},
+			}}},
+		},
+		CloudDiscoveryInterface: &clouddiscoveryfake.CloudDiscovery{
+			DiscoverComputeResourcesResp: [][]*spb.SapDiscovery_Resource{{}, {}, {}, {}},
+		},
+		HostDiscoveryInterface: &hostdiscoveryfake.HostDiscovery{
+			DiscoverCurrentHostResp: []hostdiscovery.HostData{{}},
+		},
+		AppsDiscovery: func(context.Context, system.SapSystemDiscoveryInterface) *sappb.SAPInstances {
+			return &sappb.SAPInstances{}
+		},
+		OSStatReader: func(string) (os.FileInfo, error) { return nil, os.ErrNotExist },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	startDiscovery(ctx, config, d)
+	cancel()
+
+	if len(d.GetSAPSystems()) == 0 {
+		t.Errorf("startDiscovery() discovered no SAP systems, want the ABC system")
+	}
+	wlm.mu.Lock()
+	defer wlm.mu.Unlock()
+	if wlm.calls != 0 {
+		t.Errorf("startDiscovery() wrote %d insights to Workload Manager, want 0", wlm.calls)
+	}
+	if !config.GetDiscoveryConfiguration().GetEnableDiscovery().GetValue() {
+		t.Error("startDiscovery() modified the agent configuration, want enable_discovery unchanged")
+	}
+}
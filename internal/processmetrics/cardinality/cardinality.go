@@ -0,0 +1,390 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+// Package cardinality limits the number of distinct time series the process metrics
+// collectors send per metric type, and filters label values per metric family.
+//
+// Only series collected recently count toward the limit, so that series of processes or
+// sockets which are gone make room for new ones. Series over the limit or filtered out
+// are either dropped or, if enabled for GAUGE metrics, summed into a series with the
+// label values set to "other". As all collectors share the Limiter, the "other" series and
+// a metric counting the series not sent as collected per metric type are accumulated
+// across collectors, and flushed once per interval.
+package cardinality
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/protostruct"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/timeseries"
+
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+const (
+	defaultMaxSeries = 1000
+	defaultExpiry    = 10 * time.Minute
+	otherValue       = "other"
+	metricURL        = "workload.googleapis.com"
+	droppedPath      = "/sap/agent/cardinality/dropped"
+	reasonLimit      = "limit"
+	reasonFiltered   = "filtered"
+)
+
+type (
+	// Limiter keeps track of the series sent per metric type. A nil Limiter lets all series through.
+	Limiter struct {
+		mu                sync.Mutex
+		maxSeries         int
+		expiry            time.Duration
+		filters           []labelFilter
+		aggregate         bool
+		aggregatePrefixes []string
+		now               func() time.Time
+		// seen holds the time each series was last collected, per metric type.
+		seen map[string]map[string]time.Time
+		// others holds the "other" series to flush, by series key.
+		others map[string]*overflow
+		// dropped holds the keys of the series not sent as collected since the last flush.
+		dropped map[dropKey]map[string]bool
+	}
+
+	// overflow is an "other" series, summing the last value of each series it replaces.
+	overflow struct {
+		ts     *mrpb.TimeSeries
+		values map[string]*mrpb.TypedValue
+	}
+
+	labelFilter struct {
+		metricPrefix string
+		label        string
+		allow        []*regexp.Regexp
+		deny         []*regexp.Regexp
+	}
+
+	dropKey struct {
+		metric string
+		reason string
+	}
+)
+
+// New returns a Limiter for the config, or nil if the limits are not enabled.
+func New(config *cpb.CardinalityLimits) (*Limiter, error) {
+	if !config.GetEnabled() {
+		return nil, nil
+	}
+	l := &Limiter{
+		maxSeries:         int(config.GetMaxSeriesPerMetric()),
+		expiry:            time.Duration(config.GetSeriesExpirySeconds()) * time.Second,
+		aggregate:         config.GetAggregateOverflow(),
+		aggregatePrefixes: config.GetAggregateMetricPrefixes(),
+		now:               time.Now,
+		seen:              make(map[string]map[string]time.Time),
+		others:            make(map[string]*overflow),
+		dropped:           make(map[dropKey]map[string]bool),
+	}
+	if l.maxSeries <= 0 {
+		l.maxSeries = defaultMaxSeries
+	}
+	if l.expiry <= 0 {
+		l.expiry = defaultExpiry
+	}
+	for _, f := range config.GetLabelFilters() {
+		if f.GetLabel() == "" {
+			return nil, fmt.Errorf("label filter for metric prefix %q has no label", f.GetMetricPrefix())
+		}
+		allow, err := compileAll(f.GetAllow())
+		if err != nil {
+			return nil, fmt.Errorf("label filter for %q: %v", f.GetLabel(), err)
+		}
+		deny, err := compileAll(f.GetDeny())
+		if err != nil {
+			return nil, fmt.Errorf("label filter for %q: %v", f.GetLabel(), err)
+		}
+		l.filters = append(l.filters, labelFilter{
+			metricPrefix: f.GetMetricPrefix(),
+			label:        f.GetLabel(),
+			allow:        allow,
+			deny:         deny,
+		})
+	}
+	return l, nil
+}
+
+func compileAll(exprs []string) ([]*regexp.Regexp, error) {
+	var res []*regexp.Regexp
+	for _, e := range exprs {
+		re, err := regexp.Compile(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", e, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// Apply returns the time series within the limits. The series which were not sent as
+// collected are kept until the next Flush.
+func (l *Limiter) Apply(timeSeries []*mrpb.TimeSeries) []*mrpb.TimeSeries {
+	if l == nil {
+		return timeSeries
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var out []*mrpb.TimeSeries
+	for _, ts := range timeSeries {
+		metricType := ts.GetMetric().GetType()
+		filtered := l.filteredLabels(ts)
+		if len(filtered) == 0 && l.admit(metricType, seriesKey(ts), now) {
+			out = append(out, ts)
+			continue
+		}
+		reason := reasonLimit
+		if len(filtered) > 0 {
+			reason = reasonFiltered
+		}
+		k := dropKey{metric: metricType, reason: reason}
+		if l.dropped[k] == nil {
+			l.dropped[k] = make(map[string]bool)
+		}
+		l.dropped[k][seriesKey(ts)] = true
+		if !l.summable(ts) {
+			continue
+		}
+		// Filtered series keep their other labels as long as the result is within the limit.
+		other := withLabels(ts, filtered)
+		key := seriesKey(other)
+		if _, ok := l.others[key]; !ok && (len(filtered) == 0 || !l.admit(metricType, key, now)) {
+			other = withLabels(ts, labelNames(ts))
+			key = seriesKey(other)
+		}
+		o, ok := l.others[key]
+		if !ok {
+			o = &overflow{ts: other, values: make(map[string]*mrpb.TypedValue)}
+			l.others[key] = o
+		}
+		// A series collected again before the flush replaces its previous value.
+		o.values[seriesKey(ts)] = ts.GetPoints()[0].GetValue()
+	}
+	return out
+}
+
+// Flush returns the "other" series, followed by a metric per metric type and reason
+// counting the series which were not sent as collected since the last Flush.
+func (l *Limiter) Flush(config *cpb.Configuration) []*mrpb.TimeSeries {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := tspb.New(l.now())
+	keys := make([]string, 0, len(l.others))
+	for k := range l.others {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var out []*mrpb.TimeSeries
+	for _, k := range keys {
+		out = append(out, l.others[k].sum(now))
+	}
+	out = append(out, droppedMetrics(config, l.dropped, now)...)
+	l.others = make(map[string]*overflow)
+	l.dropped = make(map[dropKey]map[string]bool)
+	return out
+}
+
+// filteredLabels returns the labels of the series with values rejected by the label filters.
+func (l *Limiter) filteredLabels(ts *mrpb.TimeSeries) []string {
+	var labels []string
+	for _, f := range l.filters {
+		if !strings.HasPrefix(ts.GetMetric().GetType(), f.metricPrefix) {
+			continue
+		}
+		value, ok := ts.GetMetric().GetLabels()[f.label]
+		if !ok || value == otherValue {
+			continue
+		}
+		if (len(f.allow) > 0 && !matchesAny(f.allow, value)) || matchesAny(f.deny, value) {
+			labels = append(labels, f.label)
+		}
+	}
+	return labels
+}
+
+// admit reports whether the series is within the limit of its metric type, counting it if new.
+// Series not collected within the expiry are forgotten when the limit is reached.
+func (l *Limiter) admit(metricType, key string, now time.Time) bool {
+	keys, ok := l.seen[metricType]
+	if !ok {
+		keys = make(map[string]time.Time)
+		l.seen[metricType] = keys
+	}
+	if _, ok := keys[key]; ok {
+		keys[key] = now
+		return true
+	}
+	if len(keys) >= l.maxSeries {
+		for k, last := range keys {
+			if now.Sub(last) > l.expiry {
+				delete(keys, k)
+			}
+		}
+	}
+	if len(keys) >= l.maxSeries {
+		return false
+	}
+	keys[key] = now
+	return true
+}
+
+func hasAnyPrefix(metricType string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(metricType, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(res []*regexp.Regexp, value string) bool {
+	for _, re := range res {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// seriesKey identifies a series by its metric and monitored resource labels.
+func seriesKey(ts *mrpb.TimeSeries) string {
+	var b strings.Builder
+	b.WriteString(ts.GetMetric().GetType())
+	writeLabels(&b, ts.GetMetric().GetLabels())
+	b.WriteString("|" + ts.GetResource().GetType())
+	writeLabels(&b, ts.GetResource().GetLabels())
+	return b.String()
+}
+
+func writeLabels(b *strings.Builder, labels map[string]string) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, ",%s=%s", k, labels[k])
+	}
+}
+
+func labelNames(ts *mrpb.TimeSeries) []string {
+	var names []string
+	for k := range ts.GetMetric().GetLabels() {
+		names = append(names, k)
+	}
+	return names
+}
+
+// withLabels returns a copy of the series with the given labels set to "other".
+func withLabels(ts *mrpb.TimeSeries, labels []string) *mrpb.TimeSeries {
+	other := proto.Clone(ts).(*mrpb.TimeSeries)
+	for _, label := range labels {
+		other.GetMetric().GetLabels()[label] = otherValue
+	}
+	return other
+}
+
+// summable reports whether the series can be summed into the "other" series: aggregation
+// is enabled for its metric type, and it is a GAUGE holding a single numeric point.
+// Sums of CUMULATIVE series are not monotonic as the series summed change between
+// collections, and sums of state codes are meaningless, hence the explicit prefixes.
+func (l *Limiter) summable(ts *mrpb.TimeSeries) bool {
+	if !l.aggregate || len(ts.GetPoints()) != 1 {
+		return false
+	}
+	switch ts.GetMetricKind() {
+	case mpb.MetricDescriptor_GAUGE, mpb.MetricDescriptor_METRIC_KIND_UNSPECIFIED:
+	default:
+		return false
+	}
+	if !hasAnyPrefix(ts.GetMetric().GetType(), l.aggregatePrefixes) {
+		return false
+	}
+	switch ts.GetPoints()[0].GetValue().GetValue().(type) {
+	case *mrpb.TypedValue_Int64Value, *mrpb.TypedValue_DoubleValue:
+		return true
+	}
+	return false
+}
+
+// sum returns the "other" series with a single point at now, holding the sum of the values.
+func (o *overflow) sum(now *tspb.Timestamp) *mrpb.TimeSeries {
+	ts := proto.Clone(o.ts).(*mrpb.TimeSeries)
+	point := ts.GetPoints()[0]
+	point.Interval = &mrpb.TimeInterval{EndTime: now}
+	var i int64
+	var d float64
+	for _, v := range o.values {
+		i += v.GetInt64Value()
+		d += v.GetDoubleValue()
+	}
+	switch point.GetValue().GetValue().(type) {
+	case *mrpb.TypedValue_Int64Value:
+		point.Value = &mrpb.TypedValue{Value: &mrpb.TypedValue_Int64Value{Int64Value: i}}
+	case *mrpb.TypedValue_DoubleValue:
+		point.Value = &mrpb.TypedValue{Value: &mrpb.TypedValue_DoubleValue{DoubleValue: d}}
+	}
+	return ts
+}
+
+// droppedMetrics returns the number of series not sent as collected per metric type and reason.
+func droppedMetrics(config *cpb.Configuration, dropped map[dropKey]map[string]bool, now *tspb.Timestamp) []*mrpb.TimeSeries {
+	keys := make([]dropKey, 0, len(dropped))
+	for k := range dropped {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].metric != keys[j].metric {
+			return keys[i].metric < keys[j].metric
+		}
+		return keys[i].reason < keys[j].reason
+	})
+	cloudProps := protostruct.ConvertCloudPropertiesToStruct(config.GetCloudProperties())
+	var metrics []*mrpb.TimeSeries
+	for _, k := range keys {
+		metrics = append(metrics, timeseries.BuildInt(timeseries.Params{
+			BareMetal:    config.GetBareMetal(),
+			CloudProp:    cloudProps,
+			MetricType:   metricURL + droppedPath,
+			MetricLabels: map[string]string{"metric": k.metric, "reason": k.reason},
+			Timestamp:    now,
+			Int64Value:   int64(len(dropped[k])),
+		}))
+	}
+	return metrics
+}
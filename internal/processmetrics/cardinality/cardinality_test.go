@@ -0,0 +1,393 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package cardinality
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+func intSeries(metricType string, value int64, labels ...string) *mrpb.TimeSeries {
+	l := make(map[string]string)
+	for i := 0; i+1 < len(labels); i += 2 {
+		l[labels[i]] = labels[i+1]
+	}
+	return &mrpb.TimeSeries{
+		Metric: &mpb.Metric{Type: metricType, Labels: l},
+		Points: []*mrpb.Point{{Value: &mrpb.TypedValue{Value: &mrpb.TypedValue_Int64Value{Int64Value: value}}}},
+	}
+}
+
+func cumulative(ts *mrpb.TimeSeries) *mrpb.TimeSeries {
+	ts.MetricKind = mpb.MetricDescriptor_CUMULATIVE
+	return ts
+}
+
+// describe summarizes the series as "type{label=value,...}=value" for comparison.
+func describe(timeSeries []*mrpb.TimeSeries) []string {
+	var got []string
+	for _, ts := range timeSeries {
+		var labels []string
+		for k, v := range ts.GetMetric().GetLabels() {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		got = append(got, fmt.Sprintf("%s{%s}=%d", ts.GetMetric().GetType(), strings.Join(labels, ","), ts.GetPoints()[0].GetValue().GetInt64Value()))
+	}
+	return got
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *cpb.CardinalityLimits
+		wantNil     bool
+		wantErr     bool
+		wantMax     int
+		wantExpiry  time.Duration
+		wantFilters int
+	}{
+		{
+			name:    "NotConfigured",
+			wantNil: true,
+		},
+		{
+			name:    "Disabled",
+			config:  &cpb.CardinalityLimits{MaxSeriesPerMetric: 10},
+			wantNil: true,
+		},
+		{
+			name:       "DefaultLimit",
+			config:     &cpb.CardinalityLimits{Enabled: true},
+			wantMax:    defaultMaxSeries,
+			wantExpiry: defaultExpiry,
+		},
+		{
+			name: "WithFilters",
+			config: &cpb.CardinalityLimits{
+				Enabled:             true,
+				MaxSeriesPerMetric:  10,
+				SeriesExpirySeconds: 120,
+				LabelFilters: []*cpb.LabelFilter{
+					{Label: "process", Allow: []string{"^hdb"}},
+					{MetricPrefix: "workload.googleapis.com/sap/networkstats/", Label: "port", Deny: []string{"."}},
+				},
+			},
+			wantMax:     10,
+			wantExpiry:  2 * time.Minute,
+			wantFilters: 2,
+		},
+		{
+			name: "MissingLabel",
+			config: &cpb.CardinalityLimits{
+				Enabled:      true,
+				LabelFilters: []*cpb.LabelFilter{{Allow: []string{"a"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "InvalidRegex",
+			config: &cpb.CardinalityLimits{
+				Enabled:      true,
+				LabelFilters: []*cpb.LabelFilter{{Label: "process", Deny: []string{"("}}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := New(tc.config)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("New(%v) error = %v, want error: %t", tc.config, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if (got == nil) != tc.wantNil {
+				t.Fatalf("New(%v) = %v, want nil: %t", tc.config, got, tc.wantNil)
+			}
+			if got == nil {
+				return
+			}
+			if got.maxSeries != tc.wantMax || got.expiry != tc.wantExpiry || len(got.filters) != tc.wantFilters {
+				t.Errorf("New(%v) = {maxSeries: %d, expiry: %v, filters: %d}, want {maxSeries: %d, expiry: %v, filters: %d}", tc.config, got.maxSeries, got.expiry, len(got.filters), tc.wantMax, tc.wantExpiry, tc.wantFilters)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	const (
+		cpu     = "workload.googleapis.com/sap/control/cpu/utilization"
+		sockets = "workload.googleapis.com/sap/networkstats/rtt"
+		dropped = metricURL + droppedPath
+	)
+	tests := []struct {
+		name        string
+		config      *cpb.CardinalityLimits
+		batches     [][]*mrpb.TimeSeries
+		want        []string
+		wantFlushed []string
+	}{
+		{
+			name: "WithinLimit",
+			config: &cpb.CardinalityLimits{
+				Enabled:            true,
+				MaxSeriesPerMetric: 2,
+			},
+			batches: [][]*mrpb.TimeSeries{{
+				intSeries(cpu, 1, "process", "a"),
+				intSeries(cpu, 2, "process", "b"),
+				intSeries(sockets, 3, "port", "1"),
+			}},
+			want: []string{
+				cpu + "{process=a}=1",
+				cpu + "{process=b}=2",
+				sockets + "{port=1}=3",
+			},
+		},
+		{
+			name: "OverLimitDropped",
+			config: &cpb.CardinalityLimits{
+				Enabled:            true,
+				MaxSeriesPerMetric: 2,
+			},
+			batches: [][]*mrpb.TimeSeries{
+				{
+					intSeries(cpu, 1, "process", "a"),
+					intSeries(cpu, 2, "process", "b"),
+				},
+				// Series seen before are still sent once the limit is reached.
+				{
+					intSeries(cpu, 3, "process", "c"),
+					intSeries(cpu, 4, "process", "b"),
+					intSeries(cpu, 5, "process", "d"),
+				},
+			},
+			want:        []string{cpu + "{process=b}=4"},
+			wantFlushed: []string{dropped + "{metric=" + cpu + ",reason=limit}=2"},
+		},
+		{
+			name: "OverLimitAggregated",
+			config: &cpb.CardinalityLimits{
+				Enabled:                 true,
+				MaxSeriesPerMetric:      1,
+				AggregateOverflow:       true,
+				AggregateMetricPrefixes: []string{"workload.googleapis.com/sap/control/"},
+			},
+			batches: [][]*mrpb.TimeSeries{{
+				intSeries(cpu, 1, "process", "a", "sid", "DEV"),
+				intSeries(cpu, 2, "process", "b", "sid", "DEV"),
+				intSeries(cpu, 3, "process", "c", "sid", "DEV"),
+			}},
+			want: []string{cpu + "{process=a,sid=DEV}=1"},
+			wantFlushed: []string{
+				cpu + "{process=other,sid=other}=5",
+				dropped + "{metric=" + cpu + ",reason=limit}=2",
+			},
+		},
+		{
+			name: "LabelFilters",
+			config: &cpb.CardinalityLimits{
+				Enabled: true,
+				LabelFilters: []*cpb.LabelFilter{
+					{MetricPrefix: "workload.googleapis.com/sap/control/", Label: "process", Allow: []string{"^hdb"}},
+					{MetricPrefix: "workload.googleapis.com/sap/networkstats/", Label: "port", Deny: []string{"^3[0-9]{4}$"}},
+				},
+			},
+			batches: [][]*mrpb.TimeSeries{{
+				intSeries(cpu, 1, "process", "hdbindexserver"),
+				intSeries(cpu, 2, "process", "sapstartsrv"),
+				intSeries(cpu, 3, "sid", "DEV"),
+				intSeries(sockets, 4, "port", "30015"),
+				intSeries(sockets, 5, "port", "22"),
+			}},
+			want: []string{
+				cpu + "{process=hdbindexserver}=1",
+				cpu + "{sid=DEV}=3",
+				sockets + "{port=22}=5",
+			},
+			wantFlushed: []string{
+				dropped + "{metric=" + cpu + ",reason=filtered}=1",
+				dropped + "{metric=" + sockets + ",reason=filtered}=1",
+			},
+		},
+		{
+			name: "FilteredAggregatedKeepsOtherLabels",
+			config: &cpb.CardinalityLimits{
+				Enabled:                 true,
+				AggregateOverflow:       true,
+				AggregateMetricPrefixes: []string{"workload.googleapis.com/sap/control/"},
+				LabelFilters: []*cpb.LabelFilter{
+					{Label: "process", Allow: []string{"^hdb"}},
+				},
+			},
+			batches: [][]*mrpb.TimeSeries{{
+				intSeries(cpu, 1, "process", "sapstartsrv", "sid", "DEV"),
+				intSeries(cpu, 2, "process", "msg_server", "sid", "DEV"),
+				intSeries(cpu, 4, "process", "msg_server", "sid", "PRD"),
+			}},
+			wantFlushed: []string{
+				cpu + "{process=other,sid=DEV}=3",
+				cpu + "{process=other,sid=PRD}=4",
+				dropped + "{metric=" + cpu + ",reason=filtered}=3",
+			},
+		},
+		{
+			name: "AggregationNotEnabledForMetric",
+			config: &cpb.CardinalityLimits{
+				Enabled:                 true,
+				MaxSeriesPerMetric:      1,
+				AggregateOverflow:       true,
+				AggregateMetricPrefixes: []string{"workload.googleapis.com/sap/networkstats/"},
+			},
+			batches: [][]*mrpb.TimeSeries{{
+				intSeries(cpu, 1, "process", "a"),
+				intSeries(cpu, 2, "process", "b"),
+			}},
+			want:        []string{cpu + "{process=a}=1"},
+			wantFlushed: []string{dropped + "{metric=" + cpu + ",reason=limit}=1"},
+		},
+		{
+			name: "CumulativeNotAggregated",
+			config: &cpb.CardinalityLimits{
+				Enabled:                 true,
+				MaxSeriesPerMetric:      1,
+				AggregateOverflow:       true,
+				AggregateMetricPrefixes: []string{"workload.googleapis.com/sap/control/"},
+			},
+			batches: [][]*mrpb.TimeSeries{{
+				cumulative(intSeries(cpu, 1, "process", "a")),
+				cumulative(intSeries(cpu, 2, "process", "b")),
+			}},
+			want:        []string{cpu + "{process=a}=1"},
+			wantFlushed: []string{dropped + "{metric=" + cpu + ",reason=limit}=1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := New(tc.config)
+			if err != nil {
+				t.Fatalf("New(%v) failed: %v", tc.config, err)
+			}
+			var got []*mrpb.TimeSeries
+			for _, batch := range tc.batches {
+				got = l.Apply(batch)
+			}
+			if diff := cmp.Diff(tc.want, describe(got)); diff != "" {
+				t.Errorf("Apply() returned diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantFlushed, describe(l.Flush(&cpb.Configuration{}))); diff != "" {
+				t.Errorf("Flush() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFlush(t *testing.T) {
+	const (
+		cpu     = "workload.googleapis.com/sap/control/cpu/utilization"
+		dropped = metricURL + droppedPath
+	)
+	l, err := New(&cpb.CardinalityLimits{
+		Enabled:                 true,
+		MaxSeriesPerMetric:      1,
+		AggregateOverflow:       true,
+		AggregateMetricPrefixes: []string{"workload.googleapis.com/sap/control/"},
+	})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	// Two collectors share the limiter, the first one running twice before the flush.
+	l.Apply([]*mrpb.TimeSeries{intSeries(cpu, 1, "process", "a"), intSeries(cpu, 2, "process", "b")})
+	l.Apply([]*mrpb.TimeSeries{intSeries(cpu, 3, "process", "c")})
+	l.Apply([]*mrpb.TimeSeries{intSeries(cpu, 1, "process", "a"), intSeries(cpu, 4, "process", "b")})
+	want := []string{
+		cpu + "{process=other}=7",
+		dropped + "{metric=" + cpu + ",reason=limit}=2",
+	}
+	got := l.Flush(&cpb.Configuration{})
+	if diff := cmp.Diff(want, describe(got)); diff != "" {
+		t.Errorf("Flush() returned diff (-want +got):\n%s", diff)
+	}
+	if end := got[0].GetPoints()[0].GetInterval().GetEndTime(); end == nil {
+		t.Errorf("Flush() returned the other series without an end time")
+	}
+	if got := l.Flush(&cpb.Configuration{}); len(got) != 0 {
+		t.Errorf("Flush() after Flush() = %v, want none", describe(got))
+	}
+}
+
+func TestApplyExpiry(t *testing.T) {
+	const cpu = "workload.googleapis.com/sap/control/cpu/utilization"
+	l, err := New(&cpb.CardinalityLimits{Enabled: true, MaxSeriesPerMetric: 2, SeriesExpirySeconds: 60})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	l.now = func() time.Time { return now }
+	batches := []struct {
+		advance time.Duration
+		batch   []*mrpb.TimeSeries
+		want    []string
+	}{
+		{
+			batch: []*mrpb.TimeSeries{intSeries(cpu, 1, "process", "a"), intSeries(cpu, 2, "process", "b")},
+			want:  []string{cpu + "{process=a}=1", cpu + "{process=b}=2"},
+		},
+		{
+			// Process a is gone, process c is over the limit until a expires.
+			advance: 30 * time.Second,
+			batch:   []*mrpb.TimeSeries{intSeries(cpu, 2, "process", "b"), intSeries(cpu, 3, "process", "c")},
+			want:    []string{cpu + "{process=b}=2", metricURL + droppedPath + "{metric=" + cpu + ",reason=limit}=1"},
+		},
+		{
+			advance: 45 * time.Second,
+			batch:   []*mrpb.TimeSeries{intSeries(cpu, 2, "process", "b"), intSeries(cpu, 3, "process", "c")},
+			want:    []string{cpu + "{process=b}=2", cpu + "{process=c}=3"},
+		},
+	}
+	for i, b := range batches {
+		now = now.Add(b.advance)
+		got := l.Apply(b.batch)
+		got = append(got, l.Flush(&cpb.Configuration{})...)
+		if diff := cmp.Diff(b.want, describe(got)); diff != "" {
+			t.Errorf("Apply() for batch %d returned diff (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestApplyNilLimiter(t *testing.T) {
+	var l *Limiter
+	in := []*mrpb.TimeSeries{intSeries("workload.googleapis.com/sap/test", 1, "process", "a")}
+	if got := l.Apply(in); len(got) != 1 || got[0] != in[0] {
+		t.Errorf("Apply() on a nil Limiter = %v, want %v", got, in)
+	}
+	if got := l.Flush(&cpb.Configuration{}); got != nil {
+		t.Errorf("Flush() on a nil Limiter = %v, want nil", got)
+	}
+}
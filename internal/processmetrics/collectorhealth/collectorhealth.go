@@ -0,0 +1,331 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+// Package collectorhealth tracks the duration, series count, errors and last success
+// of the process metrics collectors, exports them as agent metrics and persists a
+// snapshot for the status command.
+package collectorhealth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/protostruct"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/timeseries"
+
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	sapb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
+)
+
+// DefaultSnapshotPath is where the agent persists the collector health for the status command.
+const DefaultSnapshotPath = "/var/lib/google-cloud-sap-agent/collector_health.json"
+
+// Collector groups.
+const (
+	GroupSlow        = "slow"
+	GroupFast        = "fast"
+	GroupReliability = "reliability"
+)
+
+const (
+	metricURL        = "workload.googleapis.com"
+	durationPath     = "/sap/agent/collector/duration"
+	seriesPath       = "/sap/agent/collector/series"
+	errorsPath       = "/sap/agent/collector/errors"
+	lastSuccessPath  = "/sap/agent/collector/last_success"
+	maxErrorLength   = 256
+	snapshotFileMode = 0644
+)
+
+type (
+	// Stats holds the health of one collector.
+	Stats struct {
+		Collector   string        `json:"collector"`
+		Group       string        `json:"group"`
+		Started     time.Time     `json:"started"`
+		Runs        int64         `json:"runs"`
+		Errors      int64         `json:"errors"`
+		Duration    time.Duration `json:"duration"`
+		Series      int           `json:"series"`
+		LastRun     time.Time     `json:"last_run"`
+		LastSuccess time.Time     `json:"last_success,omitempty"`
+		LastError   string        `json:"last_error,omitempty"`
+	}
+
+	// Tracker records collector runs. A nil Tracker discards them.
+	Tracker struct {
+		mu           sync.Mutex
+		stats        map[statsKey]*Stats
+		dirty        bool
+		snapshotPath string
+		mkdirAll     func(path string, perm os.FileMode) error
+		writeFile    func(name string, data []byte, perm os.FileMode) error
+		rename       func(oldpath, newpath string) error
+	}
+
+	// statsKey separates collectors of the same type running in different groups,
+	// ex: the fast moving and reliability HANA collectors.
+	statsKey struct {
+		group, collector string
+	}
+)
+
+// New returns a Tracker which persists a snapshot to snapshotPath when Run flushes it,
+// or keeps the stats in memory only if snapshotPath is empty.
+func New(snapshotPath string) *Tracker {
+	return &Tracker{
+		stats:        make(map[statsKey]*Stats),
+		snapshotPath: snapshotPath,
+		mkdirAll:     os.MkdirAll,
+		writeFile:    os.WriteFile,
+		rename:       os.Rename,
+	}
+}
+
+// Name returns the name identifying a collector in the health metrics, derived from its
+// package and type, and the SAP instance it collects for, ex: "netweaver/D00".
+// Collectors for remote instances also carry the host, ex: "netweaver/sapapp2/00".
+func Name(c any) string {
+	t := reflect.TypeOf(c)
+	v := reflect.ValueOf(c)
+	if t == nil {
+		return "unknown"
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		v = v.Elem()
+	}
+	// The package identifies collectors named InstanceProperties, other types are
+	// appended to it, ex: "computeresources.hana" for HANAInstanceProperties.
+	name := path.Base(t.PkgPath())
+	if kind := strings.TrimSuffix(t.Name(), "InstanceProperties"); kind != "" {
+		name += "." + strings.ToLower(kind)
+	}
+	if t.Kind() != reflect.Struct || !v.IsValid() {
+		return name
+	}
+	var instance *sapb.SAPInstance
+	if f := v.FieldByName("SAPInstance"); f.IsValid() {
+		instance, _ = f.Interface().(*sapb.SAPInstance)
+	}
+	if f := v.FieldByName("RemoteHost"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+		// Remote instances have no instance ID, the host and instance number identify them.
+		return name + "/" + f.String() + "/" + instance.GetInstanceNumber()
+	}
+	if instance.GetInstanceId() != "" {
+		name += "/" + instance.GetInstanceId()
+	}
+	return name
+}
+
+// Record stores the outcome of a collector run which started at start.
+func (t *Tracker) Record(collector, group string, start time.Time, series int, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := statsKey{group: group, collector: collector}
+	s, ok := t.stats[key]
+	if !ok {
+		s = &Stats{Collector: collector, Group: group, Started: start}
+		t.stats[key] = s
+	}
+	now := time.Now()
+	s.Runs++
+	s.Duration = now.Sub(start)
+	s.Series = series
+	s.LastRun = now
+	if err != nil {
+		s.Errors++
+		s.LastError = err.Error()
+		if len(s.LastError) > maxErrorLength {
+			s.LastError = s.LastError[:maxErrorLength]
+		}
+	} else {
+		s.LastSuccess = now
+		s.LastError = ""
+	}
+	t.dirty = true
+}
+
+// Retain drops the stats of the collectors in group which are not in collectors.
+// It is called when the collectors are recreated, so that removed instances do not
+// keep reporting their last run.
+func (t *Tracker) Retain(group string, collectors []string) {
+	if t == nil {
+		return
+	}
+	keep := make(map[string]bool)
+	for _, c := range collectors {
+		keep[c] = true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key := range t.stats {
+		if key.group == group && !keep[key.collector] {
+			delete(t.stats, key)
+			t.dirty = true
+		}
+	}
+}
+
+// Stats returns a copy of the stats sorted by collector and group.
+func (t *Tracker) Stats() []Stats {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.statsLocked()
+}
+
+func (t *Tracker) statsLocked() []Stats {
+	stats := make([]Stats, 0, len(t.stats))
+	for _, s := range t.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Collector != stats[j].Collector {
+			return stats[i].Collector < stats[j].Collector
+		}
+		return stats[i].Group < stats[j].Group
+	})
+	return stats
+}
+
+// TimeSeries returns the health metrics of the given collectors in group, or all
+// collectors in group if none are given.
+func (t *Tracker) TimeSeries(config *cpb.Configuration, group string, collectors ...string) []*mrpb.TimeSeries {
+	if t == nil {
+		return nil
+	}
+	selected := make(map[string]bool)
+	for _, c := range collectors {
+		selected[c] = true
+	}
+	now := tspb.Now()
+	cloudProps := protostruct.ConvertCloudPropertiesToStruct(config.GetCloudProperties())
+	var metrics []*mrpb.TimeSeries
+	for _, s := range t.Stats() {
+		if s.Group != group || len(selected) > 0 && !selected[s.Collector] {
+			continue
+		}
+		labels := map[string]string{"collector": s.Collector, "group": s.Group}
+		params := func(mPath string) timeseries.Params {
+			return timeseries.Params{
+				BareMetal:    config.GetBareMetal(),
+				CloudProp:    cloudProps,
+				MetricType:   metricURL + mPath,
+				MetricLabels: labels,
+				Timestamp:    now,
+			}
+		}
+		duration := params(durationPath)
+		duration.Float64Value = s.Duration.Seconds()
+		series := params(seriesPath)
+		series.Int64Value = int64(s.Series)
+		// The errors are counted since the collector was first recorded, so they are sent as
+		// a cumulative metric starting then.
+		errors := params(errorsPath)
+		errors.Int64Value = s.Errors
+		errors.MetricKind = mpb.MetricDescriptor_CUMULATIVE
+		errors.StartTime = tspb.New(s.Started)
+		metrics = append(metrics, timeseries.BuildFloat64(duration), timeseries.BuildInt(series), timeseries.BuildInt(errors))
+		if !s.LastSuccess.IsZero() {
+			lastSuccess := params(lastSuccessPath)
+			lastSuccess.Int64Value = s.LastSuccess.Unix()
+			metrics = append(metrics, timeseries.BuildInt(lastSuccess))
+		}
+	}
+	return metrics
+}
+
+// Run writes the snapshot every interval if collectors ran since the last write,
+// until ctx is cancelled.
+func (t *Tracker) Run(ctx context.Context, interval time.Duration) {
+	if t == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.WriteSnapshot(); err != nil {
+				log.CtxLogger(ctx).Debugw("Could not persist collector health", "error", err)
+			}
+		}
+	}
+}
+
+// WriteSnapshot persists the stats if they changed since the last write, replacing
+// the previous snapshot atomically.
+func (t *Tracker) WriteSnapshot() error {
+	if t == nil || t.snapshotPath == "" {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.dirty {
+		return nil
+	}
+	data, err := json.Marshal(t.statsLocked())
+	if err != nil {
+		return fmt.Errorf("marshaling collector health: %v", err)
+	}
+	if err := t.mkdirAll(filepath.Dir(t.snapshotPath), 0755); err != nil {
+		return fmt.Errorf("creating collector health directory: %v", err)
+	}
+	tmp := t.snapshotPath + ".tmp"
+	if err := t.writeFile(tmp, data, snapshotFileMode); err != nil {
+		return fmt.Errorf("writing collector health snapshot: %v", err)
+	}
+	if err := t.rename(tmp, t.snapshotPath); err != nil {
+		return fmt.Errorf("replacing collector health snapshot: %v", err)
+	}
+	t.dirty = false
+	return nil
+}
+
+// ReadSnapshot reads the collector health persisted by the agent at snapshotPath.
+func ReadSnapshot(readFile func(string) ([]byte, error), snapshotPath string) ([]Stats, error) {
+	data, err := readFile(filepath.Clean(snapshotPath))
+	if err != nil {
+		return nil, err
+	}
+	var stats []Stats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("parsing collector health snapshot %s: %v", snapshotPath, err)
+	}
+	return stats, nil
+}
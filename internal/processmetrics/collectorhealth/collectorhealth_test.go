@@ -0,0 +1,307 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package collectorhealth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	sapb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
+)
+
+type (
+	InstanceProperties struct {
+		SAPInstance *sapb.SAPInstance
+	}
+	HANAInstanceProperties struct {
+		SAPInstance *sapb.SAPInstance
+	}
+	RemoteInstanceProperties struct {
+		SAPInstance *sapb.SAPInstance
+		RemoteHost  string
+	}
+	Properties struct{}
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name      string
+		collector any
+		want      string
+	}{
+		{
+			name:      "InstanceProperties",
+			collector: &InstanceProperties{SAPInstance: &sapb.SAPInstance{InstanceId: "D00"}},
+			want:      "collectorhealth/D00",
+		},
+		{
+			name:      "NamedInstanceProperties",
+			collector: &HANAInstanceProperties{SAPInstance: &sapb.SAPInstance{InstanceId: "HDB00"}},
+			want:      "collectorhealth.hana/HDB00",
+		},
+		{
+			name:      "RemoteInstance",
+			collector: &RemoteInstanceProperties{SAPInstance: &sapb.SAPInstance{Sapsid: "DEV", InstanceNumber: "01"}, RemoteHost: "sapapp2"},
+			want:      "collectorhealth.remote/sapapp2/01",
+		},
+		{
+			name:      "LocalInstanceWithRemoteHostField",
+			collector: &RemoteInstanceProperties{SAPInstance: &sapb.SAPInstance{InstanceId: "D00", InstanceNumber: "00"}},
+			want:      "collectorhealth.remote/D00",
+		},
+		{
+			name:      "NoInstance",
+			collector: &HANAInstanceProperties{},
+			want:      "collectorhealth.hana",
+		},
+		{
+			name:      "OtherType",
+			collector: Properties{},
+			want:      "collectorhealth.properties",
+		},
+		{
+			name: "Nil",
+			want: "unknown",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Name(test.collector); got != test.want {
+				t.Errorf("Name(%v) = %q, want %q", test.collector, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	tr := New("")
+	start := time.Now().Add(-2 * time.Second)
+	tr.Record("netweaver/D00", GroupSlow, start, 10, nil)
+	tr.Record("netweaver/D00", GroupSlow, start, 0, errors.New(strings.Repeat("x", 300)))
+	tr.Record("hana/HDB00", GroupFast, start, 4, nil)
+	tr.Record("hana/HDB00", GroupReliability, start, 2, nil)
+
+	got := tr.Stats()
+	want := []Stats{
+		{Collector: "hana/HDB00", Group: GroupFast, Runs: 1, Series: 4},
+		{Collector: "hana/HDB00", Group: GroupReliability, Runs: 1, Series: 2},
+		{Collector: "netweaver/D00", Group: GroupSlow, Runs: 2, Errors: 1, Series: 0, LastError: strings.Repeat("x", maxErrorLength)},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Stats{}, "Started", "Duration", "LastRun", "LastSuccess")); diff != "" {
+		t.Errorf("Stats() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	for _, s := range got {
+		if s.Duration < 2*time.Second {
+			t.Errorf("Stats() duration for %s = %v, want at least 2s", s.Collector, s.Duration)
+		}
+		if s.LastSuccess.IsZero() {
+			t.Errorf("Stats() last success for %s is unset", s.Collector)
+		}
+	}
+}
+
+func TestRetain(t *testing.T) {
+	tr := New("")
+	tr.Record("netweaver/D00", GroupSlow, time.Now(), 10, nil)
+	tr.Record("netweaver/D01", GroupSlow, time.Now(), 0, errors.New("instance removed"))
+	tr.Record("netweaver/D01", GroupFast, time.Now(), 3, nil)
+
+	tr.Retain(GroupSlow, []string{"netweaver/D00"})
+
+	want := []Stats{
+		{Collector: "netweaver/D00", Group: GroupSlow, Runs: 1, Series: 10},
+		{Collector: "netweaver/D01", Group: GroupFast, Runs: 1, Series: 3},
+	}
+	if diff := cmp.Diff(want, tr.Stats(), cmpopts.IgnoreFields(Stats{}, "Started", "Duration", "LastRun", "LastSuccess")); diff != "" {
+		t.Errorf("Retain() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestNilTracker(t *testing.T) {
+	var tr *Tracker
+	tr.Record("netweaver", GroupSlow, time.Now(), 1, nil)
+	tr.Retain(GroupSlow, nil)
+	tr.Run(context.Background(), time.Millisecond)
+	if got := tr.Stats(); got != nil {
+		t.Errorf("Stats() on nil tracker = %v, want nil", got)
+	}
+	if got := tr.TimeSeries(&cpb.Configuration{}, GroupSlow); got != nil {
+		t.Errorf("TimeSeries() on nil tracker = %v, want nil", got)
+	}
+	if err := tr.WriteSnapshot(); err != nil {
+		t.Errorf("WriteSnapshot() on nil tracker = %v, want nil", err)
+	}
+}
+
+func TestTimeSeries(t *testing.T) {
+	config := &cpb.Configuration{CloudProperties: &iipb.CloudProperties{ProjectId: "test-project", InstanceId: "123", Zone: "us-central1-a"}}
+	tr := New("")
+	tr.Record("netweaver/D00", GroupSlow, time.Now(), 10, nil)
+	tr.Record("cluster", GroupSlow, time.Now(), 0, errors.New("crm_mon failed"))
+	tr.Record("hana/HDB00", GroupReliability, time.Now(), 2, nil)
+
+	tests := []struct {
+		name       string
+		group      string
+		collectors []string
+		wantTypes  []string
+	}{
+		{
+			name:  "AllCollectors",
+			group: GroupSlow,
+			wantTypes: []string{
+				"workload.googleapis.com/sap/agent/collector/duration",
+				"workload.googleapis.com/sap/agent/collector/series",
+				"workload.googleapis.com/sap/agent/collector/errors",
+				"workload.googleapis.com/sap/agent/collector/duration",
+				"workload.googleapis.com/sap/agent/collector/series",
+				"workload.googleapis.com/sap/agent/collector/errors",
+				"workload.googleapis.com/sap/agent/collector/last_success",
+			},
+		},
+		{
+			name:       "SelectedCollector",
+			group:      GroupSlow,
+			collectors: []string{"cluster"},
+			wantTypes: []string{
+				"workload.googleapis.com/sap/agent/collector/duration",
+				"workload.googleapis.com/sap/agent/collector/series",
+				"workload.googleapis.com/sap/agent/collector/errors",
+			},
+		},
+		{
+			name:       "OtherGroup",
+			group:      GroupFast,
+			collectors: []string{"cluster"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotTypes []string
+			for _, ts := range tr.TimeSeries(config, test.group, test.collectors...) {
+				gotTypes = append(gotTypes, ts.GetMetric().GetType())
+				if ts.GetMetric().GetLabels()["collector"] == "" {
+					t.Errorf("TimeSeries() metric %s has no collector label", ts.GetMetric().GetType())
+				}
+				if got := ts.GetMetric().GetLabels()["group"]; got != test.group {
+					t.Errorf("TimeSeries() metric %s group = %q, want %q", ts.GetMetric().GetType(), got, test.group)
+				}
+			}
+			if diff := cmp.Diff(test.wantTypes, gotTypes); diff != "" {
+				t.Errorf("TimeSeries(%s, %v) returned unexpected diff (-want +got):\n%s", test.group, test.collectors, diff)
+			}
+		})
+	}
+}
+
+func TestTimeSeriesErrorsCumulative(t *testing.T) {
+	started := time.Now().Add(-time.Hour)
+	tr := New("")
+	tr.Record("cluster", GroupSlow, started, 0, errors.New("crm_mon failed"))
+	tr.Record("cluster", GroupSlow, time.Now(), 0, errors.New("crm_mon failed"))
+
+	for _, ts := range tr.TimeSeries(&cpb.Configuration{}, GroupSlow) {
+		if ts.GetMetric().GetType() != "workload.googleapis.com/sap/agent/collector/errors" {
+			continue
+		}
+		if got := ts.GetMetricKind(); got != mpb.MetricDescriptor_CUMULATIVE {
+			t.Errorf("TimeSeries() errors metric kind = %v, want CUMULATIVE", got)
+		}
+		if got := ts.GetPoints()[0].GetValue().GetInt64Value(); got != 2 {
+			t.Errorf("TimeSeries() errors = %d, want 2", got)
+		}
+		if got := ts.GetPoints()[0].GetInterval().GetStartTime().AsTime(); !got.Equal(started) {
+			t.Errorf("TimeSeries() errors start time = %v, want %v", got, started)
+		}
+		return
+	}
+	t.Error("TimeSeries() did not return the errors metric")
+}
+
+func TestSnapshot(t *testing.T) {
+	snapshotPath := filepath.Join(t.TempDir(), "state", "collector_health.json")
+	tr := New(snapshotPath)
+	tr.Record("netweaver/D00", GroupSlow, time.Now(), 10, nil)
+	tr.Record("cluster", GroupSlow, time.Now(), 0, errors.New("crm_mon failed"))
+	if _, err := os.Stat(snapshotPath); !os.IsNotExist(err) {
+		t.Fatalf("os.Stat(%q) before WriteSnapshot() = %v, want not exist", snapshotPath, err)
+	}
+	if err := tr.WriteSnapshot(); err != nil {
+		t.Fatalf("WriteSnapshot() = %v, want nil", err)
+	}
+
+	got, err := ReadSnapshot(os.ReadFile, snapshotPath)
+	if err != nil {
+		t.Fatalf("ReadSnapshot(%q) = %v, want nil", snapshotPath, err)
+	}
+	if diff := cmp.Diff(tr.Stats(), got, cmpopts.EquateApproxTime(time.Millisecond)); diff != "" {
+		t.Errorf("ReadSnapshot(%q) returned unexpected diff (-want +got):\n%s", snapshotPath, diff)
+	}
+}
+
+func TestWriteSnapshotUnchanged(t *testing.T) {
+	tr := New(filepath.Join(t.TempDir(), "collector_health.json"))
+	writes := 0
+	tr.writeFile = func(name string, data []byte, perm os.FileMode) error {
+		writes++
+		return os.WriteFile(name, data, perm)
+	}
+	tr.Record("cluster", GroupSlow, time.Now(), 1, nil)
+	for i := 0; i < 2; i++ {
+		if err := tr.WriteSnapshot(); err != nil {
+			t.Fatalf("WriteSnapshot() = %v, want nil", err)
+		}
+	}
+	if writes != 1 {
+		t.Errorf("WriteSnapshot() wrote the snapshot %d times without new runs, want 1", writes)
+	}
+}
+
+func TestReadSnapshotErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		readFile func(string) ([]byte, error)
+	}{
+		{
+			name:     "ReadFailure",
+			readFile: func(string) ([]byte, error) { return nil, os.ErrNotExist },
+		},
+		{
+			name:     "InvalidJSON",
+			readFile: func(string) ([]byte, error) { return []byte("{"), nil },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ReadSnapshot(test.readFile, DefaultSnapshotPath); err == nil {
+				t.Errorf("ReadSnapshot() succeeded, want error")
+			}
+		})
+	}
+}
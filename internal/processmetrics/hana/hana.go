@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -56,12 +57,19 @@ type (
 		SAPInstance        *sapb.SAPInstance
 		Config             *cnfpb.Configuration
 		Client             cloudmonitoring.TimeSeriesCreator
-		HANAQueryFailCount int64
+		HANAQueryFailCount *FailCounter
 		SkippedMetrics     map[string]bool
 		PMBackoffPolicy    backoff.BackOffContext
 		ReplicationConfig  sapdiscovery.ReplicationConfig
 		SapSystemInterface system.SapSystemDiscoveryInterface
 	}
+
+	// FailCounter counts the failed HANA DB logins of an instance.
+	// Collectors that log in with the same credentials share one FailCounter,
+	// so that together they stay below the lockout threshold.
+	FailCounter struct {
+		n atomic.Int64
+	}
 )
 
 // HANA DB locks the user out after 3 failed authentication attempts, so we
@@ -70,6 +78,21 @@ const (
 	maxHANAQueryFailCount = 2
 )
 
+// Add records a failed login.
+func (c *FailCounter) Add() {
+	c.n.Add(1)
+}
+
+// Count returns the number of failed logins.
+func (c *FailCounter) Count() int64 {
+	return c.n.Load()
+}
+
+// Exceeded reports whether another login could lock the user out.
+func (c *FailCounter) Exceeded() bool {
+	return c.Count() >= maxHANAQueryFailCount
+}
+
 // HANA HA replication: Any code from 10-15 is a valid return code. Anything else needs to be treated as failure.
 const (
 	replicationOff             int64 = 10
@@ -232,10 +255,10 @@ func collectHANAQueryMetrics(ctx context.Context, p *InstanceProperties, exec co
 		return nil, nil
 	}
 	now := tspb.Now()
-	if p.HANAQueryFailCount >= maxHANAQueryFailCount {
+	if p.HANAQueryFailCount.Exceeded() {
 		// if HANAQueryFailCount reaches maxHANAQueryFailCount we should not let it
 		// query again, because the user can be locked out.
-		log.CtxLogger(ctx).Debugw("Not queryig for HANAQuery Metrics as failcount has reached max allowed fail count.", "instanceid", p.SAPInstance.GetInstanceId(), "failcount", p.HANAQueryFailCount)
+		log.CtxLogger(ctx).Debugw("Not queryig for HANAQuery Metrics as failcount has reached max allowed fail count.", "instanceid", p.SAPInstance.GetInstanceId(), "failcount", p.HANAQueryFailCount.Count())
 		return nil, nil
 	}
 	queryState, err := runHANAQuery(ctx, p, exec)
@@ -362,7 +385,7 @@ func runHANAQuery(ctx context.Context, p *InstanceProperties, exec commandlineex
 	})
 	log.CtxLogger(ctx).Debugw("HANA query command returned", "sql", hdbsql, "stdout", result.StdOut, "stderror", result.StdErr, "state", result.ExitCode, "err", result.Error)
 	if strings.Contains(result.StdErr, "authentication failed") {
-		p.HANAQueryFailCount++
+		p.HANAQueryFailCount.Add()
 	}
 	// We do not want to check the result Error, error can exist even when the exec was successful.
 
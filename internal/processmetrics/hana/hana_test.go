@@ -69,31 +69,36 @@ var (
 	}
 
 	defaultInstanceProperties = &InstanceProperties{
-		Config:      defaultConfig,
-		SAPInstance: defaultSAPInstance,
+		Config:             defaultConfig,
+		SAPInstance:        defaultSAPInstance,
+		HANAQueryFailCount: &FailCounter{},
 	}
 
 	defaultAPIInstanceProperties = &InstanceProperties{
-		Config:      defaultConfig,
-		SAPInstance: defaultSAPInstance,
+		Config:             defaultConfig,
+		SAPInstance:        defaultSAPInstance,
+		HANAQueryFailCount: &FailCounter{},
 	}
 
 	instancePropertiesWithReplication = &InstanceProperties{
-		Config:            defaultConfig,
-		SAPInstance:       defaultSAPInstance,
-		ReplicationConfig: defaultReplicationConfig,
+		Config:             defaultConfig,
+		SAPInstance:        defaultSAPInstance,
+		ReplicationConfig:  defaultReplicationConfig,
+		HANAQueryFailCount: &FailCounter{},
 	}
 
 	instancePropertiesWithReplicationDisabled = &InstanceProperties{
-		Config:            defaultConfig,
-		SAPInstance:       defaultSAPInstance,
-		ReplicationConfig: replicationConfigForStandaloneInstance,
+		Config:             defaultConfig,
+		SAPInstance:        defaultSAPInstance,
+		ReplicationConfig:  replicationConfigForStandaloneInstance,
+		HANAQueryFailCount: &FailCounter{},
 	}
 
 	instancePropertiesWithReplicationRefreshFailure = &InstanceProperties{
-		Config:            defaultConfig,
-		SAPInstance:       defaultSAPInstance,
-		ReplicationConfig: replicationConfigForRefreshFailure,
+		Config:             defaultConfig,
+		SAPInstance:        defaultSAPInstance,
+		ReplicationConfig:  replicationConfigForRefreshFailure,
+		HANAQueryFailCount: &FailCounter{},
 	}
 
 	defaultReplicationConfig = func(ctx context.Context, user, sid, instID string, sapSystemInterface system.SapSystemDiscoveryInterface) (int, int64, *sapb.HANAReplicaSite, error) {
@@ -194,7 +199,8 @@ func TestCollectHANAServiceMetrics(t *testing.T) {
 				},
 			},
 			instanceProperties: &InstanceProperties{
-				SAPInstance: defaultSAPInstance,
+				SAPInstance:        defaultSAPInstance,
+				HANAQueryFailCount: &FailCounter{},
 				Config: &cpb.Configuration{
 					CollectionConfiguration: &cpb.CollectionConfiguration{
 						ProcessMetricsToSkip: []string{servicePath},
@@ -366,7 +372,7 @@ func TestCollectHANAQueryMetricsWithMaxFailCounts(t *testing.T) {
 	ip := &InstanceProperties{
 		Config:             defaultConfig,
 		SAPInstance:        defaultSAPInstance,
-		HANAQueryFailCount: 0,
+		HANAQueryFailCount: &FailCounter{},
 	}
 
 	for i := 0; i < 3; i++ {
@@ -558,7 +564,8 @@ func TestCollect(t *testing.T) {
 		{
 			name: "MetricCountTest",
 			properties: &InstanceProperties{
-				Config: defaultConfig,
+				Config:             defaultConfig,
+				HANAQueryFailCount: &FailCounter{},
 				SAPInstance: &sapb.SAPInstance{
 					Sapsid:         "TST",
 					InstanceNumber: "00",
@@ -576,7 +583,8 @@ func TestCollect(t *testing.T) {
 		{
 			name: "MetricCountTestUserstoreAuth",
 			properties: &InstanceProperties{
-				Config: defaultConfig,
+				Config:             defaultConfig,
+				HANAQueryFailCount: &FailCounter{},
 				SAPInstance: &sapb.SAPInstance{
 					Sapsid:          "TST",
 					InstanceNumber:  "00",
@@ -593,7 +601,8 @@ func TestCollect(t *testing.T) {
 		{
 			name: "NoHANADBUserAndKey",
 			properties: &InstanceProperties{
-				Config: defaultConfig,
+				Config:             defaultConfig,
+				HANAQueryFailCount: &FailCounter{},
 				SAPInstance: &sapb.SAPInstance{
 					Sapsid:         "TST",
 					InstanceNumber: "00",
@@ -608,7 +617,8 @@ func TestCollect(t *testing.T) {
 		{
 			name: "NoHANADBUser",
 			properties: &InstanceProperties{
-				Config: defaultConfig,
+				Config:             defaultConfig,
+				HANAQueryFailCount: &FailCounter{},
 				SAPInstance: &sapb.SAPInstance{
 					Sapsid:         "TST",
 					InstanceNumber: "00",
@@ -624,7 +634,8 @@ func TestCollect(t *testing.T) {
 		{
 			name: "NoHANADBPassword",
 			properties: &InstanceProperties{
-				Config: defaultConfig,
+				Config:             defaultConfig,
+				HANAQueryFailCount: &FailCounter{},
 				SAPInstance: &sapb.SAPInstance{
 					Sapsid:         "TST",
 					InstanceNumber: "00",
@@ -640,7 +651,8 @@ func TestCollect(t *testing.T) {
 		{
 			name: "HANASecondaryNode",
 			properties: &InstanceProperties{
-				Config: defaultConfig,
+				Config:             defaultConfig,
+				HANAQueryFailCount: &FailCounter{},
 				SAPInstance: &sapb.SAPInstance{
 					Sapsid:         "TST",
 					InstanceNumber: "00",
@@ -0,0 +1,300 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+// Package hanareplication collects SAP HANA system replication lag and throughput metrics
+// from M_SERVICE_REPLICATION on the primary site.
+package hanareplication
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/hana"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/sapdiscovery"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system"
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/protostruct"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/timeseries"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+	cnfpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	sapb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
+)
+
+type (
+	// InstanceProperties has necessary context for Metrics collection.
+	// InstanceProperties implements Collector interface for HANA system replication.
+	InstanceProperties struct {
+		SAPInstance        *sapb.SAPInstance
+		Config             *cnfpb.Configuration
+		Client             cloudmonitoring.TimeSeriesCreator
+		Executor           commandlineexecutor.Execute
+		SkippedMetrics     map[string]bool
+		PMBackoffPolicy    backoff.BackOffContext
+		ReplicationConfig  sapdiscovery.ReplicationConfig
+		SapSystemInterface system.SapSystemDiscoveryInterface
+		// HANAQueryFailCount is shared with the HANA collector of the instance,
+		// which logs in with the same credentials.
+		HANAQueryFailCount *hana.FailCounter
+	}
+
+	// serviceReplication is a row of M_SERVICE_REPLICATION.
+	serviceReplication struct {
+		siteName, secondarySiteName, secondaryHost, port  string
+		replicationMode, operationMode, replicationStatus string
+		// Values are nil when HANA returns NULL.
+		logShippingDelaySec, shippedLogBuffersSize, shippedLogBuffersDuration, shippedLogPosition *int64
+	}
+)
+
+// HANA HA replication status, using the codes of /sap/hana/ha/replication.
+var replicationStatusCodes = map[string]int64{
+	"ERROR":        11,
+	"UNKNOWN":      12,
+	"INITIALIZING": 13,
+	"SYNCING":      14,
+	"ACTIVE":       15,
+}
+
+const (
+	metricURL                     = "workload.googleapis.com"
+	replicationStatusPath         = "/sap/hana/ha/replication/status"
+	logShippingDelayPath          = "/sap/hana/ha/replication/log_shipping_delay"
+	shippedLogBuffersSizePath     = "/sap/hana/ha/replication/shipped_log_buffers_size"
+	shippedLogBuffersDurationPath = "/sap/hana/ha/replication/shipped_log_buffers_duration"
+	shippedLogPositionPath        = "/sap/hana/ha/replication/shipped_log_position"
+	hanaPrimary                   = 1
+	// The log shipping delay is the time between the last log position written on the
+	// primary and the last log position shipped to the secondary.
+	replicationQuery = "SELECT SITE_NAME, SECONDARY_SITE_NAME, SECONDARY_HOST, PORT, REPLICATION_MODE, OPERATION_MODE, REPLICATION_STATUS, SECONDS_BETWEEN(SHIPPED_LOG_POSITION_TIME, LAST_LOG_POSITION_TIME), SHIPPED_LOG_BUFFERS_SIZE, SHIPPED_LOG_BUFFERS_DURATION, SHIPPED_LOG_POSITION FROM SYS.M_SERVICE_REPLICATION"
+)
+
+// Collect is an implementation of Collector interface from processmetrics.go.
+// Collects system replication metrics per replicated service:
+//   - /sap/hana/ha/replication/status
+//   - /sap/hana/ha/replication/log_shipping_delay
+//   - /sap/hana/ha/replication/shipped_log_buffers_size
+//   - /sap/hana/ha/replication/shipped_log_buffers_duration
+//   - /sap/hana/ha/replication/shipped_log_position
+//
+// Metrics are only collected on the primary site, with HANA DB credentials set.
+func (p *InstanceProperties) Collect(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+	if !p.hasCredentials() {
+		return nil, nil
+	}
+	if p.HANAQueryFailCount.Exceeded() {
+		log.CtxLogger(ctx).Debugw("Not querying for HANA replication metrics as failcount has reached max allowed fail count", "instanceid", p.SAPInstance.GetInstanceId(), "failcount", p.HANAQueryFailCount.Count())
+		return nil, nil
+	}
+	mode, _, sites, err := p.ReplicationConfig(ctx, p.SAPInstance.GetUser(), p.SAPInstance.GetSapsid(), p.SAPInstance.GetInstanceId(), p.SapSystemInterface)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to refresh HANA HA Replication config for instance", "instanceid", p.SAPInstance.GetInstanceId(), "error", err)
+		return nil, err
+	}
+	if mode != hanaPrimary {
+		log.CtxLogger(ctx).Debugw("Skipping HANA replication metrics, instance is not the primary site", "instanceid", p.SAPInstance.GetInstanceId(), "mode", mode)
+		return nil, nil
+	}
+	rows, err := p.queryReplication(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.createTSList(rows, sites, tspb.Now()), nil
+}
+
+// CollectWithRetry decorates the Collect method with retry mechanism.
+func (p *InstanceProperties) CollectWithRetry(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+	var (
+		attempt = 1
+		res     []*mrpb.TimeSeries
+	)
+	err := backoff.Retry(func() error {
+		select {
+		case <-ctx.Done():
+			log.CtxLogger(ctx).Debugw("Context cancelled, exiting CollectWithRetry")
+			return nil
+		default:
+			var err error
+			res, err = p.Collect(ctx)
+			if err != nil {
+				log.CtxLogger(ctx).Debugw("Error in Collection", "attempt", attempt, "error", err)
+				attempt++
+			}
+			return err
+		}
+	}, p.PMBackoffPolicy)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Retry limit exceeded", "InstanceId", p.SAPInstance.GetInstanceId(), "error", err)
+	}
+	return res, err
+}
+
+func (p *InstanceProperties) hasCredentials() bool {
+	return (p.SAPInstance.GetHanaDbUser() != "" && p.SAPInstance.GetHanaDbPassword() != "") || p.SAPInstance.GetHdbuserstoreKey() != ""
+}
+
+// queryReplication runs the M_SERVICE_REPLICATION query with hdbsql.
+// Uses SAP Instance's hana_db_user/hana_db_password or hdbuserstore_key for authentication with the DB.
+func (p *InstanceProperties) queryReplication(ctx context.Context) ([]serviceReplication, error) {
+	hdbsql := fmt.Sprintf("/usr/sap/%s/%s/exe/hdbsql", p.SAPInstance.GetSapsid(), p.SAPInstance.GetInstanceId())
+	auth := ""
+	if p.SAPInstance.GetHdbuserstoreKey() != "" {
+		auth = fmt.Sprintf("-U %s", p.SAPInstance.GetHdbuserstoreKey())
+	} else {
+		auth = fmt.Sprintf("-n localhost:3%s15 -u %s -p %s", p.SAPInstance.GetInstanceNumber(), p.SAPInstance.GetHanaDbUser(), p.SAPInstance.GetHanaDbPassword())
+	}
+	result := p.Executor(ctx, commandlineexecutor.Params{
+		Executable:  hdbsql,
+		ArgsToSplit: fmt.Sprintf("%s -a -x -j '%s'", auth, replicationQuery),
+		User:        p.SAPInstance.GetUser(),
+	})
+	if strings.Contains(result.StdErr, "authentication failed") {
+		p.HANAQueryFailCount.Add()
+	}
+	if result.Error != nil {
+		log.CtxLogger(ctx).Debugw("HANA replication query failed", "stderr", result.StdErr, "exitcode", result.ExitCode, "error", result.Error)
+		return nil, result.Error
+	}
+	return parseReplication(result.StdOut)
+}
+
+// parseReplication parses the hdbsql output of the replication query, one row per line.
+func parseReplication(out string) ([]serviceReplication, error) {
+	r := csv.NewReader(strings.NewReader(out))
+	r.FieldsPerRecord = 11
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing HANA replication query output: %w", err)
+	}
+	var rows []serviceReplication
+	for _, rec := range records {
+		row := serviceReplication{
+			siteName:          rec[0],
+			secondarySiteName: rec[1],
+			secondaryHost:     rec[2],
+			port:              rec[3],
+			replicationMode:   rec[4],
+			operationMode:     rec[5],
+			replicationStatus: rec[6],
+		}
+		for i, v := range []**int64{&row.logShippingDelaySec, &row.shippedLogBuffersSize, &row.shippedLogBuffersDuration, &row.shippedLogPosition} {
+			field := rec[7+i]
+			// hdbsql prints NULL as "?".
+			if field == "?" || field == "" {
+				continue
+			}
+			n, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parsing HANA replication query value %q: %w", field, err)
+			}
+			*v = &n
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// createTSList creates the replication metrics for each replicated service.
+func (p *InstanceProperties) createTSList(rows []serviceReplication, sites *sapb.HANAReplicaSite, now *tspb.Timestamp) []*mrpb.TimeSeries {
+	var metrics []*mrpb.TimeSeries
+	for _, row := range rows {
+		primary, secondary := siteNames(sites, row.secondaryHost)
+		labels := map[string]string{
+			"primary_site":        primary,
+			"secondary_site":      secondary,
+			"site_name":           row.siteName,
+			"secondary_site_name": row.secondarySiteName,
+			"port":                row.port,
+			"replication_mode":    row.replicationMode,
+			"operation_mode":      row.operationMode,
+		}
+		if !p.SkippedMetrics[replicationStatusPath] {
+			status, ok := replicationStatusCodes[row.replicationStatus]
+			if !ok {
+				status = replicationStatusCodes["UNKNOWN"]
+			}
+			metrics = append(metrics, p.createMetric(replicationStatusPath, labels, now, status))
+		}
+		for _, m := range []struct {
+			path  string
+			value *int64
+		}{
+			{logShippingDelayPath, row.logShippingDelaySec},
+			{shippedLogBuffersSizePath, row.shippedLogBuffersSize},
+			{shippedLogBuffersDurationPath, row.shippedLogBuffersDuration},
+			{shippedLogPositionPath, row.shippedLogPosition},
+		} {
+			if m.value == nil || p.SkippedMetrics[m.path] {
+				continue
+			}
+			metrics = append(metrics, p.createMetric(m.path, labels, now, *m.value))
+		}
+	}
+	return metrics
+}
+
+// siteNames returns the names of the sites replicating to and from the secondary host,
+// as discovered in the replication site tree. Falls back to the root site and the host
+// itself when the secondary is not part of the tree.
+func siteNames(sites *sapb.HANAReplicaSite, secondaryHost string) (primary, secondary string) {
+	if parent := findParent(sites, secondaryHost); parent != nil {
+		return parent.GetName(), secondaryHost
+	}
+	return sites.GetName(), secondaryHost
+}
+
+// findParent returns the site which has a target with the given name.
+func findParent(site *sapb.HANAReplicaSite, name string) *sapb.HANAReplicaSite {
+	for _, t := range site.GetTargets() {
+		if t.GetName() == name {
+			return site
+		}
+		if parent := findParent(t, name); parent != nil {
+			return parent
+		}
+	}
+	return nil
+}
+
+// createMetric creates a mrpb.TimeSeries object for the given metric.
+func (p *InstanceProperties) createMetric(mPath string, extraLabels map[string]string, now *tspb.Timestamp, val int64) *mrpb.TimeSeries {
+	mLabels := map[string]string{
+		"sid":           p.SAPInstance.GetSapsid(),
+		"instance_nr":   p.SAPInstance.GetInstanceNumber(),
+		"instance_name": p.Config.GetCloudProperties().GetInstanceName(),
+	}
+	for k, v := range extraLabels {
+		mLabels[k] = v
+	}
+	params := timeseries.Params{
+		CloudProp:    protostruct.ConvertCloudPropertiesToStruct(p.Config.GetCloudProperties()),
+		MetricType:   metricURL + mPath,
+		MetricLabels: mLabels,
+		Timestamp:    now,
+		Int64Value:   val,
+		BareMetal:    p.Config.GetBareMetal(),
+	}
+	return timeseries.BuildInt(params)
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package hanareplication
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/hana"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	sapb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
+)
+
+func TestMain(t *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(t.Run())
+}
+
+const replicationOutput = `"SiteA","SiteB","hana-2",30001,"SYNC","logreplay","ACTIVE",0,1048576,2000,9876543
+"SiteA","SiteB","hana-2",30003,"SYNC","logreplay","SYNCING",12,4096,100,?
+`
+
+var (
+	defaultConfig = &cpb.Configuration{
+		CloudProperties: &iipb.CloudProperties{
+			InstanceName: "test-instance",
+			ProjectId:    "test-project",
+			Zone:         "test-zone",
+		},
+	}
+
+	defaultSAPInstance = &sapb.SAPInstance{
+		Sapsid:          "TST",
+		InstanceNumber:  "00",
+		InstanceId:      "HDB00",
+		Type:            sapb.InstanceType_HANA,
+		HdbuserstoreKey: "MONITOR",
+	}
+
+	replicaSites = &sapb.HANAReplicaSite{
+		Name: "hana-1",
+		Targets: []*sapb.HANAReplicaSite{
+			{
+				Name:    "hana-2",
+				Targets: []*sapb.HANAReplicaSite{{Name: "hana-3"}},
+			},
+		},
+	}
+)
+
+func fakeReplicationConfig(mode int, err error) func(context.Context, string, string, string, system.SapSystemDiscoveryInterface) (int, int64, *sapb.HANAReplicaSite, error) {
+	return func(context.Context, string, string, string, system.SapSystemDiscoveryInterface) (int, int64, *sapb.HANAReplicaSite, error) {
+		return mode, 15, replicaSites, err
+	}
+}
+
+func fakeExecutor(stdout, stderr string, err error) commandlineexecutor.Execute {
+	return func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+		return commandlineexecutor.Result{StdOut: stdout, StdErr: stderr, Error: err}
+	}
+}
+
+// failCounter returns a HANA fail counter with n failed logins.
+func failCounter(n int) *hana.FailCounter {
+	c := &hana.FailCounter{}
+	for i := 0; i < n; i++ {
+		c.Add()
+	}
+	return c
+}
+
+// metricValues returns the metric types and int64 values of the time series.
+func metricValues(metrics []*mrpb.TimeSeries) map[string][]int64 {
+	got := make(map[string][]int64)
+	for _, m := range metrics {
+		got[m.GetMetric().GetType()] = append(got[m.GetMetric().GetType()], m.GetPoints()[0].GetValue().GetInt64Value())
+	}
+	return got
+}
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name      string
+		props     *InstanceProperties
+		want      map[string][]int64
+		wantErr   bool
+		wantFails int64
+	}{
+		{
+			name: "Primary",
+			props: &InstanceProperties{
+				SAPInstance:        defaultSAPInstance,
+				Config:             defaultConfig,
+				Executor:           fakeExecutor(replicationOutput, "", nil),
+				ReplicationConfig:  fakeReplicationConfig(1, nil),
+				HANAQueryFailCount: &hana.FailCounter{},
+			},
+			want: map[string][]int64{
+				"workload.googleapis.com/sap/hana/ha/replication/status":                       {15, 14},
+				"workload.googleapis.com/sap/hana/ha/replication/log_shipping_delay":           {0, 12},
+				"workload.googleapis.com/sap/hana/ha/replication/shipped_log_buffers_size":     {1048576, 4096},
+				"workload.googleapis.com/sap/hana/ha/replication/shipped_log_buffers_duration": {2000, 100},
+				"workload.googleapis.com/sap/hana/ha/replication/shipped_log_position":         {9876543},
+			},
+		},
+		{
+			name: "SkippedMetrics",
+			props: &InstanceProperties{
+				SAPInstance:        defaultSAPInstance,
+				Config:             defaultConfig,
+				Executor:           fakeExecutor(replicationOutput, "", nil),
+				ReplicationConfig:  fakeReplicationConfig(1, nil),
+				HANAQueryFailCount: &hana.FailCounter{},
+				SkippedMetrics: map[string]bool{
+					replicationStatusPath:         true,
+					shippedLogBuffersSizePath:     true,
+					shippedLogBuffersDurationPath: true,
+					shippedLogPositionPath:        true,
+				},
+			},
+			want: map[string][]int64{
+				"workload.googleapis.com/sap/hana/ha/replication/log_shipping_delay": {0, 12},
+			},
+		},
+		{
+			name: "Secondary",
+			props: &InstanceProperties{
+				SAPInstance:        defaultSAPInstance,
+				Config:             defaultConfig,
+				Executor:           fakeExecutor(replicationOutput, "", nil),
+				ReplicationConfig:  fakeReplicationConfig(2, nil),
+				HANAQueryFailCount: &hana.FailCounter{},
+			},
+			want: map[string][]int64{},
+		},
+		{
+			name: "NoCredentials",
+			props: &InstanceProperties{
+				SAPInstance:        &sapb.SAPInstance{Sapsid: "TST", InstanceNumber: "00"},
+				Config:             defaultConfig,
+				Executor:           fakeExecutor(replicationOutput, "", nil),
+				ReplicationConfig:  fakeReplicationConfig(1, nil),
+				HANAQueryFailCount: &hana.FailCounter{},
+			},
+			want: map[string][]int64{},
+		},
+		{
+			name: "ReplicationConfigError",
+			props: &InstanceProperties{
+				SAPInstance:        defaultSAPInstance,
+				Config:             defaultConfig,
+				ReplicationConfig:  fakeReplicationConfig(0, errors.New("replication config error")),
+				HANAQueryFailCount: &hana.FailCounter{},
+			},
+			want:    map[string][]int64{},
+			wantErr: true,
+		},
+		{
+			name: "AuthenticationFailure",
+			props: &InstanceProperties{
+				SAPInstance:        defaultSAPInstance,
+				Config:             defaultConfig,
+				Executor:           fakeExecutor("", "* 10: authentication failed", errors.New("exit status 10")),
+				ReplicationConfig:  fakeReplicationConfig(1, nil),
+				HANAQueryFailCount: &hana.FailCounter{},
+			},
+			want:      map[string][]int64{},
+			wantErr:   true,
+			wantFails: 1,
+		},
+		{
+			name: "MaxFailCountReached",
+			props: &InstanceProperties{
+				SAPInstance:        defaultSAPInstance,
+				Config:             defaultConfig,
+				Executor:           fakeExecutor(replicationOutput, "", nil),
+				ReplicationConfig:  fakeReplicationConfig(1, nil),
+				HANAQueryFailCount: failCounter(2),
+			},
+			want:      map[string][]int64{},
+			wantFails: 2,
+		},
+		{
+			name: "MalformedOutput",
+			props: &InstanceProperties{
+				SAPInstance:        defaultSAPInstance,
+				Config:             defaultConfig,
+				Executor:           fakeExecutor(`"SiteA","SiteB"`, "", nil),
+				ReplicationConfig:  fakeReplicationConfig(1, nil),
+				HANAQueryFailCount: &hana.FailCounter{},
+			},
+			want:    map[string][]int64{},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.props.Collect(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Collect() returned error %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, metricValues(got)); diff != "" {
+				t.Errorf("Collect() returned unexpected metric values (-want +got):\n%s", diff)
+			}
+			if got := tc.props.HANAQueryFailCount.Count(); got != tc.wantFails {
+				t.Errorf("Collect() fail count = %d, want %d", got, tc.wantFails)
+			}
+		})
+	}
+}
+
+func TestCollectLabels(t *testing.T) {
+	p := &InstanceProperties{
+		SAPInstance:        defaultSAPInstance,
+		Config:             defaultConfig,
+		Executor:           fakeExecutor(replicationOutput, "", nil),
+		ReplicationConfig:  fakeReplicationConfig(1, nil),
+		HANAQueryFailCount: &hana.FailCounter{},
+		SkippedMetrics: map[string]bool{
+			logShippingDelayPath:          true,
+			shippedLogBuffersSizePath:     true,
+			shippedLogBuffersDurationPath: true,
+			shippedLogPositionPath:        true,
+		},
+	}
+	got, err := p.Collect(context.Background())
+	if err != nil || len(got) != 2 {
+		t.Fatalf("Collect() = (%d metrics, %v), want 2 metrics", len(got), err)
+	}
+	want := map[string]string{
+		"sid":                 "TST",
+		"instance_nr":         "00",
+		"instance_name":       "test-instance",
+		"primary_site":        "hana-1",
+		"secondary_site":      "hana-2",
+		"site_name":           "SiteA",
+		"secondary_site_name": "SiteB",
+		"port":                "30001",
+		"replication_mode":    "SYNC",
+		"operation_mode":      "logreplay",
+	}
+	if diff := cmp.Diff(want, got[0].GetMetric().GetLabels()); diff != "" {
+		t.Errorf("Collect() returned unexpected labels (-want +got):\n%s", diff)
+	}
+}
+
+func TestSiteNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		secondaryHost string
+		wantPrimary   string
+		wantSecondary string
+	}{
+		{
+			name:          "Secondary",
+			secondaryHost: "hana-2",
+			wantPrimary:   "hana-1",
+			wantSecondary: "hana-2",
+		},
+		{
+			name:          "Tertiary",
+			secondaryHost: "hana-3",
+			wantPrimary:   "hana-2",
+			wantSecondary: "hana-3",
+		},
+		{
+			name:          "NotInTree",
+			secondaryHost: "hana-9",
+			wantPrimary:   "hana-1",
+			wantSecondary: "hana-9",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotPrimary, gotSecondary := siteNames(replicaSites, tc.secondaryHost)
+			if gotPrimary != tc.wantPrimary || gotSecondary != tc.wantSecondary {
+				t.Errorf("siteNames(%q) = (%q, %q), want (%q, %q)", tc.secondaryHost, gotPrimary, gotSecondary, tc.wantPrimary, tc.wantSecondary)
+			}
+		})
+	}
+}
+
+func TestCollectWithRetry(t *testing.T) {
+	p := &InstanceProperties{
+		SAPInstance:        defaultSAPInstance,
+		Config:             defaultConfig,
+		Executor:           fakeExecutor(replicationOutput, "", nil),
+		ReplicationConfig:  fakeReplicationConfig(1, nil),
+		PMBackoffPolicy:    backoff.WithContext(&backoff.StopBackOff{}, context.Background()),
+		HANAQueryFailCount: &hana.FailCounter{},
+	}
+	got, err := p.CollectWithRetry(context.Background())
+	if err != nil || len(got) != 9 {
+		t.Errorf("CollectWithRetry() = (%d metrics, %v), want 9 metrics", len(got), err)
+	}
+}
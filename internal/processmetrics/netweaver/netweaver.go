@@ -52,6 +52,11 @@ type (
 		Client          cloudmonitoring.TimeSeriesCreator
 		SkippedMetrics  map[string]bool
 		PMBackoffPolicy backoff.BackOffContext
+		// SAPControlClient overrides the local sapstartsrv client when set.
+		SAPControlClient sapcontrol.ClientInterface
+		// RemoteHost is set for instances on other hosts, which are limited to
+		// the metrics available through the SAPControl web service.
+		RemoteHost string
 	}
 )
 
@@ -78,6 +83,17 @@ const (
 	nwABAPRFCPath              = "/sap/nw/abap/rfc"
 	nwEnqLocksPath             = "/sap/nw/enq/locks/usercountowner"
 	nwInstanceRolePath         = "/sap/nw/instance/role"
+	nwCCMSPath                 = "/sap/nw/ccms"
+	nwCCMSAlertStatePath       = "/sap/nw/ccms/alert_state"
+	nwCCMSOpenAlertsPath       = "/sap/nw/ccms/open_alerts"
+)
+
+// CCMS monitor tree element states.
+const (
+	ccmsStateGray   = 0
+	ccmsStateGreen  = 1
+	ccmsStateYellow = 2
+	ccmsStateRed    = 3
 )
 
 var (
@@ -96,6 +112,29 @@ var (
 	// 9. Instance number
 	// 10. Instance name
 	appProcessRegex = regexp.MustCompile(`(dw|enq|en|enqr|er|jc|ms)\.sap([A-Za-z][A-Za-z0-9]{2})_(D|ASCS|ERS|SCS|J)([0-9]{2}) pf=/(usr/sap|sapmnt)/([A-Za-z][A-Za-z0-9]{2})/SYS/profile/([A-Za-z][A-Za-z0-9]{2})_(D|ASCS|SCS|ERS|J)([0-9]{2})_(.*)`)
+
+	// defaultCCMSNodeFilters select the dialog response time, update errors, short dumps,
+	// spool errors and background job failures when no node filters are configured.
+	defaultCCMSNodeFilters = []string{
+		`/Dialog/ResponseTime$`,
+		`/Dialog/FrontEndResponseTime$`,
+		`/Update/.*Error`,
+		`/Shortdumps/`,
+		`/Spool/.*Error`,
+		`/Background/AbortedJobs$`,
+	}
+
+	// Expected format: "(value) (unit)", ex: "423 msec" or "0.5 /min".
+	ccmsValueRegex  = regexp.MustCompile(`^\s*(-?[0-9]+(?:\.[0-9]+)?)\s*(.*)$`)
+	ccmsWordRegex   = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+	ccmsInvalidChar = regexp.MustCompile(`[^a-z0-9]+`)
+
+	ccmsStates = map[string]int64{
+		"GRAY":   ccmsStateGray,
+		"GREEN":  ccmsStateGreen,
+		"YELLOW": ccmsStateYellow,
+		"RED":    ccmsStateRed,
+	}
 )
 
 // Collect is Netweaver implementation of Collector interface from processmetrics.go.
@@ -103,7 +142,13 @@ var (
 // Collect method keeps on collecting all the metrics it can, logs errors if it encounters
 // any and returns the collected metrics with the last error encountered while collecting metrics.
 func (p *InstanceProperties) Collect(ctx context.Context) ([]*mrpb.TimeSeries, error) {
-	scc := sapcontrolclient.New(p.SAPInstance.GetInstanceNumber())
+	var scc sapcontrol.ClientInterface = sapcontrolclient.New(p.SAPInstance.GetInstanceNumber())
+	if p.SAPControlClient != nil {
+		scc = p.SAPControlClient
+	}
+	if p.RemoteHost != "" {
+		return collectRemoteMetrics(ctx, p, scc)
+	}
 	var metricsCollectionError error
 	metrics, err := collectNetWeaverMetrics(ctx, p, scc)
 	if err != nil {
@@ -186,6 +231,14 @@ func (p *InstanceProperties) Collect(ctx context.Context) ([]*mrpb.TimeSeries, e
 		metrics = append(metrics, enqLockMetrics...)
 	}
 
+	ccmsMetrics, err := collectCCMSMetrics(ctx, p, scc)
+	if err != nil {
+		metricsCollectionError = err
+	}
+	if ccmsMetrics != nil {
+		metrics = append(metrics, ccmsMetrics...)
+	}
+
 	roleMetrics, err := collectRoleMetrics(ctx, p, commandlineexecutor.ExecuteCommand)
 	if err != nil {
 		log.CtxLogger(ctx).Debugw("Error in collecting role metrics", "error", err)
@@ -198,6 +251,29 @@ func (p *InstanceProperties) Collect(ctx context.Context) ([]*mrpb.TimeSeries, e
 	return metrics, metricsCollectionError
 }
 
+// collectRemoteMetrics collects the metrics of a remote instance that are available
+// through the SAPControl web service, skipping the ones that need local executables.
+func collectRemoteMetrics(ctx context.Context, p *InstanceProperties, scc sapcontrol.ClientInterface) ([]*mrpb.TimeSeries, error) {
+	var metricsCollectionError error
+	metrics, err := collectNetWeaverMetrics(ctx, p, scc)
+	if err != nil {
+		metricsCollectionError = err
+	}
+	collectors := []func(context.Context, *InstanceProperties, sapcontrol.ClientInterface) ([]*mrpb.TimeSeries, error){
+		collectABAPProcessStatus,
+		collectABAPQueueStats,
+		collectCCMSMetrics,
+	}
+	for _, collect := range collectors {
+		m, err := collect(ctx, p, scc)
+		if err != nil {
+			metricsCollectionError = err
+		}
+		metrics = append(metrics, m...)
+	}
+	return metrics, metricsCollectionError
+}
+
 // CollectWithRetry decorates the Collect method with retry mechanism.
 func (p *InstanceProperties) CollectWithRetry(ctx context.Context) ([]*mrpb.TimeSeries, error) {
 	var (
@@ -642,6 +718,131 @@ func collectRoleMetrics(ctx context.Context, p *InstanceProperties, exec command
 	return createMetrics(p, nwInstanceRolePath, roles, tspb.Now(), 1), nil
 }
 
+// collectCCMSMetrics collects the CCMS monitor tree elements selected by the node filters
+// using the GetAlertTree and GetAlerts web methods.
+// Performance attributes are exported as /sap/nw/ccms/<parent>/<name> with the value
+// reported by the attribute, every selected element as /sap/nw/ccms/alert_state.
+func collectCCMSMetrics(ctx context.Context, p *InstanceProperties, scc sapcontrol.ClientInterface) ([]*mrpb.TimeSeries, error) {
+	cfg := p.Config.GetCollectionConfiguration().GetCcmsMetrics()
+	if !cfg.GetEnabled() || p.SkippedMetrics[nwCCMSPath] {
+		return nil, nil
+	}
+	now := tspb.Now()
+	filters := ccmsNodeFilters(ctx, cfg.GetNodeFilters())
+	sc := &sapcontrol.Properties{Instance: p.SAPInstance}
+	nodes, err := sc.GetAlertTree(ctx, scc)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Sapcontrol web method failed", "error", err)
+		return nil, err
+	}
+
+	var (
+		metrics       []*mrpb.TimeSeries
+		collectionErr error
+	)
+	for _, n := range nodes {
+		if !matchesAny(filters, n.Path) {
+			continue
+		}
+		extraLabels := map[string]string{"node": n.Path}
+		if state, ok := ccmsStates[n.State]; ok && !p.SkippedMetrics[nwCCMSAlertStatePath] {
+			metrics = append(metrics, createMetrics(p, nwCCMSAlertStatePath, extraLabels, now, state))
+		}
+		if value, unit, ok := parseCCMSValue(n.Description); ok && n.IsLeaf {
+			mPath := ccmsMetricPath(n.Path)
+			if !p.SkippedMetrics[mPath] {
+				valueLabels := map[string]string{"node": n.Path, "unit": unit}
+				log.CtxLogger(ctx).Debugw("Creating metric for CCMS node", "metric", mPath, "node", n.Path, "value", value, "unit", unit)
+				metrics = append(metrics, createFloatMetrics(p, mPath, valueLabels, now, value))
+			}
+		}
+		if !cfg.GetCollectOpenAlerts() || p.SkippedMetrics[nwCCMSOpenAlertsPath] {
+			continue
+		}
+		if n.State != "YELLOW" && n.State != "RED" {
+			continue
+		}
+		count, err := sc.GetAlerts(ctx, scc, n.Tid)
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Sapcontrol web method failed", "node", n.Path, "error", err)
+			collectionErr = err
+			continue
+		}
+		metrics = append(metrics, createMetrics(p, nwCCMSOpenAlertsPath, extraLabels, now, int64(count)))
+	}
+	log.CtxLogger(ctx).Debugw("Time taken to collect metrics in collectCCMSMetrics()", "time", time.Since(now.AsTime()))
+	return metrics, collectionErr
+}
+
+// ccmsNodeFilters compiles the configured node filters, falling back to the defaults.
+// Filters that are not valid regular expressions are logged and ignored.
+func ccmsNodeFilters(ctx context.Context, patterns []string) []*regexp.Regexp {
+	if len(patterns) == 0 {
+		patterns = defaultCCMSNodeFilters
+	}
+	var filters []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Ignoring invalid CCMS node filter", "filter", pattern, "error", err)
+			continue
+		}
+		filters = append(filters, re)
+	}
+	return filters
+}
+
+// matchesAny reports whether s matches any of the regular expressions.
+func matchesAny(filters []*regexp.Regexp, s string) bool {
+	for _, re := range filters {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCCMSValue extracts the value and unit of a performance attribute description.
+func parseCCMSValue(description string) (float64, string, bool) {
+	match := ccmsValueRegex.FindStringSubmatch(description)
+	if match == nil {
+		return 0, "", false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return value, strings.TrimSpace(match[2]), true
+}
+
+// ccmsMetricPath maps a monitor tree path to a metric path built from the last two
+// tree elements, ex: "DEV/sapdev_DEV_00/R3Services/Dialog/ResponseTime" maps to
+// "/sap/nw/ccms/dialog/response_time".
+func ccmsMetricPath(nodePath string) string {
+	elems := strings.Split(nodePath, "/")
+	if len(elems) > 2 {
+		elems = elems[len(elems)-2:]
+	}
+	for i, e := range elems {
+		e = strings.ToLower(ccmsWordRegex.ReplaceAllString(e, "${1}_${2}"))
+		elems[i] = strings.Trim(ccmsInvalidChar.ReplaceAllString(e, "_"), "_")
+	}
+	return nwCCMSPath + "/" + strings.Join(elems, "/")
+}
+
+// createFloatMetrics - create mrpb.TimeSeries object with a double value for the given metric.
+func createFloatMetrics(p *InstanceProperties, mPath string, extraLabels map[string]string, now *tspb.Timestamp, val float64) *mrpb.TimeSeries {
+	params := timeseries.Params{
+		CloudProp:    protostruct.ConvertCloudPropertiesToStruct(p.Config.CloudProperties),
+		MetricType:   metricURL + mPath,
+		MetricLabels: metricLabels(p, extraLabels),
+		Timestamp:    now,
+		Float64Value: val,
+		BareMetal:    p.Config.BareMetal,
+	}
+	return timeseries.BuildFloat64(params)
+}
+
 // createMetrics - create mrpb.TimeSeries object for the given metric.
 func createMetrics(p *InstanceProperties, mPath string, extraLabels map[string]string, now *tspb.Timestamp, val int64) *mrpb.TimeSeries {
 	params := timeseries.Params{
@@ -663,6 +864,9 @@ func metricLabels(p *InstanceProperties, extraLabels map[string]string) map[stri
 		"instance_nr":   p.SAPInstance.GetInstanceNumber(),
 		"instance_name": p.Config.CloudProperties.InstanceName,
 	}
+	if p.RemoteHost != "" {
+		defaultLabels["remote_host"] = p.RemoteHost
+	}
 	for k, v := range extraLabels {
 		defaultLabels[k] = v
 	}
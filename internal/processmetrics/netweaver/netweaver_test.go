@@ -578,6 +578,33 @@ func TestCollect(t *testing.T) {
 	}
 }
 
+func TestCollectRemote(t *testing.T) {
+	p := &InstanceProperties{
+		Config:      defaultConfig,
+		SAPInstance: &sapb.SAPInstance{Sapsid: "TST", InstanceNumber: "00", Type: sapb.InstanceType_NETWEAVER},
+		SAPControlClient: sapcontrolclienttest.Fake{
+			Processes: []sapcontrolclient.OSProcess{
+				{Name: "disp+work", Dispstatus: "SAPControl-GREEN", Pid: 111},
+				{Name: "igswd_mt", Dispstatus: "SAPControl-GREEN", Pid: 222},
+			},
+			ErrGetQueueStatistic: cmpopts.AnyError,
+		},
+		RemoteHost: "sapapp1",
+	}
+	metrics, err := p.Collect(context.Background())
+	if !cmp.Equal(err, cmpopts.AnyError, cmpopts.EquateErrors()) {
+		t.Errorf("Collect()=%v, want %v", err, cmpopts.AnyError)
+	}
+	if len(metrics) != 2 {
+		t.Errorf("Collect() metric count mismatch, got: %v want: 2.", len(metrics))
+	}
+	for _, m := range metrics {
+		if got := m.GetMetric().GetLabels()["remote_host"]; got != "sapapp1" {
+			t.Errorf("Collect() metric %s has remote_host label %q, want %q", m.GetMetric().GetType(), got, "sapapp1")
+		}
+	}
+}
+
 func TestCollectHTTPMetrics(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1755,3 +1782,135 @@ tstadm   13447 13436  0 Apr26 ?        00:01:10 ms.sapTST_ASCS00 pf=/usr/sap/TST
 		})
 	}
 }
+
+func TestCollectCCMSMetrics(t *testing.T) {
+	ccmsConfig := func(ccms *cpb.CCMSMetrics) *cpb.Configuration {
+		return &cpb.Configuration{
+			CollectionConfiguration: &cpb.CollectionConfiguration{CcmsMetrics: ccms},
+			CloudProperties:         defaultConfig.GetCloudProperties(),
+		}
+	}
+	alertTree := []sapcontrolclient.AlertNode{
+		{Name: "DEV", Parent: -1, ActualValue: "SAPControl-RED"},
+		{Name: "sapdev_DEV_00", Parent: 0, ActualValue: "SAPControl-RED"},
+		{Name: "R3Services", Parent: 1, ActualValue: "SAPControl-RED"},
+		{Name: "Dialog", Parent: 2, ActualValue: "SAPControl-GREEN"},
+		{Name: "ResponseTime", Parent: 3, ActualValue: "SAPControl-GREEN", Description: "423 msec"},
+		{Name: "UsersLoggedIn", Parent: 3, ActualValue: "SAPControl-GREEN", Description: "12"},
+		{Name: "R3Abap", Parent: 1, ActualValue: "SAPControl-RED"},
+		{Name: "Shortdumps", Parent: 6, ActualValue: "SAPControl-RED"},
+		{Name: "ShortdumpsFrequency", Parent: 7, ActualValue: "SAPControl-RED", Description: "12 /min"},
+	}
+	tests := []struct {
+		name                string
+		properties          *InstanceProperties
+		fakeClient          sapcontrolclienttest.Fake
+		wantMetricTypes     []string
+		wantResponseTimeVal float64
+		wantErr             error
+	}{
+		{
+			name:       "Disabled",
+			properties: &InstanceProperties{SAPInstance: defaultSAPInstance, Config: defaultConfig},
+			fakeClient: sapcontrolclienttest.Fake{AlertNodes: alertTree},
+		},
+		{
+			name: "DefaultFilters",
+			properties: &InstanceProperties{
+				SAPInstance: defaultSAPInstance,
+				Config:      ccmsConfig(&cpb.CCMSMetrics{Enabled: true}),
+			},
+			fakeClient: sapcontrolclienttest.Fake{AlertNodes: alertTree},
+			wantMetricTypes: []string{
+				"workload.googleapis.com/sap/nw/ccms/alert_state",
+				"workload.googleapis.com/sap/nw/ccms/dialog/response_time",
+				"workload.googleapis.com/sap/nw/ccms/alert_state",
+				"workload.googleapis.com/sap/nw/ccms/shortdumps/shortdumps_frequency",
+			},
+			wantResponseTimeVal: 423,
+		},
+		{
+			name: "ConfiguredFiltersAndOpenAlerts",
+			properties: &InstanceProperties{
+				SAPInstance: defaultSAPInstance,
+				Config:      ccmsConfig(&cpb.CCMSMetrics{Enabled: true, NodeFilters: []string{"Shortdumps$", "("}, CollectOpenAlerts: true}),
+			},
+			fakeClient: sapcontrolclienttest.Fake{AlertNodes: alertTree, Alerts: []sapcontrolclient.Alert{{Object: "Shortdumps"}}},
+			wantMetricTypes: []string{
+				"workload.googleapis.com/sap/nw/ccms/alert_state",
+				"workload.googleapis.com/sap/nw/ccms/open_alerts",
+			},
+		},
+		{
+			name: "GetAlertsFailure",
+			properties: &InstanceProperties{
+				SAPInstance: defaultSAPInstance,
+				Config:      ccmsConfig(&cpb.CCMSMetrics{Enabled: true, NodeFilters: []string{"Shortdumps$"}, CollectOpenAlerts: true}),
+			},
+			fakeClient:      sapcontrolclienttest.Fake{AlertNodes: alertTree, ErrGetAlerts: cmpopts.AnyError},
+			wantMetricTypes: []string{"workload.googleapis.com/sap/nw/ccms/alert_state"},
+			wantErr:         cmpopts.AnyError,
+		},
+		{
+			name: "SkipAlertState",
+			properties: &InstanceProperties{
+				SAPInstance:    defaultSAPInstance,
+				Config:         ccmsConfig(&cpb.CCMSMetrics{Enabled: true, NodeFilters: []string{"Dialog/ResponseTime$"}}),
+				SkippedMetrics: map[string]bool{nwCCMSAlertStatePath: true},
+			},
+			fakeClient:          sapcontrolclienttest.Fake{AlertNodes: alertTree},
+			wantMetricTypes:     []string{"workload.googleapis.com/sap/nw/ccms/dialog/response_time"},
+			wantResponseTimeVal: 423,
+		},
+		{
+			name: "GetAlertTreeFailure",
+			properties: &InstanceProperties{
+				SAPInstance: defaultSAPInstance,
+				Config:      ccmsConfig(&cpb.CCMSMetrics{Enabled: true}),
+			},
+			fakeClient: sapcontrolclienttest.Fake{ErrGetAlertTree: cmpopts.AnyError},
+			wantErr:    cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := collectCCMSMetrics(context.Background(), test.properties, test.fakeClient)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("collectCCMSMetrics()=%v, want %v", err, test.wantErr)
+			}
+			var gotTypes []string
+			for _, m := range got {
+				gotTypes = append(gotTypes, m.GetMetric().GetType())
+				if strings.HasSuffix(m.GetMetric().GetType(), "/response_time") {
+					if v := m.GetPoints()[0].GetValue().GetDoubleValue(); v != test.wantResponseTimeVal {
+						t.Errorf("collectCCMSMetrics() response time = %v, want %v", v, test.wantResponseTimeVal)
+					}
+					if unit := m.GetMetric().GetLabels()["unit"]; unit != "msec" {
+						t.Errorf("collectCCMSMetrics() response time unit = %q, want %q", unit, "msec")
+					}
+				}
+			}
+			if diff := cmp.Diff(test.wantMetricTypes, gotTypes); diff != "" {
+				t.Errorf("collectCCMSMetrics() returned unexpected metric types diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCCMSMetricPath(t *testing.T) {
+	tests := []struct {
+		nodePath string
+		want     string
+	}{
+		{nodePath: "DEV/sapdev_DEV_00/R3Services/Dialog/ResponseTime", want: "/sap/nw/ccms/dialog/response_time"},
+		{nodePath: "DEV/sapdev_DEV_00/R3Services/Dialog/FrontEndResponseTime", want: "/sap/nw/ccms/dialog/front_end_response_time"},
+		{nodePath: "DEV/sapdev_DEV_00/R3Services/Spool/Errors in WP SPO", want: "/sap/nw/ccms/spool/errors_in_wp_spo"},
+		{nodePath: "Shortdumps", want: "/sap/nw/ccms/shortdumps"},
+	}
+	for _, test := range tests {
+		if got := ccmsMetricPath(test.nodePath); got != test.want {
+			t.Errorf("ccmsMetricPath(%q)=%q, want %q", test.nodePath, got, test.want)
+		}
+	}
+}
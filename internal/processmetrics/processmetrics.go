@@ -48,10 +48,14 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/heartbeat"
 	"github.com/GoogleCloudPlatform/sapagent/internal/metricoverrides"
+	"github.com/GoogleCloudPlatform/sapagent/internal/metricspool"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/cardinality"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/cluster"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/collectorhealth"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/computeresources"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/fastmovingmetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/hana"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/hanareplication"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/hanavolume"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/infra"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/maintenance"
@@ -81,6 +85,11 @@ var (
 	slowMetricsRoutine                      *recovery.RecoverableRoutine
 	demoMetricsRoutine                      *recovery.RecoverableRoutine
 	updateMetricsCollectorsRoutine          *recovery.RecoverableRoutine
+	collectorHealthRoutine                  *recovery.RecoverableRoutine
+	cardinalityOverflowRoutine              *recovery.RecoverableRoutine
+
+	// collectorHealth is shared by the process and reliability metrics collectors.
+	collectorHealth = collectorhealth.New(collectorhealth.DefaultSnapshotPath)
 )
 
 type (
@@ -106,6 +115,9 @@ type (
 		FastMovingCollectors  []Collector
 		ReliabilityCollectors []Collector
 		HeartbeatSpec         *heartbeat.Spec
+		Spool                 *metricspool.Spool
+		Health                *collectorhealth.Tracker
+		Limiter               *cardinality.Limiter
 	}
 
 	// CreateMetricClient provides an easily testable translation to the cloud monitoring API.
@@ -123,6 +135,7 @@ type (
 		Discovery      discoveryInterface
 		PCMParams      pcm.Parameters
 		OSStatReader   func(string) (os.FileInfo, error)
+		Spool          *metricspool.Spool
 	}
 	updateMetricsCollectorsArgs struct {
 		procCtx    context.Context
@@ -139,6 +152,10 @@ const (
 	minimumFrequencyForReliability = 60
 	metricOverridePath             = "/etc/google-cloud-sap-agent/metrics-override.yaml"
 	pmMetricTypePrefix             = "workload.googleapis.com/sap/"
+
+	// collectorHealthSnapshotFrequency is how often the collector health is persisted
+	// for the status command.
+	collectorHealthSnapshotFrequency = time.Minute
 )
 
 /*
@@ -161,6 +178,13 @@ func Start(ctx context.Context, parameters Parameters) bool {
 		ExpectedMinDuration: time.Minute,
 	}
 	metricsRoutine.StartRoutine(ctx)
+	collectorHealthRoutine = &recovery.RecoverableRoutine{
+		Routine:             func(ctx context.Context, _ any) { collectorHealth.Run(ctx, collectorHealthSnapshotFrequency) },
+		ErrorCode:           usagemetrics.CollectMetricsRoutineFailure,
+		UsageLogger:         *usagemetrics.Logger,
+		ExpectedMinDuration: collectorHealthSnapshotFrequency,
+	}
+	collectorHealthRoutine.StartRoutine(ctx)
 	return true
 }
 
@@ -271,6 +295,21 @@ func startProcessMetrics(ctx context.Context, parameters Parameters) bool {
 		ExpectedMinDuration: time.Minute,
 	}
 	slowMetricsRoutine.StartRoutine(ctx)
+
+	if p.Limiter != nil {
+		cardinalityOverflowRoutine = &recovery.RecoverableRoutine{
+			Routine: func(ctx context.Context, a any) {
+				if parameters, ok := a.(Parameters); ok {
+					p.flushCardinalityOverflow(ctx, parameters.BackOffs)
+				}
+			},
+			RoutineArg:          parameters,
+			ErrorCode:           usagemetrics.SlowMetricsCollectionFailure,
+			UsageLogger:         *usagemetrics.Logger,
+			ExpectedMinDuration: time.Minute,
+		}
+		cardinalityOverflowRoutine.StartRoutine(ctx)
+	}
 	return true
 }
 
@@ -351,13 +390,62 @@ func updateMetricsCollectors(ctx context.Context, a any) {
 	}
 }
 
+// remoteSAPControlClient creates a SAPControl client for a remote instance, reading the
+// basic auth password from Secret Manager. The secret is read again whenever the remote
+// instance rejects the password, so a rotated secret takes effect without rediscovery.
+func remoteSAPControlClient(ctx context.Context, params Parameters, remote *cpb.RemoteSAPControlInstance) (sapcontrolclient.Client, error) {
+	if remote.GetHost() == "" || remote.GetInstanceNumber() == "" {
+		return sapcontrolclient.Client{}, fmt.Errorf("host and instance_number are required for remote SAPControl instances")
+	}
+	if (remote.GetUsername() != "" || remote.GetPasswordSecretName() != "") && !remote.GetUseHttps() {
+		return sapcontrolclient.Client{}, fmt.Errorf("use_https is required when username or password_secret_name are set, basic authentication would be sent in cleartext")
+	}
+	if remote.GetUsername() != "" && remote.GetInsecureSkipVerify() {
+		return sapcontrolclient.Client{}, fmt.Errorf("insecure_skip_verify cannot be used with username, the credentials would be sent to an unverified server")
+	}
+	var password string
+	var refreshPassword func() (string, error)
+	if secretName := remote.GetPasswordSecretName(); secretName != "" {
+		if params.GCEService == nil {
+			return sapcontrolclient.Client{}, fmt.Errorf("no GCE service to read secret %q", secretName)
+		}
+		projectID := params.Config.GetCloudProperties().GetProjectId()
+		refreshPassword = func() (string, error) {
+			log.CtxLogger(ctx).Infow("Reading SAPControl password secret", "host", remote.GetHost(), "secret", secretName)
+			return params.GCEService.GetSecret(ctx, projectID, secretName)
+		}
+		var err error
+		if password, err = refreshPassword(); err != nil {
+			return sapcontrolclient.Client{}, err
+		}
+	}
+	return sapcontrolclient.NewRemote(remote.GetInstanceNumber(), sapcontrolclient.RemoteOptions{
+		Host:               remote.GetHost(),
+		UseHTTPS:           remote.GetUseHttps(),
+		Username:           remote.GetUsername(),
+		Password:           password,
+		RefreshPassword:    refreshPassword,
+		CertFile:           remote.GetClientCertFile(),
+		KeyFile:            remote.GetClientKeyFile(),
+		CAFile:             remote.GetCaCertFile(),
+		InsecureSkipVerify: remote.GetInsecureSkipVerify(),
+	})
+}
+
 // createProcessCollectors sets up the processmetrics properties and metric collectors for SAP Instances.
 func createProcessCollectors(ctx context.Context, params Parameters, client cloudmonitoring.TimeSeriesCreator, sapInstances *sapb.SAPInstances) *Properties {
 	p := &Properties{
 		Config:        params.Config,
+		Health:        collectorHealth,
 		Client:        client,
 		HeartbeatSpec: params.HeartbeatSpec,
+		Spool:         params.Spool,
+	}
+	limiter, err := cardinality.New(p.Config.GetCollectionConfiguration().GetCardinalityLimits())
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Invalid cardinality limits, sending all process metrics", "error", err)
 	}
+	p.Limiter = limiter
 
 	// For retries logic and backoff policy:
 	// For slow moving process metrics we are going ahead with 3 retries on failures, which means 4 attempts in total.
@@ -475,17 +563,32 @@ func createProcessCollectors(ctx context.Context, params Parameters, client clou
 			}
 
 			log.CtxLogger(ctx).Infow("Creating HANA collector for instance.", "instance", instance)
+			// The HANA and HANA system replication collectors log in with the same
+			// credentials, so they share the failed login count.
+			hanaQueryFailCount := &hana.FailCounter{}
 			hanaCollector := &hana.InstanceProperties{
 				SAPInstance:        instance,
 				Config:             p.Config,
 				Client:             p.Client,
-				HANAQueryFailCount: 0,
+				HANAQueryFailCount: hanaQueryFailCount,
+				SkippedMetrics:     skippedMetrics,
+				PMBackoffPolicy:    cloudmonitoring.LongExponentialBackOffPolicy(ctx, time.Duration(pmSlowFreq)*time.Second, 3, 3*time.Minute, 2*time.Minute),
+				ReplicationConfig:  sapdiscovery.HANAReplicationConfig,
+				SapSystemInterface: params.Discovery,
+			}
+			log.CtxLogger(ctx).Infow("Creating HANA system replication collector for instance.", "instance", instance)
+			hanaReplicationCollector := &hanareplication.InstanceProperties{
+				SAPInstance:        instance,
+				Config:             p.Config,
+				Client:             p.Client,
+				Executor:           commandlineexecutor.ExecuteCommand,
 				SkippedMetrics:     skippedMetrics,
 				PMBackoffPolicy:    cloudmonitoring.LongExponentialBackOffPolicy(ctx, time.Duration(pmSlowFreq)*time.Second, 3, 3*time.Minute, 2*time.Minute),
 				ReplicationConfig:  sapdiscovery.HANAReplicationConfig,
 				SapSystemInterface: params.Discovery,
+				HANAQueryFailCount: hanaQueryFailCount,
 			}
-			p.Collectors = append(p.Collectors, hanaComputeresourcesCollector, hanaCollector)
+			p.Collectors = append(p.Collectors, hanaComputeresourcesCollector, hanaCollector, hanaReplicationCollector)
 
 			log.CtxLogger(ctx).Infow("Creating FastMoving Collector for HANA", "instance", instance)
 			fmCollector := &fastmovingmetrics.InstanceProperties{
@@ -535,6 +638,29 @@ func createProcessCollectors(ctx context.Context, params Parameters, client clou
 		}
 	}
 
+	for _, remote := range p.Config.GetCollectionConfiguration().GetRemoteSapcontrolInstances() {
+		scc, err := remoteSAPControlClient(ctx, params, remote)
+		if err != nil {
+			log.CtxLogger(ctx).Errorw("Could not create SAPControl client for remote instance", "host", remote.GetHost(), "instanceNumber", remote.GetInstanceNumber(), "error", err)
+			continue
+		}
+		log.CtxLogger(ctx).Infow("Creating Netweaver collector for remote instance.", "host", remote.GetHost(), "sid", remote.GetSid(), "instanceNumber", remote.GetInstanceNumber())
+		remoteCollector := &netweaver.InstanceProperties{
+			SAPInstance: &sapb.SAPInstance{
+				Sapsid:         remote.GetSid(),
+				InstanceNumber: remote.GetInstanceNumber(),
+				Type:           sapb.InstanceType_NETWEAVER,
+			},
+			Config:           p.Config,
+			Client:           p.Client,
+			SkippedMetrics:   skippedMetrics,
+			PMBackoffPolicy:  cloudmonitoring.LongExponentialBackOffPolicy(ctx, time.Duration(pmSlowFreq)*time.Second, 3, 3*time.Minute, 2*time.Minute),
+			SAPControlClient: scc,
+			RemoteHost:       remote.GetHost(),
+		}
+		p.Collectors = append(p.Collectors, remoteCollector)
+	}
+
 	if len(sids) != 0 {
 		log.CtxLogger(ctx).Info("Creating maintenance mode collector.")
 		maintenanceModeCollector := &maintenance.InstanceProperties{
@@ -565,6 +691,8 @@ func createProcessCollectors(ctx context.Context, params Parameters, client clou
 		}
 	}
 
+	p.Health.Retain(collectorhealth.GroupSlow, collectorNames(p.Collectors))
+	p.Health.Retain(collectorhealth.GroupFast, collectorNames(p.FastMovingCollectors))
 	log.CtxLogger(ctx).Infow("Created process metrics collectors.", "numberofcollectors", len(p.Collectors))
 	return p
 }
@@ -651,10 +779,12 @@ func (p *Properties) collectAndSendFastMovingMetricsOnce(ctx context.Context, bo
 				defer wg.Done()
 				if args, ok := a.(collectFastMetricsRoutineArgs); ok {
 					var err error
+					start := time.Now()
 					msgs[args.slot], err = args.c.CollectWithRetry(ctx) // Each collector writes to its own slot.
 					if err != nil {
 						log.CtxLogger(ctx).Debugw("Error collecting fast moving metrics", "error", err)
 					}
+					p.recordHealth(args.c, collectorhealth.GroupFast, start, len(msgs[args.slot]), err)
 					log.CtxLogger(ctx).Debugw("Collected fast moving metrics", "numberofmetrics", len(msgs[args.slot]))
 				}
 			},
@@ -668,7 +798,8 @@ func (p *Properties) collectAndSendFastMovingMetricsOnce(ctx context.Context, bo
 	}
 	log.CtxLogger(ctx).Debug("Waiting for fast moving collectors to finish.")
 	wg.Wait()
-	return cloudmonitoring.SendTimeSeries(ctx, flatten(msgs), p.Client, bo, p.Config.GetCloudProperties().GetProjectId())
+	metrics := append(flatten(msgs), p.Health.TimeSeries(p.Config, collectorhealth.GroupFast, collectorNames(p.FastMovingCollectors)...)...)
+	return p.send(ctx, metrics, bo)
 }
 
 /*
@@ -692,7 +823,16 @@ func startReliabilityMetrics(ctx context.Context, parameters Parameters) bool {
 	}
 	dailyMetricsRoutine.StartRoutine(ctx)
 
-	p := createReliabilityCollectors(ctx, parameters, sapInstances)
+	ua := fmt.Sprintf("sap-core-eng/%s/%s.%s/processmetrics", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
+	mc, err := parameters.MetricClient(ctx, option.WithUserAgent(ua))
+	if err != nil {
+		// The reliability collectors do not need the client, only their health is sent with it.
+		// Without it the health is still written to the local snapshot for the status command.
+		log.CtxLogger(ctx).Warnw("Failed to create Cloud Monitoring client, reliability collector health will not be sent", "error", err)
+		usagemetrics.Error(usagemetrics.ProcessMetricsMetricClientCreateFailure) // Failed to create Cloud Monitoring client
+		mc = nil
+	}
+	p := createReliabilityCollectors(ctx, parameters, mc, sapInstances)
 
 	log.CtxLogger(ctx).Info("Starting reliability metrics collection in background.")
 	collectAndSendReliabilityMetricsRoutine = &recovery.RecoverableRoutine{
@@ -711,10 +851,13 @@ func startReliabilityMetrics(ctx context.Context, parameters Parameters) bool {
 }
 
 // createReliabilityCollectors sets up the processmetrics properties and metric collectors for SAP Instances.
-func createReliabilityCollectors(ctx context.Context, params Parameters, sapInstances *sapb.SAPInstances) *Properties {
+func createReliabilityCollectors(ctx context.Context, params Parameters, client cloudmonitoring.TimeSeriesCreator, sapInstances *sapb.SAPInstances) *Properties {
 	p := &Properties{
 		Config:        params.Config,
+		Client:        client,
 		HeartbeatSpec: params.HeartbeatSpec,
+		Health:        collectorHealth,
+		Spool:         params.Spool,
 	}
 
 	// For retries logic and backoff policy: 3 retries on failures, which means 4 attempts in total.
@@ -750,6 +893,7 @@ func createReliabilityCollectors(ctx context.Context, params Parameters, sapInst
 		}
 	}
 
+	p.Health.Retain(collectorhealth.GroupReliability, collectorNames(p.ReliabilityCollectors))
 	log.CtxLogger(ctx).Infow("Created reliability metrics collectors", "numberofcollectors", len(p.ReliabilityCollectors))
 	return p
 }
@@ -775,8 +919,12 @@ func (p *Properties) collectAndSendReliabilityMetrics(ctx context.Context, bo *c
 			p.HeartbeatSpec.Beat()
 		case <-reliabilityCollectTicker.C:
 			p.HeartbeatSpec.Beat()
-			p.collectAndSendReliabilityMetricsOnce(ctx, bo)
-			log.CtxLogger(ctx).Debugw("Sent reliability metrics from collectAndSend.", "sleeping", minimumFrequencyForReliability)
+			sent, batchCount, err := p.collectAndSendReliabilityMetricsOnce(ctx, bo)
+			if err != nil {
+				log.CtxLogger(ctx).Debugw("Error sending reliability collector health", "error", err)
+				lastErr = err
+			}
+			log.CtxLogger(ctx).Debugw("Sent reliability metrics from collectAndSend.", "sent", sent, "batches", batchCount, "sleeping", minimumFrequencyForReliability)
 		}
 	}
 }
@@ -786,8 +934,9 @@ type collectReliabilityMetricsRoutineArgs struct {
 	slot int
 }
 
-// collectAndSendReliabilityMetricsOnce collects and sends metrics from reliability collectors.
-func (p *Properties) collectAndSendReliabilityMetricsOnce(ctx context.Context, bo *cloudmonitoring.BackOffIntervals) {
+// collectAndSendReliabilityMetricsOnce collects metrics from reliability collectors and
+// sends their collector health, unless there is no metric client.
+func (p *Properties) collectAndSendReliabilityMetricsOnce(ctx context.Context, bo *cloudmonitoring.BackOffIntervals) (sent, batchCount int, err error) {
 	var wg sync.WaitGroup
 	log.CtxLogger(ctx).Debugw("Starting collectors in parallel.", "numberOfCollectors", len(p.ReliabilityCollectors))
 
@@ -798,9 +947,12 @@ func (p *Properties) collectAndSendReliabilityMetricsOnce(ctx context.Context, b
 			Routine: func(ctx context.Context, a any) {
 				defer wg.Done()
 				if args, ok := a.(collectReliabilityMetricsRoutineArgs); ok {
-					if _, err := args.c.CollectWithRetry(ctx); err != nil {
+					start := time.Now()
+					metrics, err := args.c.CollectWithRetry(ctx)
+					if err != nil {
 						log.CtxLogger(ctx).Debugw("Error collecting reliability metrics", "error", err)
 					}
+					p.recordHealth(args.c, collectorhealth.GroupReliability, start, len(metrics), err)
 				}
 			},
 			RoutineArg:          collectReliabilityMetricsRoutineArgs{c: collector, slot: i},
@@ -813,6 +965,10 @@ func (p *Properties) collectAndSendReliabilityMetricsOnce(ctx context.Context, b
 	}
 	log.CtxLogger(ctx).Debug("Waiting for reliability collectors to finish.", "numberOfCollectors", len(routines))
 	wg.Wait()
+	if p.Client == nil {
+		return 0, 0, nil
+	}
+	return p.send(ctx, p.Health.TimeSeries(p.Config, collectorhealth.GroupReliability, collectorNames(p.ReliabilityCollectors)...), bo)
 }
 
 func createWorkerPoolForSlowMetrics(ctx context.Context, p *Properties, bo *cloudmonitoring.BackOffIntervals) {
@@ -848,11 +1004,58 @@ func collectAndSendSlowMovingMetrics(ctx context.Context, p *Properties, c Colle
 }
 
 func collectAndSendSlowMovingMetricsOnce(ctx context.Context, p *Properties, c Collector, bo *cloudmonitoring.BackOffIntervals) (sent, batchCount int, err error) {
+	start := time.Now()
 	metrics, err := c.CollectWithRetry(ctx)
+	p.recordHealth(c, collectorhealth.GroupSlow, start, len(metrics), err)
+	health := p.Health.TimeSeries(p.Config, collectorhealth.GroupSlow, collectorhealth.Name(c))
 	if err != nil && len(metrics) == 0 {
+		if len(health) > 0 {
+			p.send(ctx, health, bo)
+		}
 		return 0, 0, err
 	}
-	return cloudmonitoring.SendTimeSeries(ctx, metrics, p.Client, bo, p.Config.GetCloudProperties().GetProjectId())
+	return p.send(ctx, append(metrics, health...), bo)
+}
+
+// send applies the cardinality limits to the time series and sends them through the spool.
+func (p *Properties) send(ctx context.Context, metrics []*mrpb.TimeSeries, bo *cloudmonitoring.BackOffIntervals) (sent, batchCount int, err error) {
+	return p.Spool.Send(ctx, p.Limiter.Apply(metrics), p.Client, bo, p.Config.GetCloudProperties().GetProjectId())
+}
+
+// flushCardinalityOverflow sends the "other" and dropped series accumulated by the cardinality
+// limiter once per slow process metrics interval. The collectors share the limiter, so sending
+// these series along with each collector would write the same series several times per interval.
+func (p *Properties) flushCardinalityOverflow(ctx context.Context, bo *cloudmonitoring.BackOffIntervals) {
+	ticker := time.NewTicker(time.Duration(p.Config.GetCollectionConfiguration().GetSlowProcessMetricsFrequency()) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.CtxLogger(ctx).Info("Process metrics context cancelled, exiting flushCardinalityOverflow.")
+			return
+		case <-ticker.C:
+			metrics := p.Limiter.Flush(p.Config)
+			if len(metrics) == 0 {
+				continue
+			}
+			sent, batchCount, err := p.Spool.Send(ctx, metrics, p.Client, bo, p.Config.GetCloudProperties().GetProjectId())
+			log.CtxLogger(ctx).Debugw("Sent cardinality overflow metrics.", "sent", sent, "batches", batchCount, "error", err)
+		}
+	}
+}
+
+// recordHealth records the outcome of a collector run in the collector health tracker.
+func (p *Properties) recordHealth(c Collector, group string, start time.Time, series int, err error) {
+	p.Health.Record(collectorhealth.Name(c), group, start, series, err)
+}
+
+// collectorNames returns the collector health names of the collectors.
+func collectorNames(collectors []Collector) []string {
+	names := make([]string, 0, len(collectors))
+	for _, c := range collectors {
+		names = append(names, collectorhealth.Name(c))
+	}
+	return names
 }
 
 // flatten converts an 2D array of metric slices to a flat 1D array of metrics.
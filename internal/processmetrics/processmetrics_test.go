@@ -30,8 +30,10 @@ import (
 	"github.com/gammazero/workerpool"
 	"github.com/GoogleCloudPlatform/sapagent/internal/heartbeat"
 	"github.com/GoogleCloudPlatform/sapagent/internal/pacemaker"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/collectorhealth"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring/fake"
+	gcefake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/fake"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 
 	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
@@ -358,6 +360,64 @@ func TestStartProcessMetrics(t *testing.T) {
 	}
 }
 
+func TestRemoteSAPControlClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		remote  *cpb.RemoteSAPControlInstance
+		gce     *gcefake.TestGCE
+		wantErr bool
+	}{
+		{
+			name:   "NoCredentials",
+			remote: &cpb.RemoteSAPControlInstance{Sid: "DEV", InstanceNumber: "00", Host: "sapapp1"},
+		},
+		{
+			name:   "CredentialsOverHTTPS",
+			remote: &cpb.RemoteSAPControlInstance{Sid: "DEV", InstanceNumber: "00", Host: "sapapp1", UseHttps: true, Username: "sapadm", PasswordSecretName: "sapadm-password"},
+			gce:    &gcefake.TestGCE{GetSecretResp: []string{"secret"}, GetSecretErr: []error{nil}},
+		},
+		{
+			name:    "MissingHost",
+			remote:  &cpb.RemoteSAPControlInstance{Sid: "DEV", InstanceNumber: "00"},
+			wantErr: true,
+		},
+		{
+			name:    "UsernameWithoutHTTPS",
+			remote:  &cpb.RemoteSAPControlInstance{Sid: "DEV", InstanceNumber: "00", Host: "sapapp1", Username: "sapadm"},
+			wantErr: true,
+		},
+		{
+			name:    "SecretWithoutHTTPS",
+			remote:  &cpb.RemoteSAPControlInstance{Sid: "DEV", InstanceNumber: "00", Host: "sapapp1", PasswordSecretName: "sapadm-password"},
+			gce:     &gcefake.TestGCE{GetSecretResp: []string{"secret"}, GetSecretErr: []error{nil}},
+			wantErr: true,
+		},
+		{
+			name:    "UsernameWithInsecureSkipVerify",
+			remote:  &cpb.RemoteSAPControlInstance{Sid: "DEV", InstanceNumber: "00", Host: "sapapp1", UseHttps: true, Username: "sapadm", InsecureSkipVerify: true},
+			wantErr: true,
+		},
+		{
+			name:    "SecretReadError",
+			remote:  &cpb.RemoteSAPControlInstance{Sid: "DEV", InstanceNumber: "00", Host: "sapapp1", UseHttps: true, Username: "sapadm", PasswordSecretName: "sapadm-password"},
+			gce:     &gcefake.TestGCE{GetSecretResp: []string{""}, GetSecretErr: []error{cmpopts.AnyError}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			params := Parameters{Config: &cpb.Configuration{CloudProperties: defaultCloudProperties}}
+			if tc.gce != nil {
+				params.GCEService = tc.gce
+			}
+			_, err := remoteSAPControlClient(context.Background(), params, tc.remote)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("remoteSAPControlClient(%v) returned error: %v, want error: %t", tc.remote, err, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestCreateProcessCollectors(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -369,7 +429,7 @@ func TestCreateProcessCollectors(t *testing.T) {
 		{
 			name:                   "HANAStandaloneInstance",
 			sapInstances:           fakeSAPInstances("HANA"),
-			wantCollectorCount:     10,
+			wantCollectorCount:     11,
 			wantFastCollectorCount: 1,
 			params: Parameters{
 				Config: defaultConfig,
@@ -378,7 +438,7 @@ func TestCreateProcessCollectors(t *testing.T) {
 		{
 			name:                   "HANAClusterInstance",
 			sapInstances:           fakeSAPInstances("HANACluster"),
-			wantCollectorCount:     10,
+			wantCollectorCount:     11,
 			wantFastCollectorCount: 1,
 			params: Parameters{
 				Config: defaultConfig,
@@ -402,6 +462,27 @@ func TestCreateProcessCollectors(t *testing.T) {
 				Config: defaultConfig,
 			},
 		},
+		{
+			name:                   "RemoteSAPControlInstances",
+			sapInstances:           &sapb.SAPInstances{},
+			wantCollectorCount:     6,
+			wantFastCollectorCount: 0,
+			params: Parameters{
+				Config: &cpb.Configuration{
+					CollectionConfiguration: &cpb.CollectionConfiguration{
+						CollectProcessMetrics:       true,
+						ProcessMetricsFrequency:     5,
+						SlowProcessMetricsFrequency: 30,
+						RemoteSapcontrolInstances: []*cpb.RemoteSAPControlInstance{
+							{Sid: "DEV", InstanceNumber: "00", Host: "sapapp1", UseHttps: true, Username: "sapadm"},
+							{Sid: "DEV", InstanceNumber: "01"},
+							{Sid: "DEV", InstanceNumber: "02", Host: "sapapp2", PasswordSecretName: "sapadm-password"},
+						},
+					},
+					CloudProperties: defaultCloudProperties,
+				},
+			},
+		},
 		{
 			name:                   "NonNilWorkloadConfig",
 			sapInstances:           fakeSAPInstances("TwoNetweaverInstancesOnSameMachine"),
@@ -625,6 +706,68 @@ func TestCollectAndSendOnceFastMovingMetrics(t *testing.T) {
 	}
 }
 
+func TestCollectAndSendOnceReliabilityMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		properties     *Properties
+		wantSent       int
+		wantBatchCount int
+		wantErr        error
+	}{
+		{
+			name: "SendsCollectorHealth",
+			properties: &Properties{
+				Client:                &fake.TimeSeriesCreatorThreadSafe{},
+				ReliabilityCollectors: fakeCollectors(1, 3),
+				Config:                quickTestConfig,
+				Health:                collectorhealth.New(""),
+			},
+			// The collected reliability metrics are not sent, only the duration, series,
+			// errors and last success of the collector.
+			wantSent:       4,
+			wantBatchCount: 1,
+		},
+		{
+			name: "SendFailure",
+			properties: &Properties{
+				Client:                &fake.TimeSeriesCreator{Err: cmpopts.AnyError},
+				ReliabilityCollectors: fakeCollectors(1, 3),
+				Config:                quickTestConfig,
+				Health:                collectorhealth.New(""),
+			},
+			wantErr:        cmpopts.AnyError,
+			wantBatchCount: 1,
+		},
+		{
+			name: "NoClient",
+			properties: &Properties{
+				ReliabilityCollectors: fakeCollectors(1, 3),
+				Config:                quickTestConfig,
+				Health:                collectorhealth.New(""),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotSent, gotBatchCount, gotErr := test.properties.collectAndSendReliabilityMetricsOnce(context.Background(), defaultBackOffIntervals)
+
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("collectAndSendReliabilityMetricsOnce() gotErr: %v wantErr: %v.", gotErr, test.wantErr)
+			}
+			if gotBatchCount != test.wantBatchCount {
+				t.Errorf("collectAndSendReliabilityMetricsOnce() gotBatchCount: %v wantBatchCount: %v.", gotBatchCount, test.wantBatchCount)
+			}
+			if gotSent != test.wantSent {
+				t.Errorf("collectAndSendReliabilityMetricsOnce() gotSent: %v wantSent: %v.", gotSent, test.wantSent)
+			}
+			if got := len(test.properties.Health.Stats()); got != 1 {
+				t.Errorf("collectAndSendReliabilityMetricsOnce() recorded the health of %d collectors, want 1", got)
+			}
+		})
+	}
+}
+
 func TestInstancesWithCredentials(t *testing.T) {
 	tests := []struct {
 		name   string
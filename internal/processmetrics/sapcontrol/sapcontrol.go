@@ -61,6 +61,8 @@ type (
 		ABAPGetWPTable() ([]sapcontrolclient.WorkProcess, error)
 		GetQueueStatistic() ([]sapcontrolclient.TaskHandlerQueue, error)
 		GetEnqLockTable() ([]sapcontrolclient.EnqLock, error)
+		GetAlertTree() ([]sapcontrolclient.AlertNode, error)
+		GetAlerts(tid sapcontrolclient.AlertTid) ([]sapcontrolclient.Alert, error)
 	}
 
 	// ProcessStatus has the sap process status.
@@ -77,6 +79,14 @@ type (
 		UserCountOwner, UserCountOwnerVB            int64
 		Client, User, Transaction, Object, Backup   string
 	}
+
+	// CCMSNode is an element of the CCMS monitor tree returned by sapcontrol's GetAlertTree function.
+	// Path joins the names of the node and all of its ancestors with "/".
+	CCMSNode struct {
+		Name, Path, State, Description string
+		Tid                            sapcontrolclient.AlertTid
+		IsLeaf                         bool
+	}
 )
 
 // ExecProcessList uses the SAPControl command to obtain the process list result.
@@ -321,3 +331,56 @@ func (p *Properties) EnqGetLockTable(ctx context.Context, c ClientInterface) ([]
 	}
 	return enqLocks, nil
 }
+
+// GetAlertTree performs the GetAlertTree SOAP API request and resolves the
+// parent references of the flat response into monitor tree paths.
+// returns
+//   - A slice of CCMSNode structs in the order returned by sapcontrol
+//   - error if API call fails
+func (p *Properties) GetAlertTree(ctx context.Context, c ClientInterface) ([]*CCMSNode, error) {
+	resp, err := c.GetAlertTree()
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("GetAlertTree API call failed", log.Error(err))
+		return nil, err
+	}
+	return processGetAlertTreeResponse(resp), nil
+}
+
+// processGetAlertTreeResponse builds the CCMSNode list from the GetAlertTree response.
+func processGetAlertTreeResponse(resp []sapcontrolclient.AlertNode) []*CCMSNode {
+	nodes := make([]*CCMSNode, len(resp))
+	for i, n := range resp {
+		nodes[i] = &CCMSNode{
+			Name:        n.Name,
+			State:       strings.TrimPrefix(n.ActualValue, "SAPControl-"),
+			Description: n.Description,
+			Tid:         n.Tid,
+			IsLeaf:      true,
+		}
+	}
+	for i, n := range resp {
+		// The parent always precedes its children; anything else is treated as a root
+		// so that a malformed response cannot send path resolution into a loop.
+		if n.Parent >= 0 && n.Parent < int64(i) {
+			parent := nodes[n.Parent]
+			parent.IsLeaf = false
+			nodes[i].Path = parent.Path + "/" + n.Name
+			continue
+		}
+		nodes[i].Path = n.Name
+	}
+	return nodes
+}
+
+// GetAlerts performs the GetAlerts SOAP API request for a monitor tree element.
+// returns
+//   - The number of open alerts of the element
+//   - error if API call fails
+func (p *Properties) GetAlerts(ctx context.Context, c ClientInterface, tid sapcontrolclient.AlertTid) (int, error) {
+	resp, err := c.GetAlerts(tid)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("GetAlerts API call failed", log.Error(err))
+		return 0, err
+	}
+	return len(resp), nil
+}
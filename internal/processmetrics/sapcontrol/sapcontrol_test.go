@@ -408,3 +408,85 @@ func TestEnqGetLockTable(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAlertTree(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       ClientInterface
+		want    []*CCMSNode
+		wantErr error
+	}{
+		{
+			name: "Success",
+			c: sapcontrolclienttest.Fake{
+				AlertNodes: []sapcontrolclient.AlertNode{
+					{Name: "DEV", Parent: -1, ActualValue: "SAPControl-YELLOW"},
+					{Name: "R3Services", Parent: 0, ActualValue: "SAPControl-YELLOW"},
+					{Name: "Dialog", Parent: 1, ActualValue: "SAPControl-GREEN"},
+					{Name: "ResponseTime", Parent: 2, ActualValue: "SAPControl-GREEN", Description: "423 msec", Tid: sapcontrolclient.AlertTid{MtIndex: "0000000021"}},
+					{Name: "Loop", Parent: 7, ActualValue: "SAPControl-GRAY"},
+				},
+			},
+			want: []*CCMSNode{
+				{Name: "DEV", Path: "DEV", State: "YELLOW"},
+				{Name: "R3Services", Path: "DEV/R3Services", State: "YELLOW"},
+				{Name: "Dialog", Path: "DEV/R3Services/Dialog", State: "GREEN"},
+				{Name: "ResponseTime", Path: "DEV/R3Services/Dialog/ResponseTime", State: "GREEN", Description: "423 msec", Tid: sapcontrolclient.AlertTid{MtIndex: "0000000021"}, IsLeaf: true},
+				{Name: "Loop", Path: "Loop", State: "GRAY", IsLeaf: true},
+			},
+		},
+		{
+			name:    "Error",
+			c:       sapcontrolclienttest.Fake{ErrGetAlertTree: cmpopts.AnyError},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var p Properties
+			got, err := p.GetAlertTree(context.Background(), tc.c)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetAlertTree(%v)=%v, want %v", tc.c, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetAlertTree(%v) returned an unexpected diff (-want +got): %v", tc.c, diff)
+			}
+		})
+	}
+}
+
+func TestGetAlerts(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       ClientInterface
+		want    int
+		wantErr error
+	}{
+		{
+			name: "Success",
+			c: sapcontrolclienttest.Fake{
+				Alerts: []sapcontrolclient.Alert{{Object: "Shortdumps", Value: "SAPControl-RED"}, {Object: "Shortdumps", Value: "SAPControl-YELLOW"}},
+			},
+			want: 2,
+		},
+		{
+			name:    "Error",
+			c:       sapcontrolclienttest.Fake{ErrGetAlerts: cmpopts.AnyError},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var p Properties
+			got, err := p.GetAlerts(context.Background(), tc.c, sapcontrolclient.AlertTid{})
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetAlerts(%v)=%v, want %v", tc.c, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("GetAlerts(%v)=%d, want %d", tc.c, got, tc.want)
+			}
+		})
+	}
+}
@@ -18,9 +18,13 @@ limitations under the License.
 package sapcontrolclient
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
+	"net"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/GoogleCloudPlatform/sapagent/internal/soap"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
@@ -34,6 +38,20 @@ type (
 	Client struct {
 		soap *soap.Client
 		sn   string
+		host string
+	}
+
+	// RemoteOptions describes how to reach the sapstartsrv of an instance on another host.
+	RemoteOptions struct {
+		Host               string
+		UseHTTPS           bool
+		Username           string
+		Password           string
+		RefreshPassword    func() (string, error)
+		CertFile           string
+		KeyFile            string
+		CAFile             string
+		InsecureSkipVerify bool
 	}
 
 	// GetProcessListRequest struct for GetProcessList soap request body.
@@ -120,14 +138,245 @@ type (
 		Object          string `xml:"object,omitempty"`
 		Backup          string `xml:"backup,omitempty"`
 	}
+
+	// GetAlertTreeRequest struct for GetAlertTree soap request body.
+	GetAlertTreeRequest struct {
+		XMLName xml.Name `xml:"urn:SAPControl GetAlertTree"`
+	}
+
+	// GetAlertTreeResponse struct for GetAlertTree soap response body.
+	GetAlertTreeResponse struct {
+		XMLName xml.Name    `xml:"SAPControl GetAlertTreeResponse"`
+		Nodes   []AlertNode `xml:"tree>item"`
+	}
+
+	// AlertNode struct holds one element of the CCMS monitor tree.
+	// Parent is the index of the parent node in the response, -1 for the root.
+	AlertNode struct {
+		Name              string   `xml:"name,omitempty"`
+		Parent            int64    `xml:"parent"`
+		ActualValue       string   `xml:"ActualValue,omitempty"`
+		Description       string   `xml:"description,omitempty"`
+		Time              string   `xml:"Time,omitempty"`
+		AnalyseToolString string   `xml:"AnalyseToolString,omitempty"`
+		VisibleLevel      string   `xml:"VisibleLevel,omitempty"`
+		HighAlertValue    string   `xml:"HighAlertValue,omitempty"`
+		AlDescription     string   `xml:"AlDescription,omitempty"`
+		AlTime            string   `xml:"AlTime,omitempty"`
+		Tid               AlertTid `xml:"Tid"`
+	}
+
+	// AlertTid struct identifies a CCMS monitor tree element.
+	AlertTid struct {
+		MtSysID    string `xml:"MtSysId"`
+		MtMcName   string `xml:"MtMcName"`
+		MtNumRange string `xml:"MtNumRange"`
+		MtUID      string `xml:"MtUid"`
+		MtClass    string `xml:"MtClass"`
+		MtIndex    string `xml:"MtIndex"`
+		ExtIndex   string `xml:"ExtIndex"`
+	}
+
+	// GetAlertsRequest struct for GetAlerts soap request body.
+	GetAlertsRequest struct {
+		XMLName xml.Name `xml:"urn:SAPControl GetAlerts"`
+		Tid     AlertTid `xml:"tid"`
+	}
+
+	// GetAlertsResponse struct for GetAlerts soap response body.
+	GetAlertsResponse struct {
+		XMLName xml.Name `xml:"SAPControl GetAlertsResponse"`
+		Alerts  []Alert  `xml:"alert>item"`
+	}
+
+	// Alert struct holds an open CCMS alert of a monitor tree element.
+	Alert struct {
+		Object      string `xml:"Object,omitempty"`
+		Attribute   string `xml:"Attribute,omitempty"`
+		Value       string `xml:"Value,omitempty"`
+		Description string `xml:"Description,omitempty"`
+		ATime       string `xml:"ATime,omitempty"`
+	}
+
+	// StartRequest struct for Start soap request body.
+	StartRequest struct {
+		XMLName xml.Name `xml:"urn:SAPControl Start"`
+	}
+
+	// StartResponse struct for Start soap response body.
+	StartResponse struct {
+		XMLName xml.Name `xml:"SAPControl StartResponse"`
+	}
+
+	// StopRequest struct for Stop soap request body.
+	StopRequest struct {
+		XMLName      xml.Name `xml:"urn:SAPControl Stop"`
+		SoftTimeout  int64    `xml:"softtimeout"`
+		IsSystemStop int64    `xml:"IsSystemStop"`
+	}
+
+	// StopResponse struct for Stop soap response body.
+	StopResponse struct {
+		XMLName xml.Name `xml:"SAPControl StopResponse"`
+	}
+
+	// RestartServiceRequest struct for RestartService soap request body.
+	RestartServiceRequest struct {
+		XMLName xml.Name `xml:"urn:SAPControl RestartService"`
+	}
+
+	// RestartServiceResponse struct for RestartService soap response body.
+	RestartServiceResponse struct {
+		XMLName xml.Name `xml:"SAPControl RestartServiceResponse"`
+	}
+
+	// StartSystemRequest struct for StartSystem soap request body.
+	StartSystemRequest struct {
+		XMLName       xml.Name `xml:"urn:SAPControl StartSystem"`
+		Options       string   `xml:"options"`
+		PriorityLevel string   `xml:"prioritylevel"`
+		WaitTimeout   int64    `xml:"waittimeout"`
+	}
+
+	// StartSystemResponse struct for StartSystem soap response body.
+	StartSystemResponse struct {
+		XMLName xml.Name `xml:"SAPControl StartSystemResponse"`
+	}
+
+	// StopSystemRequest struct for StopSystem soap request body.
+	StopSystemRequest struct {
+		XMLName       xml.Name `xml:"urn:SAPControl StopSystem"`
+		Options       string   `xml:"options"`
+		PriorityLevel string   `xml:"prioritylevel"`
+		SoftTimeout   int64    `xml:"softtimeout"`
+		WaitTimeout   int64    `xml:"waittimeout"`
+	}
+
+	// StopSystemResponse struct for StopSystem soap response body.
+	StopSystemResponse struct {
+		XMLName xml.Name `xml:"SAPControl StopSystemResponse"`
+	}
+
+	// GetSystemInstanceListRequest struct for GetSystemInstanceList soap request body.
+	GetSystemInstanceListRequest struct {
+		XMLName xml.Name `xml:"urn:SAPControl GetSystemInstanceList"`
+		Timeout int64    `xml:"timeout"`
+	}
+
+	// GetSystemInstanceListResponse struct for GetSystemInstanceList soap response body.
+	GetSystemInstanceListResponse struct {
+		XMLName   xml.Name         `xml:"SAPControl GetSystemInstanceListResponse"`
+		Instances []SystemInstance `xml:"instance>item"`
+	}
+
+	// SystemInstance struct holds an instance of the SAP system.
+	SystemInstance struct {
+		Hostname      string `xml:"hostname,omitempty"`
+		InstanceNr    int64  `xml:"instanceNr,omitempty"`
+		HTTPPort      int64  `xml:"httpPort,omitempty"`
+		HTTPSPort     int64  `xml:"httpsPort,omitempty"`
+		StartPriority string `xml:"startPriority,omitempty"`
+		Features      string `xml:"features,omitempty"`
+		Dispstatus    string `xml:"dispstatus,omitempty"`
+	}
+
+	// GetVersionInfoRequest struct for GetVersionInfo soap request body.
+	GetVersionInfoRequest struct {
+		XMLName xml.Name `xml:"urn:SAPControl GetVersionInfo"`
+	}
+
+	// GetVersionInfoResponse struct for GetVersionInfo soap response body.
+	GetVersionInfoResponse struct {
+		XMLName  xml.Name      `xml:"SAPControl GetVersionInfoResponse"`
+		Versions []VersionInfo `xml:"version>item"`
+	}
+
+	// VersionInfo struct holds the version of an executable of the instance.
+	VersionInfo struct {
+		Filename    string `xml:"Filename,omitempty"`
+		VersionInfo string `xml:"VersionInfo,omitempty"`
+		Time        string `xml:"Time,omitempty"`
+	}
+
+	// WaitTimeoutError is returned when the processes or instances do not reach the
+	// requested state before the context is done.
+	WaitTimeoutError struct {
+		// State is the requested dispstatus, ex: "SAPControl-GREEN".
+		State string
+		// Pending holds the processes or instances not in the requested state at the last poll.
+		Pending []string
+		// Err holds the error of the last poll, if it failed.
+		Err error
+	}
+)
+
+// Display statuses reported by sapcontrol.
+const (
+	StatusGreen  = "SAPControl-GREEN"
+	StatusYellow = "SAPControl-YELLOW"
+	StatusRed    = "SAPControl-RED"
+	StatusGray   = "SAPControl-GRAY"
+)
+
+// Instance selections for StartSystem and StopSystem.
+const (
+	AllInstances      = "SAPControl-ALL-INSTANCES"
+	SCSInstances      = "SAPControl-SCS-INSTANCES"
+	DialogInstances   = "SAPControl-DIALOG-INSTANCES"
+	ABAPInstances     = "SAPControl-ABAP-INSTANCES"
+	J2EEInstances     = "SAPControl-J2EE-INSTANCES"
+	PriorityLevel     = "SAPControl-PRIORITY-LEVEL"
+	EnqRepInstances   = "SAPControl-ENQREP-INSTANCES"
+	HDBInstances      = "SAPControl-HDB-INSTANCES"
+	AllNoHDBInstances = "SAPControl-ALLNOHDB-INSTANCES"
 )
 
+// defaultWaitInterval is the polling interval of the wait functions.
+const defaultWaitInterval = 5 * time.Second
+
+// Error implements the error interface for WaitTimeoutError.
+func (e WaitTimeoutError) Error() string {
+	msg := fmt.Sprintf("timed out waiting for %s, pending: [%s]", e.State, strings.Join(e.Pending, ", "))
+	if e.Err != nil {
+		msg += fmt.Sprintf(", last error: %v", e.Err)
+	}
+	return msg
+}
+
+// Unwrap returns the error of the last poll.
+func (e WaitTimeoutError) Unwrap() error {
+	return e.Err
+}
+
 // New returns a Client for soap calls supported by all types of sap instances.
 // sn: system number for the sap instance.
 func New(sn string) Client {
 	// sapcontrol listens on the unix domain socket /tmp/.sapstream5#{sn}13 by default
 	soap := soap.NewUDSClient(fmt.Sprintf("/tmp/.sapstream5%s13", sn))
-	return Client{soap, sn}
+	return Client{soap: soap, sn: sn}
+}
+
+// NewRemote returns a Client for soap calls to the sapstartsrv of a sap instance on opts.Host.
+// sn: system number for the sap instance.
+func NewRemote(sn string, opts RemoteOptions) (Client, error) {
+	// sapcontrol listens for HTTP on port 5#{sn}13 and for HTTPS on port 5#{sn}14
+	url := fmt.Sprintf("http://%s/", net.JoinHostPort(opts.Host, fmt.Sprintf("5%s13", sn)))
+	if opts.UseHTTPS {
+		url = fmt.Sprintf("https://%s/", net.JoinHostPort(opts.Host, fmt.Sprintf("5%s14", sn)))
+	}
+	soap, err := soap.NewHTTPClient(url, soap.HTTPOptions{
+		Username:           opts.Username,
+		Password:           opts.Password,
+		RefreshPassword:    opts.RefreshPassword,
+		CertFile:           opts.CertFile,
+		KeyFile:            opts.KeyFile,
+		CAFile:             opts.CAFile,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	})
+	if err != nil {
+		return Client{}, fmt.Errorf("creating SAPControl client for %s: %v", url, err)
+	}
+	return Client{soap: soap, sn: sn, host: opts.Host}, nil
 }
 
 // call is a syntactic encapsulation for invoking soap.Call function.
@@ -151,6 +400,10 @@ func (c Client) GetProcessList() ([]OSProcess, error) {
 	// and manually insert missing processes with gray status and pid -1.
 	processes := res.Processes
 	systemNumber := c.sn
+	if c.host != "" {
+		// Remote instances on different hosts may share the system number.
+		systemNumber = c.host + "/" + c.sn
+	}
 	if seenProcessList == nil {
 		seenProcessList = make(map[string][]string)
 	}
@@ -217,3 +470,178 @@ func (c Client) GetEnqLockTable() ([]EnqLock, error) {
 	log.Logger.Infow("Sapcontrol GetEnqLockTable", "apiResponse", res.EnqLocks)
 	return res.EnqLocks, nil
 }
+
+// GetAlertTree performs GetAlertTree soap request.
+// Returns:
+//   - GetAlertTree API call response as a list of AlertNode structs.
+//   - Error if Client.call fails, nil otherwise.
+func (c Client) GetAlertTree() ([]AlertNode, error) {
+	res := &GetAlertTreeResponse{}
+	if err := c.call(&GetAlertTreeRequest{}, res); err != nil {
+		return nil, err
+	}
+	log.Logger.Debugw("Sapcontrol GetAlertTree", "apiResponse", res.Nodes)
+	return res.Nodes, nil
+}
+
+// GetAlerts performs GetAlerts soap request for the monitor tree element tid.
+// Returns:
+//   - GetAlerts API call response as a list of Alert structs.
+//   - Error if Client.call fails, nil otherwise.
+func (c Client) GetAlerts(tid AlertTid) ([]Alert, error) {
+	res := &GetAlertsResponse{}
+	if err := c.call(&GetAlertsRequest{Tid: tid}, res); err != nil {
+		return nil, err
+	}
+	log.Logger.Debugw("Sapcontrol GetAlerts", "apiResponse", res.Alerts)
+	return res.Alerts, nil
+}
+
+// Start performs Start soap request. The call returns once sapstartsrv has
+// accepted the request; use WaitForInstanceStatus to wait for the processes.
+func (c Client) Start() error {
+	if err := c.call(&StartRequest{}, &StartResponse{}); err != nil {
+		return err
+	}
+	log.Logger.Infow("Sapcontrol Start", "instanceNumber", c.sn)
+	return nil
+}
+
+// Stop performs Stop soap request.
+// softTimeout is the number of seconds to wait for a graceful shutdown, 0 for the
+// instance default.
+func (c Client) Stop(softTimeout int64) error {
+	if err := c.call(&StopRequest{SoftTimeout: softTimeout}, &StopResponse{}); err != nil {
+		return err
+	}
+	log.Logger.Infow("Sapcontrol Stop", "instanceNumber", c.sn, "softTimeout", softTimeout)
+	return nil
+}
+
+// RestartService performs RestartService soap request, restarting sapstartsrv
+// without affecting the instance processes.
+func (c Client) RestartService() error {
+	if err := c.call(&RestartServiceRequest{}, &RestartServiceResponse{}); err != nil {
+		return err
+	}
+	log.Logger.Infow("Sapcontrol RestartService", "instanceNumber", c.sn)
+	return nil
+}
+
+// StartSystem performs StartSystem soap request for the instances selected by options,
+// ex: AllInstances. waitTimeout is the number of seconds sapcontrol waits per priority level.
+func (c Client) StartSystem(options string, waitTimeout int64) error {
+	req := &StartSystemRequest{Options: options, WaitTimeout: waitTimeout}
+	if err := c.call(req, &StartSystemResponse{}); err != nil {
+		return err
+	}
+	log.Logger.Infow("Sapcontrol StartSystem", "options", options, "waitTimeout", waitTimeout)
+	return nil
+}
+
+// StopSystem performs StopSystem soap request for the instances selected by options,
+// ex: AllInstances.
+func (c Client) StopSystem(options string, softTimeout, waitTimeout int64) error {
+	req := &StopSystemRequest{Options: options, SoftTimeout: softTimeout, WaitTimeout: waitTimeout}
+	if err := c.call(req, &StopSystemResponse{}); err != nil {
+		return err
+	}
+	log.Logger.Infow("Sapcontrol StopSystem", "options", options, "softTimeout", softTimeout, "waitTimeout", waitTimeout)
+	return nil
+}
+
+// GetSystemInstanceList performs GetSystemInstanceList soap request.
+// timeout is the number of seconds sapcontrol waits for the other instances to respond.
+// Returns:
+//   - GetSystemInstanceList API call response as a list of SystemInstance structs.
+//   - Error if Client.call fails, nil otherwise.
+func (c Client) GetSystemInstanceList(timeout int64) ([]SystemInstance, error) {
+	res := &GetSystemInstanceListResponse{}
+	if err := c.call(&GetSystemInstanceListRequest{Timeout: timeout}, res); err != nil {
+		return nil, err
+	}
+	log.Logger.Debugw("Sapcontrol GetSystemInstanceList", "apiResponse", res.Instances)
+	return res.Instances, nil
+}
+
+// GetVersionInfo performs GetVersionInfo soap request.
+// Returns:
+//   - GetVersionInfo API call response as a list of VersionInfo structs.
+//   - Error if Client.call fails, nil otherwise.
+func (c Client) GetVersionInfo() ([]VersionInfo, error) {
+	res := &GetVersionInfoResponse{}
+	if err := c.call(&GetVersionInfoRequest{}, res); err != nil {
+		return nil, err
+	}
+	log.Logger.Debugw("Sapcontrol GetVersionInfo", "apiResponse", res.Versions)
+	return res.Versions, nil
+}
+
+// WaitForInstanceStatus polls GetProcessList every interval until all processes of the
+// instance have the dispstatus status, ex: StatusGreen after Start or StatusGray after Stop.
+// A non-positive interval defaults to 5 seconds.
+// Returns a WaitTimeoutError if ctx is done first.
+func (c Client) WaitForInstanceStatus(ctx context.Context, status string, interval time.Duration) error {
+	return waitFor(ctx, status, interval, func() ([]string, error) {
+		procs, err := c.GetProcessList()
+		if err != nil {
+			return nil, err
+		}
+		if len(procs) == 0 && status != StatusGray {
+			return nil, fmt.Errorf("no processes reported for instance %s", c.sn)
+		}
+		var pending []string
+		for _, p := range procs {
+			if p.Dispstatus != status {
+				pending = append(pending, p.Name)
+			}
+		}
+		return pending, nil
+	})
+}
+
+// WaitForSystemStatus polls GetSystemInstanceList every interval until all instances of the
+// system have the dispstatus status.
+// A non-positive interval defaults to 5 seconds.
+// Returns a WaitTimeoutError if ctx is done first.
+func (c Client) WaitForSystemStatus(ctx context.Context, status string, interval time.Duration) error {
+	return waitFor(ctx, status, interval, func() ([]string, error) {
+		instances, err := c.GetSystemInstanceList(0)
+		if err != nil {
+			return nil, err
+		}
+		if len(instances) == 0 {
+			return nil, fmt.Errorf("no instances reported for the system")
+		}
+		var pending []string
+		for _, i := range instances {
+			if i.Dispstatus != status {
+				pending = append(pending, fmt.Sprintf("%s/%02d", i.Hostname, i.InstanceNr))
+			}
+		}
+		return pending, nil
+	})
+}
+
+// waitFor calls poll every interval until it returns no pending entries.
+// Errors returned by poll are retried, as sapstartsrv may not respond while an
+// instance is starting or stopping.
+func waitFor(ctx context.Context, status string, interval time.Duration, poll func() ([]string, error)) error {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		pending, err := poll()
+		if err == nil && len(pending) == 0 {
+			return nil
+		}
+		log.Logger.Debugw("Waiting for sapcontrol status", "status", status, "pending", pending, "error", err)
+		select {
+		case <-ctx.Done():
+			return WaitTimeoutError{State: status, Pending: pending, Err: err}
+		case <-ticker.C:
+		}
+	}
+}
@@ -17,12 +17,18 @@ limitations under the License.
 package sapcontrolclient
 
 import (
+	"context"
 	_ "embed"
+	"errors"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -73,6 +79,36 @@ var (
 
 	//go:embed testdata/enqgetlocktable/enqgetlocktable_success_response.xml
 	enqLocksResponse string
+
+	//go:embed testdata/getalerttree/alert_tree.xml
+	alertTreeResponse string
+
+	//go:embed testdata/getalerts/one_alert.xml
+	alertsResponse string
+
+	//go:embed testdata/lifecycle/start_response.xml
+	startResponse string
+
+	//go:embed testdata/lifecycle/stop_response.xml
+	stopResponse string
+
+	//go:embed testdata/lifecycle/restart_service_response.xml
+	restartServiceResponse string
+
+	//go:embed testdata/lifecycle/start_system_response.xml
+	startSystemResponse string
+
+	//go:embed testdata/lifecycle/stop_system_response.xml
+	stopSystemResponse string
+
+	//go:embed testdata/getsysteminstancelist/all_instances.xml
+	systemInstanceListResponse string
+
+	//go:embed testdata/getsysteminstancelist/started_instances.xml
+	startedSystemInstanceListResponse string
+
+	//go:embed testdata/getversioninfo/version_info.xml
+	versionInfoResponse string
 )
 
 // NewSapControl returns a new mock for sapcontrol.
@@ -361,3 +397,417 @@ func TestGetEnqLockTable(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAlertTree(t *testing.T) {
+	tid := func(uid, class string) AlertTid {
+		return AlertTid{MtSysID: "DEV", MtMcName: "sapdev_DEV_00", MtNumRange: "010", MtUID: uid, MtClass: class, MtIndex: uid, ExtIndex: uid}
+	}
+	tests := []struct {
+		name         string
+		fakeResponse string
+		wantNodes    []AlertNode
+		wantErr      error
+	}{
+		{
+			name:         "SuccessAlertTree",
+			fakeResponse: alertTreeResponse,
+			wantNodes: []AlertNode{
+				{Name: "DEV", Parent: -1, ActualValue: "SAPControl-YELLOW", VisibleLevel: "3", HighAlertValue: "SAPControl-YELLOW", Tid: tid("0000000001", "050")},
+				{Name: "Dialog", Parent: 0, ActualValue: "SAPControl-GREEN", VisibleLevel: "2", HighAlertValue: "SAPControl-GREEN", Tid: tid("0000000020", "050")},
+				{Name: "ResponseTime", Parent: 1, ActualValue: "SAPControl-GREEN", Description: "423 msec", Time: "2026 10 17 09:14:02", VisibleLevel: "1", HighAlertValue: "SAPControl-GREEN", Tid: tid("0000000021", "100")},
+			},
+		},
+		{
+			name:         "Fault",
+			fakeResponse: faultResponse,
+			wantErr:      cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupSAPMocks(t, test.fakeResponse)
+			c := setupClient(t)
+			gotNodes, gotErr := c.GetAlertTree()
+
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetAlertTree(), gotErr: %v wantErr: %v.", gotErr, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantNodes, gotNodes); diff != "" {
+				t.Errorf("GetAlertTree() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestGetAlerts(t *testing.T) {
+	tests := []struct {
+		name         string
+		fakeResponse string
+		wantAlerts   []Alert
+		wantErr      error
+	}{
+		{
+			name:         "SuccessOneAlert",
+			fakeResponse: alertsResponse,
+			wantAlerts: []Alert{
+				{
+					Object:      "Shortdumps",
+					Attribute:   "ShortdumpsFrequency",
+					Value:       "SAPControl-RED",
+					Description: "Shortdumps frequency 12 /min is over threshold value 10 /min",
+					ATime:       "2026 10 17 09:12:44",
+				},
+			},
+		},
+		{
+			name:         "Fault",
+			fakeResponse: faultResponse,
+			wantErr:      cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupSAPMocks(t, test.fakeResponse)
+			c := setupClient(t)
+			gotAlerts, gotErr := c.GetAlerts(AlertTid{MtSysID: "DEV", MtUID: "0000000042"})
+
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetAlerts(), gotErr: %v wantErr: %v.", gotErr, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantAlerts, gotAlerts); diff != "" {
+				t.Errorf("GetAlerts() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestNewRemote(t *testing.T) {
+	// "99" is used as the instance for the remote sapcontrol service listening on port 59913.
+	l, err := net.Listen("tcp", "127.0.0.1:59913")
+	if err != nil {
+		t.Skipf("net.Listen() could not reserve the sapcontrol port: %v", err)
+	}
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "sapadm" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(taskQueueResponse))
+	}))
+	s.Listener.Close()
+	s.Listener = l
+	s.Start()
+	t.Cleanup(s.Close)
+
+	tests := []struct {
+		name    string
+		opts    RemoteOptions
+		wantLen int
+		wantErr error
+	}{
+		{
+			name:    "HTTPWithBasicAuth",
+			opts:    RemoteOptions{Host: "127.0.0.1", Username: "sapadm", Password: "secret"},
+			wantLen: 8,
+		},
+		{
+			name:    "WrongPassword",
+			opts:    RemoteOptions{Host: "127.0.0.1", Username: "sapadm", Password: "wrong"},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "HTTPSPortNotListening",
+			opts:    RemoteOptions{Host: "127.0.0.1", UseHTTPS: true, Username: "sapadm", Password: "secret"},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := NewRemote("99", test.opts)
+			if err != nil {
+				t.Fatalf("NewRemote(%+v) failed: %v", test.opts, err)
+			}
+			got, gotErr := c.GetQueueStatistic()
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetQueueStatistic(), gotErr: %v wantErr: %v.", gotErr, test.wantErr)
+			}
+			if len(got) != test.wantLen {
+				t.Errorf("GetQueueStatistic() returned %d queues, want %d", len(got), test.wantLen)
+			}
+		})
+	}
+}
+
+func TestNewRemoteInvalidCertificate(t *testing.T) {
+	opts := RemoteOptions{Host: "sapapp1", UseHTTPS: true, CertFile: "/does/not/exist.pem", KeyFile: "/does/not/exist.key"}
+	if _, err := NewRemote("00", opts); err == nil {
+		t.Errorf("NewRemote(%+v) succeeded, want error", opts)
+	}
+}
+
+// sequenceMock serves the responses in order, repeating the last one, and records
+// the request bodies.
+type sequenceMock struct {
+	mu        sync.Mutex
+	responses []string
+	requests  []string
+}
+
+func setupSAPSequenceMock(t *testing.T, responses ...string) *sequenceMock {
+	t.Helper()
+	m := &sequenceMock{responses: responses}
+	l, err := net.Listen("unix", DefaultSapcontrolSocket)
+	if err != nil {
+		t.Fatalf("setupSAPSequenceMock(): %v", err)
+	}
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.requests = append(m.requests, string(body))
+		w.Write([]byte(m.responses[min(len(m.requests), len(m.responses))-1]))
+	}))
+	s.Listener.Close()
+	s.Listener = l
+	s.Start()
+	t.Cleanup(s.Close)
+	return m
+}
+
+func (m *sequenceMock) lastRequest() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.requests) == 0 {
+		return ""
+	}
+	return m.requests[len(m.requests)-1]
+}
+
+func TestLifecycleOperations(t *testing.T) {
+	tests := []struct {
+		name         string
+		fakeResponse string
+		call         func(c Client) error
+		wantRequest  []string
+		wantErr      error
+	}{
+		{
+			name:         "Start",
+			fakeResponse: startResponse,
+			call:         func(c Client) error { return c.Start() },
+			wantRequest:  []string{"<Start xmlns=\"urn:SAPControl\">"},
+		},
+		{
+			name:         "Stop",
+			fakeResponse: stopResponse,
+			call:         func(c Client) error { return c.Stop(300) },
+			wantRequest:  []string{"<softtimeout>300</softtimeout>", "<IsSystemStop>0</IsSystemStop>"},
+		},
+		{
+			name:         "RestartService",
+			fakeResponse: restartServiceResponse,
+			call:         func(c Client) error { return c.RestartService() },
+			wantRequest:  []string{"<RestartService xmlns=\"urn:SAPControl\">"},
+		},
+		{
+			name:         "StartSystem",
+			fakeResponse: startSystemResponse,
+			call:         func(c Client) error { return c.StartSystem(AllInstances, 600) },
+			wantRequest:  []string{"<options>SAPControl-ALL-INSTANCES</options>", "<waittimeout>600</waittimeout>"},
+		},
+		{
+			name:         "StopSystem",
+			fakeResponse: stopSystemResponse,
+			call:         func(c Client) error { return c.StopSystem(AllNoHDBInstances, 120, 600) },
+			wantRequest:  []string{"<options>SAPControl-ALLNOHDB-INSTANCES</options>", "<softtimeout>120</softtimeout>", "<waittimeout>600</waittimeout>"},
+		},
+		{
+			name:         "StopFault",
+			fakeResponse: faultResponse,
+			call:         func(c Client) error { return c.Stop(0) },
+			wantErr:      cmpopts.AnyError,
+		},
+		{
+			name:         "StartWrongResponse",
+			fakeResponse: stopResponse,
+			call:         func(c Client) error { return c.Start() },
+			wantErr:      cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := setupSAPSequenceMock(t, test.fakeResponse)
+			gotErr := test.call(setupClient(t))
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("%s(), gotErr: %v wantErr: %v.", test.name, gotErr, test.wantErr)
+			}
+			for _, want := range test.wantRequest {
+				if got := mock.lastRequest(); !strings.Contains(got, want) {
+					t.Errorf("%s() request = %s, want it to contain %s", test.name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetSystemInstanceList(t *testing.T) {
+	tests := []struct {
+		name          string
+		fakeResponse  string
+		wantInstances []SystemInstance
+		wantErr       error
+	}{
+		{
+			name:         "SuccessAllInstances",
+			fakeResponse: systemInstanceListResponse,
+			wantInstances: []SystemInstance{
+				{Hostname: "sapascs", InstanceNr: 1, HTTPPort: 50113, HTTPSPort: 50114, StartPriority: "1", Features: "MESSAGESERVER|ENQUE", Dispstatus: StatusGreen},
+				{Hostname: "sapapp1", HTTPPort: 50013, HTTPSPort: 50014, StartPriority: "3", Features: "ABAP|GATEWAY|ICMAN|IGS", Dispstatus: StatusGray},
+			},
+		},
+		{
+			name:         "Fault",
+			fakeResponse: faultResponse,
+			wantErr:      cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupSAPMocks(t, test.fakeResponse)
+			c := setupClient(t)
+			gotInstances, gotErr := c.GetSystemInstanceList(10)
+
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetSystemInstanceList(), gotErr: %v wantErr: %v.", gotErr, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInstances, gotInstances); diff != "" {
+				t.Errorf("GetSystemInstanceList() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestGetVersionInfo(t *testing.T) {
+	version := "793, patch 100, changelist 2139373, RKS compatibility level 1, optU (Jun 11 2026, 00:49:11), linuxx86_64"
+	tests := []struct {
+		name         string
+		fakeResponse string
+		wantVersions []VersionInfo
+		wantErr      error
+	}{
+		{
+			name:         "SuccessVersionInfo",
+			fakeResponse: versionInfoResponse,
+			wantVersions: []VersionInfo{
+				{Filename: "/usr/sap/DEV/D00/exe/sapstartsrv", VersionInfo: version, Time: "2026 06 11 00:49:11"},
+				{Filename: "/usr/sap/DEV/D00/exe/disp+work", VersionInfo: version, Time: "2026 06 11 00:49:11"},
+			},
+		},
+		{
+			name:         "Fault",
+			fakeResponse: faultResponse,
+			wantErr:      cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupSAPMocks(t, test.fakeResponse)
+			c := setupClient(t)
+			gotVersions, gotErr := c.GetVersionInfo()
+
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetVersionInfo(), gotErr: %v wantErr: %v.", gotErr, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantVersions, gotVersions); diff != "" {
+				t.Errorf("GetVersionInfo() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestWaitForSystemStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		responses   []string
+		status      string
+		wantPending []string
+		wantErr     bool
+	}{
+		{
+			name:      "ReachesStatusAfterPolling",
+			responses: []string{faultResponse, systemInstanceListResponse, startedSystemInstanceListResponse},
+			status:    StatusGreen,
+		},
+		{
+			name:        "TimesOut",
+			responses:   []string{systemInstanceListResponse},
+			status:      StatusGreen,
+			wantPending: []string{"sapapp1/00"},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupSAPSequenceMock(t, test.responses...)
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			err := setupClient(t).WaitForSystemStatus(ctx, test.status, 10*time.Millisecond)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("WaitForSystemStatus(%s)=%v, wantErr: %t", test.status, err, test.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			var waitErr WaitTimeoutError
+			if !errors.As(err, &waitErr) {
+				t.Fatalf("WaitForSystemStatus(%s)=%v, want a WaitTimeoutError", test.status, err)
+			}
+			if diff := cmp.Diff(test.wantPending, waitErr.Pending); diff != "" {
+				t.Errorf("WaitForSystemStatus(%s) returned unexpected pending diff (-want +got):\n%v", test.status, diff)
+			}
+		})
+	}
+}
+
+func TestWaitForInstanceStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []string
+		status    string
+		wantErr   bool
+	}{
+		{
+			name:      "Running",
+			responses: []string{faultResponse, processListResponse},
+			status:    StatusGreen,
+		},
+		{
+			name:      "NotStopped",
+			responses: []string{processListResponse},
+			status:    StatusGray,
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupSAPSequenceMock(t, test.responses...)
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			err := setupClient(t).WaitForInstanceStatus(ctx, test.status, 10*time.Millisecond)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("WaitForInstanceStatus(%s)=%v, wantErr: %t", test.status, err, test.wantErr)
+			}
+		})
+	}
+}
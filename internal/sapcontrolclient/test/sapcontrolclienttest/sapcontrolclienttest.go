@@ -25,11 +25,15 @@ type Fake struct {
 	WorkProcesses []sapcontrolclient.WorkProcess
 	TaskQueues    []sapcontrolclient.TaskHandlerQueue
 	EnqLocks      []sapcontrolclient.EnqLock
+	AlertNodes    []sapcontrolclient.AlertNode
+	Alerts        []sapcontrolclient.Alert
 
 	ErrGetProcessList    error
 	ErrABAPGetWPTable    error
 	ErrGetQueueStatistic error
 	ErrEnqGetLockTable   error
+	ErrGetAlertTree      error
+	ErrGetAlerts         error
 }
 
 // GetProcessList a mock that returns map describing the statuses of SAP processes.
@@ -51,3 +55,13 @@ func (c Fake) GetQueueStatistic() ([]sapcontrolclient.TaskHandlerQueue, error) {
 func (c Fake) GetEnqLockTable() ([]sapcontrolclient.EnqLock, error) {
 	return c.EnqLocks, c.ErrEnqGetLockTable
 }
+
+// GetAlertTree is a fake implementation of sapcontrol package GetAlertTree method.
+func (c Fake) GetAlertTree() ([]sapcontrolclient.AlertNode, error) {
+	return c.AlertNodes, c.ErrGetAlertTree
+}
+
+// GetAlerts is a fake implementation of sapcontrol package GetAlerts method.
+func (c Fake) GetAlerts(tid sapcontrolclient.AlertTid) ([]sapcontrolclient.Alert, error) {
+	return c.Alerts, c.ErrGetAlerts
+}
@@ -22,17 +22,69 @@ package soap
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
 )
 
+const (
+	// defaultHTTPTimeout bounds requests to remote endpoints, which unlike unix domain
+	// sockets can hang on an unreachable host.
+	defaultHTTPTimeout = 30 * time.Second
+
+	// maxAuthFailures is the number of rejected logins after which the client stops
+	// sending requests, staying below the common OS account lockout threshold of 3.
+	maxAuthFailures = 2
+	// refreshPasswordInterval is the minimum time between reads of the password.
+	refreshPasswordInterval = 5 * time.Minute
+)
+
+// ErrAuthFailuresExceeded is returned without sending the request once the
+// password has been rejected maxAuthFailures times in a row.
+var ErrAuthFailuresExceeded = errors.New("password rejected too many times, not sending requests until the password changes")
+
 // Client provides an interface for making SOAP requests over HTTP.
 type Client struct {
-	httpClient *http.Client
-	url        string
+	httpClient      *http.Client
+	url             string
+	username        string
+	refreshPassword func() (string, error)
+	now             func() time.Time
+
+	mu           sync.Mutex
+	password     string
+	authFailures int
+	lastRefresh  time.Time
+}
+
+// HTTPOptions holds the optional settings of a Client for a remote HTTP(S) endpoint.
+type HTTPOptions struct {
+	// Username and Password are sent with basic authentication when Username is set.
+	// Basic authentication is only allowed for HTTPS endpoints.
+	Username string
+	Password string
+	// RefreshPassword, if set, is called to read the password again when a request is
+	// rejected as unauthorized, so that a rotated password is picked up. It is
+	// called at most once every 5 minutes. After 2 rejected logins in a row, requests
+	// are only sent again once RefreshPassword returns a different password.
+	RefreshPassword func() (string, error)
+	// CertFile and KeyFile hold a PEM encoded client certificate for HTTPS endpoints.
+	CertFile string
+	KeyFile  string
+	// CAFile holds the PEM encoded certificates trusted in addition to the system pool.
+	CAFile             string
+	InsecureSkipVerify bool
+	// Timeout defaults to 30 seconds.
+	Timeout time.Duration
 }
 
 // NewUDSClient returns a Client for making HTTP requests via unix domain sockets.
@@ -52,6 +104,66 @@ func NewUDSClient(socket string) *Client {
 	}
 }
 
+// NewHTTPClient returns a Client for making HTTP or HTTPS requests to url.
+func NewHTTPClient(url string, opts HTTPOptions) (*Client, error) {
+	if opts.Username != "" && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("basic authentication requires an https url, got %q", url)
+	}
+	if opts.Username != "" && opts.InsecureSkipVerify {
+		return nil, fmt.Errorf("basic authentication cannot be used with insecure_skip_verify, the credentials would be sent to an unverified server")
+	}
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		Timeout:   timeout,
+	}
+	return &Client{
+		httpClient:      client,
+		url:             url,
+		username:        opts.Username,
+		password:        opts.Password,
+		refreshPassword: opts.RefreshPassword,
+		now:             time.Now,
+	}, nil
+}
+
+// tlsConfig builds the TLS configuration for the client certificate and CA settings.
+func (opts HTTPOptions) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %v", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %q", opts.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
 // HTTPError is returned whenever an error HTTP response code is returned.
 type HTTPError struct {
 	Code int
@@ -142,8 +254,23 @@ func (client *Client) Call(reqBody, resBody any) error {
 		return err
 	}
 
-	// Execute the SOAP call.
-	res, err := client.post(buffer)
+	// Execute the SOAP call, retrying once if the password was rejected and has
+	// changed since.
+	content := buffer.Bytes()
+	if !client.authAllowed() {
+		return ErrAuthFailuresExceeded
+	}
+	res, err := client.post(bytes.NewBuffer(content))
+	if client.authRejected(err) {
+		changed, refreshErr := client.refresh()
+		if refreshErr != nil {
+			return fmt.Errorf("%v, refreshing password failed: %v", err, refreshErr)
+		}
+		if changed {
+			res, err = client.post(bytes.NewBuffer(content))
+			client.authRejected(err)
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -161,6 +288,61 @@ func (client *Client) Call(reqBody, resBody any) error {
 	return parseSoapBody(respEnvelope, resBody)
 }
 
+// authAllowed returns true if requests may be sent. Once the password has been
+// rejected maxAuthFailures times, requests are only sent again after the
+// refreshed password has changed.
+func (client *Client) authAllowed() bool {
+	client.mu.Lock()
+	failures := client.authFailures
+	client.mu.Unlock()
+	if client.username == "" || failures < maxAuthFailures {
+		return true
+	}
+	changed, err := client.refresh()
+	return err == nil && changed
+}
+
+// authRejected records the outcome of a request and returns true if the
+// password was rejected.
+func (client *Client) authRejected(err error) bool {
+	if client.username == "" {
+		return false
+	}
+	var httpErr HTTPError
+	rejected := errors.As(err, &httpErr) && httpErr.Code == http.StatusUnauthorized
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	switch {
+	case rejected:
+		client.authFailures++
+	case err == nil:
+		client.authFailures = 0
+	}
+	return rejected
+}
+
+// refresh reads the password again, at most once every refreshPasswordInterval,
+// and returns true if it changed. A changed password resets the failure count.
+func (client *Client) refresh() (bool, error) {
+	if client.refreshPassword == nil {
+		return false, nil
+	}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	now := client.now()
+	if !client.lastRefresh.IsZero() && now.Sub(client.lastRefresh) < refreshPasswordInterval {
+		return false, nil
+	}
+	client.lastRefresh = now
+	password, err := client.refreshPassword()
+	if err != nil || password == client.password {
+		return false, err
+	}
+	client.password = password
+	client.authFailures = 0
+	return true, nil
+}
+
 // Post function wraps the SOAP XML body in an HTTP Post request & performs the request.
 // body is the XML body for the SOAP envelope.
 func (client *Client) post(body *bytes.Buffer) (*http.Response, error) {
@@ -170,6 +352,11 @@ func (client *Client) post(body *bytes.Buffer) (*http.Response, error) {
 	}
 
 	req.Header.Add("Content-Type", "text/xml; charset=\"utf-8\"")
+	if client.username != "" {
+		client.mu.Lock()
+		req.SetBasicAuth(client.username, client.password)
+		client.mu.Unlock()
+	}
 	req.Close = true
 
 	res, err := client.httpClient.Do(req)
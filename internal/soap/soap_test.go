@@ -18,13 +18,17 @@ package soap
 
 import (
 	"bytes"
+	"encoding/pem"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -359,3 +363,181 @@ func writeHTTPResponse(httpRespXML *httpResponseXML) http.HandlerFunc {
 		w.Write([]byte(httpRespXML.XMLResp))
 	})
 }
+
+func TestHTTPClientCall(t *testing.T) {
+	authHandler := func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "sapadm" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeHTTPResponse(httpResponses["simpleResponse"])(w, r)
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(authHandler))
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatalf("os.WriteFile(%q) failed: %v", caFile, err)
+	}
+
+	tests := []struct {
+		desc         string
+		opts         HTTPOptions
+		wantRespBody any
+		wantErr      error
+	}{
+		{
+			desc:         "trustedCA",
+			opts:         HTTPOptions{Username: "sapadm", Password: "secret", CAFile: caFile},
+			wantRespBody: &simpleResponse{Success: true},
+		},
+		{
+			desc:         "wrongPassword",
+			opts:         HTTPOptions{Username: "sapadm", Password: "wrong", CAFile: caFile},
+			wantRespBody: &simpleResponse{},
+			wantErr:      cmpopts.AnyError,
+		},
+		{
+			desc:         "untrustedCertificate",
+			opts:         HTTPOptions{Username: "sapadm", Password: "secret"},
+			wantRespBody: &simpleResponse{},
+			wantErr:      cmpopts.AnyError,
+		},
+		{
+			desc: "refreshedPassword",
+			opts: HTTPOptions{
+				Username:        "sapadm",
+				Password:        "rotated",
+				RefreshPassword: func() (string, error) { return "secret", nil },
+				CAFile:          caFile,
+			},
+			wantRespBody: &simpleResponse{Success: true},
+		},
+		{
+			desc: "refreshedPasswordStillWrong",
+			opts: HTTPOptions{
+				Username:        "sapadm",
+				Password:        "rotated",
+				RefreshPassword: func() (string, error) { return "wrong", nil },
+				CAFile:          caFile,
+			},
+			wantRespBody: &simpleResponse{},
+			wantErr:      cmpopts.AnyError,
+		},
+		{
+			desc: "refreshPasswordError",
+			opts: HTTPOptions{
+				Username:        "sapadm",
+				Password:        "rotated",
+				RefreshPassword: func() (string, error) { return "", errors.New("secret not found") },
+				CAFile:          caFile,
+			},
+			wantRespBody: &simpleResponse{},
+			wantErr:      cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			client, err := NewHTTPClient(server.URL, test.opts)
+			if err != nil {
+				t.Fatalf("NewHTTPClient(%q, %+v) failed: %v", server.URL, test.opts, err)
+			}
+			got := &simpleResponse{}
+			gotErr := client.Call(&request{}, got)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("client.Call() = %v, want %v", gotErr, test.wantErr)
+			}
+			if !cmp.Equal(got, test.wantRespBody, cmpopts.IgnoreFields(simpleResponse{}, "XMLName")) {
+				t.Errorf("client.Call() response = %v, want %v", got, test.wantRespBody)
+			}
+		})
+	}
+}
+
+func TestHTTPClientStopsAfterAuthFailures(t *testing.T) {
+	var logins int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		if _, password, _ := r.BasicAuth(); password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeHTTPResponse(httpResponses["simpleResponse"])(w, r)
+	}))
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatalf("os.WriteFile(%q) failed: %v", caFile, err)
+	}
+
+	secret, refreshes := "wrong", 0
+	client, err := NewHTTPClient(server.URL, HTTPOptions{
+		Username:        "sapadm",
+		Password:        "wrong",
+		RefreshPassword: func() (string, error) { refreshes++; return secret, nil },
+		CAFile:          caFile,
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() failed: %v", err)
+	}
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		client.Call(&request{}, &simpleResponse{})
+	}
+	if logins != maxAuthFailures {
+		t.Errorf("Call() with a wrong password sent %d logins, want %d", logins, maxAuthFailures)
+	}
+	if refreshes != 1 {
+		t.Errorf("Call() with a wrong password read the password %d times, want 1", refreshes)
+	}
+	if err := client.Call(&request{}, &simpleResponse{}); !errors.Is(err, ErrAuthFailuresExceeded) {
+		t.Errorf("Call() after %d rejected logins = %v, want %v", maxAuthFailures, err, ErrAuthFailuresExceeded)
+	}
+
+	// An unchanged password is not tried again, a rotated one is.
+	now = now.Add(refreshPasswordInterval)
+	if err := client.Call(&request{}, &simpleResponse{}); !errors.Is(err, ErrAuthFailuresExceeded) {
+		t.Errorf("Call() with an unchanged password = %v, want %v", err, ErrAuthFailuresExceeded)
+	}
+	secret = "secret"
+	now = now.Add(refreshPasswordInterval)
+	got := &simpleResponse{}
+	if err := client.Call(&request{}, got); err != nil || !got.Success {
+		t.Errorf("Call() with a rotated password = (%v, %v), want success", got, err)
+	}
+	if logins != maxAuthFailures+1 {
+		t.Errorf("Call() sent %d logins, want %d", logins, maxAuthFailures+1)
+	}
+}
+
+func TestNewHTTPClientErrors(t *testing.T) {
+	emptyFile := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(emptyFile, nil, 0600); err != nil {
+		t.Fatalf("os.WriteFile(%q) failed: %v", emptyFile, err)
+	}
+	tests := []struct {
+		desc string
+		url  string
+		opts HTTPOptions
+	}{
+		{desc: "basicAuthOverHTTP", url: "http://localhost:50013", opts: HTTPOptions{Username: "sapadm", Password: "secret"}},
+		{desc: "basicAuthWithInsecureSkipVerify", opts: HTTPOptions{Username: "sapadm", Password: "secret", InsecureSkipVerify: true}},
+		{desc: "missingClientCertificate", opts: HTTPOptions{CertFile: "/does/not/exist.pem", KeyFile: "/does/not/exist.key"}},
+		{desc: "missingCAFile", opts: HTTPOptions{CAFile: "/does/not/exist.pem"}},
+		{desc: "emptyCAFile", opts: HTTPOptions{CAFile: emptyFile}},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			url := test.url
+			if url == "" {
+				url = "https://localhost:50014"
+			}
+			if _, err := NewHTTPClient(url, test.opts); err == nil {
+				t.Errorf("NewHTTPClient(%q, %+v) succeeded, want error", url, test.opts)
+			}
+		})
+	}
+}
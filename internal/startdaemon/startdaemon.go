@@ -49,6 +49,7 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/hostmetrics/cloudmetricreader"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hostmetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
+	"github.com/GoogleCloudPlatform/sapagent/internal/metricspool"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/status"
 	"github.com/GoogleCloudPlatform/sapagent/internal/pacemaker"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics"
@@ -457,20 +458,36 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 		Exists:                exists,
 	}
 
+	// Time series which could not be sent are spooled to disk, shared by process metrics and HANA monitoring.
+	var spool *metricspool.Spool
+	if d.config.GetCollectionConfiguration().GetMetricSpool().GetEnabled() {
+		s, err := metricspool.New(d.config.GetCollectionConfiguration().GetMetricSpool())
+		if err != nil {
+			log.Logger.Errorw("Failed to open metric spool, unsent time series will be dropped", "error", err)
+		} else {
+			spool = s
+		}
+	}
+
 	// Start Process Metrics Collection
 	pmCtx := log.SetCtx(ctx, "context", "ProcessMetrics")
-	pmp := ProcessMetricsParams{d.config, goos, healthMonitor, gceService, gceBetaService, systemDiscovery, pcmp}
+	pmp := ProcessMetricsParams{d.config, goos, healthMonitor, gceService, gceBetaService, systemDiscovery, pcmp, spool}
 	pmp.startCollection(pmCtx)
 
 	// Start HANA Monitoring
 	hanaCtx := log.SetCtx(ctx, "context", "HANAMonitoring")
 	ua = fmt.Sprintf("sap-core-eng/%s/%s.%s/hanamonitoring", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
 	clientOptions = []option.ClientOption{option.WithUserAgent(ua)}
-	hanaMonitoringMetricClient, err := monitoring.NewMetricClient(ctx, clientOptions...)
-	if err != nil {
-		log.Logger.Errorw("Failed to create Cloud Monitoring metric client for HANA Monitoring metrics", "error", err)
-		usagemetrics.Error(usagemetrics.MetricClientCreateFailure)
-		return
+	// The metric client is not needed when metrics are only served to Prometheus.
+	var hanaMonitoringMetricClient cloudmonitoring.TimeSeriesCreator
+	if hanamonitoring.SendToCloudMonitoring(d.config) {
+		client, err := monitoring.NewMetricClient(ctx, clientOptions...)
+		if err != nil {
+			log.Logger.Errorw("Failed to create Cloud Monitoring metric client for HANA Monitoring metrics", "error", err)
+			usagemetrics.Error(usagemetrics.MetricClientCreateFailure)
+			return
+		}
+		hanaMonitoringMetricClient = client
 	}
 	hanamonitoring.Start(hanaCtx, hanamonitoring.Parameters{
 		Config:                  d.config,
@@ -480,6 +497,7 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 		HRC:                     sapdiscovery.HANAReplicationConfig,
 		SystemDiscovery:         systemDiscovery,
 		ConnectionRetryInterval: 300 * time.Second,
+		Spool:                   spool,
 	})
 
 	// Start Status Collection
@@ -544,6 +562,7 @@ type ProcessMetricsParams struct {
 	gceBetaService *gcebeta.GCEBeta
 	discovery      *system.Discovery
 	pcmparams      pacemaker.Parameters
+	spool          *metricspool.Spool
 }
 
 // startCollection for ProcessMetricsParams initiates collection of ProcessMetrics.
@@ -566,6 +585,7 @@ func (pmp ProcessMetricsParams) startCollection(ctx context.Context) {
 		Discovery:      pmp.discovery,
 		PCMParams:      pmp.pcmparams,
 		OSStatReader:   osStatReader,
+		Spool:          pmp.spool,
 	}); success != true {
 		log.Logger.Info("Process metrics collection not started")
 	}
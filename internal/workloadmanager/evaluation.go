@@ -0,0 +1,177 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/wlmevaluation"
+)
+
+// EvaluationResult is the outcome of an evaluation rule against the collected metrics.
+type EvaluationResult struct {
+	RuleID       string
+	Description  string
+	MetricType   string
+	Passed       bool
+	Explanations []string
+}
+
+// CollectMetrics collects the Workload Manager metrics of this instance once, the same way
+// the daemon does before sending them.
+func CollectMetrics(ctx context.Context, params Parameters) WorkloadMetrics {
+	return collectMetricsFromConfig(ctx, params, metricOverridePath)
+}
+
+// MetricsFromJSON parses metrics written as one JSON time series per line, as returned by
+// CollectMetricsToJSON.
+func MetricsFromJSON(data string) (WorkloadMetrics, error) {
+	var metrics []*mrpb.TimeSeries
+	if err := parseRemoteJSON(data, &metrics); err != nil {
+		return WorkloadMetrics{}, err
+	}
+	return WorkloadMetrics{Metrics: metrics}, nil
+}
+
+// ParseEvaluationRules parses evaluation rules from JSON and validates them.
+func ParseEvaluationRules(data []byte) (*evpb.EvaluationRules, error) {
+	rules := &evpb.EvaluationRules{}
+	if err := protojson.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("parsing evaluation rules: %v", err)
+	}
+	ids := make(map[string]bool)
+	for i, r := range rules.GetRules() {
+		switch {
+		case r.GetId() == "":
+			return nil, fmt.Errorf("evaluation rule at index %d has no id", i)
+		case ids[r.GetId()]:
+			return nil, fmt.Errorf("duplicate evaluation rule id: %s", r.GetId())
+		case r.GetMetricType() == "":
+			return nil, fmt.Errorf("evaluation rule %s has no metric type", r.GetId())
+		case len(r.GetExpectations()) == 0:
+			return nil, fmt.Errorf("evaluation rule %s has no expectations", r.GetId())
+		}
+		ids[r.GetId()] = true
+		for _, e := range r.GetExpectations() {
+			if err := validateExpectation(e); err != nil {
+				return nil, fmt.Errorf("evaluation rule %s: %v", r.GetId(), err)
+			}
+		}
+	}
+	return rules, nil
+}
+
+func validateExpectation(e *evpb.LabelExpectation) error {
+	if e.GetLabel() == "" {
+		return errors.New("expectation has no label")
+	}
+	if e.GetEquals() == "" && len(e.GetOneOf()) == 0 && e.GetMatches() == "" && e.GetMin() == nil && e.GetMax() == nil {
+		return fmt.Errorf("expectation for label %s has no condition", e.GetLabel())
+	}
+	if _, err := regexp.Compile(e.GetMatches()); err != nil {
+		return fmt.Errorf("expectation for label %s has an invalid regular expression: %v", e.GetLabel(), err)
+	}
+	if e.GetMin() != nil && e.GetMax() != nil && e.GetMin().GetValue() > e.GetMax().GetValue() {
+		return fmt.Errorf("expectation for label %s has min %v greater than max %v", e.GetLabel(), e.GetMin().GetValue(), e.GetMax().GetValue())
+	}
+	return nil
+}
+
+// Evaluate checks the collected metrics against the evaluation rules, in order. A rule passes
+// if its metric was collected and all its expectations hold for every time series of the metric.
+func Evaluate(wm WorkloadMetrics, rules *evpb.EvaluationRules) []EvaluationResult {
+	var results []EvaluationResult
+	for _, rule := range rules.GetRules() {
+		metricType := rule.GetMetricType()
+		if !strings.Contains(metricType, "/") {
+			metricType = metricTypePrefix + metricType
+		}
+		result := EvaluationResult{
+			RuleID:      rule.GetId(),
+			Description: rule.GetDescription(),
+			MetricType:  metricType,
+			Passed:      true,
+		}
+		collected := false
+		for _, ts := range wm.Metrics {
+			if ts.GetMetric().GetType() != metricType {
+				continue
+			}
+			collected = true
+			for _, e := range rule.GetExpectations() {
+				passed, explanation := evaluateExpectation(e, ts.GetMetric().GetLabels())
+				result.Passed = result.Passed && passed
+				result.Explanations = append(result.Explanations, explanation)
+			}
+		}
+		if !collected {
+			result.Passed = false
+			result.Explanations = []string{fmt.Sprintf("metric %s was not collected", metricType)}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// evaluateExpectation checks the label value against each condition of the expectation, and
+// explains the outcome.
+func evaluateExpectation(e *evpb.LabelExpectation, labels map[string]string) (bool, string) {
+	value, ok := labels[e.GetLabel()]
+	if !ok {
+		if e.GetAllowMissing() {
+			return true, fmt.Sprintf("label %s was not collected, which is allowed", e.GetLabel())
+		}
+		return false, fmt.Sprintf("label %s was not collected", e.GetLabel())
+	}
+
+	var failures []string
+	if e.GetEquals() != "" && value != e.GetEquals() {
+		failures = append(failures, fmt.Sprintf("want %q", e.GetEquals()))
+	}
+	if len(e.GetOneOf()) > 0 && !slices.Contains(e.GetOneOf(), value) {
+		failures = append(failures, fmt.Sprintf("want one of %q", e.GetOneOf()))
+	}
+	if e.GetMatches() != "" {
+		if re, err := regexp.Compile(e.GetMatches()); err != nil || !re.MatchString(value) {
+			failures = append(failures, fmt.Sprintf("want a match of %q", e.GetMatches()))
+		}
+	}
+	if e.GetMin() != nil || e.GetMax() != nil {
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		switch {
+		case err != nil:
+			failures = append(failures, "want a number")
+		case e.GetMin() != nil && v < e.GetMin().GetValue():
+			failures = append(failures, fmt.Sprintf("want at least %v", e.GetMin().GetValue()))
+		case e.GetMax() != nil && v > e.GetMax().GetValue():
+			failures = append(failures, fmt.Sprintf("want at most %v", e.GetMax().GetValue()))
+		}
+	}
+	if len(failures) > 0 {
+		return false, fmt.Sprintf("label %s is %q, %s", e.GetLabel(), value, strings.Join(failures, " and "))
+	}
+	return true, fmt.Sprintf("label %s is %q as expected", e.GetLabel(), value)
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2026 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	wpb "google.golang.org/protobuf/types/known/wrapperspb"
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/wlmevaluation"
+)
+
+func evaluationMetric(metricType string, labels map[string]string) *mrpb.TimeSeries {
+	return &mrpb.TimeSeries{Metric: &mpb.Metric{Type: metricType, Labels: labels}}
+}
+
+func TestParseEvaluationRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *evpb.EvaluationRules
+		wantErr error
+	}{
+		{
+			name: "Valid",
+			data: `{"rules": [{"id": "os", "metric_type": "system", "expectations": [{"label": "os", "matches": "^sles-15"}]}]}`,
+			want: &evpb.EvaluationRules{
+				Rules: []*evpb.EvaluationRule{
+					&evpb.EvaluationRule{
+						Id:           "os",
+						MetricType:   "system",
+						Expectations: []*evpb.LabelExpectation{&evpb.LabelExpectation{Label: "os", Matches: "^sles-15"}},
+					},
+				},
+			},
+		},
+		{
+			name:    "InvalidJSON",
+			data:    `{"rules": [`,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "MissingID",
+			data:    `{"rules": [{"metric_type": "system", "expectations": [{"label": "os", "equals": "rhel-9"}]}]}`,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "DuplicateID",
+			data: `{"rules": [
+				{"id": "os", "metric_type": "system", "expectations": [{"label": "os", "equals": "rhel-9"}]},
+				{"id": "os", "metric_type": "system", "expectations": [{"label": "os", "equals": "rhel-8"}]}
+			]}`,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "MissingMetricType",
+			data:    `{"rules": [{"id": "os", "expectations": [{"label": "os", "equals": "rhel-9"}]}]}`,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "NoExpectations",
+			data:    `{"rules": [{"id": "os", "metric_type": "system"}]}`,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "ExpectationWithoutLabel",
+			data:    `{"rules": [{"id": "os", "metric_type": "system", "expectations": [{"equals": "rhel-9"}]}]}`,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "ExpectationWithoutCondition",
+			data:    `{"rules": [{"id": "os", "metric_type": "system", "expectations": [{"label": "os"}]}]}`,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "InvalidRegex",
+			data:    `{"rules": [{"id": "os", "metric_type": "system", "expectations": [{"label": "os", "matches": "sles-("}]}]}`,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "MinGreaterThanMax",
+			data:    `{"rules": [{"id": "swap", "metric_type": "system", "expectations": [{"label": "swappiness", "min": 10, "max": 1}]}]}`,
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseEvaluationRules([]byte(tc.data))
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("ParseEvaluationRules() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ParseEvaluationRules() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	wm := WorkloadMetrics{Metrics: []*mrpb.TimeSeries{
+		evaluationMetric(sapValidationSystem, map[string]string{"os": "sles-15-sp5", "agent_state": "running"}),
+		evaluationMetric(sapValidationHANA, map[string]string{"fast_restart": "enabled", "numa_balancing": "1", "transparent_hugepages": "always"}),
+		evaluationMetric(sapValidationCustom, map[string]string{"swappiness": "10"}),
+		evaluationMetric(sapValidationCustom, map[string]string{"swappiness": "60"}),
+	}}
+	tests := []struct {
+		name  string
+		rules *evpb.EvaluationRules
+		want  []EvaluationResult
+	}{
+		{
+			name: "Passed",
+			rules: &evpb.EvaluationRules{Rules: []*evpb.EvaluationRule{
+				&evpb.EvaluationRule{
+					Id:          "os",
+					Description: "Supported OS",
+					MetricType:  "system",
+					Expectations: []*evpb.LabelExpectation{
+						&evpb.LabelExpectation{Label: "os", Matches: "^(sles|rhel)-"},
+						&evpb.LabelExpectation{Label: "agent_state", OneOf: []string{"running", "starting"}},
+						&evpb.LabelExpectation{Label: "kernel_version", Equals: "5.14", AllowMissing: true},
+					},
+				},
+			}},
+			want: []EvaluationResult{
+				{
+					RuleID:      "os",
+					Description: "Supported OS",
+					MetricType:  sapValidationSystem,
+					Passed:      true,
+					Explanations: []string{
+						`label os is "sles-15-sp5" as expected`,
+						`label agent_state is "running" as expected`,
+						"label kernel_version was not collected, which is allowed",
+					},
+				},
+			},
+		},
+		{
+			name: "Failed",
+			rules: &evpb.EvaluationRules{Rules: []*evpb.EvaluationRule{
+				&evpb.EvaluationRule{
+					Id:         "hana",
+					MetricType: sapValidationHANA,
+					Expectations: []*evpb.LabelExpectation{
+						&evpb.LabelExpectation{Label: "fast_restart", Equals: "enabled"},
+						&evpb.LabelExpectation{Label: "numa_balancing", Max: wpb.Double(0)},
+						&evpb.LabelExpectation{Label: "transparent_hugepages", Equals: "never", OneOf: []string{"never", "madvise"}},
+						&evpb.LabelExpectation{Label: "fast_restart", Min: wpb.Double(1)},
+						&evpb.LabelExpectation{Label: "hana_version", Equals: "2.00"},
+					},
+				},
+			}},
+			want: []EvaluationResult{
+				{
+					RuleID:     "hana",
+					MetricType: sapValidationHANA,
+					Explanations: []string{
+						`label fast_restart is "enabled" as expected`,
+						`label numa_balancing is "1", want at most 0`,
+						`label transparent_hugepages is "always", want "never" and want one of ["never" "madvise"]`,
+						`label fast_restart is "enabled", want a number`,
+						"label hana_version was not collected",
+					},
+				},
+			},
+		},
+		{
+			name: "EveryTimeSeriesChecked",
+			rules: &evpb.EvaluationRules{Rules: []*evpb.EvaluationRule{
+				&evpb.EvaluationRule{
+					Id:           "swappiness",
+					MetricType:   "custom",
+					Expectations: []*evpb.LabelExpectation{&evpb.LabelExpectation{Label: "swappiness", Min: wpb.Double(0), Max: wpb.Double(10)}},
+				},
+			}},
+			want: []EvaluationResult{
+				{
+					RuleID:     "swappiness",
+					MetricType: sapValidationCustom,
+					Explanations: []string{
+						`label swappiness is "10" as expected`,
+						`label swappiness is "60", want at most 10`,
+					},
+				},
+			},
+		},
+		{
+			name: "MetricNotCollected",
+			rules: &evpb.EvaluationRules{Rules: []*evpb.EvaluationRule{
+				&evpb.EvaluationRule{
+					Id:           "pacemaker",
+					MetricType:   "pacemaker",
+					Expectations: []*evpb.LabelExpectation{&evpb.LabelExpectation{Label: "fence_agent", Equals: "fence_gce"}},
+				},
+			}},
+			want: []EvaluationResult{
+				{
+					RuleID:       "pacemaker",
+					MetricType:   sapValidationPacemaker,
+					Explanations: []string{"metric workload.googleapis.com/sap/validation/pacemaker was not collected"},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Evaluate(wm, tc.rules)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Evaluate() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetricsFromJSON(t *testing.T) {
+	got, err := MetricsFromJSON(defaultRemoteCollectionStdout + "\n")
+	if err != nil {
+		t.Fatalf("MetricsFromJSON() failed: %v", err)
+	}
+	if len(got.Metrics) != 1 || got.Metrics[0].GetMetric().GetType() != sapValidationSystem {
+		t.Errorf("MetricsFromJSON() = %v, want one %s time series", got.Metrics, sapValidationSystem)
+	}
+	if _, err := MetricsFromJSON("not json"); err == nil {
+		t.Error("MetricsFromJSON(not json) succeeded, want error")
+	}
+}
@@ -100,11 +100,27 @@ func (p *Parameters) Init(ctx context.Context) {
 	}
 	p.osVendorID = osData.OSVendor
 	p.osVersion = osData.OSVersion
-	p.hanaInsightRules = readHANAInsightsRules()
+	p.hanaInsightRules = readHANAInsightsRules(ctx, p.Config.GetCollectionConfiguration().GetHanaInsightsRules())
 }
 
-// readHANAInsightsRules reads the HANA Insights rules.
-func readHANAInsightsRules() []*rpb.Rule {
+// readHANAInsightsRules reads the built-in HANA Insights rules and the configured rule packs.
+func readHANAInsightsRules(ctx context.Context, config *cpb.HANAInsightsRules) []*rpb.Rule {
+	if len(config.GetRulePaths()) == 0 && len(config.GetDisabledRuleIds()) == 0 {
+		return readBuiltInHANAInsightsRules()
+	}
+	hanaInsightRules, err := preprocessor.ReadRulesWithPacks(ctx, preprocessor.RulePackOptions{
+		RulePaths:       config.GetRulePaths(),
+		DisabledRuleIDs: config.GetDisabledRuleIds(),
+	})
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Error reading HANA Insights rule packs, using the built-in rules", "error", err)
+		return readBuiltInHANAInsightsRules()
+	}
+	return hanaInsightRules
+}
+
+// readBuiltInHANAInsightsRules reads the HANA Insights rules compiled into the agent.
+func readBuiltInHANAInsightsRules() []*rpb.Rule {
 	hanaInsightRules, err := preprocessor.ReadRules(preprocessor.RuleFilenames)
 	if err != nil {
 		log.Logger.Errorw("Error Reading HANA Insights rules", "error", err)
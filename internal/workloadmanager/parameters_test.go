@@ -21,6 +21,7 @@ import (
 	"io"
 	"testing"
 
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
 	rpb "github.com/GoogleCloudPlatform/sapagent/protos/hanainsights"
 )
 
@@ -67,3 +68,45 @@ func TestInit(t *testing.T) {
 		})
 	}
 }
+
+func TestReadHANAInsightsRules(t *testing.T) {
+	builtIn := readBuiltInHANAInsightsRules()
+	tests := []struct {
+		name      string
+		config    *cpb.HANAInsightsRules
+		wantCount int
+		wantNoID  string
+	}{
+		{
+			name:      "BuiltInRules",
+			wantCount: len(builtIn),
+		},
+		{
+			name:      "DisabledRule",
+			config:    &cpb.HANAInsightsRules{DisabledRuleIds: []string{"r_backup_encryption"}},
+			wantCount: len(builtIn) - 1,
+			wantNoID:  "r_backup_encryption",
+		},
+		{
+			name: "InvalidRulePackFallsBackToBuiltInRules",
+			config: &cpb.HANAInsightsRules{
+				RulePaths:       []string{"test_data/no-such-rule-pack"},
+				DisabledRuleIds: []string{"r_backup_encryption"},
+			},
+			wantCount: len(builtIn),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := readHANAInsightsRules(context.Background(), tc.config)
+			if len(got) != tc.wantCount {
+				t.Errorf("readHANAInsightsRules(%v) returned %d rules, want %d", tc.config, len(got), tc.wantCount)
+			}
+			for _, r := range got {
+				if r.GetId() == tc.wantNoID {
+					t.Errorf("readHANAInsightsRules(%v) returned disabled rule %s", tc.config, r.GetId())
+				}
+			}
+		})
+	}
+}
@@ -100,6 +100,10 @@ const (
 	Function_INQUIRE              Function = 3
 	Function_DELETE               Function = 4
 	Function_DIAGNOSE             Function = 5
+	Function_REWRAP               Function = 6
+	Function_HOUSEKEEPING         Function = 7
+	Function_VERIFY               Function = 8
+	Function_REPLICATE            Function = 9
 )
 
 // Enum value maps for Function.
@@ -111,6 +115,10 @@ var (
 		3: "INQUIRE",
 		4: "DELETE",
 		5: "DIAGNOSE",
+		6: "REWRAP",
+		7: "HOUSEKEEPING",
+		8: "VERIFY",
+		9: "REPLICATE",
 	}
 	Function_value = map[string]int32{
 		"FUNCTION_UNSPECIFIED": 0,
@@ -119,6 +127,10 @@ var (
 		"INQUIRE":              3,
 		"DELETE":               4,
 		"DIAGNOSE":             5,
+		"REWRAP":               6,
+		"HOUSEKEEPING":         7,
+		"VERIFY":               8,
+		"REPLICATE":            9,
 	}
 )
 
@@ -149,6 +161,159 @@ func (Function) EnumDescriptor() ([]byte, []int) {
 	return file_protos_backint_backint_proto_rawDescGZIP(), []int{1}
 }
 
+type ReplicationMode int32
+
+const (
+	ReplicationMode_REPLICATION_MODE_UNSPECIFIED ReplicationMode = 0
+	ReplicationMode_SYNC                         ReplicationMode = 1
+	ReplicationMode_ASYNC                        ReplicationMode = 2
+)
+
+// Enum value maps for ReplicationMode.
+var (
+	ReplicationMode_name = map[int32]string{
+		0: "REPLICATION_MODE_UNSPECIFIED",
+		1: "SYNC",
+		2: "ASYNC",
+	}
+	ReplicationMode_value = map[string]int32{
+		"REPLICATION_MODE_UNSPECIFIED": 0,
+		"SYNC":                         1,
+		"ASYNC":                        2,
+	}
+)
+
+func (x ReplicationMode) Enum() *ReplicationMode {
+	p := new(ReplicationMode)
+	*p = x
+	return p
+}
+
+func (x ReplicationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReplicationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_backint_backint_proto_enumTypes[2].Descriptor()
+}
+
+func (ReplicationMode) Type() protoreflect.EnumType {
+	return &file_protos_backint_backint_proto_enumTypes[2]
+}
+
+func (x ReplicationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReplicationMode.Descriptor instead.
+func (ReplicationMode) EnumDescriptor() ([]byte, []int) {
+	return file_protos_backint_backint_proto_rawDescGZIP(), []int{2}
+}
+
+type CompressionCodec int32
+
+const (
+	CompressionCodec_COMPRESSION_CODEC_UNSPECIFIED CompressionCodec = 0
+	CompressionCodec_GZIP                          CompressionCodec = 1
+	CompressionCodec_ZSTD                          CompressionCodec = 2
+	CompressionCodec_LZ4                           CompressionCodec = 3
+)
+
+// Enum value maps for CompressionCodec.
+var (
+	CompressionCodec_name = map[int32]string{
+		0: "COMPRESSION_CODEC_UNSPECIFIED",
+		1: "GZIP",
+		2: "ZSTD",
+		3: "LZ4",
+	}
+	CompressionCodec_value = map[string]int32{
+		"COMPRESSION_CODEC_UNSPECIFIED": 0,
+		"GZIP":                          1,
+		"ZSTD":                          2,
+		"LZ4":                           3,
+	}
+)
+
+func (x CompressionCodec) Enum() *CompressionCodec {
+	p := new(CompressionCodec)
+	*p = x
+	return p
+}
+
+func (x CompressionCodec) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CompressionCodec) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_backint_backint_proto_enumTypes[3].Descriptor()
+}
+
+func (CompressionCodec) Type() protoreflect.EnumType {
+	return &file_protos_backint_backint_proto_enumTypes[3]
+}
+
+func (x CompressionCodec) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CompressionCodec.Descriptor instead.
+func (CompressionCodec) EnumDescriptor() ([]byte, []int) {
+	return file_protos_backint_backint_proto_rawDescGZIP(), []int{3}
+}
+
+type StorageBackend int32
+
+const (
+	StorageBackend_STORAGE_BACKEND_UNSPECIFIED StorageBackend = 0
+	StorageBackend_GCS                         StorageBackend = 1
+	StorageBackend_FILESYSTEM                  StorageBackend = 2
+	StorageBackend_S3                          StorageBackend = 3
+)
+
+// Enum value maps for StorageBackend.
+var (
+	StorageBackend_name = map[int32]string{
+		0: "STORAGE_BACKEND_UNSPECIFIED",
+		1: "GCS",
+		2: "FILESYSTEM",
+		3: "S3",
+	}
+	StorageBackend_value = map[string]int32{
+		"STORAGE_BACKEND_UNSPECIFIED": 0,
+		"GCS":                         1,
+		"FILESYSTEM":                  2,
+		"S3":                          3,
+	}
+)
+
+func (x StorageBackend) Enum() *StorageBackend {
+	p := new(StorageBackend)
+	*p = x
+	return p
+}
+
+func (x StorageBackend) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StorageBackend) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_backint_backint_proto_enumTypes[4].Descriptor()
+}
+
+func (StorageBackend) Type() protoreflect.EnumType {
+	return &file_protos_backint_backint_proto_enumTypes[4]
+}
+
+func (x StorageBackend) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StorageBackend.Descriptor instead.
+func (StorageBackend) EnumDescriptor() ([]byte, []int) {
+	return file_protos_backint_backint_proto_rawDescGZIP(), []int{4}
+}
+
 type StorageClass int32
 
 const (
@@ -188,11 +353,11 @@ func (x StorageClass) String() string {
 }
 
 func (StorageClass) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_backint_backint_proto_enumTypes[2].Descriptor()
+	return file_protos_backint_backint_proto_enumTypes[5].Descriptor()
 }
 
 func (StorageClass) Type() protoreflect.EnumType {
-	return &file_protos_backint_backint_proto_enumTypes[2]
+	return &file_protos_backint_backint_proto_enumTypes[5]
 }
 
 func (x StorageClass) Number() protoreflect.EnumNumber {
@@ -201,7 +366,7 @@ func (x StorageClass) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use StorageClass.Descriptor instead.
 func (StorageClass) EnumDescriptor() ([]byte, []int) {
-	return file_protos_backint_backint_proto_rawDescGZIP(), []int{2}
+	return file_protos_backint_backint_proto_rawDescGZIP(), []int{5}
 }
 
 type BackintConfiguration struct {
@@ -260,6 +425,78 @@ type BackintConfiguration struct {
 	ParallelRecoveryStreams int64  `protobuf:"varint,39,opt,name=parallel_recovery_streams,json=parallelRecoveryStreams,proto3" json:"parallel_recovery_streams,omitempty"`
 	ObjectRetentionMode     string `protobuf:"bytes,40,opt,name=object_retention_mode,json=objectRetentionMode,proto3" json:"object_retention_mode,omitempty"`
 	ObjectRetentionTime     string `protobuf:"bytes,41,opt,name=object_retention_time,json=objectRetentionTime,proto3" json:"object_retention_time,omitempty"` // This updates the object retain-until time (Reference:
+	// https://cloud.google.com/storage/docs/object-lock):
+	// Accepted values match the custom_time field above.
+	ResumableUploads        bool           `protobuf:"varint,42,opt,name=resumable_uploads,json=resumableUploads,proto3" json:"resumable_uploads,omitempty"`
+	ResumableStateDirectory string         `protobuf:"bytes,43,opt,name=resumable_state_directory,json=resumableStateDirectory,proto3" json:"resumable_state_directory,omitempty"`
+	Deduplicate             bool           `protobuf:"varint,44,opt,name=deduplicate,proto3" json:"deduplicate,omitempty"`
+	DedupChunkSizeKb        int64          `protobuf:"varint,45,opt,name=dedup_chunk_size_kb,json=dedupChunkSizeKb,proto3" json:"dedup_chunk_size_kb,omitempty"`
+	StorageBackend          StorageBackend `protobuf:"varint,46,opt,name=storage_backend,json=storageBackend,proto3,enum=sapagent.protos.backint.StorageBackend" json:"storage_backend,omitempty"`
+	// Root directory of the FILESYSTEM storage backend, such as an NFS mount.
+	FilesystemPath string `protobuf:"bytes,47,opt,name=filesystem_path,json=filesystemPath,proto3" json:"filesystem_path,omitempty"`
+	// Endpoint of the S3 storage backend, for example "https://s3.example.com".
+	S3Endpoint string `protobuf:"bytes,48,opt,name=s3_endpoint,json=s3Endpoint,proto3" json:"s3_endpoint,omitempty"`
+	S3Region   string `protobuf:"bytes,49,opt,name=s3_region,json=s3Region,proto3" json:"s3_region,omitempty"`
+	// JSON file holding "access_key_id" and "secret_access_key" for the S3
+	// storage backend. If empty, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+	// environment variables are used.
+	S3CredentialsFile string `protobuf:"bytes,50,opt,name=s3_credentials_file,json=s3CredentialsFile,proto3" json:"s3_credentials_file,omitempty"`
+	// Use path-style requests (https://endpoint/bucket/object) instead of
+	// virtual-hosted-style requests. Most on-premises appliances require this.
+	S3PathStyle bool `protobuf:"varint,51,opt,name=s3_path_style,json=s3PathStyle,proto3" json:"s3_path_style,omitempty"`
+	// Encrypt each object with a random data key which is wrapped by a
+	// key-encryption key (KEK) and stored in the object metadata.
+	EnvelopeEncryption bool `protobuf:"varint,52,opt,name=envelope_encryption,json=envelopeEncryption,proto3" json:"envelope_encryption,omitempty"`
+	// JSON keyring file used as the KEK, in the format
+	// {"primary": "<key_id>", "keys": {"<key_id>": "<base64 AES-256 key>"}}.
+	// Keys which are no longer primary are kept to unwrap older backups.
+	EnvelopeKeyringFile string `protobuf:"bytes,53,opt,name=envelope_keyring_file,json=envelopeKeyringFile,proto3" json:"envelope_keyring_file,omitempty"`
+	// Cloud KMS key used as the KEK instead of a keyring file, in the format
+	// projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>.
+	EnvelopeKmsKey string `protobuf:"bytes,54,opt,name=envelope_kms_key,json=envelopeKmsKey,proto3" json:"envelope_kms_key,omitempty"`
+	// HANA userstore key used by the HOUSEKEEPING function to read the backup
+	// catalog of the database named in the user ID, queried as <sid>adm. The key
+	// must connect to that database, or to the SYSTEMDB of the same SID.
+	HousekeepingHdbuserstoreKey string `protobuf:"bytes,55,opt,name=housekeeping_hdbuserstore_key,json=housekeepingHdbuserstoreKey,proto3" json:"housekeeping_hdbuserstore_key,omitempty"`
+	// Delete the objects HOUSEKEEPING finds. By default they are only reported.
+	HousekeepingDelete bool `protobuf:"varint,56,opt,name=housekeeping_delete,json=housekeepingDelete,proto3" json:"housekeeping_delete,omitempty"`
+	// Objects created more recently than this are never deleted by
+	// HOUSEKEEPING, so backups still in progress are not removed. Defaults to 24.
+	HousekeepingMinAgeHours int64 `protobuf:"varint,57,opt,name=housekeeping_min_age_hours,json=housekeepingMinAgeHours,proto3" json:"housekeeping_min_age_hours,omitempty"`
+	// Codec used to compress backups. GZIP is equivalent to compress and is
+	// decompressed by Cloud Storage. ZSTD and LZ4 are multi-threaded and are
+	// applied by Backint, so they are supported by every storage_backend.
+	// The codec is recorded in the object metadata for RESTORE.
+	CompressionCodec CompressionCodec `protobuf:"varint,58,opt,name=compression_codec,json=compressionCodec,proto3,enum=sapagent.protos.backint.CompressionCodec" json:"compression_codec,omitempty"`
+	// Compression level for ZSTD (1-22) or LZ4 (1-9). Defaults to the codec's
+	// default level.
+	CompressionLevel int32 `protobuf:"varint,59,opt,name=compression_level,json=compressionLevel,proto3" json:"compression_level,omitempty"`
+	// Record the size and SHA-256 checksum of each file in an integrity
+	// manifest per BACKUP, which the VERIFY function checks backups against.
+	IntegrityManifest bool `protobuf:"varint,60,opt,name=integrity_manifest,json=integrityManifest,proto3" json:"integrity_manifest,omitempty"`
+	// Percentage of the files in each integrity manifest read by VERIFY,
+	// selected at random. Defaults to 100.
+	VerifySamplePercent int32 `protobuf:"varint,61,opt,name=verify_sample_percent,json=verifySamplePercent,proto3" json:"verify_sample_percent,omitempty"`
+	// Replicate each completed BACKUP object to recovery_bucket under
+	// recovery_folder_prefix. SYNC replicates before the object is reported as
+	// saved, ASYNC queues the object for the REPLICATE function, which BACKUP
+	// starts in the background once finished. The status is recorded in the
+	// object metadata, and the queue depth and age are sent as metrics with
+	// send_metrics_to_monitoring. When set, RESTORE reads from bucket and
+	// INQUIRE and RESTORE fall back to the replica instead of always reading
+	// from recovery_bucket.
+	ReplicationMode ReplicationMode `protobuf:"varint,62,opt,name=replication_mode,json=replicationMode,proto3,enum=sapagent.protos.backint.ReplicationMode" json:"replication_mode,omitempty"`
+	// Directory holding objects queued for replication. Defaults to a
+	// "replication" directory next to the parameters file.
+	ReplicationQueueDirectory string `protobuf:"bytes,63,opt,name=replication_queue_directory,json=replicationQueueDirectory,proto3" json:"replication_queue_directory,omitempty"`
+	// Rate limits applied during time-of-day windows in the host's local time.
+	// The first matching window applies, otherwise rate_limit_mb is used.
+	// Limits are adjusted while a transfer is running. Restores with windows
+	// are downloaded sequentially, parallel_recovery_streams is ignored unless
+	// restore_bypass_rate_limit is set.
+	RateLimitWindows []*RateLimitWindow `protobuf:"bytes,64,rep,name=rate_limit_windows,json=rateLimitWindows,proto3" json:"rate_limit_windows,omitempty"`
+	// Do not rate limit RESTORE, so recoveries run at full speed.
+	RestoreBypassRateLimit bool `protobuf:"varint,65,opt,name=restore_bypass_rate_limit,json=restoreBypassRateLimit,proto3" json:"restore_bypass_rate_limit,omitempty"`
 }
 
 func (x *BackintConfiguration) Reset() {
@@ -581,6 +818,251 @@ func (x *BackintConfiguration) GetObjectRetentionTime() string {
 	return ""
 }
 
+func (x *BackintConfiguration) GetResumableUploads() bool {
+	if x != nil {
+		return x.ResumableUploads
+	}
+	return false
+}
+
+func (x *BackintConfiguration) GetResumableStateDirectory() string {
+	if x != nil {
+		return x.ResumableStateDirectory
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetDeduplicate() bool {
+	if x != nil {
+		return x.Deduplicate
+	}
+	return false
+}
+
+func (x *BackintConfiguration) GetDedupChunkSizeKb() int64 {
+	if x != nil {
+		return x.DedupChunkSizeKb
+	}
+	return 0
+}
+
+func (x *BackintConfiguration) GetStorageBackend() StorageBackend {
+	if x != nil {
+		return x.StorageBackend
+	}
+	return StorageBackend_STORAGE_BACKEND_UNSPECIFIED
+}
+
+func (x *BackintConfiguration) GetFilesystemPath() string {
+	if x != nil {
+		return x.FilesystemPath
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetS3Endpoint() string {
+	if x != nil {
+		return x.S3Endpoint
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetS3Region() string {
+	if x != nil {
+		return x.S3Region
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetS3CredentialsFile() string {
+	if x != nil {
+		return x.S3CredentialsFile
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetS3PathStyle() bool {
+	if x != nil {
+		return x.S3PathStyle
+	}
+	return false
+}
+
+func (x *BackintConfiguration) GetEnvelopeEncryption() bool {
+	if x != nil {
+		return x.EnvelopeEncryption
+	}
+	return false
+}
+
+func (x *BackintConfiguration) GetEnvelopeKeyringFile() string {
+	if x != nil {
+		return x.EnvelopeKeyringFile
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetEnvelopeKmsKey() string {
+	if x != nil {
+		return x.EnvelopeKmsKey
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetHousekeepingHdbuserstoreKey() string {
+	if x != nil {
+		return x.HousekeepingHdbuserstoreKey
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetHousekeepingDelete() bool {
+	if x != nil {
+		return x.HousekeepingDelete
+	}
+	return false
+}
+
+func (x *BackintConfiguration) GetHousekeepingMinAgeHours() int64 {
+	if x != nil {
+		return x.HousekeepingMinAgeHours
+	}
+	return 0
+}
+
+func (x *BackintConfiguration) GetCompressionCodec() CompressionCodec {
+	if x != nil {
+		return x.CompressionCodec
+	}
+	return CompressionCodec_COMPRESSION_CODEC_UNSPECIFIED
+}
+
+func (x *BackintConfiguration) GetCompressionLevel() int32 {
+	if x != nil {
+		return x.CompressionLevel
+	}
+	return 0
+}
+
+func (x *BackintConfiguration) GetIntegrityManifest() bool {
+	if x != nil {
+		return x.IntegrityManifest
+	}
+	return false
+}
+
+func (x *BackintConfiguration) GetVerifySamplePercent() int32 {
+	if x != nil {
+		return x.VerifySamplePercent
+	}
+	return 0
+}
+
+func (x *BackintConfiguration) GetReplicationMode() ReplicationMode {
+	if x != nil {
+		return x.ReplicationMode
+	}
+	return ReplicationMode_REPLICATION_MODE_UNSPECIFIED
+}
+
+func (x *BackintConfiguration) GetReplicationQueueDirectory() string {
+	if x != nil {
+		return x.ReplicationQueueDirectory
+	}
+	return ""
+}
+
+func (x *BackintConfiguration) GetRateLimitWindows() []*RateLimitWindow {
+	if x != nil {
+		return x.RateLimitWindows
+	}
+	return nil
+}
+
+func (x *BackintConfiguration) GetRestoreBypassRateLimit() bool {
+	if x != nil {
+		return x.RestoreBypassRateLimit
+	}
+	return false
+}
+
+// RateLimitWindow limits transfers between start_time and end_time on days.
+type RateLimitWindow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Days of the week the window starts on, as a range or list such as
+	// "Mon-Fri" or "Sat,Sun". Empty means every day.
+	Days string `protobuf:"bytes,1,opt,name=days,proto3" json:"days,omitempty"`
+	// Start and end of the window as "HH:MM". An end_time before the
+	// start_time ends the window on the following day.
+	StartTime string `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   string `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Rate limit in MB/s during the window, 0 for unlimited.
+	RateLimitMb int64 `protobuf:"varint,4,opt,name=rate_limit_mb,json=rateLimitMb,proto3" json:"rate_limit_mb,omitempty"`
+}
+
+func (x *RateLimitWindow) Reset() {
+	*x = RateLimitWindow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_backint_backint_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RateLimitWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitWindow) ProtoMessage() {}
+
+func (x *RateLimitWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_backint_backint_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitWindow.ProtoReflect.Descriptor instead.
+func (*RateLimitWindow) Descriptor() ([]byte, []int) {
+	return file_protos_backint_backint_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RateLimitWindow) GetDays() string {
+	if x != nil {
+		return x.Days
+	}
+	return ""
+}
+
+func (x *RateLimitWindow) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *RateLimitWindow) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *RateLimitWindow) GetRateLimitMb() int64 {
+	if x != nil {
+		return x.RateLimitMb
+	}
+	return 0
+}
+
 var File_protos_backint_backint_proto protoreflect.FileDescriptor
 
 var file_protos_backint_backint_proto_rawDesc = []byte{
@@ -589,7 +1071,7 @@ var file_protos_backint_backint_proto_rawDesc = []byte{
 	0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
 	0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x74, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
-	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x97, 0x0f, 0x0a, 0x14, 0x42, 0x61, 0x63, 0x6b,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xaf, 0x19, 0x0a, 0x14, 0x42, 0x61, 0x63, 0x6b,
 	0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72,
@@ -707,33 +1189,141 @@ var file_protos_backint_backint_proto_rawDesc = []byte{
 	0x12, 0x32, 0x0a, 0x15, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x74, 0x65, 0x6e,
 	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x29, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x13, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e,
-	0x54, 0x69, 0x6d, 0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x2a, 0x52, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19, 0x0a,
-	0x15, 0x4c, 0x4f, 0x47, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42, 0x55,
-	0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x02, 0x12, 0x0b, 0x0a,
-	0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52,
-	0x52, 0x4f, 0x52, 0x10, 0x04, 0x2a, 0x64, 0x0a, 0x08, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x18, 0x0a, 0x14, 0x46, 0x55, 0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x42,
-	0x41, 0x43, 0x4b, 0x55, 0x50, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x53, 0x54, 0x4f,
-	0x52, 0x45, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x51, 0x55, 0x49, 0x52, 0x45, 0x10,
-	0x03, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x04, 0x12, 0x0c, 0x0a,
-	0x08, 0x44, 0x49, 0x41, 0x47, 0x4e, 0x4f, 0x53, 0x45, 0x10, 0x05, 0x2a, 0x64, 0x0a, 0x0c, 0x53,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x19, 0x53,
-	0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54,
-	0x41, 0x4e, 0x44, 0x41, 0x52, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x45, 0x41, 0x52,
-	0x4c, 0x49, 0x4e, 0x45, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4c, 0x44, 0x4c, 0x49,
-	0x4e, 0x45, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x10,
-	0x04, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x6c, 0x61, 0x74, 0x66,
-	0x6f, 0x72, 0x6d, 0x2f, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x73, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x10, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x73, 0x12, 0x3a, 0x0a, 0x19, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x2b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x2c, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x64, 0x65, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12,
+	0x2d, 0x0a, 0x13, 0x64, 0x65, 0x64, 0x75, 0x70, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x5f, 0x6b, 0x62, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x64, 0x65,
+	0x64, 0x75, 0x70, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x4b, 0x62, 0x12, 0x50,
+	0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e,
+	0x74, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x52, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x12, 0x27, 0x0a, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x33, 0x5f,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x30, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x33, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x33,
+	0x5f, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x31, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x33, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x33, 0x5f, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x32,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x33, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x33, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x5f, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x33, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x73, 0x33, 0x50, 0x61, 0x74, 0x68, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x65,
+	0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x5f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x34, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f,
+	0x70, 0x65, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x15,
+	0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x35, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x65, 0x6e, 0x76,
+	0x65, 0x6c, 0x6f, 0x70, 0x65, 0x4b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x28, 0x0a, 0x10, 0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x5f, 0x6b, 0x6d, 0x73,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x36, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6e, 0x76, 0x65,
+	0x6c, 0x6f, 0x70, 0x65, 0x4b, 0x6d, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x42, 0x0a, 0x1d, 0x68, 0x6f,
+	0x75, 0x73, 0x65, 0x6b, 0x65, 0x65, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x64, 0x62, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x37, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x1b, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x6b, 0x65, 0x65, 0x70, 0x69, 0x6e, 0x67, 0x48,
+	0x64, 0x62, 0x75, 0x73, 0x65, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x2f,
+	0x0a, 0x13, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x6b, 0x65, 0x65, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x38, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x68, 0x6f, 0x75,
+	0x73, 0x65, 0x6b, 0x65, 0x65, 0x70, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12,
+	0x3b, 0x0a, 0x1a, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x6b, 0x65, 0x65, 0x70, 0x69, 0x6e, 0x67, 0x5f,
+	0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x39, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x17, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x6b, 0x65, 0x65, 0x70, 0x69, 0x6e,
+	0x67, 0x4d, 0x69, 0x6e, 0x41, 0x67, 0x65, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x56, 0x0a, 0x11,
+	0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x63, 0x18, 0x3a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e,
+	0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64,
+	0x65, 0x63, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x64, 0x65, 0x63, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x3b, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x10, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x18, 0x3c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69,
+	0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74,
+	0x12, 0x32, 0x0a, 0x15, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x3d, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x13, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x12, 0x53, 0x0a, 0x10, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x3e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28,
+	0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x72, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x3f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x56, 0x0a, 0x12, 0x72, 0x61, 0x74,
+	0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x18,
+	0x40, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x74, 0x2e,
+	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x52,
+	0x10, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x73, 0x12, 0x39, 0x0a, 0x19, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x79, 0x70,
+	0x61, 0x73, 0x73, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x41,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x79, 0x70,
+	0x61, 0x73, 0x73, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x1a, 0x3b, 0x0a, 0x0d,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x52, 0x61,
+	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x79,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x72,
+	0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x6d, 0x62, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x62, 0x2a,
+	0x52, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19, 0x0a, 0x15, 0x4c,
+	0x4f, 0x47, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10,
+	0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x57,
+	0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x04, 0x2a, 0x9d, 0x01, 0x0a, 0x08, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x14, 0x46, 0x55, 0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x41,
+	0x43, 0x4b, 0x55, 0x50, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x53, 0x54, 0x4f, 0x52,
+	0x45, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x51, 0x55, 0x49, 0x52, 0x45, 0x10, 0x03,
+	0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08,
+	0x44, 0x49, 0x41, 0x47, 0x4e, 0x4f, 0x53, 0x45, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45,
+	0x57, 0x52, 0x41, 0x50, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x48, 0x4f, 0x55, 0x53, 0x45, 0x4b,
+	0x45, 0x45, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x56, 0x45, 0x52, 0x49,
+	0x46, 0x59, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54,
+	0x45, 0x10, 0x09, 0x2a, 0x48, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x50, 0x4c, 0x49, 0x43,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x59, 0x4e, 0x43,
+	0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x02, 0x2a, 0x52, 0x0a,
+	0x10, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65,
+	0x63, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x43, 0x4f, 0x44, 0x45, 0x43, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x5a, 0x49, 0x50, 0x10, 0x01, 0x12, 0x08,
+	0x0a, 0x04, 0x5a, 0x53, 0x54, 0x44, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x5a, 0x34, 0x10,
+	0x03, 0x2a, 0x52, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x42,
+	0x41, 0x43, 0x4b, 0x45, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x43, 0x53, 0x10, 0x01, 0x12, 0x0e, 0x0a,
+	0x0a, 0x46, 0x49, 0x4c, 0x45, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x02, 0x12, 0x06, 0x0a,
+	0x02, 0x53, 0x33, 0x10, 0x03, 0x2a, 0x64, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45,
+	0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x41, 0x52, 0x44,
+	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x45, 0x41, 0x52, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x02,
+	0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4c, 0x44, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x03, 0x12, 0x0b,
+	0x0a, 0x07, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x10, 0x04, 0x42, 0x38, 0x5a, 0x36, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x73, 0x61,
+	0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x62, 0x61,
+	0x63, 0x6b, 0x69, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -748,28 +1338,36 @@ func file_protos_backint_backint_proto_rawDescGZIP() []byte {
 	return file_protos_backint_backint_proto_rawDescData
 }
 
-var file_protos_backint_backint_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_protos_backint_backint_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protos_backint_backint_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_protos_backint_backint_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_protos_backint_backint_proto_goTypes = []interface{}{
 	(LogLevel)(0),                // 0: sapagent.protos.backint.LogLevel
 	(Function)(0),                // 1: sapagent.protos.backint.Function
-	(StorageClass)(0),            // 2: sapagent.protos.backint.StorageClass
-	(*BackintConfiguration)(nil), // 3: sapagent.protos.backint.BackintConfiguration
-	nil,                          // 4: sapagent.protos.backint.BackintConfiguration.MetadataEntry
-	(*wrapperspb.BoolValue)(nil), // 5: google.protobuf.BoolValue
+	(ReplicationMode)(0),         // 2: sapagent.protos.backint.ReplicationMode
+	(CompressionCodec)(0),        // 3: sapagent.protos.backint.CompressionCodec
+	(StorageBackend)(0),          // 4: sapagent.protos.backint.StorageBackend
+	(StorageClass)(0),            // 5: sapagent.protos.backint.StorageClass
+	(*BackintConfiguration)(nil), // 6: sapagent.protos.backint.BackintConfiguration
+	(*RateLimitWindow)(nil),      // 7: sapagent.protos.backint.RateLimitWindow
+	nil,                          // 8: sapagent.protos.backint.BackintConfiguration.MetadataEntry
+	(*wrapperspb.BoolValue)(nil), // 9: google.protobuf.BoolValue
 }
 var file_protos_backint_backint_proto_depIdxs = []int32{
-	0, // 0: sapagent.protos.backint.BackintConfiguration.log_level:type_name -> sapagent.protos.backint.LogLevel
-	1, // 1: sapagent.protos.backint.BackintConfiguration.function:type_name -> sapagent.protos.backint.Function
-	5, // 2: sapagent.protos.backint.BackintConfiguration.log_to_cloud:type_name -> google.protobuf.BoolValue
-	2, // 3: sapagent.protos.backint.BackintConfiguration.storage_class:type_name -> sapagent.protos.backint.StorageClass
-	4, // 4: sapagent.protos.backint.BackintConfiguration.metadata:type_name -> sapagent.protos.backint.BackintConfiguration.MetadataEntry
-	5, // 5: sapagent.protos.backint.BackintConfiguration.send_metrics_to_monitoring:type_name -> google.protobuf.BoolValue
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	0,  // 0: sapagent.protos.backint.BackintConfiguration.log_level:type_name -> sapagent.protos.backint.LogLevel
+	1,  // 1: sapagent.protos.backint.BackintConfiguration.function:type_name -> sapagent.protos.backint.Function
+	9,  // 2: sapagent.protos.backint.BackintConfiguration.log_to_cloud:type_name -> google.protobuf.BoolValue
+	5,  // 3: sapagent.protos.backint.BackintConfiguration.storage_class:type_name -> sapagent.protos.backint.StorageClass
+	8,  // 4: sapagent.protos.backint.BackintConfiguration.metadata:type_name -> sapagent.protos.backint.BackintConfiguration.MetadataEntry
+	9,  // 5: sapagent.protos.backint.BackintConfiguration.send_metrics_to_monitoring:type_name -> google.protobuf.BoolValue
+	4,  // 6: sapagent.protos.backint.BackintConfiguration.storage_backend:type_name -> sapagent.protos.backint.StorageBackend
+	3,  // 7: sapagent.protos.backint.BackintConfiguration.compression_codec:type_name -> sapagent.protos.backint.CompressionCodec
+	2,  // 8: sapagent.protos.backint.BackintConfiguration.replication_mode:type_name -> sapagent.protos.backint.ReplicationMode
+	7,  // 9: sapagent.protos.backint.BackintConfiguration.rate_limit_windows:type_name -> sapagent.protos.backint.RateLimitWindow
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_protos_backint_backint_proto_init() }
@@ -790,14 +1388,26 @@ func file_protos_backint_backint_proto_init() {
 				return nil
 			}
 		}
+		file_protos_backint_backint_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateLimitWindow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protos_backint_backint_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   2,
+			NumEnums:      6,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
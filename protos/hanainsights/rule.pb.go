@@ -38,40 +38,64 @@ const (
 type EvalNode_EvalType int32
 
 const (
-	EvalNode_UNDEFINED EvalNode_EvalType = 0
-	EvalNode_OR        EvalNode_EvalType = 1
-	EvalNode_AND       EvalNode_EvalType = 2
-	EvalNode_EQ        EvalNode_EvalType = 3
-	EvalNode_NEQ       EvalNode_EvalType = 4
-	EvalNode_LT        EvalNode_EvalType = 5
-	EvalNode_LTE       EvalNode_EvalType = 6
-	EvalNode_GT        EvalNode_EvalType = 7
-	EvalNode_GTE       EvalNode_EvalType = 8
+	EvalNode_UNDEFINED   EvalNode_EvalType = 0
+	EvalNode_OR          EvalNode_EvalType = 1
+	EvalNode_AND         EvalNode_EvalType = 2
+	EvalNode_EQ          EvalNode_EvalType = 3
+	EvalNode_NEQ         EvalNode_EvalType = 4
+	EvalNode_LT          EvalNode_EvalType = 5
+	EvalNode_LTE         EvalNode_EvalType = 6
+	EvalNode_GT          EvalNode_EvalType = 7
+	EvalNode_GTE         EvalNode_EvalType = 8
+	EvalNode_NOT         EvalNode_EvalType = 9  // negates its single child eval
+	EvalNode_IN          EvalNode_EvalType = 10 // rhs is a comma separated list of values
+	EvalNode_NOT_IN      EvalNode_EvalType = 11 // rhs is a comma separated list of values
+	EvalNode_MATCHES     EvalNode_EvalType = 12 // rhs is a regular expression
+	EvalNode_VERSION_LT  EvalNode_EvalType = 13 // compares dot separated versions, ex: 2.00.059.04
+	EvalNode_VERSION_LTE EvalNode_EvalType = 14
+	EvalNode_VERSION_GT  EvalNode_EvalType = 15
+	EvalNode_VERSION_GTE EvalNode_EvalType = 16
 )
 
 // Enum value maps for EvalNode_EvalType.
 var (
 	EvalNode_EvalType_name = map[int32]string{
-		0: "UNDEFINED",
-		1: "OR",
-		2: "AND",
-		3: "EQ",
-		4: "NEQ",
-		5: "LT",
-		6: "LTE",
-		7: "GT",
-		8: "GTE",
+		0:  "UNDEFINED",
+		1:  "OR",
+		2:  "AND",
+		3:  "EQ",
+		4:  "NEQ",
+		5:  "LT",
+		6:  "LTE",
+		7:  "GT",
+		8:  "GTE",
+		9:  "NOT",
+		10: "IN",
+		11: "NOT_IN",
+		12: "MATCHES",
+		13: "VERSION_LT",
+		14: "VERSION_LTE",
+		15: "VERSION_GT",
+		16: "VERSION_GTE",
 	}
 	EvalNode_EvalType_value = map[string]int32{
-		"UNDEFINED": 0,
-		"OR":        1,
-		"AND":       2,
-		"EQ":        3,
-		"NEQ":       4,
-		"LT":        5,
-		"LTE":       6,
-		"GT":        7,
-		"GTE":       8,
+		"UNDEFINED":   0,
+		"OR":          1,
+		"AND":         2,
+		"EQ":          3,
+		"NEQ":         4,
+		"LT":          5,
+		"LTE":         6,
+		"GT":          7,
+		"GTE":         8,
+		"NOT":         9,
+		"IN":          10,
+		"NOT_IN":      11,
+		"MATCHES":     12,
+		"VERSION_LT":  13,
+		"VERSION_LTE": 14,
+		"VERSION_GT":  15,
+		"VERSION_GTE": 16,
 	}
 )
 
@@ -369,10 +393,17 @@ type EvalNode struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lhs        string            `protobuf:"bytes,1,opt,name=lhs,proto3" json:"lhs,omitempty"` // used when type is COMPARISON
-	Rhs        string            `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"` // used when type is COMPARISON
+	// lhs and rhs are used when type is COMPARISON. Besides literals they may be
+	// query_name:column_name, count(), min(), max() or sum() of it, and for lhs
+	// any() or all() of it to compare each row. A MATCHES rhs of the form
+	// query_name:column_name is read from the knowledge base, to match such text
+	// literally write the regular expression as (?:word:word).
+	// A comparison whose values cannot be read or compared is unknown, and
+	// unknown conditions do not trigger a recommendation, also under NOT.
+	Lhs        string            `protobuf:"bytes,1,opt,name=lhs,proto3" json:"lhs,omitempty"`
+	Rhs        string            `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
 	Operation  EvalNode_EvalType `protobuf:"varint,3,opt,name=operation,proto3,enum=sapagent.protos.hanainsights.EvalNode_EvalType" json:"operation,omitempty"`
-	ChildEvals []*EvalNode       `protobuf:"bytes,4,rep,name=child_evals,json=childEvals,proto3" json:"child_evals,omitempty"` // used when type is OR, AND
+	ChildEvals []*EvalNode       `protobuf:"bytes,4,rep,name=child_evals,json=childEvals,proto3" json:"child_evals,omitempty"` // used when type is OR, AND, NOT
 }
 
 func (x *EvalNode) Reset() {
@@ -556,7 +587,7 @@ var file_protos_hanainsights_rule_proto_rawDesc = []byte{
 	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x6f,
 	0x72, 0x63, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65,
 	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
-	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0xa5, 0x02, 0x0a, 0x08, 0x45,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x92, 0x03, 0x0a, 0x08, 0x45,
 	0x76, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x68, 0x73, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x68, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x68, 0x73,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x68, 0x73, 0x12, 0x4d, 0x0a, 0x09, 0x6f,
@@ -569,25 +600,32 @@ var file_protos_hanainsights_rule_proto_rawDesc = []byte{
 	0x26, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x73, 0x2e, 0x68, 0x61, 0x6e, 0x61, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x73, 0x2e, 0x45,
 	0x76, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x45, 0x76,
-	0x61, 0x6c, 0x73, 0x22, 0x5d, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x06,
-	0x0a, 0x02, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x44, 0x10, 0x02, 0x12,
-	0x06, 0x0a, 0x02, 0x45, 0x51, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45, 0x51, 0x10, 0x04,
-	0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x45, 0x10,
-	0x06, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54, 0x10, 0x07, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54, 0x45,
-	0x10, 0x08, 0x22, 0x78, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x42, 0x3f, 0x50, 0x01,
-	0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
-	0x2f, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2f, 0x68, 0x61, 0x6e, 0x61, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x6c, 0x73, 0x22, 0xc9, 0x01, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x06, 0x0a, 0x02, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x44, 0x10, 0x02,
+	0x12, 0x06, 0x0a, 0x02, 0x45, 0x51, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45, 0x51, 0x10,
+	0x04, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x45,
+	0x10, 0x06, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54, 0x10, 0x07, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54,
+	0x45, 0x10, 0x08, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x4f, 0x54, 0x10, 0x09, 0x12, 0x06, 0x0a, 0x02,
+	0x49, 0x4e, 0x10, 0x0a, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x10, 0x0b,
+	0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x45, 0x53, 0x10, 0x0c, 0x12, 0x0e, 0x0a,
+	0x0a, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x54, 0x10, 0x0d, 0x12, 0x0f, 0x0a,
+	0x0b, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x54, 0x45, 0x10, 0x0e, 0x12, 0x0e,
+	0x0a, 0x0a, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x47, 0x54, 0x10, 0x0f, 0x12, 0x0f,
+	0x0a, 0x0b, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x47, 0x54, 0x45, 0x10, 0x10, 0x22,
+	0x78, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x42, 0x3f, 0x50, 0x01, 0x5a, 0x3b, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x73, 0x61,
+	0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x68, 0x61,
+	0x6e, 0x61, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
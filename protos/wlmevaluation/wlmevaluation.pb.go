@@ -0,0 +1,401 @@
+//
+//Copyright 2026 Google LLC
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//https://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v4.23.4
+// source: protos/wlmevaluation/wlmevaluation.proto
+
+package wlmevaluation
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EvaluationRules hold the expected values of the Workload Manager metrics
+// collected by the agent, evaluated locally by the wlmevaluate command.
+type EvaluationRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*EvaluationRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *EvaluationRules) Reset() {
+	*x = EvaluationRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluationRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluationRules) ProtoMessage() {}
+
+func (x *EvaluationRules) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluationRules.ProtoReflect.Descriptor instead.
+func (*EvaluationRules) Descriptor() ([]byte, []int) {
+	return file_protos_wlmevaluation_wlmevaluation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EvaluationRules) GetRules() []*EvaluationRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type EvaluationRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Required: unique across all rules.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Required: the metric type, ex: workload.googleapis.com/sap/validation/system
+	// The workload.googleapis.com/sap/validation/ prefix may be omitted.
+	MetricType   string              `protobuf:"bytes,3,opt,name=metric_type,json=metricType,proto3" json:"metric_type,omitempty"`
+	Expectations []*LabelExpectation `protobuf:"bytes,4,rep,name=expectations,proto3" json:"expectations,omitempty"` // Required: at least one.
+}
+
+func (x *EvaluationRule) Reset() {
+	*x = EvaluationRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluationRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluationRule) ProtoMessage() {}
+
+func (x *EvaluationRule) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluationRule.ProtoReflect.Descriptor instead.
+func (*EvaluationRule) Descriptor() ([]byte, []int) {
+	return file_protos_wlmevaluation_wlmevaluation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EvaluationRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EvaluationRule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EvaluationRule) GetMetricType() string {
+	if x != nil {
+		return x.MetricType
+	}
+	return ""
+}
+
+func (x *EvaluationRule) GetExpectations() []*LabelExpectation {
+	if x != nil {
+		return x.Expectations
+	}
+	return nil
+}
+
+// LabelExpectation checks the value of a metric label. All the conditions set
+// must hold for the expectation to pass.
+type LabelExpectation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Label   string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"` // Required
+	Equals  string   `protobuf:"bytes,2,opt,name=equals,proto3" json:"equals,omitempty"`
+	OneOf   []string `protobuf:"bytes,3,rep,name=one_of,json=oneOf,proto3" json:"one_of,omitempty"`
+	Matches string   `protobuf:"bytes,4,opt,name=matches,proto3" json:"matches,omitempty"` // Regular expression the value must match.
+	// Inclusive numeric range of the value.
+	Min *wrapperspb.DoubleValue `protobuf:"bytes,5,opt,name=min,proto3" json:"min,omitempty"`
+	Max *wrapperspb.DoubleValue `protobuf:"bytes,6,opt,name=max,proto3" json:"max,omitempty"`
+	// Passes if the label was not collected, instead of failing.
+	AllowMissing bool `protobuf:"varint,7,opt,name=allow_missing,json=allowMissing,proto3" json:"allow_missing,omitempty"`
+}
+
+func (x *LabelExpectation) Reset() {
+	*x = LabelExpectation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelExpectation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelExpectation) ProtoMessage() {}
+
+func (x *LabelExpectation) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelExpectation.ProtoReflect.Descriptor instead.
+func (*LabelExpectation) Descriptor() ([]byte, []int) {
+	return file_protos_wlmevaluation_wlmevaluation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LabelExpectation) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *LabelExpectation) GetEquals() string {
+	if x != nil {
+		return x.Equals
+	}
+	return ""
+}
+
+func (x *LabelExpectation) GetOneOf() []string {
+	if x != nil {
+		return x.OneOf
+	}
+	return nil
+}
+
+func (x *LabelExpectation) GetMatches() string {
+	if x != nil {
+		return x.Matches
+	}
+	return ""
+}
+
+func (x *LabelExpectation) GetMin() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *LabelExpectation) GetMax() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *LabelExpectation) GetAllowMissing() bool {
+	if x != nil {
+		return x.AllowMissing
+	}
+	return false
+}
+
+var File_protos_wlmevaluation_wlmevaluation_proto protoreflect.FileDescriptor
+
+var file_protos_wlmevaluation_wlmevaluation_proto_rawDesc = []byte{
+	0x0a, 0x28, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x77, 0x6c, 0x6d, 0x65, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x77, 0x6c, 0x6d, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x73, 0x61, 0x70, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x77, 0x6c, 0x6d, 0x65,
+	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x56, 0x0a, 0x0f, 0x45, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x05,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x73, 0x61,
+	0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x77, 0x6c,
+	0x6d, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x22, 0xb8, 0x01, 0x0a, 0x0e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x77, 0x6c, 0x6d, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xf6, 0x01, 0x0a,
+	0x10, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x71, 0x75, 0x61, 0x6c,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x71, 0x75, 0x61, 0x6c, 0x73, 0x12,
+	0x15, 0x0a, 0x06, 0x6f, 0x6e, 0x65, 0x5f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x6f, 0x6e, 0x65, 0x4f, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x12, 0x2e, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6d, 0x69, 0x6e,
+	0x12, 0x2e, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6d, 0x61, 0x78,
+	0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4d, 0x69,
+	0x73, 0x73, 0x69, 0x6e, 0x67, 0x42, 0x40, 0x50, 0x01, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x43, 0x6c, 0x6f, 0x75,
+	0x64, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x77, 0x6c, 0x6d, 0x65, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protos_wlmevaluation_wlmevaluation_proto_rawDescOnce sync.Once
+	file_protos_wlmevaluation_wlmevaluation_proto_rawDescData = file_protos_wlmevaluation_wlmevaluation_proto_rawDesc
+)
+
+func file_protos_wlmevaluation_wlmevaluation_proto_rawDescGZIP() []byte {
+	file_protos_wlmevaluation_wlmevaluation_proto_rawDescOnce.Do(func() {
+		file_protos_wlmevaluation_wlmevaluation_proto_rawDescData = protoimpl.X.CompressGZIP(file_protos_wlmevaluation_wlmevaluation_proto_rawDescData)
+	})
+	return file_protos_wlmevaluation_wlmevaluation_proto_rawDescData
+}
+
+var file_protos_wlmevaluation_wlmevaluation_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_protos_wlmevaluation_wlmevaluation_proto_goTypes = []interface{}{
+	(*EvaluationRules)(nil),        // 0: sapagent.protos.wlmevaluation.EvaluationRules
+	(*EvaluationRule)(nil),         // 1: sapagent.protos.wlmevaluation.EvaluationRule
+	(*LabelExpectation)(nil),       // 2: sapagent.protos.wlmevaluation.LabelExpectation
+	(*wrapperspb.DoubleValue)(nil), // 3: google.protobuf.DoubleValue
+}
+var file_protos_wlmevaluation_wlmevaluation_proto_depIdxs = []int32{
+	1, // 0: sapagent.protos.wlmevaluation.EvaluationRules.rules:type_name -> sapagent.protos.wlmevaluation.EvaluationRule
+	2, // 1: sapagent.protos.wlmevaluation.EvaluationRule.expectations:type_name -> sapagent.protos.wlmevaluation.LabelExpectation
+	3, // 2: sapagent.protos.wlmevaluation.LabelExpectation.min:type_name -> google.protobuf.DoubleValue
+	3, // 3: sapagent.protos.wlmevaluation.LabelExpectation.max:type_name -> google.protobuf.DoubleValue
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_protos_wlmevaluation_wlmevaluation_proto_init() }
+func file_protos_wlmevaluation_wlmevaluation_proto_init() {
+	if File_protos_wlmevaluation_wlmevaluation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluationRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluationRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_wlmevaluation_wlmevaluation_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelExpectation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protos_wlmevaluation_wlmevaluation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protos_wlmevaluation_wlmevaluation_proto_goTypes,
+		DependencyIndexes: file_protos_wlmevaluation_wlmevaluation_proto_depIdxs,
+		MessageInfos:      file_protos_wlmevaluation_wlmevaluation_proto_msgTypes,
+	}.Build()
+	File_protos_wlmevaluation_wlmevaluation_proto = out.File
+	file_protos_wlmevaluation_wlmevaluation_proto_rawDesc = nil
+	file_protos_wlmevaluation_wlmevaluation_proto_goTypes = nil
+	file_protos_wlmevaluation_wlmevaluation_proto_depIdxs = nil
+}